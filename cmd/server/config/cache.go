@@ -0,0 +1,20 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/elangreza/content-management-system/internal/cache"
+)
+
+func SetupCache(cfg *Config) (cache.Cache, error) {
+	switch cfg.CACHE_BACKEND {
+	case "", "memory":
+		return cache.NewMemoryCache(cfg.CACHE_MEMORY_SIZE), nil
+	case "redis":
+		return cache.NewRedisCache(cache.RedisConfig{
+			URL: cfg.REDIS_URL,
+		})
+	default:
+		return nil, fmt.Errorf("%s is not valid cache backend", cfg.CACHE_BACKEND)
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/elangreza/content-management-system/internal/cdn"
+)
+
+// SetupCDNPurger returns nil when CDN_PURGE_URL is empty, the articles are not purged then.
+func SetupCDNPurger(cfg *Config) (cdn.Purger, error) {
+	if cfg.CDN_PURGE_URL == "" {
+		return nil, nil
+	}
+
+	return cdn.NewHTTPPurger(cdn.HTTPPurgerConfig{
+		URL:   cfg.CDN_PURGE_URL,
+		Token: cfg.CDN_PURGE_TOKEN,
+	})
+}
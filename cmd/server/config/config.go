@@ -15,6 +15,57 @@ type Config struct {
 	POSTGRES_PORT     string `koanf:"POSTGRES_PORT"`
 	POSTGRES_DB       string `koanf:"POSTGRES_DB"`
 	POSTGRES_SSL      string `koanf:"POSTGRES_SSL"`
+
+	// local or s3
+	MEDIA_STORAGE   string `koanf:"MEDIA_STORAGE"`
+	MEDIA_LOCAL_DIR string `koanf:"MEDIA_LOCAL_DIR"`
+	// in bytes
+	MEDIA_MAX_SIZE int64 `koanf:"MEDIA_MAX_SIZE"`
+	// comma separated name:WIDTHxHEIGHT:fit:format, for example small:480x0:contain:webp
+	MEDIA_PRESETS        string `koanf:"MEDIA_PRESETS"`
+	S3_ENDPOINT          string `koanf:"S3_ENDPOINT"`
+	S3_REGION            string `koanf:"S3_REGION"`
+	S3_BUCKET            string `koanf:"S3_BUCKET"`
+	S3_ACCESS_KEY_ID     string `koanf:"S3_ACCESS_KEY_ID"`
+	S3_SECRET_ACCESS_KEY string `koanf:"S3_SECRET_ACCESS_KEY"`
+
+	// in characters
+	TAG_MAX_LENGTH      int `koanf:"TAG_MAX_LENGTH"`
+	TAG_MAX_PER_ARTICLE int `koanf:"TAG_MAX_PER_ARTICLE"`
+	// none, dash or ascii
+	TAG_SLUG_MODE string `koanf:"TAG_SLUG_MODE"`
+
+	// in days or go duration, for example 7d or 36h
+	TAG_TRENDING_WINDOW    string `koanf:"TAG_TRENDING_WINDOW"`
+	TAG_TRENDING_HALF_LIFE string `koanf:"TAG_TRENDING_HALF_LIFE"`
+
+	// number of jobs which are run at the same time
+	JOB_WORKERS int `koanf:"JOB_WORKERS"`
+
+	// memory or redis
+	CACHE_BACKEND string `koanf:"CACHE_BACKEND"`
+	// in keys
+	CACHE_MEMORY_SIZE int `koanf:"CACHE_MEMORY_SIZE"`
+	// redis://[:password@]host:port[/db]
+	REDIS_URL string `koanf:"REDIS_URL"`
+
+	// endpoint which receives the purged paths, empty disables the purge
+	CDN_PURGE_URL   string `koanf:"CDN_PURGE_URL"`
+	CDN_PURGE_TOKEN string `koanf:"CDN_PURGE_TOKEN"`
+
+	// number of the last events which a reconnecting event stream can resume from
+	EVENT_STREAM_LOG_SIZE int `koanf:"EVENT_STREAM_LOG_SIZE"`
+
+	// in seconds, an edit lock which is not renewed expires after it
+	ARTICLE_LOCK_TTL int `koanf:"ARTICLE_LOCK_TTL"`
+
+	// mail server of the notification emails, empty disables the email delivery
+	SMTP_HOST     string `koanf:"SMTP_HOST"`
+	SMTP_PORT     int    `koanf:"SMTP_PORT"`
+	SMTP_USERNAME string `koanf:"SMTP_USERNAME"`
+	SMTP_PASSWORD string `koanf:"SMTP_PASSWORD"`
+	// for example "CMS <noreply@cms.test>"
+	MAIL_FROM string `koanf:"MAIL_FROM"`
 }
 
 func LoadConfig() (*Config, error) {
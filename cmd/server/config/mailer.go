@@ -0,0 +1,20 @@
+package config
+
+import (
+	"github.com/elangreza/content-management-system/internal/mailer"
+)
+
+// SetupMailer returns nil when SMTP_HOST is empty, the notifications are only shown in the app then.
+func SetupMailer(cfg *Config) (mailer.Sender, error) {
+	if cfg.SMTP_HOST == "" {
+		return nil, nil
+	}
+
+	return mailer.NewSMTPSender(mailer.SMTPConfig{
+		Host:     cfg.SMTP_HOST,
+		Port:     cfg.SMTP_PORT,
+		Username: cfg.SMTP_USERNAME,
+		Password: cfg.SMTP_PASSWORD,
+		From:     cfg.MAIL_FROM,
+	})
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/elangreza/content-management-system/internal/storage"
+)
+
+const defaultMediaMaxSize = 10 << 20
+
+func SetupStorage(cfg *Config) (storage.Storage, error) {
+	if cfg.MEDIA_MAX_SIZE <= 0 {
+		cfg.MEDIA_MAX_SIZE = defaultMediaMaxSize
+	}
+
+	switch cfg.MEDIA_STORAGE {
+	case "", "local":
+		dir := cfg.MEDIA_LOCAL_DIR
+		if dir == "" {
+			dir = "media"
+		}
+		return storage.NewLocalStorage(dir)
+	case "s3":
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.S3_ENDPOINT,
+			Region:          cfg.S3_REGION,
+			Bucket:          cfg.S3_BUCKET,
+			AccessKeyID:     cfg.S3_ACCESS_KEY_ID,
+			SecretAccessKey: cfg.S3_SECRET_ACCESS_KEY,
+		})
+	default:
+		return nil, fmt.Errorf("%s is not valid media storage", cfg.MEDIA_STORAGE)
+	}
+}
+
+var presetNameRegex = regexp.MustCompile(`^[a-z0-9-]{1,32}$`)
+
+// SetupImagePresets parses the configured presets, the default presets are used when it is empty.
+func SetupImagePresets(cfg *Config) ([]entity.ImagePreset, error) {
+	if strings.TrimSpace(cfg.MEDIA_PRESETS) == "" {
+		return entity.DefaultImagePresets, nil
+	}
+
+	var presets []entity.ImagePreset
+	names := map[string]bool{}
+	for _, raw := range strings.Split(cfg.MEDIA_PRESETS, ",") {
+		parts := strings.Split(strings.TrimSpace(raw), ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("%s is not valid preset, the format is name:WIDTHxHEIGHT:fit:format", raw)
+		}
+
+		preset := entity.ImagePreset{
+			Name:   parts[0],
+			Fit:    constanta.ImageFit(parts[2]),
+			Format: constanta.ImageFormat(parts[3]),
+		}
+
+		if !presetNameRegex.MatchString(preset.Name) || names[preset.Name] {
+			return nil, fmt.Errorf("%s is not valid or unique preset name", preset.Name)
+		}
+		names[preset.Name] = true
+
+		width, height, ok := strings.Cut(parts[1], "x")
+		if !ok {
+			return nil, fmt.Errorf("%s is not valid preset size", parts[1])
+		}
+
+		var err error
+		if preset.Width, err = strconv.Atoi(width); err != nil {
+			return nil, fmt.Errorf("%s is not valid preset width", width)
+		}
+		if preset.Height, err = strconv.Atoi(height); err != nil {
+			return nil, fmt.Errorf("%s is not valid preset height", height)
+		}
+
+		switch {
+		case preset.Fit != constanta.FitContain && preset.Fit != constanta.FitCover:
+			return nil, fmt.Errorf("%s is not valid preset fit", preset.Fit)
+		case preset.Format != constanta.JPEG && preset.Format != constanta.PNG && preset.Format != constanta.WebP:
+			return nil, fmt.Errorf("%s is not valid preset format", preset.Format)
+		case preset.Width < 0 || preset.Height < 0 || preset.Width > 4096 || preset.Height > 4096:
+			return nil, fmt.Errorf("size of preset %s must be between 0 and 4096", preset.Name)
+		case preset.Fit == constanta.FitCover && (preset.Width == 0 || preset.Height == 0):
+			return nil, fmt.Errorf("preset %s with cover fit needs width and height", preset.Name)
+		case preset.Width == 0 && preset.Height == 0:
+			return nil, fmt.Errorf("preset %s needs width or height", preset.Name)
+		}
+
+		presets = append(presets, preset)
+	}
+
+	return presets, nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+)
+
+// SetupTagRules returns the configured tag rules, the default rules are used for the empty values.
+func SetupTagRules(cfg *Config) (entity.TagRules, error) {
+	rules := entity.DefaultTagRules
+
+	if cfg.TAG_MAX_LENGTH < 0 || cfg.TAG_MAX_PER_ARTICLE < 0 {
+		return rules, fmt.Errorf("tag max length and max per article cannot be negative")
+	}
+	if cfg.TAG_MAX_LENGTH > 0 {
+		rules.MaxLength = cfg.TAG_MAX_LENGTH
+	}
+	if cfg.TAG_MAX_PER_ARTICLE > 0 {
+		rules.MaxPerArticle = cfg.TAG_MAX_PER_ARTICLE
+	}
+
+	switch mode := constanta.TagSlugMode(cfg.TAG_SLUG_MODE); mode {
+	case "":
+	case constanta.TagSlugNone, constanta.TagSlugDash, constanta.TagSlugASCII:
+		rules.SlugMode = mode
+	default:
+		return rules, fmt.Errorf("%s is not valid tag slug mode", cfg.TAG_SLUG_MODE)
+	}
+
+	return rules, nil
+}
+
+// SetupTagTrending returns the configured trending window and half life, the default values are used for the empty values.
+func SetupTagTrending(cfg *Config) (entity.TagTrending, error) {
+	trending := entity.DefaultTagTrending
+
+	if cfg.TAG_TRENDING_WINDOW != "" {
+		window, err := entity.ParseDayDuration(cfg.TAG_TRENDING_WINDOW)
+		if err != nil {
+			return trending, err
+		}
+		if window < 24*time.Hour || window > 90*24*time.Hour {
+			return trending, fmt.Errorf("tag trending window must be between 1d and 90d")
+		}
+		trending.Window = window
+	}
+
+	if cfg.TAG_TRENDING_HALF_LIFE != "" {
+		halfLife, err := entity.ParseDayDuration(cfg.TAG_TRENDING_HALF_LIFE)
+		if err != nil {
+			return trending, err
+		}
+		if halfLife <= 0 {
+			return trending, fmt.Errorf("tag trending half life must be positive")
+		}
+		trending.HalfLife = halfLife
+	}
+
+	return trending, nil
+}
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
@@ -16,6 +15,7 @@ import (
 	"github.com/elangreza/content-management-system/internal/postgresql"
 	"github.com/elangreza/content-management-system/internal/rest"
 	"github.com/elangreza/content-management-system/internal/service"
+	"github.com/elangreza/content-management-system/internal/webhook"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -42,6 +42,27 @@ func main() {
 	dn, err := config.SetupDB(cfg)
 	errChecker(err)
 
+	mediaStorage, err := config.SetupStorage(cfg)
+	errChecker(err)
+
+	imagePresets, err := config.SetupImagePresets(cfg)
+	errChecker(err)
+
+	tagRules, err := config.SetupTagRules(cfg)
+	errChecker(err)
+
+	tagTrending, err := config.SetupTagTrending(cfg)
+	errChecker(err)
+
+	cacheStore, err := config.SetupCache(cfg)
+	errChecker(err)
+
+	cdnPurger, err := config.SetupCDNPurger(cfg)
+	errChecker(err)
+
+	mailSender, err := config.SetupMailer(cfg)
+	errChecker(err)
+
 	// deps, err := InitializeProductHandler(cfg)
 	// errChecker(err)
 
@@ -54,8 +75,8 @@ func main() {
 	handler.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
-		ExposedHeaders:   []string{"Content-Length", "Content-Type"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "Range", "If-None-Match", "If-Modified-Since", "Last-Event-ID"},
+		ExposedHeaders:   []string{"Content-Length", "Content-Type", "Link", "Accept-Ranges", "Content-Range", "ETag", "Last-Modified"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -65,15 +86,34 @@ func main() {
 	tokenRepo := postgresql.NewTokenRepo(dn)
 	articleRepo := postgresql.NewArticleRepo(dn)
 	tagRepo := postgresql.NewTagRepo(dn)
+	mediaRepo := postgresql.NewMediaRepo(dn)
+	categoryRepo := postgresql.NewCategoryRepo(dn)
+	jobRepo := postgresql.NewJobRepo(dn)
+	webhookRepo := postgresql.NewWebhookRepo(dn)
+	outboxRepo := postgresql.NewOutboxRepo(dn)
+	articleLockRepo := postgresql.NewArticleLockRepo(dn)
+	commentRepo := postgresql.NewCommentRepo(dn)
+	notificationRepo := postgresql.NewNotificationRepo(dn)
 
 	// services
-	authService := service.NewAuthService(userRepo, tokenRepo)
+	authService := service.NewAuthService(userRepo, tokenRepo, cacheStore)
 	profileService := service.NewProfileService(userRepo)
-	tagService := service.NewTagService(articleRepo, tagRepo)
-	articleService := service.NewArticleService(articleRepo, tagService)
+	jobService := service.NewJobService(jobRepo, cfg.JOB_WORKERS)
+	outboxService := service.NewOutboxService(outboxRepo, nil)
+	webhookService := service.NewWebhookService(webhookRepo, jobService, outboxService, webhook.NewSender(nil))
+	tagService := service.NewTagService(articleRepo, tagRepo, jobService, outboxService, tagRules, tagTrending)
+	// the cdn service only works on the events and the jobs
+	service.NewCDNService(jobService, outboxService, cdnPurger)
+	articleLockService := service.NewArticleLockService(articleLockRepo, userRepo, time.Duration(cfg.ARTICLE_LOCK_TTL)*time.Second)
+	articleService := service.NewArticleService(articleRepo, tagService, articleLockService, cacheStore)
+	commentService := service.NewCommentService(commentRepo, articleRepo)
+	notificationService := service.NewNotificationService(notificationRepo, jobService, outboxService, mailSender)
+	eventStreamService := service.NewEventStreamService(outboxService, cfg.EVENT_STREAM_LOG_SIZE)
+	mediaService := service.NewMediaService(mediaRepo, mediaStorage, cfg.MEDIA_MAX_SIZE, imagePresets)
+	categoryService := service.NewCategoryService(categoryRepo)
 
 	rest.NewAuthHandler(handler, authService)
-	rest.NewHandlerWithMiddleware(handler, profileService, authService, articleService, tagService)
+	rest.NewHandlerWithMiddleware(handler, profileService, authService, articleService, tagService, mediaService, categoryService, jobService, webhookService, eventStreamService, articleLockService, commentService, notificationService)
 
 	// Swagger docs endpoint
 	handler.Get("/swagger/*", httpSwagger.Handler())
@@ -85,6 +125,15 @@ func main() {
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
 	}
+	srv.RegisterOnShutdown(eventStreamService.Close)
+	srv.RegisterOnShutdown(articleLockService.Close)
+
+	// background services, they are stopped after the server so their last triggers are drained
+	startCtx, cancelStart := context.WithTimeout(context.Background(), 30*time.Second)
+	errChecker(tagService.Start(startCtx))
+	errChecker(jobService.Start(startCtx))
+	errChecker(outboxService.Start(startCtx))
+	cancelStart()
 
 	go func() {
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
@@ -94,12 +143,29 @@ func main() {
 
 	slog.Info("server started", "port", cfg.HTTP_PORT)
 
-	<-gracefulShutdown(context.Background(), 5*time.Second,
+	<-gracefulShutdown(context.Background(), 30*time.Second,
 		operation{
 			name: "server",
 			shutdownFunc: func(ctx context.Context) error {
 				return srv.Shutdown(ctx)
 			}},
+		operation{
+			name:         "outbox",
+			shutdownFunc: outboxService.Stop,
+		},
+		operation{
+			name:         "jobs",
+			shutdownFunc: jobService.Stop,
+		},
+		operation{
+			name:         "tags",
+			shutdownFunc: tagService.Stop,
+		},
+		operation{
+			name: "cache",
+			shutdownFunc: func(ctx context.Context) error {
+				return cacheStore.Close()
+			}},
 		operation{
 			name: "postgres",
 			shutdownFunc: func(ctx context.Context) error {
@@ -119,6 +185,8 @@ type operation struct {
 	shutdownFunc func(ctx context.Context) error
 }
 
+// gracefulShutdown runs the operations one by one in the given order after a signal,
+// so a dependency is shut down after the operations which use it.
 func gracefulShutdown(ctx context.Context, timeout time.Duration, ops ...operation) <-chan struct{} {
 	wait := make(chan struct{})
 	go func() {
@@ -132,31 +200,29 @@ func gracefulShutdown(ctx context.Context, timeout time.Duration, ops ...operati
 		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
+		done := make(chan struct{})
 		go func() {
-			<-ctx.Done()
-			slog.Info("force quit the app")
-			wait <- struct{}{}
-		}()
-
-		var wg sync.WaitGroup
-
-		for key, op := range ops {
-			wg.Add(1)
-			go func(key int, op operation) {
-				defer wg.Done()
+			defer close(done)
 
+			for _, op := range ops {
 				slog.Info(op.name, "shutdown", "started")
 
 				if err := op.shutdownFunc(ctx); err != nil {
 					slog.Error(op.name, "err", err.Error())
-					return
+					continue
 				}
 
 				slog.Info(op.name, "shutdown", "finished")
-			}(key, op)
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			slog.Info("force quit the app")
 		}
 
-		wg.Wait()
+		wait <- struct{}{}
 	}()
 
 	return wait
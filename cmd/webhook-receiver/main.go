@@ -0,0 +1,33 @@
+// Command webhook-receiver logs the webhook deliveries whose signature is valid, it is used to test the webhooks locally.
+//
+//	go run ./cmd/webhook-receiver -addr :9000 -secret {secret of the webhook}
+//
+// Then create a webhook with the url http://localhost:9000/ and the same secret, and ping it.
+package main
+
+import (
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/elangreza/content-management-system/internal/webhook"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "address to listen on")
+	secret := flag.String("secret", os.Getenv("WEBHOOK_SECRET"), "secret of the webhook, WEBHOOK_SECRET by default")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatal("secret is required")
+	}
+
+	receiver := webhook.NewReceiver(*secret, webhook.DefaultTolerance, func(d webhook.Delivery) {
+		slog.Info("webhook received", "event", d.Event, "delivery_id", d.DeliveryID, "body", string(d.Body))
+	})
+
+	slog.Info("webhook receiver is listening", "addr", *addr)
+	log.Fatal(http.ListenAndServe(*addr, receiver))
+}
@@ -18,6 +18,76 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the background jobs from the newest one with the number of jobs in every status. Use next_before_id as before to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get Jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageJob. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, running, succeeded or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "job type, for example tag.calculate_stats",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id of the last job of the previous page",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
         "/articles": {
             "get": {
                 "security": [
@@ -71,6 +141,30 @@ const docTemplate = `{
                         "name": "page",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Cursor from pagination.next_cursor. Returns the articles after it, page is ignored",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from pagination.prev_cursor. Returns the articles before it, page is ignored",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exact (default) | estimated. Estimated total is cheaper for very large result sets",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "raw (default) | html. html adds body_html with the sanitized HTML of the body",
+                        "name": "render",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Status 0 for draft, 1 for published, 2 for archived (comma-separated, integer values)",
@@ -88,6 +182,18 @@ const docTemplate = `{
                         "description": "Updated by (comma-separated, UUID values)",
                         "name": "updated_by",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID. Returns the articles in the category or one of its subcategories",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached response, 304 is returned when it is not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -98,6 +204,22 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/params.ArticleVersionResponse"
                             }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Changes with the article, its versions, categories and tags, and with the query"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -171,6 +293,101 @@ const docTemplate = `{
                 }
             }
         },
+        "/articles/by-slug/{slug}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get article detail with the given slug. Old slugs of the article are redirected to the current slug.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "articles"
+                ],
+                "summary": "Get article detail by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles.",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Article slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "raw (default) | html. html adds body_html with the sanitized HTML of the body",
+                        "name": "render",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached response, 304 is returned when it is not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached response, 304 is returned when it is not changed",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetArticleDetailResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Changes with the article, its versions, categories and tags, and with the query"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Latest change of the article or one of its versions"
+                            }
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
         "/articles/{articleID}": {
             "get": {
                 "security": [
@@ -202,6 +419,24 @@ const docTemplate = `{
                         "name": "articleID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "raw (default) | html. html adds body_html with the sanitized HTML of the body",
+                        "name": "render",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached response, 304 is returned when it is not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached response, 304 is returned when it is not changed",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -209,6 +444,22 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/params.GetArticleDetailResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Changes with the article, its versions, categories and tags, and with the query"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Latest change of the article or one of its versions"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -231,7 +482,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new article version with reference from an article ID with the given parameters",
+                "description": "Create a new article version with reference from an article ID with the given parameters. It is rejected when the user does not hold the edit lock of the article. The SEO fields which are empty keep the values of the reference version.",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,6 +531,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Conflict"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -343,14 +600,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/articles/{articleID}/versions": {
-            "get": {
+        "/articles/{articleID}/categories": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all versions of an article with the given ID",
+                "description": "Replace the primary and the secondary categories of the article. An empty body removes every category.",
                 "consumes": [
                     "application/json"
                 ],
@@ -360,13 +617,14 @@ const docTemplate = `{
                 "tags": [
                     "articles"
                 ],
-                "summary": "Get all versions of an article by ID",
+                "summary": "Set the categories of an article",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. ",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -374,16 +632,22 @@ const docTemplate = `{
                         "name": "articleID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Set Article Categories Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.SetArticleCategoriesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/params.ArticleVersionResponse"
-                            }
+                            "$ref": "#/definitions/params.ArticleCategoriesResponse"
                         }
                     },
                     "400": {
@@ -392,39 +656,43 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/articles/{articleID}/versions/{articleVersionID}": {
-            "get": {
+        "/articles/{articleID}/comments/{commentID}/resolve": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get article version with the given article ID and article version ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Resolve the thread of its first comment, a resolved thread is not carried to a new version",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "articles"
+                    "comments"
                 ],
-                "summary": "Get article version by ID and article ID",
+                "summary": "Resolve a comment thread",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. ",
+                        "description": "MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -435,17 +703,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Article Version ID",
-                        "name": "articleVersionID",
+                        "description": "ID of the first comment of the thread",
+                        "name": "commentID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/params.ArticleVersionResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -454,35 +722,38 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new article version with reference from an article ID and version ID with the given parameters",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Open the thread of its first comment again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "articles"
+                    "comments"
                 ],
-                "summary": "Create a new article version with reference from an article ID and version ID",
+                "summary": "Unresolve a comment thread",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "description": "MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
@@ -496,26 +767,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Article Version ID",
-                        "name": "articleVersionID",
+                        "description": "ID of the first comment of the thread",
+                        "name": "commentID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Create Article Version Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/params.CreateArticleVersionRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/params.CreateArticleVersionResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -524,37 +786,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/articles/{articleID}/versions/{articleVersionID}/status": {
-            "put": {
+        "/articles/{articleID}/lock": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the status of an article version with the given parameters",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the active edit lock of the article",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "articles"
                 ],
-                "summary": "Update the status of an article version",
+                "summary": "Get the lock of an article",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MUST HAVE PERMISSION UpdateStatusArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
@@ -565,29 +830,13 @@ const docTemplate = `{
                         "name": "articleID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Article Version ID",
-                        "name": "articleVersionID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Article Status Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/params.UpdateArticleStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/params.ArticleLockResponse"
                         }
                     },
                     "400": {
@@ -596,44 +845,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/auth/login": {
+            },
             "post": {
-                "description": "Authenticate a user with email and password.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Take the edit lock of the article, only the holder of the lock creates new versions until it expires. The holder calls it again to renew the lock. An expired lock is taken over by the next user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "articles"
                 ],
-                "summary": "Login User",
+                "summary": "Lock an article",
                 "parameters": [
                     {
-                        "description": "Login User Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/params.LoginUserRequest"
-                        }
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/params.ArticleLockResponse"
                         }
                     },
                     "400": {
@@ -642,6 +902,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Conflict"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -649,34 +921,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user with the provided details.",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Release the edit lock of the article which is held by the user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "articles"
                 ],
-                "summary": "Register User",
+                "summary": "Unlock an article",
                 "parameters": [
                     {
-                        "description": "Register User Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/params.RegisterUserRequest"
-                        }
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
+                    "200": {
                         "description": "ok",
                         "schema": {
                             "type": "string"
@@ -688,6 +965,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -697,37 +980,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile": {
-            "get": {
+        "/articles/{articleID}/lock/force": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the profile of the authenticated user.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove the edit lock of the article which is held by any user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "articles"
                 ],
-                "summary": "Get User Profile",
+                "summary": "Break the lock of an article",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Fill with bearer and token for accessing draft, published, and archived articles. If authorization is not provided default is showing published articles",
+                        "description": "MUST HAVE PERMISSION BreakArticleLock. Fill with bearer and token. The token can be accessed via api /auth/login.",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/params.UserProfileResponse"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
                         }
                     },
                     "500": {
@@ -739,46 +1039,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags": {
+        "/articles/{articleID}/presence": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of tags with optional sorting.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stream who is viewing the article and who holds its edit lock as server-sent events. The user is a viewer while the stream is open. A presence event is sent right away and after every change, its data is params.ArticlePresenceResponse. A comment is sent as heartbeat and the stream ends before the timeout of the request, the client reconnects. The viewers are only seen by the streams on the same instance of the application.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Tags"
+                    "articles"
                 ],
-                "summary": "Get Tags",
+                "summary": "Stream the presence of an article",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise if the token is appered and user habe a permission to read drafted and archiver article, the token can be used to accessing draft, published, and archived articles. ",
+                        "description": "MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Sort by usage_count:asc | usage_count:desc | trending_score:asc | trending_score:desc | name:asc | name:desc | last_used:asc | last_used:desc",
-                        "name": "sort",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "stream of presence events",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/params.GetTagResponse"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -794,47 +1090,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new tag with the provided names.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/articles/{articleID}/related": {
+            "get": {
+                "description": "Rank the other published articles by the tags they share with the article, weighted by how rare the tags are, then by their recency and their tag relationship score. The ranking is refreshed every 10 seconds.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
+                    "articles"
                 ],
-                "summary": "Create Tag",
+                "summary": "Get Related Articles",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Create Tag Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/params.CreateTagRequest"
-                        }
+                        "type": "integer",
+                        "description": "default 5, max 20",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.RelatedArticleResponse"
+                            }
                         }
                     },
                     "400": {
@@ -843,6 +1133,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errs.ValidationError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -852,14 +1148,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags/{name}": {
+        "/articles/{articleID}/versions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific tag by name.",
+                "description": "Get all versions of an article with the given ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -867,29 +1163,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
+                    "articles"
                 ],
-                "summary": "Get Tag",
+                "summary": "Get all versions of an article by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise if the token is appered and user habe a permission to read drafted and archiver article, the token can be used to accessing draft, published, and archived articles. ",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. ",
                         "name": "Authorization",
                         "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "Tag name",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "raw (default) | html. html adds body_html with the sanitized HTML of the body",
+                        "name": "render",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/params.GetTagResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.ArticleVersionResponse"
+                            }
                         }
                     },
                     "400": {
@@ -901,160 +1206,3989 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/rest.APIError"
+                            "type": "object"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "errs.ValidationError": {
-            "type": "object",
-            "properties": {
+        },
+        "/articles/{articleID}/versions/{articleVersionID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get article version with the given article ID and article version ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "articles"
+                ],
+                "summary": "Get article version by ID and article ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. ",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article Version ID",
+                        "name": "articleVersionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "raw (default) | html. html adds body_html with the sanitized HTML of the body",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.ArticleVersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new article version with reference from an article ID and version ID with the given parameters. It is rejected when the user does not hold the edit lock of the article. The SEO fields which are empty keep the values of the reference version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "articles"
+                ],
+                "summary": "Create a new article version with reference from an article ID and version ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article Version ID",
+                        "name": "articleVersionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Article Version Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.CreateArticleVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/params.CreateArticleVersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Conflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{articleID}/versions/{articleVersionID}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the threads of the version in the order of their creation, every thread has its replies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get the comments of an article version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article Version ID",
+                        "name": "articleVersionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only the resolved threads when true, only the unresolved threads when false",
+                        "name": "resolved",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.ArticleCommentThreadResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a thread on the version, optionally anchored to a character range [start, end) of the title or the body, or reply to the first comment of a thread with parent_id. The mentions are the ids of the users with permission ReadDraftedAndArchivedArticle. The unresolved threads are carried to a new version created from this version, an anchor follows its text and is outdated when the text is removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on an article version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article Version ID",
+                        "name": "articleVersionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Comment Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.CreateArticleCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/params.ArticleCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{articleID}/versions/{articleVersionID}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the status of an article version with the given parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "articles"
+                ],
+                "summary": "Update the status of an article version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION UpdateStatusArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "articleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Article Version ID",
+                        "name": "articleVersionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Article Status Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.UpdateArticleStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate a user with email and password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login User",
+                "parameters": [
+                    {
+                        "description": "Login User Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.LoginUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user with the provided details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register User",
+                "parameters": [
+                    {
+                        "description": "Register User Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Get every category as a tree. The root categories and the subcategories are ordered by their position.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get the category tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.CategoryResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a category. Without parent_id the category is a root category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Create a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageCategory. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Category Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/params.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.AlreadyExist"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{categoryID}": {
+            "get": {
+                "description": "Get a category with its subcategories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the name, slug, parent and position of the category. Changing the parent moves the category with its subcategories.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Update a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageCategory. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Category Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.AlreadyExist"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a category. A category with subcategories or articles cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Delete a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageCategory. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Conflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream the committed changes of the articles and the tags as server-sent events, the event name is the event type and the data is the same as the data of the webhooks. article.created, version.created and version.archived are only sent to the users with permission ReadDraftedAndArchivedArticle. A reconnect with Last-Event-ID gets the events it missed, a reset event is sent when some of them are not kept anymore, then the content must be reloaded. A comment is sent as heartbeat and the stream ends before the timeout of the request, the client reconnects.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "stream of events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image into the media library. The type is detected from the content, only jpeg, png, gif and webp are accepted.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Upload media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/params.MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{mediaID}": {
+            "get": {
+                "description": "Stream the content of the media. Range requests are supported.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get media content",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "mediaID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Byte range, for example bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "416": {
+                        "description": "Requested Range Not Satisfiable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the media. Only the uploader can delete it, and only while no article version uses it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Delete media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "mediaID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Forbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Conflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{mediaID}/{preset}": {
+            "get": {
+                "description": "Stream a resized, cropped or converted version of the image, as the preset describes. The derivative is generated on the first request. Range requests are supported.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get media derivative",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "mediaID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset name, for example thumbnail, small, medium, large or og",
+                        "name": "preset",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Byte range, for example bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the notifications of the user from the newest one. The writers of an article are notified when its version is published or archived, the writer of a version and the users in a thread are notified of a comment, and a mentioned user is notified of the mention. Use next_before_id as before to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get my notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only the notifications which are not read",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id of the last notification of the previous page",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetNotificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A user who has not saved the preferences gets every notification in the app only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get my notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.NotificationPreferenceResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Choose in_app to only see the notifications in the app, or email to also get them by email. The notifications of the muted types are not created. The email delivery can only be chosen when email_available is true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update my notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification Preference Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.NotificationPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.NotificationPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark every notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.MarkNotificationsReadResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{notificationID}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "notificationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the profile of the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get User Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token for accessing draft, published, and archived articles. If authorization is not provided default is showing published articles",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.UserProfileResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of tags with optional sorting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise if the token is appered and user habe a permission to read drafted and archiver article, the token can be used to accessing draft, published, and archived articles. ",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by usage_count:asc | usage_count:desc | trending_score:asc | trending_score:desc | name:asc | name:desc | last_used:asc | last_used:desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit. All tags are returned when limit, after and before are empty",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from pagination.next_cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from pagination.prev_cursor",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.GetTagResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new tag with the provided names. The names are normalized and the aliases are replaced with their canonical tag.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Create Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Tag Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.CreateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete every tag which is not used by any article version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete Unused Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.DeleteUnusedTagsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the source tags with the target tag in every article version, then delete the source tags. The source tags become synonyms of the target tag. The target tag is created when it does not exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Merge Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge Tags Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.MergeTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/suggest": {
+            "get": {
+                "description": "Rank the used tags by how often they occur with the current tags of the article, how well they match the typed prefix and their trending score. Without q the related and trending tags are suggested.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Suggest Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated current tags of the article, for example go,cms",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "prefix of the tag being typed",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 10, max 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.TagSuggestionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/trending": {
+            "get": {
+                "description": "List the tags published in the window ordered by their trending score. Every publish adds a use on its day and the older days decay with the configured half life.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Trending Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "window in days, for example 7d. default TAG_TRENDING_WINDOW, max 90d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 10, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.TrendingTagResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific tag by name. A name which is not found is normalized and its alias is resolved, so Golang finds the tag go when golang is its alias.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise if the token is appered and user habe a permission to read drafted and archiver article, the token can be used to accessing draft, published, and archived articles. ",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the display name, description, color and slug of the tag. A different name renames the tag in every article version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Update Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Tag Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.UpdateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.AlreadyExist"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a tag which is not used by any article version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Conflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{name}/stats": {
+            "get": {
+                "description": "Count the versions using the tag by the day they were first published, per day, week or month, with the tags used together with it and the authors using it the most in the range. Set format=csv or the Accept header text/csv to download the statistics as csv.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tag Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day, for example 2025-08-01. default 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day, for example 2025-08-31. default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week or month. default day",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of the co-occurring tags and the authors, default 10, max 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or csv. default json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.TagStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{name}/synonyms": {
+            "get": {
+                "description": "Retrieve the aliases which are replaced with the tag when they are written.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tag Synonyms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.TagSynonymResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Map the aliases to the tag. The aliases are normalized, an existing tag cannot become an alias and must be merged instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Create Tag Synonyms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Tag Synonyms Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.CreateTagSynonymsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.TagSynonymResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errs.AlreadyExist"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{name}/synonyms/{alias}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the alias of the tag. The tags which are already written are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete Tag Synonym",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every webhook without its secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/params.WebhookResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a url to the events: article.created, version.created, version.published, version.archived, article.deleted, tag.created and comment.created. Without events every event is sent. Every delivery is signed with the secret in the X-Webhook-Signature header as sha256={hex of HMAC-SHA256 of \"{X-Webhook-Timestamp}.{body}\"}. A random secret is generated when it is empty, it is only shown in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Webhook Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{deliveryID}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the event of the delivery again in a new delivery, the attempts of the original delivery are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a webhook without its secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the url, events and active of the webhook. The secret is kept when it is empty. A disabled webhook does not get new deliveries, it can still be pinged and redelivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Webhook Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook with its deliveries",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the deliveries of the webhook from the newest one with the result of their last attempt. Use next_before_id as before to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, succeeded or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id of the last delivery of the previous page",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/params.GetWebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/ping": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a ping event to the webhook, the result is shown in its deliveries. It can be used to test a local receiver.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Ping a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login.",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/params.WebhookDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.NotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "constanta.BlockType": {
+            "type": "string",
+            "enum": [
+                "paragraph",
+                "heading",
+                "image",
+                "quote",
+                "embed",
+                "code",
+                "callout"
+            ],
+            "x-enum-varnames": [
+                "ParagraphBlock",
+                "HeadingBlock",
+                "ImageBlock",
+                "QuoteBlock",
+                "EmbedBlock",
+                "CodeBlock",
+                "CalloutBlock"
+            ]
+        },
+        "constanta.BodyFormat": {
+            "type": "string",
+            "enum": [
+                "markdown",
+                "html",
+                "plain"
+            ],
+            "x-enum-varnames": [
+                "Markdown",
+                "HTML",
+                "Plain"
+            ]
+        },
+        "constanta.CommentAnchorField": {
+            "type": "string",
+            "enum": [
+                "title",
+                "body"
+            ],
+            "x-enum-varnames": [
+                "CommentAnchorTitle",
+                "CommentAnchorBody"
+            ]
+        },
+        "constanta.EventType": {
+            "type": "string",
+            "enum": [
+                "article.created",
+                "version.created",
+                "version.published",
+                "version.archived",
+                "article.deleted",
+                "tag.created",
+                "comment.created",
+                "ping"
+            ],
+            "x-enum-varnames": [
+                "EventArticleCreated",
+                "EventVersionCreated",
+                "EventVersionPublished",
+                "EventVersionArchived",
+                "EventArticleDeleted",
+                "EventTagCreated",
+                "EventCommentCreated",
+                "EventPing"
+            ]
+        },
+        "constanta.JobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "succeeded",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "JobPending",
+                "JobRunning",
+                "JobSucceeded",
+                "JobDead"
+            ]
+        },
+        "constanta.JobType": {
+            "type": "string",
+            "enum": [
+                "tag.calculate_stats",
+                "tag.calculate_article_relation",
+                "cdn.purge",
+                "webhook.deliver",
+                "notification.send_email"
+            ],
+            "x-enum-varnames": [
+                "JobCalculateTagStats",
+                "JobCalculateArticleTagRelation",
+                "JobPurgeCDN",
+                "JobDeliverWebhook",
+                "JobSendNotificationEmail"
+            ]
+        },
+        "constanta.NotificationDelivery": {
+            "type": "string",
+            "enum": [
+                "in_app",
+                "email"
+            ],
+            "x-enum-varnames": [
+                "NotificationDeliveryInApp",
+                "NotificationDeliveryEmail"
+            ]
+        },
+        "constanta.NotificationType": {
+            "type": "string",
+            "enum": [
+                "version.published",
+                "version.archived",
+                "comment",
+                "mention"
+            ],
+            "x-enum-varnames": [
+                "NotificationVersionPublished",
+                "NotificationVersionArchived",
+                "NotificationComment",
+                "NotificationMention"
+            ]
+        },
+        "constanta.TagStatsInterval": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-varnames": [
+                "TagStatsDay",
+                "TagStatsWeek",
+                "TagStatsMonth"
+            ]
+        },
+        "constanta.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryPending",
+                "WebhookDeliverySucceeded",
+                "WebhookDeliveryFailed"
+            ]
+        },
+        "entity.Block": {
+            "type": "object",
+            "properties": {
+                "alt": {
+                    "description": "image",
+                    "type": "string"
+                },
+                "caption": {
+                    "description": "image, quote and embed",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "description": "code",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "heading, 1 to 6",
+                    "type": "integer"
+                },
+                "media_id": {
+                    "description": "image, uploaded into the media library. Used instead of the url",
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "paragraph, heading, quote, code and callout",
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/constanta.BlockType"
+                },
+                "url": {
+                    "description": "image and embed",
+                    "type": "string"
+                },
+                "variant": {
+                    "description": "callout, info by default",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BlockDocument": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Block"
+                    }
+                },
+                "schema_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "errs.AlreadyExist": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "errs.Conflict": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "errs.Forbidden": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "errs.NotFound": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "errs.ValidationError": {
+            "type": "object",
+            "properties": {
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "params.ArticleVersionResponse": {
+        "params.ArticleCategoriesResponse": {
+            "type": "object",
+            "properties": {
+                "breadcrumbs": {
+                    "description": "from the root to the primary category",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.BreadcrumbResponse"
+                    }
+                },
+                "primary_category": {
+                    "$ref": "#/definitions/params.CategoryResponse"
+                },
+                "secondary_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.CategoryResponse"
+                    }
+                }
+            }
+        },
+        "params.ArticleCommentResponse": {
+            "type": "object",
+            "properties": {
+                "anchor": {
+                    "$ref": "#/definitions/params.CommentAnchorResponse"
+                },
+                "article_id": {
+                    "type": "integer"
+                },
+                "article_version_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "carried_from_id": {
+                    "description": "id of the comment of the previous version which this comment is carried from",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "created_by_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.CommentMentionResponse"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.ArticleCommentThreadResponse": {
+            "type": "object",
+            "properties": {
+                "anchor": {
+                    "$ref": "#/definitions/params.CommentAnchorResponse"
+                },
+                "article_id": {
+                    "type": "integer"
+                },
+                "article_version_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "carried_from_id": {
+                    "description": "id of the comment of the previous version which this comment is carried from",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "created_by_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.CommentMentionResponse"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.ArticleCommentResponse"
+                    }
+                },
+                "resolved": {
+                    "type": "boolean"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.ArticleLockResponse": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "locked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.ArticleVersionResponse": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "article_version_id": {
+                    "type": "integer"
+                },
+                "blocks": {
+                    "description": "only when the version is created from blocks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BlockDocument"
+                        }
+                    ]
+                },
+                "body": {
+                    "type": "string"
+                },
+                "body_format": {
+                    "$ref": "#/definitions/constanta.BodyFormat"
+                },
+                "body_html": {
+                    "description": "sanitized HTML of the body, only when requested with render=html",
+                    "type": "string"
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "description": "generated from the body when empty",
+                    "type": "string"
+                },
+                "images": {
+                    "description": "media library images used by the body or the blocks, with their responsive sizes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.ImageResponse"
+                    }
+                },
+                "og_image": {
+                    "description": "open graph image url",
+                    "type": "string"
+                },
+                "seo_description": {
+                    "type": "string"
+                },
+                "seo_title": {
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "generated from the title when empty",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "tag_relationship_score": {
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.BreadcrumbResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.CategoryRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "empty for a root category",
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "order between the siblings, ascending",
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "generated from the name when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "params.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "subcategories ordered by their position. only filled in the tree",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.CategoryResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "depth": {
+                    "description": "zero for a root category",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.CommentAnchorRequest": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "field": {
+                    "description": "title or body",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constanta.CommentAnchorField"
+                        }
+                    ]
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.CommentAnchorResponse": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "field": {
+                    "$ref": "#/definitions/constanta.CommentAnchorField"
+                },
+                "outdated": {
+                    "description": "the text is not in this version anymore",
+                    "type": "boolean"
+                },
+                "start": {
+                    "description": "zero when the anchor is outdated",
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "the anchored text when the comment was created",
+                    "type": "string"
+                }
+            }
+        },
+        "params.CommentMentionResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.CreateArticleCommentRequest": {
+            "type": "object",
+            "properties": {
+                "anchor": {
+                    "description": "only for a new thread, empty when the comment is not anchored",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/params.CommentAnchorRequest"
+                        }
+                    ]
+                },
+                "body": {
+                    "type": "string"
+                },
+                "mentions": {
+                    "description": "id of the mentioned users",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parent_id": {
+                    "description": "id of the first comment of the thread which is replied, zero starts a new thread",
+                    "type": "integer"
+                }
+            }
+        },
+        "params.CreateArticleRequest": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "description": "alternative of the body. The body is generated from the blocks as markdown",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BlockDocument"
+                        }
+                    ]
+                },
+                "body": {
+                    "type": "string"
+                },
+                "body_format": {
+                    "description": "markdown by default",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constanta.BodyFormat"
+                        }
+                    ]
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "description": "generated from the body when empty",
+                    "type": "string"
+                },
+                "media_ids": {
+                    "description": "media library items used by the body. Media of the image blocks are added automatically",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "og_image": {
+                    "description": "open graph image url",
+                    "type": "string"
+                },
+                "seo_description": {
+                    "type": "string"
+                },
+                "seo_title": {
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "generated from the title when empty",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.CreateArticleResponse": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "article_version_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.CreateArticleVersionRequest": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "description": "alternative of the body. The body is generated from the blocks as markdown",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BlockDocument"
+                        }
+                    ]
+                },
+                "body": {
+                    "type": "string"
+                },
+                "body_format": {
+                    "description": "markdown by default",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constanta.BodyFormat"
+                        }
+                    ]
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "description": "generated from the body when empty",
+                    "type": "string"
+                },
+                "media_ids": {
+                    "description": "media library items used by the body. Media of the image blocks are added automatically",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "og_image": {
+                    "description": "open graph image url",
+                    "type": "string"
+                },
+                "seo_description": {
+                    "type": "string"
+                },
+                "seo_title": {
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "generated from the title when empty",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.CreateArticleVersionResponse": {
+            "type": "object",
+            "properties": {
+                "article_version_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.CreateTagRequest": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "params.CreateTagSynonymsRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "replaced with the tag when they are used as a tag",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "params.DeleteUnusedTagsResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.GetArticleDetailResponse": {
+            "type": "object",
+            "properties": {
+                "archived_version": {
+                    "$ref": "#/definitions/params.ArticleVersionResponse"
+                },
+                "categories": {
+                    "description": "categories of the article with the breadcrumbs of the primary category",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/params.ArticleCategoriesResponse"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "drafted_version": {
+                    "$ref": "#/definitions/params.ArticleVersionResponse"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "published_version": {
+                    "$ref": "#/definitions/params.ArticleVersionResponse"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.GetJobsResponse": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "number of jobs in every status",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.JobResponse"
+                    }
+                },
+                "next_before_id": {
+                    "description": "before of the next page, zero on the last page",
+                    "type": "integer"
+                }
+            }
+        },
+        "params.GetNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "next_before_id": {
+                    "description": "before of the next page, zero on the last page",
+                    "type": "integer"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.NotificationResponse"
+                    }
+                },
+                "unread_count": {
+                    "description": "the notifications of the user which are not read, in every page",
+                    "type": "integer"
+                }
+            }
+        },
+        "params.GetTagResponse": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "shown instead of the name when it is not empty",
+                    "type": "string"
+                },
+                "last_used": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "trending_score": {
+                    "type": "number"
+                },
+                "usage_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.GetWebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.WebhookDeliveryResponse"
+                    }
+                },
+                "next_before_id": {
+                    "description": "before of the next page, zero on the last page",
+                    "type": "integer"
+                }
+            }
+        },
+        "params.ImageResponse": {
+            "type": "object",
+            "properties": {
+                "derivatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.MediaDerivativeResponse"
+                    }
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "media_id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "srcset": {
+                    "description": "responsive sizes with width descriptors, usable as the srcset attribute of an img",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.JobResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/constanta.JobStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/constanta.JobType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.LoginUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.MarkNotificationsReadResponse": {
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "description": "number of the notifications which were not read before",
+                    "type": "integer"
+                }
+            }
+        },
+        "params.MediaDerivativeResponse": {
+            "type": "object",
+            "properties": {
+                "file_name": {
+                    "description": "file name of the original with the extension of the derivative",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "media_id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "preset": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "hex sha256 of the content",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "path of the content, usable as the src of an image",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.MergeTagsRequest": {
+            "type": "object",
+            "properties": {
+                "sources": {
+                    "description": "tags which are deleted after their article versions get the target",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "created when it does not exist",
+                    "type": "string"
+                }
+            }
+        },
+        "params.NotificationPreferenceRequest": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "description": "in_app or email, the email is sent besides the notification in the app",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constanta.NotificationDelivery"
+                        }
+                    ]
+                },
+                "muted_types": {
+                    "description": "version.published, version.archived, comment or mention",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constanta.NotificationType"
+                    }
+                }
+            }
+        },
+        "params.NotificationPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "$ref": "#/definitions/constanta.NotificationDelivery"
+                },
+                "email_available": {
+                    "description": "false when no mail server is configured, the email delivery cannot be chosen then",
+                    "type": "boolean"
+                },
+                "muted_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constanta.NotificationType"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.NotificationResponse": {
             "type": "object",
             "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
                 "article_id": {
                     "type": "integer"
                 },
                 "article_version_id": {
                     "type": "integer"
                 },
-                "body": {
-                    "type": "string"
+                "comment_id": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "string"
-                },
-                "status": {
+                "id": {
                     "type": "integer"
                 },
-                "tag_relationship_score": {
-                    "type": "number"
+                "message": {
+                    "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "read": {
+                    "type": "boolean"
                 },
-                "title": {
+                "read_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "type": {
+                    "$ref": "#/definitions/constanta.NotificationType"
+                }
+            }
+        },
+        "params.RegisterUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "updated_by": {
+                "name": {
                     "type": "string"
                 },
-                "version": {
-                    "type": "integer"
+                "password": {
+                    "type": "string"
                 }
             }
         },
-        "params.CreateArticleRequest": {
+        "params.RelatedArticleResponse": {
             "type": "object",
             "properties": {
-                "body": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "article_version_id": {
+                    "type": "integer"
+                },
+                "excerpt": {
                     "type": "string"
                 },
-                "tags": {
+                "published_at": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "between 0 and 1, the related articles are ordered by it",
+                    "type": "number"
+                },
+                "shared_tags": {
+                    "description": "tags of the article which the related article has too",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "slug": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 }
             }
         },
-        "params.CreateArticleResponse": {
+        "params.SetArticleCategoriesRequest": {
             "type": "object",
             "properties": {
-                "article_id": {
+                "primary_category_id": {
+                    "description": "zero removes every category of the article",
                     "type": "integer"
                 },
-                "article_version_id": {
-                    "type": "integer"
+                "secondary_category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "params.CreateArticleVersionRequest": {
+        "params.TagAuthorResponse": {
             "type": "object",
             "properties": {
-                "body": {
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.TagCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "params.CreateArticleVersionResponse": {
+        "params.TagStatsBucketResponse": {
             "type": "object",
             "properties": {
-                "article_version_id": {
+                "count": {
                     "type": "integer"
+                },
+                "start": {
+                    "type": "string"
                 }
             }
         },
-        "params.CreateTagRequest": {
+        "params.TagStatsResponse": {
             "type": "object",
             "properties": {
-                "names": {
+                "co_occurring_tags": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/params.TagCountResponse"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "$ref": "#/definitions/constanta.TagStatsInterval"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "series": {
+                    "description": "every bucket of the range, including the empty ones",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.TagStatsBucketResponse"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "top_authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/params.TagAuthorResponse"
                     }
+                },
+                "total": {
+                    "description": "number of published versions using the tag in the range",
+                    "type": "integer"
                 }
             }
         },
-        "params.GetArticleDetailResponse": {
+        "params.TagSuggestionResponse": {
             "type": "object",
             "properties": {
-                "archived_version": {
-                    "$ref": "#/definitions/params.ArticleVersionResponse"
-                },
-                "created_at": {
-                    "type": "string"
+                "co_occurrence": {
+                    "description": "number of published article versions which have the tag and one of the current tags",
+                    "type": "integer"
                 },
-                "created_by": {
+                "name": {
                     "type": "string"
                 },
-                "drafted_version": {
-                    "$ref": "#/definitions/params.ArticleVersionResponse"
+                "prefix_match": {
+                    "description": "the tag starts with the query, or one of its words does",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "integer"
+                "score": {
+                    "description": "between 0 and 1, the suggestions are ordered by it",
+                    "type": "number"
                 },
-                "published_version": {
-                    "$ref": "#/definitions/params.ArticleVersionResponse"
+                "trending_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "params.TagSynonymResponse": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "created_at": {
                     "type": "string"
                 },
-                "updated_by": {
+                "tag_name": {
                     "type": "string"
                 }
             }
         },
-        "params.GetTagResponse": {
+        "params.TrendingTagResponse": {
             "type": "object",
             "properties": {
                 "last_used": {
@@ -1067,63 +5201,159 @@ const docTemplate = `{
                     "type": "number"
                 },
                 "usage_count": {
+                    "description": "number of times the tag is published in the window",
                     "type": "integer"
                 }
             }
         },
-        "params.LoginUserRequest": {
+        "params.UpdateArticleStatusRequest": {
             "type": "object",
             "properties": {
-                "email": {
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "params.UpdateTagRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "description": "hex color, for example #1e90ff",
                     "type": "string"
                 },
-                "password": {
+                "description": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "renames the tag in every article version when it differs from the current name",
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "generated from the name when empty",
                     "type": "string"
                 }
             }
         },
-        "params.RegisterUserRequest": {
+        "params.UserProfileResponse": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "password": {
+                "role": {
+                    "type": "integer"
+                },
+                "role_name": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "params.UpdateArticleStatusRequest": {
+        "params.WebhookDeliveryResponse": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/constanta.EventType"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
                 "status": {
+                    "$ref": "#/definitions/constanta.WebhookDeliveryStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
                     "type": "integer"
                 }
             }
         },
-        "params.UserProfileResponse": {
+        "params.WebhookRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "active": {
+                    "description": "only used when a webhook is replaced, a new webhook is active",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "empty subscribes every event",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constanta.EventType"
+                    }
+                },
+                "secret": {
+                    "description": "generated when a webhook is created without it, kept when a webhook is replaced without it",
                     "type": "string"
                 },
-                "email": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "params.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "created_by": {
                     "type": "string"
                 },
-                "role": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constanta.EventType"
+                    }
+                },
+                "id": {
                     "type": "integer"
                 },
-                "role_name": {
+                "secret": {
+                    "description": "only shown when the secret is set",
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
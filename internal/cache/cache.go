@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when the key does not exist or is expired.
+var ErrNotFound = errors.New("cache key not found")
+
+// Cache keeps values for a while. A missing key is not an error of the caller,
+// the value is loaded from the database again.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set keeps the value until the ttl passes, a zero ttl keeps it until it is evicted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete does not return an error when a key does not exist.
+	Delete(ctx context.Context, keys ...string) error
+	Close() error
+}
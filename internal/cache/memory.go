@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMemoryCacheSize = 10000
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiredAt time.Time
+}
+
+// MemoryCache keeps the values in the process. When it is full the least recently used key is evicted.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// the front is the most recently used entry
+	lru *list.List
+	now func() time.Time
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+// NewMemoryCache keeps at most size keys, the default size is used when it is not positive.
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = defaultMemoryCacheSize
+	}
+
+	return &MemoryCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+func (mc *MemoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	elem, ok := mc.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if !entry.expiredAt.IsZero() && !mc.now().Before(entry.expiredAt) {
+		mc.remove(elem)
+		return nil, ErrNotFound
+	}
+
+	mc.lru.MoveToFront(elem)
+
+	return entry.value, nil
+}
+
+func (mc *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	var expiredAt time.Time
+	if ttl > 0 {
+		expiredAt = mc.now().Add(ttl)
+	}
+
+	if elem, ok := mc.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value, entry.expiredAt = value, expiredAt
+		mc.lru.MoveToFront(elem)
+		return nil
+	}
+
+	mc.entries[key] = mc.lru.PushFront(&memoryEntry{key: key, value: value, expiredAt: expiredAt})
+	if mc.lru.Len() > mc.size {
+		mc.remove(mc.lru.Back())
+	}
+
+	return nil
+}
+
+func (mc *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := mc.entries[key]; ok {
+			mc.remove(elem)
+		}
+	}
+
+	return nil
+}
+
+func (mc *MemoryCache) Close() error {
+	return nil
+}
+
+func (mc *MemoryCache) remove(elem *list.Element) {
+	mc.lru.Remove(elem)
+	delete(mc.entries, elem.Value.(*memoryEntry).key)
+}
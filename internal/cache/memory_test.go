@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	mc := NewMemoryCache(2)
+	mc.now = func() time.Time { return now }
+
+	assert.NoError(t, mc.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, mc.Set(ctx, "b", []byte("2"), 0))
+
+	got, err := mc.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), got)
+
+	// b is the least recently used key
+	assert.NoError(t, mc.Set(ctx, "c", []byte("3"), time.Minute))
+	_, err = mc.Get(ctx, "b")
+	assert.Equal(t, ErrNotFound, err)
+
+	now = now.Add(time.Minute)
+	_, err = mc.Get(ctx, "a")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, mc.Delete(ctx, "c", "unknown"))
+	_, err = mc.Get(ctx, "c")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, 0, mc.lru.Len())
+}
+
+func TestMemoryCache_SetReplaces(t *testing.T) {
+	ctx := context.Background()
+	mc := NewMemoryCache(0)
+
+	assert.NoError(t, mc.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, mc.Set(ctx, "a", []byte("2"), 0))
+
+	got, err := mc.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("2"), got)
+	assert.Equal(t, 1, mc.lru.Len())
+}
@@ -0,0 +1,287 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRedisPoolSize = 10
+	defaultRedisTimeout  = time.Second
+)
+
+type RedisConfig struct {
+	// redis://[:password@]host:port[/db], for example redis://localhost:6379/0
+	URL string
+	// number of idle connections which are kept, default 10
+	PoolSize int
+	// limit of a command when the context has no deadline, default 1 second
+	Timeout time.Duration
+}
+
+// RedisCache keeps the values in a server which speaks the redis protocol, so the instances share them.
+// Only GET, SET with PX and DEL are used, so any redis compatible server works.
+type RedisCache struct {
+	addr     string
+	password string
+	db       int
+	timeout  time.Duration
+	dialer   net.Dialer
+	// idle connections, a connection is taken out while a command uses it
+	idle chan *redisConn
+}
+
+var _ Cache = (*RedisCache)(nil)
+
+type redisConn struct {
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// redisError is an error reply of the server, the connection can still be used after it.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+func NewRedisCache(cfg RedisConfig) (*RedisCache, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "redis" || u.Host == "" {
+		return nil, fmt.Errorf("%s is not valid redis url", cfg.URL)
+	}
+
+	rc := &RedisCache{
+		addr:    u.Host,
+		timeout: cfg.Timeout,
+	}
+
+	if u.User != nil {
+		rc.password, _ = u.User.Password()
+	}
+
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		rc.db, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not valid redis db", db)
+		}
+	}
+
+	if rc.timeout <= 0 {
+		rc.timeout = defaultRedisTimeout
+	}
+
+	poolSize := cfg.PoolSize
+	if poolSize <= 0 {
+		poolSize = defaultRedisPoolSize
+	}
+	rc.idle = make(chan *redisConn, poolSize)
+
+	return rc, nil
+}
+
+func (rc *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := rc.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+
+	// a missing key is a nil bulk string
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+
+	return value, nil
+}
+
+func (rc *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+
+	_, err := rc.do(ctx, args...)
+	return err
+}
+
+func (rc *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := rc.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// Close closes the idle connections.
+func (rc *RedisCache) Close() error {
+	for {
+		select {
+		case c := <-rc.idle:
+			c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (rc *RedisCache) do(ctx context.Context, args ...string) (any, error) {
+	c, err := rc.getConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.do(rc.deadline(ctx), args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// the state of the connection is unknown after a network error
+		c.conn.Close()
+		return nil, err
+	}
+
+	rc.putConn(c)
+
+	return reply, err
+}
+
+func (rc *RedisCache) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(rc.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+
+	return deadline
+}
+
+func (rc *RedisCache) getConn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-rc.idle:
+		return c, nil
+	default:
+	}
+
+	dialCtx, cancel := context.WithDeadline(ctx, rc.deadline(ctx))
+	defer cancel()
+
+	conn, err := rc.dialer.DialContext(dialCtx, "tcp", rc.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &redisConn{conn: conn, rd: bufio.NewReader(conn)}
+
+	if rc.password != "" {
+		if _, err := c.do(rc.deadline(ctx), "AUTH", rc.password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if rc.db != 0 {
+		if _, err := c.do(rc.deadline(ctx), "SELECT", strconv.Itoa(rc.db)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (rc *RedisCache) putConn(c *redisConn) {
+	select {
+	case rc.idle <- c:
+	default:
+		c.conn.Close()
+	}
+}
+
+// do sends the command as an array of bulk strings and reads its reply.
+func (c *redisConn) do(deadline time.Time, args ...string) (any, error) {
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+
+	return readRedisReply(c.rd)
+}
+
+// readRedisReply reads a reply of the RESP2 protocol. A nil bulk string is returned as nil.
+func readRedisReply(rd *bufio.Reader) (any, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(rd, buf); err != nil {
+			return nil, err
+		}
+		return buf[:size], nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, size)
+		for i := range items {
+			items[i], err = readRedisReply(rd)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply %q", line)
+	}
+}
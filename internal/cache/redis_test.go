@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis is a local stand-in of a redis compatible server with the commands which are used by RedisCache.
+type fakeRedis struct {
+	mu       sync.Mutex
+	password string
+	values   map[string]string
+	ttls     map[string]string
+	listener net.Listener
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+
+	fr := &fakeRedis{password: password, values: map[string]string{}, ttls: map[string]string{}, listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go fr.serve(conn)
+		}
+	}()
+
+	t.Cleanup(func() { listener.Close() })
+
+	return fr
+}
+
+func (fr *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	rd := bufio.NewReader(conn)
+	authenticated := fr.password == ""
+	for {
+		reply, err := readRedisReply(rd)
+		if err != nil {
+			return
+		}
+
+		var args []string
+		for _, item := range reply.([]any) {
+			args = append(args, string(item.([]byte)))
+		}
+
+		if args[0] != "AUTH" && !authenticated {
+			fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+			continue
+		}
+
+		fr.mu.Lock()
+		switch args[0] {
+		case "AUTH":
+			authenticated = args[1] == fr.password
+			if authenticated {
+				fmt.Fprint(conn, "+OK\r\n")
+			} else {
+				fmt.Fprint(conn, "-WRONGPASS invalid password\r\n")
+			}
+		case "SELECT":
+			fmt.Fprint(conn, "+OK\r\n")
+		case "GET":
+			value, ok := fr.values[args[1]]
+			if ok {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(value), value)
+			} else {
+				fmt.Fprint(conn, "$-1\r\n")
+			}
+		case "SET":
+			fr.values[args[1]] = args[2]
+			if len(args) == 5 && args[3] == "PX" {
+				fr.ttls[args[1]] = args[4]
+			}
+			fmt.Fprint(conn, "+OK\r\n")
+		case "DEL":
+			deleted := 0
+			for _, key := range args[1:] {
+				if _, ok := fr.values[key]; ok {
+					deleted++
+					delete(fr.values, key)
+				}
+			}
+			fmt.Fprintf(conn, ":%d\r\n", deleted)
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		fr.mu.Unlock()
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	fr := newFakeRedis(t, "secret")
+	ctx := context.Background()
+
+	rc, err := NewRedisCache(RedisConfig{URL: "redis://:secret@" + fr.listener.Addr().String() + "/1"})
+	assert.NoError(t, err)
+	defer rc.Close()
+
+	_, err = rc.Get(ctx, "article:1")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, rc.Set(ctx, "article:1", []byte("line\r\nbreak"), 1500*time.Millisecond))
+	fr.mu.Lock()
+	assert.Equal(t, strconv.Itoa(1500), fr.ttls["article:1"])
+	fr.mu.Unlock()
+
+	got, err := rc.Get(ctx, "article:1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("line\r\nbreak"), got)
+
+	assert.NoError(t, rc.Delete(ctx, "article:1", "article:2"))
+	_, err = rc.Get(ctx, "article:1")
+	assert.Equal(t, ErrNotFound, err)
+	// the connection is reused
+	assert.Len(t, rc.idle, 1)
+}
+
+func TestRedisCache_WrongPassword(t *testing.T) {
+	fr := newFakeRedis(t, "secret")
+
+	rc, err := NewRedisCache(RedisConfig{URL: "redis://:wrong@" + fr.listener.Addr().String()})
+	assert.NoError(t, err)
+
+	_, err = rc.Get(context.Background(), "key")
+	assert.ErrorContains(t, err, "WRONGPASS")
+	assert.Len(t, rc.idle, 0)
+}
+
+func TestNewRedisCache(t *testing.T) {
+	for _, raw := range []string{"localhost:6379", "http://localhost:6379", "redis://localhost:6379/x"} {
+		_, err := NewRedisCache(RedisConfig{URL: raw})
+		assert.Error(t, err, raw)
+	}
+
+	rc, err := NewRedisCache(RedisConfig{URL: "redis://:pass@cache:6380/2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cache:6380", rc.addr)
+	assert.Equal(t, "pass", rc.password)
+	assert.Equal(t, 2, rc.db)
+}
@@ -0,0 +1,87 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Purger invalidates the cached responses of the paths in a CDN.
+type Purger interface {
+	Purge(ctx context.Context, paths []string) error
+}
+
+type HTTPPurgerConfig struct {
+	// endpoint which receives the paths, for example the purge api of the CDN or a small adapter of it
+	URL string
+	// sent as a bearer token when it is not empty
+	Token string
+	// a client with a 10 seconds timeout when nil
+	HTTPClient *http.Client
+}
+
+// HTTPPurger posts the paths as {"paths": [...]} to the purge endpoint.
+type HTTPPurger struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ Purger = (*HTTPPurger)(nil)
+
+func NewHTTPPurger(cfg HTTPPurgerConfig) (*HTTPPurger, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("%s is not valid purge url", cfg.URL)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &HTTPPurger{
+		url:    cfg.URL,
+		token:  cfg.Token,
+		client: client,
+	}, nil
+}
+
+func (hp *HTTPPurger) Purge(ctx context.Context, paths []string) error {
+	body, err := json.Marshal(map[string][]string{"paths": paths})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hp.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if hp.token != "" {
+		req.Header.Set("Authorization", "Bearer "+hp.token)
+	}
+
+	res, err := hp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("purge responded with %d: %s", res.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
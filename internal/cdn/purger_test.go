@@ -0,0 +1,46 @@
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPPurger_Purge(t *testing.T) {
+	var gotPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("bad token"))
+			return
+		}
+
+		var body struct {
+			Paths []string `json:"paths"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		gotPaths = body.Paths
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	hp, err := NewHTTPPurger(HTTPPurgerConfig{URL: srv.URL, Token: "secret"})
+	assert.NoError(t, err)
+	assert.NoError(t, hp.Purge(context.Background(), []string{"/articles/1", "/articles"}))
+	assert.Equal(t, []string{"/articles/1", "/articles"}, gotPaths)
+
+	hp, err = NewHTTPPurger(HTTPPurgerConfig{URL: srv.URL, Token: "wrong"})
+	assert.NoError(t, err)
+	assert.EqualError(t, hp.Purge(context.Background(), []string{"/articles/1"}), "purge responded with 401: bad token")
+}
+
+func TestNewHTTPPurger(t *testing.T) {
+	for _, raw := range []string{"", "cdn.local/purge", "ftp://cdn.local/purge"} {
+		_, err := NewHTTPPurger(HTTPPurgerConfig{URL: raw})
+		assert.Error(t, err, raw)
+	}
+}
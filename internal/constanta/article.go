@@ -7,3 +7,14 @@ const (
 	Published
 	Archived
 )
+
+type BodyFormat string
+
+const (
+	// rendered with CommonMark and GFM. Raw HTML inside the markdown is not rendered
+	Markdown BodyFormat = "markdown"
+	// sanitized on create, so only allowed elements are stored
+	HTML BodyFormat = "html"
+	// escaped when rendered
+	Plain BodyFormat = "plain"
+)
@@ -0,0 +1,16 @@
+package constanta
+
+type BlockType string
+
+const (
+	ParagraphBlock BlockType = "paragraph"
+	HeadingBlock   BlockType = "heading"
+	ImageBlock     BlockType = "image"
+	QuoteBlock     BlockType = "quote"
+	EmbedBlock     BlockType = "embed"
+	CodeBlock      BlockType = "code"
+	CalloutBlock   BlockType = "callout"
+)
+
+// CurrentBlockSchemaVersion is bumped when the block document changes incompatibly
+const CurrentBlockSchemaVersion = 1
@@ -0,0 +1,9 @@
+package constanta
+
+// CommentAnchorField is the field of the version which a comment is anchored to.
+type CommentAnchorField string
+
+const (
+	CommentAnchorTitle CommentAnchorField = "title"
+	CommentAnchorBody  CommentAnchorField = "body"
+)
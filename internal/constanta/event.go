@@ -0,0 +1,38 @@
+package constanta
+
+// EventType names a change of the content which is sent to the webhooks and the event stream.
+type EventType string
+
+const (
+	EventArticleCreated   EventType = "article.created"
+	EventVersionCreated   EventType = "version.created"
+	EventVersionPublished EventType = "version.published"
+	EventVersionArchived  EventType = "version.archived"
+	EventArticleDeleted   EventType = "article.deleted"
+	EventTagCreated       EventType = "tag.created"
+	EventCommentCreated   EventType = "comment.created"
+	// only sent by the ping of a webhook, it cannot be subscribed
+	EventPing EventType = "ping"
+)
+
+// Events are the event types which can be subscribed.
+var Events = []EventType{
+	EventArticleCreated,
+	EventVersionCreated,
+	EventVersionPublished,
+	EventVersionArchived,
+	EventArticleDeleted,
+	EventTagCreated,
+	EventCommentCreated,
+}
+
+// IsDraftOrArchived tells whether the event shows a draft or an archived version, or the review of a version,
+// which is only readable with the ReadDraftedAndArchivedArticle permission.
+func (e EventType) IsDraftOrArchived() bool {
+	switch e {
+	case EventArticleCreated, EventVersionCreated, EventVersionArchived, EventCommentCreated:
+		return true
+	}
+
+	return false
+}
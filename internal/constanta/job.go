@@ -0,0 +1,29 @@
+package constanta
+
+// JobType selects the handler of a background job.
+type JobType string
+
+const (
+	// reloads the tag statistics, enqueued when the tags or the published versions are changed
+	JobCalculateTagStats JobType = "tag.calculate_stats"
+	// scores the tags of an article version, enqueued when it is created or published
+	JobCalculateArticleTagRelation JobType = "tag.calculate_article_relation"
+	// invalidates the public paths of an article in the CDN, enqueued when the published version changes
+	JobPurgeCDN JobType = "cdn.purge"
+	// sends a webhook delivery to its receiver, enqueued for every subscribed webhook of an event
+	JobDeliverWebhook JobType = "webhook.deliver"
+	// sends a notification to the email of its user, enqueued for the users who chose the email delivery
+	JobSendNotificationEmail JobType = "notification.send_email"
+)
+
+type JobStatus string
+
+const (
+	// waiting for its run_at, also after a failed attempt
+	JobPending JobStatus = "pending"
+	// claimed by a worker
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	// failed every attempt, it is kept for the inspection
+	JobDead JobStatus = "dead"
+)
@@ -0,0 +1,31 @@
+package constanta
+
+type ImageFormat string
+
+const (
+	JPEG ImageFormat = "jpeg"
+	PNG  ImageFormat = "png"
+	// lossless, the encoder does not support lossy webp
+	WebP ImageFormat = "webp"
+)
+
+func (f ImageFormat) MimeType() string {
+	return "image/" + string(f)
+}
+
+func (f ImageFormat) Extension() string {
+	if f == JPEG {
+		return ".jpg"
+	}
+
+	return "." + string(f)
+}
+
+type ImageFit string
+
+const (
+	// scaled down to fit inside the size, the aspect ratio is kept
+	FitContain ImageFit = "contain"
+	// scaled and cropped from the center to fill the size exactly
+	FitCover ImageFit = "cover"
+)
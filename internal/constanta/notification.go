@@ -0,0 +1,31 @@
+package constanta
+
+// NotificationType tells why a user is notified, a user can mute each type.
+type NotificationType string
+
+const (
+	// a version of an article which the user wrote is published
+	NotificationVersionPublished NotificationType = "version.published"
+	// a version of an article which the user wrote is archived
+	NotificationVersionArchived NotificationType = "version.archived"
+	// a comment on a version which the user wrote, or in a thread which the user is in
+	NotificationComment NotificationType = "comment"
+	// the user is mentioned in a comment
+	NotificationMention NotificationType = "mention"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationVersionPublished,
+	NotificationVersionArchived,
+	NotificationComment,
+	NotificationMention,
+}
+
+type NotificationDelivery string
+
+const (
+	// the notification is only shown by GET /notifications
+	NotificationDeliveryInApp NotificationDelivery = "in_app"
+	// the notification is also sent to the email of the user
+	NotificationDeliveryEmail NotificationDelivery = "email"
+)
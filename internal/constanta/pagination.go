@@ -0,0 +1,10 @@
+package constanta
+
+type CountMode int8
+
+const (
+	// exact total with COUNT(*) OVER ()
+	ExactCount CountMode = iota
+	// planner estimation, for very large result sets
+	EstimatedCount
+)
@@ -7,4 +7,9 @@ const (
 	CreateArticle
 	DeleteArticle
 	UpdateStatusArticle
+	ManageCategory
+	ManageTag
+	ManageJob
+	ManageWebhook
+	BreakArticleLock
 )
@@ -0,0 +1,22 @@
+package constanta
+
+// TagSlugMode decides how the separators of a tag name are written.
+type TagSlugMode string
+
+const (
+	// the separators are kept, for example "machine learning"
+	TagSlugNone TagSlugMode = "none"
+	// spaces and underscores become a single dash, for example "machine-learning"
+	TagSlugDash TagSlugMode = "dash"
+	// the name is transliterated into an ascii slug, for example "cafe-creme"
+	TagSlugASCII TagSlugMode = "ascii"
+)
+
+// TagStatsInterval is the bucket size of the tag statistics, the value is the date_trunc field of postgres.
+type TagStatsInterval string
+
+const (
+	TagStatsDay   TagStatsInterval = "day"
+	TagStatsWeek  TagStatsInterval = "week"
+	TagStatsMonth TagStatsInterval = "month"
+)
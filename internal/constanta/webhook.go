@@ -0,0 +1,12 @@
+package constanta
+
+type WebhookDeliveryStatus string
+
+const (
+	// waiting for its first attempt
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// the receiver responded with 2xx
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// the last attempt failed, it is retried until the job is dead
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
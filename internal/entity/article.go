@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
@@ -22,20 +24,57 @@ type (
 	}
 
 	ArticleVersion struct {
-		ArticleVersionID     int64
-		ArticleID            int64
-		Title                string
-		Body                 string
+		ArticleVersionID int64
+		ArticleID        int64
+		Title            string
+		Body             string
+		BodyFormat       constanta.BodyFormat
+		// nil when the version is not created from blocks
+		Blocks               *BlockDocument
 		Version              int64
 		Status               constanta.ArticleVersionStatus
 		Tags                 []Tag
 		TagRelationShipScore float64
+		// media used by the version, it cannot be deleted while it is used
+		MediaIDs []int64
+		// the unresolved comments of this version are carried to a new version, zero when none are carried
+		CommentsFromVersionID int64
+		ArticleMetadata
 
 		CreatedBy uuid.UUID
 		CreatedAt time.Time
 		UpdatedBy uuid.UUID
 		UpdatedAt *time.Time
 	}
+
+	// RelatedArticle is a published article ranked by the tags it shares with another article.
+	RelatedArticle struct {
+		ArticleID        int64
+		ArticleVersionID int64
+		Title            string
+		Slug             string
+		Excerpt          string
+		SharedTags       []string
+		Score            float64
+		PublishedAt      time.Time
+	}
+
+	// CacheValidator changes whenever a read of the articles can change, so the read is only built when it is not cached yet.
+	CacheValidator struct {
+		Key string
+		// zero for the lists, a deleted article does not move it
+		LastModified time.Time
+	}
+
+	// ArticleMetadata is stored per version, so each version can be published with its own slug and SEO data.
+	ArticleMetadata struct {
+		Slug           string
+		Excerpt        string
+		SEOTitle       string
+		SEODescription string
+		CanonicalURL   string
+		OGImage        string
+	}
 )
 
 func NewArticle(title, body string, createdBy uuid.UUID) *Article {
@@ -57,6 +96,33 @@ func NewArticleVersion(articleID int64, title, body string, createdBy uuid.UUID,
 	}
 }
 
+const maxExcerptLength = 200
+
+// NewExcerpt returns the beginning of the body, cut at a word boundary.
+func NewExcerpt(body string) string {
+	excerpt := strings.Join(strings.Fields(body), " ")
+	runes := []rune(excerpt)
+	if len(runes) <= maxExcerptLength {
+		return excerpt
+	}
+
+	excerpt = string(runes[:maxExcerptLength])
+	if i := strings.LastIndex(excerpt, " "); i > 0 {
+		excerpt = excerpt[:i]
+	}
+
+	return strings.TrimRight(excerpt, " ,.;:") + "…"
+}
+
+// SetMetadata sets the metadata of the version. The excerpt is generated from the body when empty.
+func (av *ArticleVersion) SetMetadata(metadata ArticleMetadata) {
+	if metadata.Excerpt == "" {
+		metadata.Excerpt = NewExcerpt(av.Body)
+	}
+
+	av.ArticleMetadata = metadata
+}
+
 type GetArticlesQueryServiceParams struct {
 	Search      string
 	Status      []constanta.ArticleVersionStatus
@@ -65,4 +131,50 @@ type GetArticlesQueryServiceParams struct {
 	OrderClause string
 	Limit       int
 	Page        int
+
+	// keyset pagination. when Cursor is set, Page is ignored
+	// and the rows are ordered by Sorts instead of OrderClause
+	Sorts  []SortField
+	Cursor *Cursor
+
+	CountMode constanta.CountMode
+
+	// articles in the category or one of its descendants. zero means every article
+	CategoryID int64
+}
+
+// CursorValues returns the values of the given sort keys, used to build the
+// keyset cursor pointing at this article version.
+func (av ArticleVersion) CursorValues(sorts []SortField) []string {
+	values := make([]string, len(sorts))
+	for i, sort := range sorts {
+		switch sort.Key {
+		case "article_id":
+			values[i] = strconv.FormatInt(av.ArticleID, 10)
+		case "article_version_id":
+			values[i] = strconv.FormatInt(av.ArticleVersionID, 10)
+		case "created_by":
+			values[i] = av.CreatedBy.String()
+		case "updated_by":
+			values[i] = av.UpdatedBy.String()
+		case "title":
+			values[i] = av.Title
+		case "status":
+			values[i] = strconv.Itoa(int(av.Status))
+		case "version":
+			values[i] = strconv.FormatInt(av.Version, 10)
+		case "created_at":
+			values[i] = av.CreatedAt.Format(time.RFC3339Nano)
+		case "updated_at":
+			// null updated_at is sorted as -infinity
+			values[i] = "-infinity"
+			if av.UpdatedAt != nil {
+				values[i] = av.UpdatedAt.Format(time.RFC3339Nano)
+			}
+		case "tag_relationship_score":
+			values[i] = strconv.FormatFloat(av.TagRelationShipScore, 'g', -1, 64)
+		}
+	}
+
+	return values
 }
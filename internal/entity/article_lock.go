@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// ArticleLock is the edit lock of an article, only its holder creates the new versions until it expires.
+	ArticleLock struct {
+		ArticleID int64
+		UserID    uuid.UUID
+		// name of the holder
+		UserName  string
+		ExpiresAt time.Time
+		CreatedAt time.Time
+	}
+
+	ArticleViewer struct {
+		UserID uuid.UUID
+		Name   string
+	}
+
+	// ArticlePresence is who is viewing the article and who is editing it.
+	ArticlePresence struct {
+		ArticleID int64
+		Viewers   []ArticleViewer
+		// nil when the article is not locked
+		Lock *ArticleLock
+	}
+
+	// PresenceSubscription gets the presence of an article every time a viewer joins or leaves, or its lock changes.
+	PresenceSubscription struct {
+		ArticleID int64
+		Updates   <-chan ArticlePresence
+	}
+)
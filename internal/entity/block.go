@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+)
+
+type (
+	// BlockDocument is the structured alternative of the body.
+	// It is stored as JSON, so the json tags are part of the schema.
+	BlockDocument struct {
+		SchemaVersion int     `json:"schema_version"`
+		Blocks        []Block `json:"blocks"`
+	}
+
+	// Block keeps its ID between versions, so diffs and comments can target it.
+	Block struct {
+		ID   string              `json:"id"`
+		Type constanta.BlockType `json:"type"`
+		// paragraph, heading, quote, code and callout
+		Text string `json:"text,omitempty"`
+		// heading, 1 to 6
+		Level int `json:"level,omitempty"`
+		// image and embed
+		URL string `json:"url,omitempty"`
+		// image, uploaded into the media library. Used instead of the url
+		MediaID int64 `json:"media_id,omitempty"`
+		// image
+		Alt string `json:"alt,omitempty"`
+		// image, quote and embed
+		Caption string `json:"caption,omitempty"`
+		// code
+		Language string `json:"language,omitempty"`
+		// callout, info by default
+		Variant string `json:"variant,omitempty"`
+	}
+)
+
+// NewBlockID returns a random ID for a block that is created without one.
+func NewBlockID() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MediaIDs returns the media library IDs used by the image blocks, in order and without duplicates.
+func (bd BlockDocument) MediaIDs() []int64 {
+	var ids []int64
+	for _, block := range bd.Blocks {
+		if block.Type == constanta.ImageBlock && block.MediaID > 0 && !slices.Contains(ids, block.MediaID) {
+			ids = append(ids, block.MediaID)
+		}
+	}
+
+	return ids
+}
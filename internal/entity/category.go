@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Category struct {
+		ID int64
+		// nil for the root categories
+		ParentID *int64
+		Name     string
+		Slug     string
+		// ids from the root to the category itself, for example /1/4/9/
+		Path  string
+		Depth int
+		// order between the siblings
+		Position int
+
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+	}
+
+	ArticleCategory struct {
+		Category
+		IsPrimary bool
+	}
+)
+
+// CategoryPath returns the path of a category under the parent with the given path.
+// The parent path is empty for the root categories.
+func CategoryPath(parentPath string, id int64) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+
+	return parentPath + strconv.FormatInt(id, 10) + "/"
+}
+
+// PathIDs returns the ids of the ancestors and the category itself, from the root.
+func (c Category) PathIDs() []int64 {
+	var ids []int64
+	for _, part := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Contains reports whether the other category is the category itself or one of its descendants.
+func (c Category) Contains(other Category) bool {
+	return c.Path != "" && strings.HasPrefix(other.Path, c.Path)
+}
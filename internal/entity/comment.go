@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/google/uuid"
+)
+
+type (
+	// ArticleComment is a review comment of a version. The first comment of a thread has no parent,
+	// it holds the anchor and the resolution of the thread.
+	ArticleComment struct {
+		ID               int64
+		ArticleID        int64
+		ArticleVersionID int64
+		// zero for the first comment of a thread
+		ParentID int64
+		// nil when the comment is not anchored
+		Anchor   *CommentAnchor
+		Body     string
+		Mentions []uuid.UUID
+		// nil when the thread is not resolved
+		ResolvedBy *uuid.UUID
+		ResolvedAt *time.Time
+		// zero when the comment is not carried from a previous version
+		CarriedFromID int64
+
+		CreatedBy     uuid.UUID
+		CreatedByName string
+		CreatedAt     time.Time
+		UpdatedAt     *time.Time
+	}
+
+	// CommentAnchor is a character range of the title or the body, the end is excluded.
+	CommentAnchor struct {
+		Field constanta.CommentAnchorField
+		Start int
+		End   int
+		// the anchored text when the comment was created
+		Text string
+		// the text is not found in the version which the comment is carried to
+		Outdated bool
+	}
+
+	CommentEventData struct {
+		CommentID        int64       `json:"comment_id"`
+		ArticleID        int64       `json:"article_id"`
+		ArticleVersionID int64       `json:"article_version_id"`
+		ParentID         int64       `json:"parent_id,omitempty"`
+		CreatedBy        uuid.UUID   `json:"created_by"`
+		Mentions         []uuid.UUID `json:"mentions,omitempty"`
+	}
+)
+
+// NewCommentAnchor anchors a comment to the characters [start, end) of the text.
+// It returns false when the range is not in the text.
+func NewCommentAnchor(field constanta.CommentAnchorField, text string, start, end int) (*CommentAnchor, bool) {
+	runes := []rune(text)
+	if start < 0 || start >= end || end > len(runes) {
+		return nil, false
+	}
+
+	return &CommentAnchor{
+		Field: field,
+		Start: start,
+		End:   end,
+		Text:  string(runes[start:end]),
+	}, true
+}
+
+// IsResolved tells whether the thread of the first comment is resolved.
+func (ac ArticleComment) IsResolved() bool {
+	return ac.ResolvedAt != nil
+}
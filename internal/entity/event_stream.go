@@ -0,0 +1,11 @@
+package entity
+
+// EventSubscription receives the committed events of the content which its user can read.
+type EventSubscription struct {
+	// the events after the Last-Event-ID which are still in the log of the stream
+	Backlog []OutboxEvent
+	// some events after the Last-Event-ID are not in the log anymore, the subscriber must reload the content
+	Reset bool
+	// closed when the subscriber is too slow, it resumes with the id of its last event
+	Events <-chan OutboxEvent
+}
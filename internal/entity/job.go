@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+)
+
+const DefaultJobMaxAttempts = 5
+
+type (
+	Job struct {
+		ID      int64
+		Type    constanta.JobType
+		Payload json.RawMessage
+		Status  constanta.JobStatus
+		// number of the started attempts, including the running one
+		Attempts    int
+		MaxAttempts int
+		// the job is not claimed before this time
+		RunAt     time.Time
+		LastError string
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+	}
+
+	PurgeCDNPayload struct {
+		Paths []string
+	}
+
+	// JobFilter lists the jobs from the newest, the empty values are not filtered.
+	JobFilter struct {
+		Status constanta.JobStatus
+		Type   constanta.JobType
+		// jobs older than this id, for the next page
+		BeforeID int64
+		Limit    int
+	}
+)
+
+// NewJob encodes the payload of a job which is run as soon as possible.
+func NewJob(jobType constanta.JobType, payload any) (*Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Job{
+		Type:        jobType,
+		Payload:     raw,
+		Status:      constanta.JobPending,
+		MaxAttempts: DefaultJobMaxAttempts,
+		RunAt:       time.Now(),
+	}, nil
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/google/uuid"
+)
+
+type Media struct {
+	ID         int64
+	StorageKey string
+	FileName   string
+	MimeType   string
+	Size       int64
+	// hex sha256 of the content
+	Checksum string
+	Width    int
+	Height   int
+
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+// MediaPath returns the path where the media content is served.
+func MediaPath(id int64) string {
+	return "/media/" + strconv.FormatInt(id, 10)
+}
+
+// ImagePreset describes a derivative of an image, addressed as /media/{id}/{name}.
+type ImagePreset struct {
+	Name string
+	// zero height with contain fit means a width only responsive size
+	Width  int
+	Height int
+	Fit    constanta.ImageFit
+	Format constanta.ImageFormat
+}
+
+// DefaultImagePresets are used when the presets are not configured.
+var DefaultImagePresets = []ImagePreset{
+	{Name: "thumbnail", Width: 200, Height: 200, Fit: constanta.FitCover, Format: constanta.WebP},
+	{Name: "small", Width: 480, Fit: constanta.FitContain, Format: constanta.WebP},
+	{Name: "medium", Width: 960, Fit: constanta.FitContain, Format: constanta.WebP},
+	{Name: "large", Width: 1600, Fit: constanta.FitContain, Format: constanta.WebP},
+	{Name: "og", Width: 1200, Height: 630, Fit: constanta.FitCover, Format: constanta.JPEG},
+}
+
+// Responsive reports whether the preset is one of the widths of the srcset.
+func (ip ImagePreset) Responsive() bool {
+	return ip.Fit == constanta.FitContain && ip.Height == 0
+}
+
+// Size returns the size of the derivative of an image with the given size.
+// Images are never upscaled.
+func (ip ImagePreset) Size(width, height int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+
+	if ip.Fit == constanta.FitCover {
+		w, h := ip.Width, ip.Height
+		// the crop keeps the ratio of the preset when the image is smaller
+		if scale := min(float64(width)/float64(w), float64(height)/float64(h), 1); scale < 1 {
+			w, h = max(1, int(float64(w)*scale+0.5)), max(1, int(float64(h)*scale+0.5))
+		}
+		return w, h
+	}
+
+	scale := 1.0
+	if ip.Width > 0 {
+		scale = min(scale, float64(ip.Width)/float64(width))
+	}
+	if ip.Height > 0 {
+		scale = min(scale, float64(ip.Height)/float64(height))
+	}
+
+	return max(1, int(float64(width)*scale+0.5)), max(1, int(float64(height)*scale+0.5))
+}
+
+// DerivativePath returns the path where the derivative of the media is served.
+func DerivativePath(id int64, preset string) string {
+	return MediaPath(id) + "/" + preset
+}
+
+var mediaPathRegex = regexp.MustCompile(`/media/(\d+)\b`)
+
+// MediaIDsFromBody returns the media library IDs referenced by the body, in order and without duplicates.
+func MediaIDsFromBody(body string) []int64 {
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, match := range mediaPathRegex.FindAllStringSubmatch(body, -1) {
+		id, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids
+}
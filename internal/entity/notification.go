@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/google/uuid"
+)
+
+type (
+	Notification struct {
+		ID     int64
+		UserID uuid.UUID
+		Type   constanta.NotificationType
+		// id of the outbox event which created the notification
+		EventID          int64
+		ArticleID        int64
+		ArticleVersionID int64
+		// zero when the notification is not about a comment
+		CommentID int64
+		// nil when the user who made the change is not known
+		ActorID   *uuid.UUID
+		ActorName string
+		Message   string
+		// nil when the notification is not read
+		ReadAt    *time.Time
+		CreatedAt time.Time
+	}
+
+	// NotificationAudience is the change of a version which is notified, with the users who are notified about it.
+	NotificationAudience struct {
+		ArticleID        int64
+		ArticleVersionID int64
+		// zero when the change is not a comment
+		CommentID int64
+		Title     string
+		Version   int64
+		ActorID   *uuid.UUID
+		ActorName string
+		// the writers of the article, and for a comment the users in its thread, without the actor
+		Recipients []uuid.UUID
+	}
+
+	NotificationPreference struct {
+		UserID     uuid.UUID
+		Delivery   constanta.NotificationDelivery
+		MutedTypes []constanta.NotificationType
+		UpdatedAt  *time.Time
+	}
+
+	// NotificationFilter lists the notifications of a user from the newest.
+	NotificationFilter struct {
+		UserID uuid.UUID
+		// only the notifications which are not read
+		Unread bool
+		// notifications older than this id, for the next page
+		BeforeID int64
+		Limit    int
+	}
+
+	// NotificationEmail is a notification with the address of its user.
+	NotificationEmail struct {
+		Notification
+		UserName  string
+		UserEmail string
+	}
+
+	SendNotificationEmailPayload struct {
+		NotificationID int64
+	}
+)
+
+// DefaultNotificationPreference is used for the users who have not saved their preference,
+// every notification is shown in the app only.
+func DefaultNotificationPreference(userID uuid.UUID) NotificationPreference {
+	return NotificationPreference{
+		UserID:     userID,
+		Delivery:   constanta.NotificationDeliveryInApp,
+		MutedTypes: []constanta.NotificationType{},
+	}
+}
+
+func (np NotificationPreference) Mutes(notificationType constanta.NotificationType) bool {
+	return slices.Contains(np.MutedTypes, notificationType)
+}
+
+// NewNotification notifies the user about the change of the audience.
+func NewNotification(userID uuid.UUID, notificationType constanta.NotificationType, eventID int64, audience NotificationAudience) Notification {
+	actor := audience.ActorName
+	if actor == "" {
+		actor = "someone"
+	}
+
+	target := fmt.Sprintf("version %d of %q", audience.Version, audience.Title)
+
+	var message string
+	switch notificationType {
+	case constanta.NotificationVersionPublished:
+		message = fmt.Sprintf("%s published %s", actor, target)
+	case constanta.NotificationVersionArchived:
+		message = fmt.Sprintf("%s archived %s", actor, target)
+	case constanta.NotificationMention:
+		message = fmt.Sprintf("%s mentioned you in a comment on %s", actor, target)
+	default:
+		message = fmt.Sprintf("%s commented on %s", actor, target)
+	}
+
+	return Notification{
+		UserID:           userID,
+		Type:             notificationType,
+		EventID:          eventID,
+		ArticleID:        audience.ArticleID,
+		ArticleVersionID: audience.ArticleVersionID,
+		CommentID:        audience.CommentID,
+		ActorID:          audience.ActorID,
+		ActorName:        audience.ActorName,
+		Message:          message,
+	}
+}
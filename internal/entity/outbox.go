@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+)
+
+// OutboxEvent is a domain event which is saved in the transaction of its change,
+// it is published by the relay after the transaction is committed.
+type OutboxEvent struct {
+	ID      int64
+	Type    constanta.EventType
+	Payload json.RawMessage
+	// number of the started publishes, including the running one
+	Attempts int
+	// the event is dead after this attempt fails
+	MaxAttempts int
+	// the subscribers which handled the event in an earlier publish
+	Delivered []string
+	CreatedAt time.Time
+}
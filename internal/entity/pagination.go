@@ -0,0 +1,15 @@
+package entity
+
+type (
+	SortField struct {
+		Key  string
+		Desc bool
+	}
+
+	// Cursor is the decoded keyset position. Values are ordered the same as
+	// the sort fields it was created from.
+	Cursor struct {
+		Values   []string
+		Backward bool
+	}
+)
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxSlugLength = 100
+
+// letters that are not decomposed into a base letter and a mark by NFD
+var slugTransliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'ø': "o", 'Ø': "o", 'œ': "oe", 'Œ': "oe",
+	'đ': "d", 'Đ': "d", 'ð': "d", 'Ð': "d", 'þ': "th", 'Þ': "th", 'ł': "l", 'Ł': "l",
+	'ı': "i", '&': " and ",
+}
+
+// NewSlug transliterates the text into a lowercase url safe slug.
+// Characters that cannot be transliterated into ascii are dropped.
+func NewSlug(text string) string {
+	var replaced strings.Builder
+	for _, r := range text {
+		if val, ok := slugTransliterations[r]; ok {
+			replaced.WriteString(val)
+			continue
+		}
+		replaced.WriteRune(r)
+	}
+
+	// decompose the accented letters and remove the marks. e.g. é => e
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	ascii, _, err := transform.String(t, replaced.String())
+	if err != nil {
+		ascii = replaced.String()
+	}
+
+	var slug strings.Builder
+	lastIsDash := true
+	for _, r := range strings.ToLower(ascii) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			slug.WriteRune(r)
+			lastIsDash = false
+		case !lastIsDash:
+			slug.WriteRune('-')
+			lastIsDash = true
+		}
+	}
+
+	result := strings.Trim(slug.String(), "-")
+	if len(result) > maxSlugLength {
+		result = strings.TrimRight(result[:maxSlugLength], "-")
+	}
+
+	return result
+}
+
+// IsValidSlug reports whether the slug only contains lowercase ascii letters, digits and single dashes.
+func IsValidSlug(slug string) bool {
+	if slug == "" || len(slug) > maxSlugLength {
+		return false
+	}
+
+	return slug == NewSlug(slug)
+}
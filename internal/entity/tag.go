@@ -1,10 +1,43 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/google/uuid"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
 
 type (
 	Tag struct {
 		Name string
+		// shown instead of the name when it is not empty
+		DisplayName string
+		Description string
+		// hex color, for example #1e90ff
+		Color string
+		Slug  string
+	}
+
+	// TagSynonym maps an alias to the canonical tag, the alias is replaced with the tag when it is written.
+	TagSynonym struct {
+		Alias     string
+		TagName   string
+		CreatedAt time.Time
+	}
+
+	// TagRules are applied to the tag names of the articles and of the tag api.
+	TagRules struct {
+		// in characters
+		MaxLength     int
+		MaxPerArticle int
+		SlugMode      constanta.TagSlugMode
 	}
 
 	ArticleVersionTag struct {
@@ -18,6 +51,52 @@ type (
 		TrendingScore float64
 	}
 
+	// TagUsageDaily is the number of times the tag is published on the day.
+	TagUsageDaily struct {
+		TagName string
+		Day     time.Time
+		Count   int
+	}
+
+	// TagStatsFilter selects the published versions of the tag between From and To, To is exclusive.
+	TagStatsFilter struct {
+		TagName  string
+		Interval constanta.TagStatsInterval
+		From     time.Time
+		To       time.Time
+		// number of the co-occurring tags and the authors
+		Limit int
+	}
+
+	TagStats struct {
+		// only the buckets with usage, ordered by their start
+		Series          []TagStatsBucket
+		CoOccurringTags []TagCount
+		TopAuthors      []TagAuthor
+	}
+
+	TagStatsBucket struct {
+		Start time.Time
+		Count int
+	}
+
+	TagCount struct {
+		Name  string
+		Count int
+	}
+
+	TagAuthor struct {
+		ID    uuid.UUID
+		Name  string
+		Count int
+	}
+
+	// TagTrending configures the trending score, the daily usage in the window decays with the half life.
+	TagTrending struct {
+		Window   time.Duration
+		HalfLife time.Duration
+	}
+
 	CalculateArticleVersionTagRelationShipScorePayload struct {
 		Tags             []Tag
 		ArticleVersionID int64
@@ -31,3 +110,114 @@ func NewTags(tags ...string) []Tag {
 	}
 	return result
 }
+
+// DefaultTagRules are used when the tag rules are not configured.
+var DefaultTagRules = TagRules{
+	MaxLength:     50,
+	MaxPerArticle: 20,
+	SlugMode:      constanta.TagSlugDash,
+}
+
+// DefaultTagTrending is used when the trending score is not configured.
+var DefaultTagTrending = TagTrending{
+	Window:   7 * 24 * time.Hour,
+	HalfLife: 2 * 24 * time.Hour,
+}
+
+// ParseDayDuration parses a duration which can be written in days, for example 7d, 36h or 1d12h.
+func ParseDayDuration(text string) (time.Duration, error) {
+	days, rest, ok := strings.Cut(text, "d")
+	if !ok {
+		return time.ParseDuration(text)
+	}
+
+	n, err := strconv.Atoi(days)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s is not valid duration", text)
+	}
+
+	duration := time.Duration(n) * 24 * time.Hour
+	if rest != "" {
+		extra, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not valid duration", text)
+		}
+		duration += extra
+	}
+
+	return duration, nil
+}
+
+// TagStatsBucketStart returns the start of the bucket of t in UTC, the same as date_trunc of postgres.
+// The weeks start on monday.
+func TagStatsBucketStart(t time.Time, interval constanta.TagStatsInterval) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	switch interval {
+	case constanta.TagStatsWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case constanta.TagStatsMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// NextTagStatsBucket returns the start of the bucket after the bucket starting at start.
+func NextTagStatsBucket(start time.Time, interval constanta.TagStatsInterval) time.Time {
+	switch interval {
+	case constanta.TagStatsWeek:
+		return start.AddDate(0, 0, 7)
+	case constanta.TagStatsMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+var tagCaseFolder = cases.Fold()
+
+// NormalizeTagName trims, normalizes with NFKC and case folds the name, then writes
+// its separators with the slug mode. e.g. " Go_Lang " => "go-lang" with the dash mode.
+func NormalizeTagName(name string, mode constanta.TagSlugMode) string {
+	name = norm.NFKC.String(strings.TrimSpace(name))
+	// folding can produce a string which is not NFKC anymore
+	name = norm.NFKC.String(tagCaseFolder.String(name))
+
+	switch mode {
+	case constanta.TagSlugASCII:
+		return NewSlug(name)
+	case constanta.TagSlugDash:
+		return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '_' || r == '-'
+		}), "-")
+	default:
+		return strings.Join(strings.Fields(name), " ")
+	}
+}
+
+// reservedTagNames are the paths of the tag API next to /tags/{name}, a tag with one of these names could not be read.
+var reservedTagNames = []string{"suggest", "trending"}
+
+// IsReservedTagName reports whether the normalized name is used by a path of the tag API.
+func IsReservedTagName(name string) bool {
+	return slices.Contains(reservedTagNames, name)
+}
+
+// IsValidTagName reports whether the normalized name only contains letters, digits,
+// spaces and the characters - + # . used by names like c++, c# and node.js.
+func IsValidTagName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+		case r == ' ', r == '-', r == '+', r == '#', r == '.':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/google/uuid"
+)
+
+type (
+	Webhook struct {
+		ID  int64
+		URL string
+		// key of the HMAC-SHA256 signature of the deliveries
+		Secret string
+		// subscribed events, empty subscribes every event
+		Events []constanta.EventType
+		// a disabled webhook does not get new deliveries
+		Active    bool
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+	}
+
+	// WebhookDelivery is an event sent to a webhook, the attempts of a redelivery are kept in a new delivery.
+	WebhookDelivery struct {
+		ID        int64
+		WebhookID int64
+		// the relayed event of the delivery, zero for the pings and the redeliveries
+		OutboxEventID int64
+		Event         constanta.EventType
+		// data of the event
+		Payload  json.RawMessage
+		Status   constanta.WebhookDeliveryStatus
+		Attempts int
+		// status code and the beginning of the body of the last response, zero when there is no response
+		ResponseStatus int
+		ResponseBody   string
+		LastError      string
+		CreatedAt      time.Time
+		UpdatedAt      *time.Time
+		DeliveredAt    *time.Time
+	}
+
+	// WebhookDeliveryAttempt is the result of sending a delivery once.
+	WebhookDeliveryAttempt struct {
+		Status         constanta.WebhookDeliveryStatus
+		ResponseStatus int
+		ResponseBody   string
+		LastError      string
+	}
+
+	// WebhookDeliveryFilter lists the deliveries of a webhook from the newest, the empty values are not filtered.
+	WebhookDeliveryFilter struct {
+		WebhookID int64
+		Status    constanta.WebhookDeliveryStatus
+		// deliveries older than this id, for the next page
+		BeforeID int64
+		Limit    int
+	}
+
+	DeliverWebhookPayload struct {
+		DeliveryID int64
+	}
+
+	// ArticleEventData is the data of the article and version events.
+	ArticleEventData struct {
+		ArticleID int64 `json:"article_id"`
+		// empty for article.deleted
+		ArticleVersionID int64  `json:"article_version_id,omitempty"`
+		Version          int64  `json:"version,omitempty"`
+		Title            string `json:"title,omitempty"`
+		Slug             string `json:"slug,omitempty"`
+		// slug of the published version replaced by version.published, only when it is another slug
+		PreviousSlug string   `json:"previous_slug,omitempty"`
+		Tags         []string `json:"tags,omitempty"`
+	}
+
+	TagEventData struct {
+		Name string `json:"name"`
+	}
+)
+
+// NewWebhook subscribes the url to the events, a random secret is generated when it is empty.
+func NewWebhook(url, secret string, events []constanta.EventType, createdBy uuid.UUID) (*Webhook, error) {
+	if secret == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(key)
+	}
+
+	return &Webhook{
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Active:    true,
+		CreatedBy: createdBy,
+	}, nil
+}
+
+// Subscribes checks whether the webhook gets the event.
+func (w Webhook) Subscribes(event constanta.EventType) bool {
+	return w.Active && (len(w.Events) == 0 || slices.Contains(w.Events, event))
+}
+
+// NewWebhookDelivery encodes the data of the event for the webhook.
+func NewWebhookDelivery(webhookID int64, event constanta.EventType, data any) (*WebhookDelivery, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookDelivery{
+		WebhookID: webhookID,
+		Event:     event,
+		Payload:   raw,
+		Status:    constanta.WebhookDeliveryPending,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"net/http"
+)
+
+type Conflict struct {
+	Message string
+}
+
+func (e Conflict) Error() string {
+	if e.Message == "" {
+		return "conflict"
+	}
+
+	return e.Message
+}
+
+func (a Conflict) HttpStatusCode() int {
+	return http.StatusConflict
+}
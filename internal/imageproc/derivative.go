@@ -0,0 +1,69 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Derivative resizes or crops the image as the preset describes and encodes it with the format of the preset.
+// The metadata of the source is never copied.
+func Derivative(src io.Reader, preset entity.ImagePreset) ([]byte, error) {
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return nil, err
+	}
+
+	b := img.Bounds()
+	width, height := preset.Size(b.Dx(), b.Dy())
+
+	// the source area with the ratio of the derivative, centered
+	crop := b
+	if preset.Fit == constanta.FitCover {
+		if b.Dx()*height > b.Dy()*width {
+			cropWidth := b.Dy() * width / height
+			crop.Min.X += (b.Dx() - cropWidth) / 2
+			crop.Max.X = crop.Min.X + cropWidth
+		} else {
+			cropHeight := b.Dx() * height / width
+			crop.Min.Y += (b.Dy() - cropHeight) / 2
+			crop.Max.Y = crop.Min.Y + cropHeight
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if preset.Format == constanta.JPEG {
+		// jpeg has no transparency, transparent areas become white instead of black
+		draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Over, nil)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	}
+
+	var out bytes.Buffer
+	switch preset.Format {
+	case constanta.JPEG:
+		err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: 85})
+	case constanta.PNG:
+		err = png.Encode(&out, dst)
+	case constanta.WebP:
+		err = nativewebp.Encode(&out, dst, nil)
+	default:
+		err = errors.New(string(preset.Format) + " is not supported format")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivative(t *testing.T) {
+	var src bytes.Buffer
+	require.NoError(t, png.Encode(&src, testImage(40, 20)))
+
+	tests := []struct {
+		name       string
+		preset     entity.ImagePreset
+		wantFormat string
+		wantWidth  int
+		wantHeight int
+	}{
+		{
+			name:       "contain webp",
+			preset:     entity.ImagePreset{Name: "small", Width: 10, Fit: constanta.FitContain, Format: constanta.WebP},
+			wantFormat: "webp",
+			wantWidth:  10,
+			wantHeight: 5,
+		},
+		{
+			name:       "cover jpeg",
+			preset:     entity.ImagePreset{Name: "thumbnail", Width: 8, Height: 8, Fit: constanta.FitCover, Format: constanta.JPEG},
+			wantFormat: "jpeg",
+			wantWidth:  8,
+			wantHeight: 8,
+		},
+		{
+			name:       "never upscaled",
+			preset:     entity.ImagePreset{Name: "large", Width: 100, Fit: constanta.FitContain, Format: constanta.PNG},
+			wantFormat: "png",
+			wantWidth:  40,
+			wantHeight: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Derivative(bytes.NewReader(src.Bytes()), tt.preset)
+			require.NoError(t, err)
+
+			config, format, err := image.DecodeConfig(bytes.NewReader(got))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, format)
+			assert.Equal(t, tt.wantWidth, config.Width)
+			assert.Equal(t, tt.wantHeight, config.Height)
+		})
+	}
+}
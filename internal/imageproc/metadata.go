@@ -0,0 +1,370 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+)
+
+var errMalformed = errors.New("malformed image")
+
+// StripMetadata removes the metadata of the image, like EXIF (camera and GPS location), XMP, IPTC and comments.
+// The image data is copied as it is, except for JPEG images with an EXIF orientation:
+// they are rotated and re-encoded, because the orientation is lost with the EXIF.
+func StripMetadata(data []byte, mimeType string) ([]byte, error) {
+	switch mimeType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	case "image/gif":
+		return stripGIF(data)
+	default:
+		return nil, errors.New(mimeType + " is not supported")
+	}
+}
+
+const (
+	jpegSOI  = 0xD8
+	jpegSOS  = 0xDA
+	jpegEOI  = 0xD9
+	jpegAPP1 = 0xE1
+	// photoshop, contains IPTC
+	jpegAPP13 = 0xED
+	jpegCOM   = 0xFE
+)
+
+// stripJPEG drops the APP1, APP13 and COM segments. JFIF, ICC profile and Adobe segments are kept,
+// they are needed to show the colors correctly.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegSOI {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	orientation := 1
+	i := 2
+	for {
+		if i+4 > len(data) || data[i] != 0xFF {
+			return nil, errMalformed
+		}
+
+		marker := data[i+1]
+		// fill bytes before the marker
+		if marker == 0xFF {
+			i++
+			continue
+		}
+
+		if marker == jpegEOI {
+			out.Write(data[i:])
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil, errMalformed
+		}
+		segment := data[i : i+2+length]
+
+		// the entropy coded data is copied until the end
+		if marker == jpegSOS {
+			out.Write(data[i:])
+			break
+		}
+
+		switch marker {
+		case jpegAPP1:
+			if o, ok := exifOrientation(segment[4:]); ok {
+				orientation = o
+			}
+		case jpegAPP13, jpegCOM:
+		default:
+			out.Write(segment)
+		}
+
+		i += 2 + length
+	}
+
+	if orientation <= 1 || orientation > 8 {
+		return out.Bytes(), nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	var oriented bytes.Buffer
+	if err := jpeg.Encode(&oriented, applyOrientation(img, orientation), &jpeg.Options{Quality: 92}); err != nil {
+		return nil, err
+	}
+
+	return oriented.Bytes(), nil
+}
+
+// exifOrientation reads the orientation tag of the first IFD.
+func exifOrientation(payload []byte) (int, bool) {
+	if len(payload) < 14 || string(payload[:6]) != "Exif\x00\x00" {
+		return 0, false
+	}
+	tiff := payload[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 0, false
+	}
+
+	entries := int(order.Uint16(tiff[offset:]))
+	for n := range entries {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0, false
+		}
+
+		// 0x0112 is the orientation, a SHORT value
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:])), true
+		}
+	}
+
+	return 0, false
+}
+
+// applyOrientation transforms the image, so it looks as the orientation describes without the tag.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := range h {
+		for x := range w {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			default:
+				dx, dy = x, y
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// text, time and exif chunks are dropped
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+
+	i := len(pngSignature)
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		// length, type, data and crc
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+
+		i = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+const (
+	webpXMPFlag  = 0x04
+	webpEXIFFlag = 0x08
+)
+
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	// the riff size is written after the chunks are copied
+	out.Write(data[:12])
+
+	i := 12
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+
+		chunkType := string(data[i : i+4])
+		length := int(binary.LittleEndian.Uint32(data[i+4:]))
+		// chunks are padded into even size
+		end := i + 8 + length + length%2
+		if length < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+
+		switch chunkType {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := out.Len()
+			out.Write(data[i:end])
+			if length > 0 {
+				out.Bytes()[start+8] &^= webpEXIFFlag | webpXMPFlag
+			}
+		default:
+			out.Write(data[i:end])
+		}
+
+		i = end
+	}
+
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+
+	return stripped, nil
+}
+
+const (
+	gifExtension     = 0x21
+	gifImage         = 0x2C
+	gifTrailer       = 0x3B
+	gifCommentLabel  = 0xFE
+	gifAppLabel      = 0xFF
+	gifColorTableBit = 0x80
+)
+
+// stripGIF drops the comments and the application extensions, except the animation loop count.
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, errMalformed
+	}
+
+	i := 13
+	if data[10]&gifColorTableBit != 0 {
+		i += 3 << (int(data[10]&0x07) + 1)
+	}
+	if i > len(data) {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:i])
+
+	for i < len(data) {
+		start := i
+		switch data[i] {
+		case gifTrailer:
+			out.WriteByte(gifTrailer)
+			return out.Bytes(), nil
+		case gifExtension:
+			if i+2 > len(data) {
+				return nil, errMalformed
+			}
+			label := data[i+1]
+
+			end, err := skipGIFSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+
+			keep := label != gifCommentLabel
+			if label == gifAppLabel {
+				keep = start+14 <= len(data) && string(data[start+3:start+14]) == "NETSCAPE2.0"
+			}
+			if keep {
+				out.Write(data[start:end])
+			}
+		case gifImage:
+			if i+10 > len(data) {
+				return nil, errMalformed
+			}
+			i += 10
+			if data[i-1]&gifColorTableBit != 0 {
+				i += 3 << (int(data[i-1]&0x07) + 1)
+			}
+			// lzw minimum code size
+			i++
+			if i > len(data) {
+				return nil, errMalformed
+			}
+
+			end, err := skipGIFSubBlocks(data, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			out.Write(data[start:end])
+		default:
+			return nil, errMalformed
+		}
+	}
+
+	return nil, errMalformed
+}
+
+func skipGIFSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errMalformed
+		}
+
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+}
@@ -0,0 +1,139 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x * 40), G: uint8(y * 40), B: 100, A: 255})
+		}
+	}
+
+	return img
+}
+
+func exifSegment(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM\x00\x2a\x00\x00\x00\x08")
+	binary.Write(&tiff, binary.BigEndian, uint16(2))
+	// orientation, SHORT, count 1
+	binary.Write(&tiff, binary.BigEndian, []uint16{0x0112, 3, 0, 1, orientation, 0})
+	// gps info pointer, LONG, count 1
+	binary.Write(&tiff, binary.BigEndian, []uint16{0x8825, 4, 0, 1, 0, 0})
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, jpegAPP1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+	return append(segment, payload...)
+}
+
+func TestStripMetadata_JPEG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(4, 2), nil))
+	plain := buf.Bytes()
+
+	t.Run("exif is removed", func(t *testing.T) {
+		withExif := append(append(append([]byte{}, plain[:2]...), exifSegment(1)...), plain[2:]...)
+
+		got, err := StripMetadata(withExif, "image/jpeg")
+		require.NoError(t, err)
+		assert.Equal(t, plain, got)
+	})
+
+	t.Run("orientation is applied", func(t *testing.T) {
+		withExif := append(append(append([]byte{}, plain[:2]...), exifSegment(6)...), plain[2:]...)
+
+		got, err := StripMetadata(withExif, "image/jpeg")
+		require.NoError(t, err)
+		assert.NotContains(t, string(got), "Exif")
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(got))
+		require.NoError(t, err)
+		assert.Equal(t, 2, config.Width)
+		assert.Equal(t, 4, config.Height)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := StripMetadata(plain[:10], "image/jpeg")
+		assert.Error(t, err)
+	})
+}
+
+func TestStripMetadata_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(2, 2)))
+	plain := buf.Bytes()
+
+	text := []byte("Comment\x00secret location")
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)))
+	chunk = append(chunk, "tEXt"...)
+	chunk = append(chunk, text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	// after the IHDR chunk
+	ihdrEnd := len(pngSignature) + 25
+	withText := append(append(append([]byte{}, plain[:ihdrEnd]...), chunk...), plain[ihdrEnd:]...)
+
+	got, err := StripMetadata(withText, "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, plain, got)
+}
+
+func TestStripMetadata_WebP(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, nativewebp.Encode(&buf, testImage(2, 2), nil))
+	plain := buf.Bytes()
+
+	withExif := append([]byte{}, plain...)
+	withExif = append(withExif, "EXIF"...)
+	withExif = binary.LittleEndian.AppendUint32(withExif, 3)
+	withExif = append(withExif, "gps\x00"...)
+	binary.LittleEndian.PutUint32(withExif[4:], uint32(len(withExif)-8))
+
+	got, err := StripMetadata(withExif, "image/webp")
+	require.NoError(t, err)
+	assert.Equal(t, plain, got)
+}
+
+func TestStripMetadata_GIF(t *testing.T) {
+	var buf bytes.Buffer
+	palette := color.Palette{color.Black, color.White}
+	require.NoError(t, gif.EncodeAll(&buf, &gif.GIF{
+		Image:     []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 2, 2), palette), image.NewPaletted(image.Rect(0, 0, 2, 2), palette)},
+		Delay:     []int{10, 10},
+		LoopCount: 0,
+	}))
+	plain := buf.Bytes()
+
+	comment := []byte{gifExtension, gifCommentLabel, 6}
+	comment = append(comment, "secret"...)
+	comment = append(comment, 0)
+
+	// before the trailer
+	withComment := append(append(append([]byte{}, plain[:len(plain)-1]...), comment...), gifTrailer)
+
+	got, err := StripMetadata(withComment, "image/gif")
+	require.NoError(t, err)
+	assert.Equal(t, plain, got)
+
+	decoded, err := gif.DecodeAll(bytes.NewReader(got))
+	require.NoError(t, err)
+	assert.Len(t, decoded.Image, 2)
+}
@@ -0,0 +1,107 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveSMTP answers one conversation without STARTTLS and authentication, and returns the received data.
+func serveSMTP(ln net.Listener) <-chan string {
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 localhost ESMTP")
+		var data strings.Builder
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				lines, _ := tp.ReadDotLines()
+				data.WriteString(strings.Join(lines, "\n"))
+				tp.PrintfLine("250 queued")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				received <- data.String()
+				return
+			default:
+				tp.PrintfLine("250 ok")
+			}
+		}
+	}()
+
+	return received
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+	received := serveSMTP(ln)
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	s, err := NewSMTPSender(SMTPConfig{Host: host, Port: portNumber, From: "CMS <noreply@cms.test>"})
+	assert.NoError(t, err)
+	s.now = func() time.Time { return time.Date(2025, 8, 26, 9, 0, 0, 0, time.UTC) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = s.Send(ctx, Message{To: "writer@cms.test", Subject: "Artikel terbit", Body: "version 2 of \"hello\" is published"})
+	assert.NoError(t, err)
+
+	data := <-received
+	assert.Contains(t, data, "From: \"CMS\" <noreply@cms.test>")
+	assert.Contains(t, data, "To: <writer@cms.test>")
+	assert.Contains(t, data, "Subject: Artikel terbit")
+	assert.Contains(t, data, "Date: Tue, 26 Aug 2025 09:00:00 +0000")
+	assert.Contains(t, data, "version 2 of \"hello\" is published")
+}
+
+func TestSMTPSender_buildMessage(t *testing.T) {
+	s, err := NewSMTPSender(SMTPConfig{Host: "localhost", From: "noreply@cms.test"})
+	assert.NoError(t, err)
+
+	to := &mail.Address{Address: "writer@cms.test"}
+
+	_, err = s.buildMessage(to, Message{Subject: "hello\r\nBcc: someone@cms.test"})
+	assert.EqualError(t, err, "subject cannot contain a line break")
+
+	body, err := s.buildMessage(to, Message{Subject: "Komentar baru – artikel", Body: "line one\nline two"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "Subject: =?utf-8?q?Komentar_baru_=E2=80=93_artikel?=\r\n")
+	assert.Contains(t, string(body), "\r\n\r\nline one\r\nline two")
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	_, err := NewSMTPSender(SMTPConfig{From: "noreply@cms.test"})
+	assert.Error(t, err)
+
+	_, err = NewSMTPSender(SMTPConfig{Host: "localhost", From: "not an address"})
+	assert.Error(t, err)
+
+	s, err := NewSMTPSender(SMTPConfig{Host: "localhost", From: "noreply@cms.test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:587", s.addr)
+	assert.Nil(t, s.auth)
+}
@@ -0,0 +1,59 @@
+package params
+
+import (
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+)
+
+type ArticleLockResponse struct {
+	ArticleID int64     `json:"article_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	ExpiresAt time.Time `json:"expires_at"`
+	LockedAt  time.Time `json:"locked_at"`
+}
+
+func NewArticleLockResponse(lock entity.ArticleLock) ArticleLockResponse {
+	return ArticleLockResponse{
+		ArticleID: lock.ArticleID,
+		UserID:    lock.UserID,
+		UserName:  lock.UserName,
+		ExpiresAt: lock.ExpiresAt,
+		LockedAt:  lock.CreatedAt,
+	}
+}
+
+type ArticleViewerResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+type ArticlePresenceResponse struct {
+	ArticleID int64                   `json:"article_id"`
+	Viewers   []ArticleViewerResponse `json:"viewers"`
+	// null when nobody is editing the article
+	Lock *ArticleLockResponse `json:"lock"`
+}
+
+func NewArticlePresenceResponse(presence entity.ArticlePresence) ArticlePresenceResponse {
+	res := ArticlePresenceResponse{
+		ArticleID: presence.ArticleID,
+		Viewers:   make([]ArticleViewerResponse, 0, len(presence.Viewers)),
+	}
+
+	for _, viewer := range presence.Viewers {
+		res.Viewers = append(res.Viewers, ArticleViewerResponse{
+			UserID: viewer.UserID,
+			Name:   viewer.Name,
+		})
+	}
+
+	if presence.Lock != nil {
+		lock := NewArticleLockResponse(*presence.Lock)
+		res.Lock = &lock
+	}
+
+	return res
+}
@@ -1,17 +1,90 @@
 package params
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
 	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/google/uuid"
 )
 
+type ArticleMetadata struct {
+	// generated from the title when empty
+	Slug string `json:"slug"`
+	// generated from the body when empty
+	Excerpt        string `json:"excerpt"`
+	SEOTitle       string `json:"seo_title"`
+	SEODescription string `json:"seo_description"`
+	CanonicalURL   string `json:"canonical_url"`
+	// open graph image url
+	OGImage string `json:"og_image"`
+}
+
+func (am *ArticleMetadata) Validate() error {
+	am.Slug = strings.TrimSpace(am.Slug)
+	if am.Slug != "" && !entity.IsValidSlug(am.Slug) {
+		return errs.ValidationError{Message: "slug can only contain lowercase letters, numbers and dashes"}
+	}
+
+	if len(am.SEOTitle) > 255 {
+		return errs.ValidationError{Message: "seo_title cannot be longer than 255 characters"}
+	}
+
+	if am.CanonicalURL != "" && !isValidURL(am.CanonicalURL) {
+		return errs.ValidationError{Message: "canonical_url must be an absolute http or https url"}
+	}
+
+	if am.OGImage != "" && !isValidURL(am.OGImage) {
+		return errs.ValidationError{Message: "og_image must be an absolute http or https url"}
+	}
+
+	return nil
+}
+
+func (am ArticleMetadata) ToEntity() entity.ArticleMetadata {
+	return entity.ArticleMetadata{
+		Slug:           am.Slug,
+		Excerpt:        am.Excerpt,
+		SEOTitle:       am.SEOTitle,
+		SEODescription: am.SEODescription,
+		CanonicalURL:   am.CanonicalURL,
+		OGImage:        am.OGImage,
+	}
+}
+
+func NewArticleMetadata(metadata entity.ArticleMetadata) ArticleMetadata {
+	return ArticleMetadata{
+		Slug:           metadata.Slug,
+		Excerpt:        metadata.Excerpt,
+		SEOTitle:       metadata.SEOTitle,
+		SEODescription: metadata.SEODescription,
+		CanonicalURL:   metadata.CanonicalURL,
+		OGImage:        metadata.OGImage,
+	}
+}
+
+func isValidURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 type CreateArticleRequest struct {
 	Title, Body string
-	Tags        []string
+	// markdown by default
+	BodyFormat constanta.BodyFormat `json:"body_format"`
+	// alternative of the body. The body is generated from the blocks as markdown
+	Blocks *entity.BlockDocument `json:"blocks"`
+	Tags   []string
+	// media library items used by the body. Media of the image blocks are added automatically
+	MediaIDs []int64 `json:"media_ids"`
+	ArticleMetadata
 }
 
 func (car *CreateArticleRequest) Validate() error {
@@ -19,11 +92,19 @@ func (car *CreateArticleRequest) Validate() error {
 		return errs.ValidationError{Message: "title is required"}
 	}
 
-	if car.Body == "" {
-		return errs.ValidationError{Message: "body is required"}
+	if car.Body == "" && car.Blocks == nil {
+		return errs.ValidationError{Message: "body or blocks is required"}
 	}
 
-	return nil
+	if err := validateBody(car.Body, &car.BodyFormat, car.Blocks); err != nil {
+		return err
+	}
+
+	if err := validateMediaIDs(car.MediaIDs); err != nil {
+		return err
+	}
+
+	return car.ArticleMetadata.Validate()
 }
 
 type CreateArticleResponse struct {
@@ -37,7 +118,77 @@ type UpdateArticleStatusRequest struct {
 
 type CreateArticleVersionRequest struct {
 	Title, Body string
-	Tags        []string
+	// markdown by default
+	BodyFormat constanta.BodyFormat `json:"body_format"`
+	// alternative of the body. The body is generated from the blocks as markdown
+	Blocks *entity.BlockDocument `json:"blocks"`
+	Tags   []string
+	// media library items used by the body. Media of the image blocks are added automatically
+	MediaIDs []int64 `json:"media_ids"`
+	ArticleMetadata
+}
+
+func (cavr *CreateArticleVersionRequest) Validate() error {
+	if err := validateBody(cavr.Body, &cavr.BodyFormat, cavr.Blocks); err != nil {
+		return err
+	}
+
+	if err := validateMediaIDs(cavr.MediaIDs); err != nil {
+		return err
+	}
+
+	return cavr.ArticleMetadata.Validate()
+}
+
+func validateBody(body string, format *constanta.BodyFormat, blocks *entity.BlockDocument) error {
+	if blocks == nil {
+		return validateBodyFormat(format)
+	}
+
+	if body != "" {
+		return errs.ValidationError{Message: "body and blocks cannot be used together"}
+	}
+
+	if *format != "" && *format != constanta.Markdown {
+		return errs.ValidationError{Message: "body_format of blocks is always markdown"}
+	}
+	*format = constanta.Markdown
+
+	return validateBlockDocument(blocks)
+}
+
+func validateMediaIDs(ids []int64) error {
+	for _, id := range ids {
+		if id <= 0 {
+			return errs.ValidationError{Message: "media_ids must be positive"}
+		}
+	}
+
+	return nil
+}
+
+func validateBodyFormat(format *constanta.BodyFormat) error {
+	switch *format {
+	case "":
+		*format = constanta.Markdown
+	case constanta.Markdown, constanta.HTML, constanta.Plain:
+	default:
+		return errs.ValidationError{Message: "body_format must be markdown, html or plain"}
+	}
+
+	return nil
+}
+
+// ParseRenderOption parses the render query value. Empty value means raw.
+func ParseRenderOption(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "raw":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, errs.ValidationError{Message: "render must be raw or html"}
+	}
 }
 
 type CreateArticleVersionResponse struct {
@@ -45,14 +196,22 @@ type CreateArticleVersionResponse struct {
 }
 
 type ArticleVersionResponse struct {
-	ArticleID            int64    `json:"article_id"`
-	ArticleVersionID     int64    `json:"article_version_id"`
-	Title                string   `json:"title"`
-	Body                 string   `json:"body"`
-	Version              int64    `json:"version"`
-	Status               int8     `json:"status"`
-	Tags                 []string `json:"tags"`
-	TagRelationShipScore float64  `json:"tag_relationship_score"`
+	ArticleID        int64  `json:"article_id"`
+	ArticleVersionID int64  `json:"article_version_id"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+	// sanitized HTML of the body, only when requested with render=html
+	BodyHTML   string               `json:"body_html,omitempty"`
+	BodyFormat constanta.BodyFormat `json:"body_format"`
+	// only when the version is created from blocks
+	Blocks *entity.BlockDocument `json:"blocks,omitempty"`
+	// media library images used by the body or the blocks, with their responsive sizes
+	Images               []ImageResponse `json:"images,omitempty"`
+	Version              int64           `json:"version"`
+	Status               int8            `json:"status"`
+	Tags                 []string        `json:"tags"`
+	TagRelationShipScore float64         `json:"tag_relationship_score"`
+	ArticleMetadata
 
 	CreatedBy uuid.UUID  `json:"created_by"`
 	CreatedAt time.Time  `json:"created_at"`
@@ -65,6 +224,8 @@ type GetArticleDetailResponse struct {
 	DraftedVersion   *ArticleVersionResponse `json:"drafted_version"`
 	PublishedVersion *ArticleVersionResponse `json:"published_version"`
 	ArchivedVersion  *ArticleVersionResponse `json:"archived_version"`
+	// categories of the article with the breadcrumbs of the primary category
+	Categories *ArticleCategoriesResponse `json:"categories"`
 
 	CreatedBy uuid.UUID `json:"created_by"`
 	CreatedAt time.Time `json:"created_at"`
@@ -78,6 +239,8 @@ type GetArticlesQueryParams struct {
 	Status    []constanta.ArticleVersionStatus
 	CreatedBy []uuid.UUID
 	UpdatedBy []uuid.UUID
+	// articles in the category or one of its descendants
+	CategoryID int64
 
 	// Embedding PaginationParams for pagination and sorting
 	PaginationParams
@@ -89,6 +252,10 @@ func (pqr *GetArticlesQueryParams) Validate() error {
 		pqr.Sorts = append(pqr.Sorts, "created_at:desc")
 	}
 
+	if pqr.CategoryID < 0 {
+		return errs.ValidationError{Message: "not valid category"}
+	}
+
 	for _, v := range pqr.Status {
 		if v < constanta.Draft || v > constanta.Archived {
 			return errs.ValidationError{Message: "not valid status"}
@@ -104,7 +271,7 @@ func (pqr *GetArticlesQueryParams) Validate() error {
 	pqr.PaginationParams.setValidSortKey(
 		"article_id",
 		"article_version_id",
-		"crated_by",
+		"created_by",
 		"updated_by",
 		"title",
 		"status",
@@ -113,6 +280,7 @@ func (pqr *GetArticlesQueryParams) Validate() error {
 		"updated_at",
 		"tag_relationship_score",
 	)
+	pqr.PaginationParams.setTieBreakerKey("article_version_id")
 
 	if err := pqr.PaginationParams.Validate(); err != nil {
 		return errs.ValidationError{Message: err.Error()}
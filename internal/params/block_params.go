@@ -0,0 +1,144 @@
+package params
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+)
+
+const (
+	maxBlocks          = 500
+	maxBlockTextLength = 10000
+)
+
+var (
+	blockIDRegex         = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+	codeLanguageRegex    = regexp.MustCompile(`^[A-Za-z0-9_+-]{0,32}$`)
+	validCalloutVariants = map[string]bool{"info": true, "tip": true, "warning": true, "danger": true}
+)
+
+// validateBlockDocument validates the blocks and fills the defaults.
+// Blocks without ID get a new one, the client must send the IDs back to keep them stable.
+func validateBlockDocument(doc *entity.BlockDocument) error {
+	if doc.SchemaVersion == 0 {
+		doc.SchemaVersion = constanta.CurrentBlockSchemaVersion
+	}
+
+	if doc.SchemaVersion != constanta.CurrentBlockSchemaVersion {
+		return errs.ValidationError{Message: fmt.Sprintf("blocks schema_version %d is not supported", doc.SchemaVersion)}
+	}
+
+	if len(doc.Blocks) == 0 {
+		return errs.ValidationError{Message: "blocks cannot be empty"}
+	}
+
+	if len(doc.Blocks) > maxBlocks {
+		return errs.ValidationError{Message: fmt.Sprintf("blocks cannot have more than %d blocks", maxBlocks)}
+	}
+
+	ids := make(map[string]bool, len(doc.Blocks))
+	for i := range doc.Blocks {
+		block := &doc.Blocks[i]
+
+		if block.ID == "" {
+			block.ID = entity.NewBlockID()
+		}
+
+		if !blockIDRegex.MatchString(block.ID) {
+			return blockError(i, "id can only contain letters, numbers, dashes and underscores")
+		}
+
+		if ids[block.ID] {
+			return blockError(i, fmt.Sprintf("id %s is duplicated", block.ID))
+		}
+		ids[block.ID] = true
+
+		if err := validateBlock(i, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateBlock(i int, block *entity.Block) error {
+	// indentation of the code is kept
+	if block.Type != constanta.CodeBlock {
+		block.Text = strings.TrimSpace(block.Text)
+	}
+
+	if len(block.Text) > maxBlockTextLength {
+		return blockError(i, fmt.Sprintf("text cannot be longer than %d characters", maxBlockTextLength))
+	}
+
+	switch block.Type {
+	case constanta.ParagraphBlock, constanta.QuoteBlock:
+		if block.Text == "" {
+			return blockError(i, "text is required")
+		}
+	case constanta.HeadingBlock:
+		if block.Text == "" {
+			return blockError(i, "text is required")
+		}
+
+		if block.Level == 0 {
+			block.Level = 2
+		}
+
+		if block.Level < 1 || block.Level > 6 {
+			return blockError(i, "level must be between 1 and 6")
+		}
+	case constanta.ImageBlock:
+		if block.MediaID < 0 {
+			return blockError(i, "media_id must be positive")
+		}
+
+		if block.MediaID > 0 && block.URL != "" {
+			return blockError(i, "url and media_id cannot be used together")
+		}
+
+		if block.MediaID == 0 && !isValidURL(block.URL) {
+			return blockError(i, "url must be an absolute http or https url, or media_id must be set")
+		}
+	case constanta.EmbedBlock:
+		if block.MediaID != 0 {
+			return blockError(i, "media_id can only be used by image blocks")
+		}
+
+		if !isValidURL(block.URL) {
+			return blockError(i, "url must be an absolute http or https url")
+		}
+	case constanta.CodeBlock:
+		if strings.TrimSpace(block.Text) == "" {
+			return blockError(i, "text is required")
+		}
+
+		if !codeLanguageRegex.MatchString(block.Language) {
+			return blockError(i, "language can only contain letters, numbers, dashes, underscores and plus signs")
+		}
+	case constanta.CalloutBlock:
+		if block.Text == "" {
+			return blockError(i, "text is required")
+		}
+
+		if block.Variant == "" {
+			block.Variant = "info"
+		}
+
+		if !validCalloutVariants[block.Variant] {
+			return blockError(i, "variant must be info, tip, warning or danger")
+		}
+	default:
+		return blockError(i, fmt.Sprintf("%s is not valid block type", block.Type))
+	}
+
+	return nil
+}
+
+func blockError(i int, message string) error {
+	return errs.ValidationError{Message: fmt.Sprintf("blocks[%d]: %s", i, message)}
+}
@@ -0,0 +1,177 @@
+package params
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+)
+
+const (
+	maxCategoryNameLength     = 100
+	maxSecondaryCategoryCount = 10
+)
+
+// CategoryRequest is used to create a category and to replace it.
+type CategoryRequest struct {
+	Name string `json:"name"`
+	// generated from the name when empty
+	Slug string `json:"slug"`
+	// empty for a root category
+	ParentID *int64 `json:"parent_id"`
+	// order between the siblings, ascending
+	Position int `json:"position"`
+}
+
+func (cr *CategoryRequest) Validate() error {
+	cr.Name = strings.TrimSpace(cr.Name)
+	if cr.Name == "" {
+		return errs.ValidationError{Message: "name is required"}
+	}
+
+	if len(cr.Name) > maxCategoryNameLength {
+		return errs.ValidationError{Message: "name cannot be longer than 100 characters"}
+	}
+
+	cr.Slug = strings.TrimSpace(cr.Slug)
+	if cr.Slug == "" {
+		cr.Slug = entity.NewSlug(cr.Name)
+	}
+	if !entity.IsValidSlug(cr.Slug) {
+		return errs.ValidationError{Message: "slug can only contain lowercase letters, numbers and dashes"}
+	}
+
+	if cr.ParentID != nil && *cr.ParentID <= 0 {
+		return errs.ValidationError{Message: "not valid parent_id"}
+	}
+
+	if cr.Position < 0 {
+		return errs.ValidationError{Message: "position cannot be negative"}
+	}
+
+	return nil
+}
+
+type CategoryResponse struct {
+	ID       int64  `json:"id"`
+	ParentID *int64 `json:"parent_id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	// zero for a root category
+	Depth    int `json:"depth"`
+	Position int `json:"position"`
+	// subcategories ordered by their position. only filled in the tree
+	Children []CategoryResponse `json:"children,omitempty"`
+
+	CreatedBy uuid.UUID  `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewCategoryResponse(category entity.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:        category.ID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		Depth:     category.Depth,
+		Position:  category.Position,
+		CreatedBy: category.CreatedBy,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// NewCategoryTree nests the categories under their parents.
+// The categories must be ordered by depth, the order of the siblings is kept.
+// Categories whose parent is not in the list become roots of the tree.
+func NewCategoryTree(categories []entity.Category) []CategoryResponse {
+	children := make(map[int64][]entity.Category)
+	ids := make(map[int64]bool, len(categories))
+	for _, category := range categories {
+		ids[category.ID] = true
+	}
+
+	var roots []entity.Category
+	for _, category := range categories {
+		if category.ParentID == nil || !ids[*category.ParentID] {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	var build func(categories []entity.Category) []CategoryResponse
+	build = func(categories []entity.Category) []CategoryResponse {
+		res := make([]CategoryResponse, 0, len(categories))
+		for _, category := range categories {
+			node := NewCategoryResponse(category)
+			if len(children[category.ID]) > 0 {
+				node.Children = build(children[category.ID])
+			}
+			res = append(res, node)
+		}
+		return res
+	}
+
+	return build(roots)
+}
+
+type SetArticleCategoriesRequest struct {
+	// zero removes every category of the article
+	PrimaryCategoryID    int64   `json:"primary_category_id"`
+	SecondaryCategoryIDs []int64 `json:"secondary_category_ids"`
+}
+
+func (sr *SetArticleCategoriesRequest) Validate() error {
+	if sr.PrimaryCategoryID < 0 {
+		return errs.ValidationError{Message: "not valid primary_category_id"}
+	}
+
+	if sr.PrimaryCategoryID == 0 && len(sr.SecondaryCategoryIDs) > 0 {
+		return errs.ValidationError{Message: "primary_category_id is required when secondary_category_ids is set"}
+	}
+
+	if len(sr.SecondaryCategoryIDs) > maxSecondaryCategoryCount {
+		return errs.ValidationError{Message: "an article can have at most 10 secondary categories"}
+	}
+
+	for i, id := range sr.SecondaryCategoryIDs {
+		if id <= 0 {
+			return errs.ValidationError{Message: "not valid secondary_category_ids"}
+		}
+		if id == sr.PrimaryCategoryID {
+			return errs.ValidationError{Message: "the primary category cannot be a secondary category"}
+		}
+		if slices.Contains(sr.SecondaryCategoryIDs[:i], id) {
+			return errs.ValidationError{Message: "secondary_category_ids cannot contain duplicates"}
+		}
+	}
+
+	return nil
+}
+
+// CategoryIDs returns the primary category followed by the secondary ones.
+func (sr SetArticleCategoriesRequest) CategoryIDs() []int64 {
+	if sr.PrimaryCategoryID == 0 {
+		return nil
+	}
+
+	return append([]int64{sr.PrimaryCategoryID}, sr.SecondaryCategoryIDs...)
+}
+
+type BreadcrumbResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ArticleCategoriesResponse struct {
+	PrimaryCategory     *CategoryResponse  `json:"primary_category"`
+	SecondaryCategories []CategoryResponse `json:"secondary_categories"`
+	// from the root to the primary category
+	Breadcrumbs []BreadcrumbResponse `json:"breadcrumbs"`
+}
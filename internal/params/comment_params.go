@@ -0,0 +1,157 @@
+package params
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentBodyLength = 5000
+	maxCommentMentions   = 20
+)
+
+type CreateArticleCommentRequest struct {
+	Body string `json:"body"`
+	// id of the first comment of the thread which is replied, zero starts a new thread
+	ParentID int64 `json:"parent_id"`
+	// only for a new thread, empty when the comment is not anchored
+	Anchor *CommentAnchorRequest `json:"anchor"`
+	// id of the mentioned users
+	Mentions []uuid.UUID `json:"mentions"`
+}
+
+// CommentAnchorRequest is a character range of the title or the body of the version, the end is excluded.
+type CommentAnchorRequest struct {
+	// title or body
+	Field constanta.CommentAnchorField `json:"field"`
+	Start int                          `json:"start"`
+	End   int                          `json:"end"`
+}
+
+func (cr *CreateArticleCommentRequest) Validate() error {
+	cr.Body = strings.TrimSpace(cr.Body)
+	if cr.Body == "" {
+		return errs.ValidationError{Message: "body is required"}
+	}
+
+	if utf8.RuneCountInString(cr.Body) > maxCommentBodyLength {
+		return errs.ValidationError{Message: fmt.Sprintf("body cannot be longer than %d characters", maxCommentBodyLength)}
+	}
+
+	if cr.ParentID < 0 {
+		return errs.ValidationError{Message: "not valid parent_id"}
+	}
+
+	if cr.Anchor != nil {
+		if cr.ParentID != 0 {
+			return errs.ValidationError{Message: "a reply cannot be anchored"}
+		}
+
+		switch cr.Anchor.Field {
+		case constanta.CommentAnchorTitle, constanta.CommentAnchorBody:
+		default:
+			return errs.ValidationError{Message: "anchor field must be title or body"}
+		}
+
+		if cr.Anchor.Start < 0 || cr.Anchor.End <= cr.Anchor.Start {
+			return errs.ValidationError{Message: "anchor end must be after anchor start"}
+		}
+	}
+
+	slices.SortFunc(cr.Mentions, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	cr.Mentions = slices.Compact(cr.Mentions)
+	if len(cr.Mentions) > maxCommentMentions {
+		return errs.ValidationError{Message: fmt.Sprintf("a comment can mention at most %d users", maxCommentMentions)}
+	}
+
+	return nil
+}
+
+type GetArticleCommentsRequest struct {
+	// nil returns every thread
+	Resolved *bool
+}
+
+type CommentAnchorResponse struct {
+	Field constanta.CommentAnchorField `json:"field"`
+	// zero when the anchor is outdated
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// the anchored text when the comment was created
+	Text string `json:"text"`
+	// the text is not in this version anymore
+	Outdated bool `json:"outdated"`
+}
+
+type CommentMentionResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+type ArticleCommentResponse struct {
+	ID               int64                    `json:"id"`
+	ArticleID        int64                    `json:"article_id"`
+	ArticleVersionID int64                    `json:"article_version_id"`
+	ParentID         int64                    `json:"parent_id,omitempty"`
+	Anchor           *CommentAnchorResponse   `json:"anchor,omitempty"`
+	Body             string                   `json:"body"`
+	Mentions         []CommentMentionResponse `json:"mentions"`
+	// id of the comment of the previous version which this comment is carried from
+	CarriedFromID int64      `json:"carried_from_id,omitempty"`
+	CreatedBy     uuid.UUID  `json:"created_by"`
+	CreatedByName string     `json:"created_by_name"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
+
+// NewArticleCommentResponse shows the mentioned users with their names, a deleted user is not shown.
+func NewArticleCommentResponse(comment entity.ArticleComment, userNames map[uuid.UUID]string) ArticleCommentResponse {
+	res := ArticleCommentResponse{
+		ID:               comment.ID,
+		ArticleID:        comment.ArticleID,
+		ArticleVersionID: comment.ArticleVersionID,
+		ParentID:         comment.ParentID,
+		Body:             comment.Body,
+		Mentions:         make([]CommentMentionResponse, 0, len(comment.Mentions)),
+		CarriedFromID:    comment.CarriedFromID,
+		CreatedBy:        comment.CreatedBy,
+		CreatedByName:    comment.CreatedByName,
+		CreatedAt:        comment.CreatedAt,
+		UpdatedAt:        comment.UpdatedAt,
+	}
+
+	if comment.Anchor != nil {
+		res.Anchor = &CommentAnchorResponse{
+			Field:    comment.Anchor.Field,
+			Start:    comment.Anchor.Start,
+			End:      comment.Anchor.End,
+			Text:     comment.Anchor.Text,
+			Outdated: comment.Anchor.Outdated,
+		}
+	}
+
+	for _, userID := range comment.Mentions {
+		if name, ok := userNames[userID]; ok {
+			res.Mentions = append(res.Mentions, CommentMentionResponse{UserID: userID, Name: name})
+		}
+	}
+
+	return res
+}
+
+// ArticleCommentThreadResponse is the first comment of a thread with its resolution and its replies.
+type ArticleCommentThreadResponse struct {
+	ArticleCommentResponse
+	Resolved   bool                     `json:"resolved"`
+	ResolvedBy *uuid.UUID               `json:"resolved_by"`
+	ResolvedAt *time.Time               `json:"resolved_at"`
+	Replies    []ArticleCommentResponse `json:"replies"`
+}
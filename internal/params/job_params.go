@@ -0,0 +1,86 @@
+package params
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+)
+
+const (
+	defaultJobLimit = 20
+	maxJobLimit     = 100
+)
+
+type GetJobsRequest struct {
+	Status constanta.JobStatus
+	Type   constanta.JobType
+	// id of the last job of the previous page
+	BeforeID int64
+	Limit    int
+}
+
+func (gjr *GetJobsRequest) Validate() error {
+	switch gjr.Status {
+	case "", constanta.JobPending, constanta.JobRunning, constanta.JobSucceeded, constanta.JobDead:
+	default:
+		return errs.ValidationError{Message: "status must be pending, running, succeeded or dead"}
+	}
+
+	if gjr.BeforeID < 0 {
+		return errs.ValidationError{Message: "not valid before"}
+	}
+
+	if gjr.Limit < 0 || gjr.Limit > maxJobLimit {
+		return errs.ValidationError{Message: "limit must be between 1 and 100"}
+	}
+
+	if gjr.Limit == 0 {
+		gjr.Limit = defaultJobLimit
+	}
+
+	return nil
+}
+
+type GetJobsResponse struct {
+	// number of jobs in every status
+	Counts map[constanta.JobStatus]int64 `json:"counts"`
+	Jobs   []JobResponse                 `json:"jobs"`
+	// before of the next page, zero on the last page
+	NextBeforeID int64 `json:"next_before_id"`
+}
+
+type JobResponse struct {
+	ID          int64               `json:"id"`
+	Type        constanta.JobType   `json:"type"`
+	Payload     json.RawMessage     `json:"payload" swaggertype:"object"`
+	Status      constanta.JobStatus `json:"status"`
+	Attempts    int                 `json:"attempts"`
+	MaxAttempts int                 `json:"max_attempts"`
+	RunAt       time.Time           `json:"run_at"`
+	LastError   string              `json:"last_error"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   *time.Time          `json:"updated_at"`
+}
+
+func NewJobResponses(jobs []entity.Job) []JobResponse {
+	res := make([]JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		res = append(res, JobResponse{
+			ID:          job.ID,
+			Type:        job.Type,
+			Payload:     job.Payload,
+			Status:      job.Status,
+			Attempts:    job.Attempts,
+			MaxAttempts: job.MaxAttempts,
+			RunAt:       job.RunAt,
+			LastError:   job.LastError,
+			CreatedAt:   job.CreatedAt,
+			UpdatedAt:   job.UpdatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,122 @@
+package params
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+)
+
+type MediaResponse struct {
+	ID       int64  `json:"id"`
+	FileName string `json:"file_name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+	// hex sha256 of the content
+	Checksum string `json:"checksum"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	// path of the content, usable as the src of an image
+	URL string `json:"url"`
+
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewMediaResponse(media entity.Media) MediaResponse {
+	return MediaResponse{
+		ID:        media.ID,
+		FileName:  media.FileName,
+		MimeType:  media.MimeType,
+		Size:      media.Size,
+		Checksum:  media.Checksum,
+		Width:     media.Width,
+		Height:    media.Height,
+		URL:       entity.MediaPath(media.ID),
+		CreatedBy: media.CreatedBy,
+		CreatedAt: media.CreatedAt,
+	}
+}
+
+type MediaDerivativeResponse struct {
+	MediaID  int64  `json:"media_id"`
+	Preset   string `json:"preset"`
+	MimeType string `json:"mime_type"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	URL      string `json:"url"`
+	// file name of the original with the extension of the derivative
+	FileName string `json:"file_name"`
+	// the derivative is generated from the original with the same preset, so it changes only with them
+	ETag      string    `json:"-"`
+	CreatedAt time.Time `json:"-"`
+}
+
+func NewMediaDerivativeResponse(media entity.Media, preset entity.ImagePreset) MediaDerivativeResponse {
+	width, height := preset.Size(media.Width, media.Height)
+
+	return MediaDerivativeResponse{
+		MediaID:   media.ID,
+		Preset:    preset.Name,
+		MimeType:  preset.Format.MimeType(),
+		Width:     width,
+		Height:    height,
+		URL:       entity.DerivativePath(media.ID, preset.Name),
+		FileName:  strings.TrimSuffix(media.FileName, path.Ext(media.FileName)) + preset.Format.Extension(),
+		ETag:      fmt.Sprintf(`"%s-%s-%dx%d-%s-%s"`, media.Checksum, preset.Name, preset.Width, preset.Height, preset.Fit, preset.Format),
+		CreatedAt: media.CreatedAt,
+	}
+}
+
+// ImageResponse describes a media library image that is used by an article version.
+type ImageResponse struct {
+	MediaID  int64  `json:"media_id"`
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	// responsive sizes with width descriptors, usable as the srcset attribute of an img
+	Srcset      string                    `json:"srcset"`
+	Derivatives []MediaDerivativeResponse `json:"derivatives"`
+}
+
+func NewImageResponse(media entity.Media, presets []entity.ImagePreset) ImageResponse {
+	res := ImageResponse{
+		MediaID:     media.ID,
+		URL:         entity.MediaPath(media.ID),
+		MimeType:    media.MimeType,
+		Width:       media.Width,
+		Height:      media.Height,
+		Derivatives: make([]MediaDerivativeResponse, 0, len(presets)),
+	}
+
+	var sources []MediaDerivativeResponse
+	for _, preset := range presets {
+		derivative := NewMediaDerivativeResponse(media, preset)
+		res.Derivatives = append(res.Derivatives, derivative)
+
+		// larger sizes would be the same as the original
+		if preset.Responsive() && derivative.Width < media.Width {
+			sources = append(sources, derivative)
+		}
+	}
+
+	slices.SortFunc(sources, func(a, b MediaDerivativeResponse) int {
+		return a.Width - b.Width
+	})
+
+	srcset := make([]string, 0, len(sources)+1)
+	for _, source := range sources {
+		srcset = append(srcset, fmt.Sprintf("%s %dw", source.URL, source.Width))
+	}
+	if media.Width > 0 {
+		srcset = append(srcset, fmt.Sprintf("%s %dw", res.URL, media.Width))
+	}
+	res.Srcset = strings.Join(srcset, ", ")
+
+	return res
+}
@@ -0,0 +1,134 @@
+package params
+
+import (
+	"slices"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+type GetNotificationsRequest struct {
+	// only the notifications which are not read
+	Unread bool
+	// id of the last notification of the previous page
+	BeforeID int64
+	Limit    int
+}
+
+func (gnr *GetNotificationsRequest) Validate() error {
+	if gnr.BeforeID < 0 {
+		return errs.ValidationError{Message: "not valid before"}
+	}
+
+	if gnr.Limit < 0 || gnr.Limit > maxNotificationLimit {
+		return errs.ValidationError{Message: "limit must be between 1 and 100"}
+	}
+
+	if gnr.Limit == 0 {
+		gnr.Limit = defaultNotificationLimit
+	}
+
+	return nil
+}
+
+type GetNotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	// the notifications of the user which are not read, in every page
+	UnreadCount int `json:"unread_count"`
+	// before of the next page, zero on the last page
+	NextBeforeID int64 `json:"next_before_id"`
+}
+
+type NotificationResponse struct {
+	ID               int64                      `json:"id"`
+	Type             constanta.NotificationType `json:"type"`
+	ArticleID        int64                      `json:"article_id"`
+	ArticleVersionID int64                      `json:"article_version_id"`
+	CommentID        int64                      `json:"comment_id,omitempty"`
+	ActorID          *uuid.UUID                 `json:"actor_id"`
+	ActorName        string                     `json:"actor_name"`
+	Message          string                     `json:"message"`
+	Read             bool                       `json:"read"`
+	ReadAt           *time.Time                 `json:"read_at"`
+	CreatedAt        time.Time                  `json:"created_at"`
+}
+
+func NewNotificationResponses(notifications ...entity.Notification) []NotificationResponse {
+	res := make([]NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		res = append(res, NotificationResponse{
+			ID:               notification.ID,
+			Type:             notification.Type,
+			ArticleID:        notification.ArticleID,
+			ArticleVersionID: notification.ArticleVersionID,
+			CommentID:        notification.CommentID,
+			ActorID:          notification.ActorID,
+			ActorName:        notification.ActorName,
+			Message:          notification.Message,
+			Read:             notification.ReadAt != nil,
+			ReadAt:           notification.ReadAt,
+			CreatedAt:        notification.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+type MarkNotificationsReadResponse struct {
+	// number of the notifications which were not read before
+	Updated int64 `json:"updated"`
+}
+
+type NotificationPreferenceRequest struct {
+	// in_app or email, the email is sent besides the notification in the app
+	Delivery constanta.NotificationDelivery `json:"delivery"`
+	// version.published, version.archived, comment or mention
+	MutedTypes []constanta.NotificationType `json:"muted_types"`
+}
+
+func (npr *NotificationPreferenceRequest) Validate() error {
+	switch npr.Delivery {
+	case constanta.NotificationDeliveryInApp, constanta.NotificationDeliveryEmail:
+	default:
+		return errs.ValidationError{Message: "delivery must be in_app or email"}
+	}
+
+	for _, mutedType := range npr.MutedTypes {
+		if !slices.Contains(constanta.NotificationTypes, mutedType) {
+			return errs.ValidationError{Message: "muted_types must be version.published, version.archived, comment or mention"}
+		}
+	}
+
+	slices.Sort(npr.MutedTypes)
+	npr.MutedTypes = slices.Compact(npr.MutedTypes)
+	if npr.MutedTypes == nil {
+		npr.MutedTypes = []constanta.NotificationType{}
+	}
+
+	return nil
+}
+
+type NotificationPreferenceResponse struct {
+	Delivery   constanta.NotificationDelivery `json:"delivery"`
+	MutedTypes []constanta.NotificationType   `json:"muted_types"`
+	// false when no mail server is configured, the email delivery cannot be chosen then
+	EmailAvailable bool       `json:"email_available"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+func NewNotificationPreferenceResponse(preference entity.NotificationPreference, emailAvailable bool) NotificationPreferenceResponse {
+	return NotificationPreferenceResponse{
+		Delivery:       preference.Delivery,
+		MutedTypes:     preference.MutedTypes,
+		EmailAvailable: emailAvailable,
+		UpdatedAt:      preference.UpdatedAt,
+	}
+}
@@ -1,9 +1,14 @@
 package params
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
 )
 
 type PaginationParams struct {
@@ -11,13 +16,24 @@ type PaginationParams struct {
 	Limit int
 	Page  int
 
+	// exact by default, estimated is cheaper for very large result sets
+	CountMode constanta.CountMode
+
+	// opaque keyset cursors. Only one of them can be used at a time
+	After  string
+	Before string
+
 	// to validate sort keys
 	validSortKeys map[string]bool
+	// appended to the sorts to make the ordering deterministic
+	tieBreakerKey string
 
 	// local var. Used for sorting in the DB
 	// sortMap        map[string]string
 	sortDirections []string
 	orderClause    string
+	sortFields     []entity.SortField
+	cursor         *entity.Cursor
 }
 
 func (pqr *PaginationParams) Validate() error {
@@ -29,6 +45,10 @@ func (pqr *PaginationParams) Validate() error {
 		pqr.Limit = 10
 	}
 
+	if pqr.CountMode != constanta.ExactCount && pqr.CountMode != constanta.EstimatedCount {
+		return errors.New("not valid count mode")
+	}
+
 	if len(pqr.Sorts) > 0 {
 		newSorts := []string{}
 		for _, sort := range pqr.Sorts {
@@ -41,6 +61,7 @@ func (pqr *PaginationParams) Validate() error {
 
 		// pqr.sortMap = make(map[string]string)
 		pqr.sortDirections = make([]string, len(newSorts))
+		pqr.sortFields = make([]entity.SortField, len(newSorts))
 		for index, sortRaw := range newSorts {
 			parts := strings.Split(sortRaw, ":")
 			if len(parts) != 2 {
@@ -59,12 +80,43 @@ func (pqr *PaginationParams) Validate() error {
 			}
 
 			pqr.sortDirections[index] = fmt.Sprintf("%s %s", value, direction)
+			pqr.sortFields[index] = entity.SortField{Key: value, Desc: direction == "desc"}
+		}
 
+		if pqr.tieBreakerKey != "" && !pqr.hasSortField(pqr.tieBreakerKey) {
+			last := pqr.sortFields[len(pqr.sortFields)-1]
+			pqr.sortFields = append(pqr.sortFields, entity.SortField{Key: pqr.tieBreakerKey, Desc: last.Desc})
+			pqr.sortDirections = append(pqr.sortDirections, fmt.Sprintf("%s %s", pqr.tieBreakerKey, directionOf(last)))
 		}
 
 		pqr.orderClause = strings.Join(pqr.sortDirections, ", ")
 	}
 
+	if pqr.After != "" && pqr.Before != "" {
+		return errors.New("after and before cannot be used together")
+	}
+
+	rawCursor := pqr.After
+	if pqr.Before != "" {
+		rawCursor = pqr.Before
+	}
+
+	if rawCursor != "" {
+		values, err := DecodeCursor(rawCursor, pqr.orderClause)
+		if err != nil {
+			return err
+		}
+
+		if len(values) != len(pqr.sortFields) {
+			return errors.New("not valid cursor")
+		}
+
+		pqr.cursor = &entity.Cursor{
+			Values:   values,
+			Backward: pqr.Before != "",
+		}
+	}
+
 	return nil
 }
 
@@ -72,6 +124,15 @@ func (pqr *PaginationParams) GetOrderClause() string {
 	return pqr.orderClause
 }
 
+func (pqr *PaginationParams) GetSortFields() []entity.SortField {
+	return pqr.sortFields
+}
+
+// GetCursor returns nil when the request uses page based pagination.
+func (pqr *PaginationParams) GetCursor() *entity.Cursor {
+	return pqr.cursor
+}
+
 func (pqr *PaginationParams) setValidSortKey(sortKeys ...string) {
 	if pqr.validSortKeys == nil {
 		pqr.validSortKeys = make(map[string]bool)
@@ -81,3 +142,81 @@ func (pqr *PaginationParams) setValidSortKey(sortKeys ...string) {
 		pqr.validSortKeys[sortKey] = true
 	}
 }
+
+// setTieBreakerKey sets a unique key that is appended to the sorts.
+// Keyset pagination needs a total order to not skip or repeat rows.
+func (pqr *PaginationParams) setTieBreakerKey(sortKey string) {
+	pqr.tieBreakerKey = sortKey
+}
+
+func (pqr *PaginationParams) hasSortField(key string) bool {
+	for _, sortField := range pqr.sortFields {
+		if sortField.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func directionOf(sortField entity.SortField) string {
+	if sortField.Desc {
+		return "desc"
+	}
+
+	return "asc"
+}
+
+type PaginationResponse struct {
+	Total int64 `json:"total"`
+	// true when total is the planner estimation instead of an exact count
+	TotalEstimated bool `json:"total_estimated"`
+	// empty when the page is requested with a cursor
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// cursor is the payload of the opaque after/before value.
+// Sorts pins the cursor to the ordering it was created with.
+type cursor struct {
+	Sorts  string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+func EncodeCursor(sorts string, values []string) string {
+	raw, _ := json.Marshal(cursor{Sorts: sorts, Values: values})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(raw, sorts string) ([]string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("not valid cursor")
+	}
+
+	var c cursor
+	if err := json.Unmarshal(decoded, &c); err != nil {
+		return nil, errors.New("not valid cursor")
+	}
+
+	if c.Sorts != sorts {
+		return nil, errors.New("cursor does not match the requested sorts")
+	}
+
+	return c.Values, nil
+}
+
+// ParseCountMode parses the count query value. Empty value means exact.
+func ParseCountMode(raw string) (constanta.CountMode, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "exact":
+		return constanta.ExactCount, nil
+	case "estimated":
+		return constanta.EstimatedCount, nil
+	default:
+		return 0, errors.New("count must be exact or estimated")
+	}
+}
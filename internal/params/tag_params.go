@@ -1,9 +1,16 @@
 package params
 
 import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
 	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
 )
 
 type CreateTagRequest struct {
@@ -16,7 +23,7 @@ func (ctr *CreateTagRequest) Validate() error {
 	}
 
 	for _, name := range ctr.Names {
-		if name == "" {
+		if strings.TrimSpace(name) == "" {
 			return errs.ValidationError{Message: "tag name cannot be empty"}
 		}
 	}
@@ -25,7 +32,12 @@ func (ctr *CreateTagRequest) Validate() error {
 }
 
 type GetTagResponse struct {
-	Name          string    `json:"name"`
+	Name string `json:"name"`
+	// shown instead of the name when it is not empty
+	DisplayName   string    `json:"display_name"`
+	Description   string    `json:"description"`
+	Color         string    `json:"color"`
+	Slug          string    `json:"slug"`
 	UsageCount    int       `json:"usage_count"`
 	TrendingScore float64   `json:"trending_score"`
 	LastUsed      time.Time `json:"last_used"`
@@ -34,4 +46,379 @@ type GetTagResponse struct {
 type GetTagsRequest struct {
 	SortValue string
 	Direction string
+
+	// optional. All tags are returned when limit and cursors are empty
+	Limit  int
+	After  string
+	Before string
+}
+
+func (gtr *GetTagsRequest) Validate() error {
+	if gtr.Limit < 0 {
+		return errs.ValidationError{Message: "limit cannot be negative"}
+	}
+
+	if gtr.After != "" && gtr.Before != "" {
+		return errs.ValidationError{Message: "after and before cannot be used together"}
+	}
+
+	return nil
+}
+
+// IsPaginated reports whether the caller asked for a single page of tags.
+func (gtr *GetTagsRequest) IsPaginated() bool {
+	return gtr.Limit > 0 || gtr.After != "" || gtr.Before != ""
+}
+
+// GetSorts returns the cursor signature of the requested ordering.
+func (gtr *GetTagsRequest) GetSorts() string {
+	return gtr.SortValue + ":" + gtr.Direction
+}
+
+var tagColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type UpdateTagRequest struct {
+	// renames the tag in every article version when it differs from the current name
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	// hex color, for example #1e90ff
+	Color string `json:"color"`
+	// generated from the name when empty
+	Slug string `json:"slug"`
+}
+
+func (utr *UpdateTagRequest) Validate() error {
+	utr.Name = strings.TrimSpace(utr.Name)
+	if utr.Name == "" {
+		return errs.ValidationError{Message: "tag name cannot be empty"}
+	}
+
+	utr.DisplayName = strings.TrimSpace(utr.DisplayName)
+	if len(utr.DisplayName) > 100 {
+		return errs.ValidationError{Message: "display_name cannot be longer than 100 characters"}
+	}
+
+	if len(utr.Description) > 500 {
+		return errs.ValidationError{Message: "description cannot be longer than 500 characters"}
+	}
+
+	if utr.Color != "" && !tagColorRegex.MatchString(utr.Color) {
+		return errs.ValidationError{Message: "color must be a hex color, for example #1e90ff"}
+	}
+
+	utr.Slug = strings.TrimSpace(utr.Slug)
+	if utr.Slug == "" {
+		utr.Slug = entity.NewSlug(utr.Name)
+	}
+	if utr.Slug != "" && !entity.IsValidSlug(utr.Slug) {
+		return errs.ValidationError{Message: "slug can only contain lowercase letters, numbers and dashes"}
+	}
+
+	return nil
+}
+
+type MergeTagsRequest struct {
+	// tags which are deleted after their article versions get the target
+	Sources []string `json:"sources"`
+	// created when it does not exist
+	Target string `json:"target"`
+}
+
+func (mtr *MergeTagsRequest) Validate() error {
+	if len(mtr.Sources) == 0 {
+		return errs.ValidationError{Message: "at least one source tag is required"}
+	}
+
+	mtr.Target = strings.TrimSpace(mtr.Target)
+	if mtr.Target == "" {
+		return errs.ValidationError{Message: "target tag cannot be empty"}
+	}
+
+	for i, source := range mtr.Sources {
+		if source == "" {
+			return errs.ValidationError{Message: "tag name cannot be empty"}
+		}
+		if source == mtr.Target {
+			return errs.ValidationError{Message: "target tag cannot be a source tag"}
+		}
+		if slices.Contains(mtr.Sources[:i], source) {
+			return errs.ValidationError{Message: "source tags cannot contain duplicates"}
+		}
+	}
+
+	return nil
+}
+
+type DeleteUnusedTagsResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+type CreateTagSynonymsRequest struct {
+	// replaced with the tag when they are used as a tag
+	Aliases []string `json:"aliases"`
+}
+
+func (ctsr *CreateTagSynonymsRequest) Validate() error {
+	if len(ctsr.Aliases) == 0 {
+		return errs.ValidationError{Message: "at least one alias is required"}
+	}
+
+	for _, alias := range ctsr.Aliases {
+		if strings.TrimSpace(alias) == "" {
+			return errs.ValidationError{Message: "alias cannot be empty"}
+		}
+	}
+
+	return nil
+}
+
+type TagSynonymResponse struct {
+	Alias     string    `json:"alias"`
+	TagName   string    `json:"tag_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewTagSynonymResponses(synonyms []entity.TagSynonym) []TagSynonymResponse {
+	res := make([]TagSynonymResponse, 0, len(synonyms))
+	for _, synonym := range synonyms {
+		res = append(res, TagSynonymResponse{
+			Alias:     synonym.Alias,
+			TagName:   synonym.TagName,
+			CreatedAt: synonym.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+const (
+	defaultTagSuggestionLimit = 10
+	maxTagSuggestionLimit     = 50
+)
+
+type GetTagSuggestionsRequest struct {
+	// current tags of the article, they are not suggested again
+	Tags []string
+	// prefix of the tag being typed. optional
+	Query string
+	Limit int
+}
+
+func (gtsr *GetTagSuggestionsRequest) Validate() error {
+	if gtsr.Limit < 0 || gtsr.Limit > maxTagSuggestionLimit {
+		return errs.ValidationError{Message: "limit must be between 1 and 50"}
+	}
+
+	if gtsr.Limit == 0 {
+		gtsr.Limit = defaultTagSuggestionLimit
+	}
+
+	tags := make([]string, 0, len(gtsr.Tags))
+	for _, tag := range gtsr.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	gtsr.Tags = tags
+	gtsr.Query = strings.TrimSpace(gtsr.Query)
+
+	return nil
+}
+
+type TagSuggestionResponse struct {
+	Name string `json:"name"`
+	// between 0 and 1, the suggestions are ordered by it
+	Score float64 `json:"score"`
+	// number of published article versions which have the tag and one of the current tags
+	CoOccurrence  int     `json:"co_occurrence"`
+	TrendingScore float64 `json:"trending_score"`
+	// the tag starts with the query, or one of its words does
+	PrefixMatch bool `json:"prefix_match"`
+}
+
+type RelatedArticleResponse struct {
+	ArticleID        int64  `json:"article_id"`
+	ArticleVersionID int64  `json:"article_version_id"`
+	Title            string `json:"title"`
+	Slug             string `json:"slug"`
+	Excerpt          string `json:"excerpt"`
+	// tags of the article which the related article has too
+	SharedTags []string `json:"shared_tags"`
+	// between 0 and 1, the related articles are ordered by it
+	Score       float64   `json:"score"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func NewRelatedArticleResponses(related []entity.RelatedArticle) []RelatedArticleResponse {
+	res := make([]RelatedArticleResponse, 0, len(related))
+	for _, article := range related {
+		res = append(res, RelatedArticleResponse{
+			ArticleID:        article.ArticleID,
+			ArticleVersionID: article.ArticleVersionID,
+			Title:            article.Title,
+			Slug:             article.Slug,
+			Excerpt:          article.Excerpt,
+			SharedTags:       article.SharedTags,
+			Score:            article.Score,
+			PublishedAt:      article.PublishedAt,
+		})
+	}
+
+	return res
+}
+
+const (
+	defaultTrendingTagLimit = 10
+	maxTrendingTagLimit     = 100
+	maxTrendingWindow       = 90 * 24 * time.Hour
+)
+
+type GetTrendingTagsRequest struct {
+	// in days, for example 7d. the configured window is used when it is empty
+	Window string
+	Limit  int
+
+	window time.Duration
+}
+
+// Validate parses the window. WindowDuration is zero when the window is empty.
+func (gttr *GetTrendingTagsRequest) Validate() error {
+	if gttr.Window != "" {
+		window, err := entity.ParseDayDuration(gttr.Window)
+		if err != nil || window%(24*time.Hour) != 0 {
+			return errs.ValidationError{Message: "window must be in days, for example 7d"}
+		}
+		if window < 24*time.Hour || window > maxTrendingWindow {
+			return errs.ValidationError{Message: "window must be between 1d and 90d"}
+		}
+		gttr.window = window
+	}
+
+	if gttr.Limit < 0 || gttr.Limit > maxTrendingTagLimit {
+		return errs.ValidationError{Message: "limit must be between 1 and 100"}
+	}
+
+	if gttr.Limit == 0 {
+		gttr.Limit = defaultTrendingTagLimit
+	}
+
+	return nil
+}
+
+// WindowDuration returns the window parsed by Validate.
+func (gttr GetTrendingTagsRequest) WindowDuration() time.Duration {
+	return gttr.window
+}
+
+type TrendingTagResponse struct {
+	Name          string  `json:"name"`
+	TrendingScore float64 `json:"trending_score"`
+	// number of times the tag is published in the window
+	UsageCount int       `json:"usage_count"`
+	LastUsed   time.Time `json:"last_used"`
+}
+
+const (
+	defaultTagStatsDays  = 30
+	maxTagStatsBuckets   = 366
+	defaultTagStatsLimit = 10
+	maxTagStatsLimit     = 50
+)
+
+type GetTagStatsRequest struct {
+	// first day of the statistics, default 30 days before To
+	From time.Time
+	// last day of the statistics, default today
+	To       time.Time
+	Interval constanta.TagStatsInterval
+	// number of the co-occurring tags and the authors
+	Limit int
+}
+
+func (gtsr *GetTagStatsRequest) Validate() error {
+	switch gtsr.Interval {
+	case "":
+		gtsr.Interval = constanta.TagStatsDay
+	case constanta.TagStatsDay, constanta.TagStatsWeek, constanta.TagStatsMonth:
+	default:
+		return errs.ValidationError{Message: "interval must be day, week or month"}
+	}
+
+	if gtsr.To.IsZero() {
+		gtsr.To = time.Now()
+	}
+	gtsr.To = gtsr.To.UTC().Truncate(24 * time.Hour)
+
+	if gtsr.From.IsZero() {
+		gtsr.From = gtsr.To.AddDate(0, 0, 1-defaultTagStatsDays)
+	}
+	gtsr.From = gtsr.From.UTC().Truncate(24 * time.Hour)
+
+	if gtsr.From.After(gtsr.To) {
+		return errs.ValidationError{Message: "from cannot be after to"}
+	}
+
+	buckets := 0
+	for start := entity.TagStatsBucketStart(gtsr.From, gtsr.Interval); !start.After(gtsr.To); start = entity.NextTagStatsBucket(start, gtsr.Interval) {
+		buckets++
+		if buckets > maxTagStatsBuckets {
+			return errs.ValidationError{Message: "the range cannot have more than 366 buckets, use a bigger interval"}
+		}
+	}
+
+	if gtsr.Limit < 0 || gtsr.Limit > maxTagStatsLimit {
+		return errs.ValidationError{Message: "limit must be between 1 and 50"}
+	}
+
+	if gtsr.Limit == 0 {
+		gtsr.Limit = defaultTagStatsLimit
+	}
+
+	return nil
+}
+
+type TagStatsResponse struct {
+	Name     string                     `json:"name"`
+	From     time.Time                  `json:"from"`
+	To       time.Time                  `json:"to"`
+	Interval constanta.TagStatsInterval `json:"interval"`
+	// number of published versions using the tag in the range
+	Total int `json:"total"`
+	// every bucket of the range, including the empty ones
+	Series          []TagStatsBucketResponse `json:"series"`
+	CoOccurringTags []TagCountResponse       `json:"co_occurring_tags"`
+	TopAuthors      []TagAuthorResponse      `json:"top_authors"`
+}
+
+type TagStatsBucketResponse struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type TagCountResponse struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type TagAuthorResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+}
+
+// CSVRecords flattens the statistics into section, key and count columns.
+func (tsr TagStatsResponse) CSVRecords() [][]string {
+	records := [][]string{{"section", "key", "count"}}
+	for _, bucket := range tsr.Series {
+		records = append(records, []string{"series", bucket.Start.Format(time.DateOnly), strconv.Itoa(bucket.Count)})
+	}
+	for _, tag := range tsr.CoOccurringTags {
+		records = append(records, []string{"co_occurring_tag", tag.Name, strconv.Itoa(tag.Count)})
+	}
+	for _, author := range tsr.TopAuthors {
+		records = append(records, []string{"author", author.Name, strconv.Itoa(author.Count)})
+	}
+
+	return records
 }
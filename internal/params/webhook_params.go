@@ -0,0 +1,153 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+)
+
+const (
+	minWebhookSecretLength = 16
+	defaultDeliveryLimit   = 20
+	maxDeliveryLimit       = 100
+)
+
+// WebhookRequest is used to create a webhook and to replace it.
+type WebhookRequest struct {
+	URL string `json:"url"`
+	// generated when a webhook is created without it, kept when a webhook is replaced without it
+	Secret string `json:"secret"`
+	// empty subscribes every event
+	Events []constanta.EventType `json:"events"`
+	// only used when a webhook is replaced, a new webhook is active
+	Active bool `json:"active"`
+}
+
+func (wr *WebhookRequest) Validate() error {
+	wr.URL = strings.TrimSpace(wr.URL)
+	if !isValidURL(wr.URL) {
+		return errs.ValidationError{Message: "url must be a valid http or https url"}
+	}
+
+	if wr.Secret != "" && len(wr.Secret) < minWebhookSecretLength {
+		return errs.ValidationError{Message: fmt.Sprintf("secret must be at least %d characters", minWebhookSecretLength)}
+	}
+
+	for _, event := range wr.Events {
+		if !slices.Contains(constanta.Events, event) {
+			return errs.ValidationError{Message: fmt.Sprintf("%s is not valid event", event)}
+		}
+	}
+	slices.Sort(wr.Events)
+	wr.Events = slices.Compact(wr.Events)
+
+	return nil
+}
+
+type WebhookResponse struct {
+	ID     int64                 `json:"id"`
+	URL    string                `json:"url"`
+	Events []constanta.EventType `json:"events"`
+	Active bool                  `json:"active"`
+	// only shown when the secret is set
+	Secret    string     `json:"secret,omitempty"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewWebhookResponse(webhook entity.Webhook) WebhookResponse {
+	events := webhook.Events
+	if events == nil {
+		events = []constanta.EventType{}
+	}
+
+	return WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		Active:    webhook.Active,
+		CreatedBy: webhook.CreatedBy,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+type GetWebhookDeliveriesRequest struct {
+	Status constanta.WebhookDeliveryStatus
+	// id of the last delivery of the previous page
+	BeforeID int64
+	Limit    int
+}
+
+func (gdr *GetWebhookDeliveriesRequest) Validate() error {
+	switch gdr.Status {
+	case "", constanta.WebhookDeliveryPending, constanta.WebhookDeliverySucceeded, constanta.WebhookDeliveryFailed:
+	default:
+		return errs.ValidationError{Message: "status must be pending, succeeded or failed"}
+	}
+
+	if gdr.BeforeID < 0 {
+		return errs.ValidationError{Message: "not valid before"}
+	}
+
+	if gdr.Limit < 0 || gdr.Limit > maxDeliveryLimit {
+		return errs.ValidationError{Message: "limit must be between 1 and 100"}
+	}
+
+	if gdr.Limit == 0 {
+		gdr.Limit = defaultDeliveryLimit
+	}
+
+	return nil
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	// before of the next page, zero on the last page
+	NextBeforeID int64 `json:"next_before_id"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64                           `json:"id"`
+	WebhookID      int64                           `json:"webhook_id"`
+	Event          constanta.EventType             `json:"event"`
+	Payload        json.RawMessage                 `json:"payload" swaggertype:"object"`
+	Status         constanta.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                             `json:"attempts"`
+	ResponseStatus int                             `json:"response_status"`
+	ResponseBody   string                          `json:"response_body"`
+	LastError      string                          `json:"last_error"`
+	CreatedAt      time.Time                       `json:"created_at"`
+	UpdatedAt      *time.Time                      `json:"updated_at"`
+	DeliveredAt    *time.Time                      `json:"delivered_at"`
+}
+
+func NewWebhookDeliveryResponses(deliveries ...entity.WebhookDelivery) []WebhookDeliveryResponse {
+	res := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		res = append(res, WebhookDeliveryResponse{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			ResponseBody:   delivery.ResponseBody,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt,
+			UpdatedAt:      delivery.UpdatedAt,
+			DeliveredAt:    delivery.DeliveredAt,
+		})
+	}
+
+	return res
+}
@@ -3,11 +3,16 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
@@ -27,14 +32,34 @@ func NewArticleRepo(db *sql.DB) *ArticleRepo {
 const (
 	createArticleQuery        = `INSERT INTO articles(created_by) VALUES($1) RETURNING id;`
 	createArticleVersionQuery = `INSERT INTO article_versions
-		(article_id, title, body, "version", status, created_by)
-		VALUES($1, $2, $3, $4, $5, $6) RETURNING id;`
+		(article_id, title, body, body_format, blocks, "version", status, created_by, slug, excerpt, seo_title, seo_description, canonical_url, og_image)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id;`
 	updateLatestArticleVersionQuery = `UPDATE articles
 		SET updated_by=$1, drafted_version_id=$2, version_sequence=$3 WHERE id=$4;`
 	createArticleVersionTagsQuery = `INSERT INTO article_version_tags (article_version_id, tag_name)
 		VALUES ($1,$2) ON CONFLICT (article_version_id, tag_name) DO NOTHING;`
+	createArticleVersionMediaQuery = `INSERT INTO article_version_media (article_version_id, media_id)
+		VALUES ($1,$2) ON CONFLICT (article_version_id, media_id) DO NOTHING;`
 )
 
+// createArticleVersionMedia marks the media as used by the article version, so it cannot be deleted.
+func createArticleVersionMedia(ctx context.Context, tx *sql.Tx, articleVersionID int64, mediaIDs []int64) error {
+	for _, mediaID := range mediaIDs {
+		_, err := tx.ExecContext(ctx, createArticleVersionMediaQuery, articleVersionID, mediaID)
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+			return errs.ValidationError{Message: fmt.Sprintf("media %d not found", mediaID)}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (ar *ArticleRepo) CreateArticle(ctx context.Context, article entity.Article, articleVersion entity.ArticleVersion) (int64, int64, error) {
 	var articleID, articleVersionID int64
 	err := runInTx(ctx, ar.db, func(tx *sql.Tx) error {
@@ -46,9 +71,17 @@ func (ar *ArticleRepo) CreateArticle(ctx context.Context, article entity.Article
 			articleID,
 			articleVersion.Title,
 			articleVersion.Body,
+			articleVersion.BodyFormat,
+			blocksColumn{&articleVersion.Blocks},
 			articleVersion.Version,
 			articleVersion.Status,
 			articleVersion.CreatedBy,
+			articleVersion.Slug,
+			articleVersion.Excerpt,
+			articleVersion.SEOTitle,
+			articleVersion.SEODescription,
+			articleVersion.CanonicalURL,
+			articleVersion.OGImage,
 		).Scan(&articleVersionID); err != nil {
 			return err
 		}
@@ -69,6 +102,10 @@ func (ar *ArticleRepo) CreateArticle(ctx context.Context, article entity.Article
 			}
 		}
 
+		if err := createArticleVersionMedia(ctx, tx, articleVersionID, articleVersion.MediaIDs); err != nil {
+			return err
+		}
+
 		if _, err := tx.ExecContext(ctx, updateLatestArticleVersionQuery,
 			article.CreatedBy,
 			articleVersionID,
@@ -78,7 +115,7 @@ func (ar *ArticleRepo) CreateArticle(ctx context.Context, article entity.Article
 			return err
 		}
 
-		return nil
+		return insertArticleVersionEvent(ctx, tx, constanta.EventArticleCreated, articleVersionID, 0)
 	})
 	if err != nil {
 		return 0, 0, err
@@ -88,6 +125,7 @@ func (ar *ArticleRepo) CreateArticle(ctx context.Context, article entity.Article
 }
 
 const (
+	getArticlePublishedVersionIdQuery          = `SELECT published_version_id FROM articles WHERE id=$1`
 	resetArticlePublishedAndDraftedToNullQuery = `UPDATE articles
 		SET published_version_id=NULL, drafted_version_id=NULL WHERE id=$1;`
 	deleteArticleVersionsByArticleIdQuery = `DELETE FROM article_versions
@@ -98,6 +136,21 @@ const (
 
 func (ar *ArticleRepo) DeleteArticle(ctx context.Context, articleID int64) error {
 	err := runInTx(ctx, ar.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, insertArticleDeletedEventQuery, constanta.EventArticleDeleted, articleID); err != nil {
+			return err
+		}
+
+		var publishedVersionID sql.NullInt64
+		if err := tx.QueryRowContext(ctx, getArticlePublishedVersionIdQuery, articleID).Scan(&publishedVersionID); err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if publishedVersionID.Valid {
+			if err := changeTagStats(ctx, tx, publishedVersionID.Int64, -1); err != nil {
+				return err
+			}
+		}
+
 		if _, err := tx.ExecContext(ctx, resetArticlePublishedAndDraftedToNullQuery, articleID); err != nil {
 			return err
 		}
@@ -116,15 +169,55 @@ func (ar *ArticleRepo) DeleteArticle(ctx context.Context, articleID int64) error
 	return err
 }
 
+// blocksColumn reads and writes the nullable JSONB blocks column
+type blocksColumn struct {
+	blocks **entity.BlockDocument
+}
+
+func (bc blocksColumn) Scan(src any) error {
+	switch raw := src.(type) {
+	case nil:
+		*bc.blocks = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(raw, bc.blocks)
+	case string:
+		return json.Unmarshal([]byte(raw), bc.blocks)
+	default:
+		return fmt.Errorf("cannot scan %T into blocks", src)
+	}
+}
+
+func (bc blocksColumn) Value() (driver.Value, error) {
+	if *bc.blocks == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(*bc.blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
 const (
 	getArticleVersionWithIDAndArticleIDQuery = `SELECT
 		id,
 		article_id,
 		title,
 		body,
+		body_format,
+		blocks,
 		"version",
 		status,
 		tag_relationship_score,
+		slug,
+		excerpt,
+		seo_title,
+		seo_description,
+		canonical_url,
+		og_image,
 		created_by,
 		created_at,
 		updated_by,
@@ -142,9 +235,17 @@ func (ar *ArticleRepo) GetArticleVersionWithIDAndArticleID(ctx context.Context,
 		&articleVersion.ArticleID,
 		&articleVersion.Title,
 		&articleVersion.Body,
+		&articleVersion.BodyFormat,
+		blocksColumn{&articleVersion.Blocks},
 		&articleVersion.Version,
 		&articleVersion.Status,
 		&articleVersion.TagRelationShipScore,
+		&articleVersion.Slug,
+		&articleVersion.Excerpt,
+		&articleVersion.SEOTitle,
+		&articleVersion.SEODescription,
+		&articleVersion.CanonicalURL,
+		&articleVersion.OGImage,
 		&articleVersion.CreatedBy,
 		&articleVersion.CreatedAt,
 		&articleVersion.UpdatedBy,
@@ -164,16 +265,33 @@ func (ar *ArticleRepo) GetArticleVersionWithIDAndArticleID(ctx context.Context,
 const (
 	updateArticleVersionWithStatusPublishedIntoArchivedQuery = `UPDATE article_versions
 		SET status=$1, updated_by=$2 WHERE article_id=$3 AND status=$4;`
+	// the stats of the tags count the version on the day it was first published
 	updateArticleVersionQuery = `UPDATE article_versions
-		SET status=$1, updated_by=$2 WHERE article_id=$3 AND id=$4;`
+		SET status=$1, updated_by=$2,
+			published_at = CASE WHEN $1 = 1 THEN COALESCE(published_at, NOW()) ELSE published_at END
+		WHERE article_id=$3 AND id=$4;`
 	updateArticlePublishedIdQuery = `UPDATE articles
 		SET published_version_id=$1, updated_by=$2 WHERE id=$3;`
 	updateArticleArchivedIdQuery = `UPDATE articles
 		SET archived_version_id=$1, updated_by=$2 WHERE id=$3;`
+	// postgres error code of a unique violation
+	uniqueViolationCode = "23505"
+
+	// the slug history keeps the old urls of the article working after a rename
+	upsertArticleSlugQuery = `INSERT INTO article_slugs (slug, article_id)
+		SELECT slug, article_id FROM article_versions WHERE id=$1
+		ON CONFLICT (slug) DO UPDATE SET article_id=EXCLUDED.article_id;`
+	// every publish counts once in the trending score of the tags
+	incrementTagUsageDailyQuery = `INSERT INTO tag_usage_daily (tag_name, "day", "count")
+		SELECT tag_name, CURRENT_DATE, 1 FROM article_version_tags WHERE article_version_id=$1
+		ON CONFLICT (tag_name, "day") DO UPDATE SET "count" = tag_usage_daily."count" + 1;`
 )
 
 func (ar *ArticleRepo) UpdateArticleStatus(ctx context.Context, articleID, articleVersionID int64, status, prevStatus constanta.ArticleVersionStatus, updatedBy uuid.UUID) error {
 	err := runInTx(ctx, ar.db, func(tx *sql.Tx) error {
+		// the slug of the replaced version is purged with the publish
+		var existingPublishedVersionID int64
+
 		// update article version published into archived with article id
 		if status == constanta.Published {
 
@@ -182,7 +300,6 @@ func (ar *ArticleRepo) UpdateArticleStatus(ctx context.Context, articleID, artic
 				return err
 			}
 			defer row.Close()
-			var existingPublishedVersionID int64
 			for row.Next() {
 				err := row.Scan(&existingPublishedVersionID)
 				if err != nil {
@@ -213,6 +330,10 @@ func (ar *ArticleRepo) UpdateArticleStatus(ctx context.Context, articleID, artic
 				); err != nil {
 					return err
 				}
+
+				if err := changeTagStats(ctx, tx, existingPublishedVersionID, -1); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -240,6 +361,10 @@ func (ar *ArticleRepo) UpdateArticleStatus(ctx context.Context, articleID, artic
 				if _, err := tx.ExecContext(ctx, updateArticlePublishedIdQuery, nil, updatedBy, articleID); err != nil {
 					return err
 				}
+
+				if err := changeTagStats(ctx, tx, articleVersionID, -1); err != nil {
+					return err
+				}
 			}
 
 			if prevStatus == constanta.Draft {
@@ -288,6 +413,18 @@ func (ar *ArticleRepo) UpdateArticleStatus(ctx context.Context, articleID, artic
 				return err
 			}
 
+			if _, err := tx.ExecContext(ctx, upsertArticleSlugQuery, articleVersionID); err != nil {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, incrementTagUsageDailyQuery, articleVersionID); err != nil {
+				return err
+			}
+
+			if err := changeTagStats(ctx, tx, articleVersionID, 1); err != nil {
+				return err
+			}
+
 			// Ensure the version exists
 			// if there's no draft version, set the drafted_version_id to NULL
 			// This is to ensure that the article has a valid draft version
@@ -320,26 +457,59 @@ func (ar *ArticleRepo) UpdateArticleStatus(ctx context.Context, articleID, artic
 			}
 		}
 
+		switch status {
+		case constanta.Published:
+			return insertArticleVersionEvent(ctx, tx, constanta.EventVersionPublished, articleVersionID, existingPublishedVersionID)
+		case constanta.Archived:
+			return insertArticleVersionEvent(ctx, tx, constanta.EventVersionArchived, articleVersionID, 0)
+		}
+
 		return nil
 	})
 
+	// another article can publish the same slug after this version was drafted
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == "article_versions_published_slug_index" {
+		return errs.AlreadyExist{Name: "published slug"}
+	}
+
 	return err
 }
 
 const (
 	deleteArticleVersionTags = `DELETE FROM article_version_tags WHERE article_version_id = $1`
+	// the lock is kept until the version is committed, so it cannot expire or be broken and taken in the meantime
+	getActiveArticleLockOfUserQuery = `SELECT 1 FROM article_locks WHERE article_id = $1 AND user_id = $2 AND expires_at > NOW() FOR SHARE`
 )
 
+// CreateArticleVersion creates the version when its creator holds the active edit lock of the article,
+// otherwise it returns errs.Conflict.
 func (ar *ArticleRepo) CreateArticleVersion(ctx context.Context, articleVersion entity.ArticleVersion) (int64, error) {
 	var articleVersionID int64
 	err := runInTx(ctx, ar.db, func(tx *sql.Tx) error {
+		var locked int
+		if err := tx.QueryRowContext(ctx, getActiveArticleLockOfUserQuery, articleVersion.ArticleID, articleVersion.CreatedBy).Scan(&locked); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errs.Conflict{Message: "the article must be locked by the user to create a version"}
+			}
+			return err
+		}
+
 		if err := tx.QueryRowContext(ctx, createArticleVersionQuery,
 			articleVersion.ArticleID,
 			articleVersion.Title,
 			articleVersion.Body,
+			articleVersion.BodyFormat,
+			blocksColumn{&articleVersion.Blocks},
 			articleVersion.Version,
 			articleVersion.Status,
 			articleVersion.CreatedBy,
+			articleVersion.Slug,
+			articleVersion.Excerpt,
+			articleVersion.SEOTitle,
+			articleVersion.SEODescription,
+			articleVersion.CanonicalURL,
+			articleVersion.OGImage,
 		).Scan(&articleVersionID); err != nil {
 			return err
 		}
@@ -366,6 +536,10 @@ func (ar *ArticleRepo) CreateArticleVersion(ctx context.Context, articleVersion
 			}
 		}
 
+		if err := createArticleVersionMedia(ctx, tx, articleVersionID, articleVersion.MediaIDs); err != nil {
+			return err
+		}
+
 		if _, err := tx.ExecContext(ctx, updateLatestArticleVersionQuery,
 			articleVersion.CreatedBy,
 			articleVersionID,
@@ -375,7 +549,13 @@ func (ar *ArticleRepo) CreateArticleVersion(ctx context.Context, articleVersion
 			return err
 		}
 
-		return nil
+		if articleVersion.CommentsFromVersionID != 0 {
+			if err := carryArticleComments(ctx, tx, articleVersion.CommentsFromVersionID, articleVersionID); err != nil {
+				return err
+			}
+		}
+
+		return insertArticleVersionEvent(ctx, tx, constanta.EventVersionCreated, articleVersionID, 0)
 	})
 	if err != nil {
 		return 0, err
@@ -437,9 +617,17 @@ const (
 	article_id, 
 	title, 
 	body, 
+	body_format,
+	blocks,
 	"version", 
 	status,
 	tag_relationship_score,
+	slug,
+	excerpt,
+	seo_title,
+	seo_description,
+	canonical_url,
+	og_image,
 	created_by, 
 	created_at, 
 	updated_by, 
@@ -468,9 +656,17 @@ func (ar *ArticleRepo) GetArticleVersionsWithArticleIDAndStatuses(ctx context.Co
 			&version.ArticleID,
 			&version.Title,
 			&version.Body,
+			&version.BodyFormat,
+			blocksColumn{&version.Blocks},
 			&version.Version,
 			&version.Status,
 			&version.TagRelationShipScore,
+			&version.Slug,
+			&version.Excerpt,
+			&version.SEOTitle,
+			&version.SEODescription,
+			&version.CanonicalURL,
+			&version.OGImage,
 			&version.CreatedBy,
 			&version.CreatedAt,
 			&version.UpdatedBy,
@@ -500,9 +696,17 @@ func getArticleQueryByStatus(status constanta.ArticleVersionStatus) string {
 			av.article_id as article_id, 
 			av.title as title, 
 			av.body as body, 
+			av.body_format as body_format,
+			av.blocks as blocks,
 			av.version as "version", 
 			av.status as status, 
 			av.tag_relationship_score as tag_relationship_score,
+			av.slug as slug,
+			av.excerpt as excerpt,
+			av.seo_title as seo_title,
+			av.seo_description as seo_description,
+			av.canonical_url as canonical_url,
+			av.og_image as og_image,
 			av.created_by as created_by, 
 			av.created_at as created_at, 
 			av.updated_by as updated_by, 
@@ -513,56 +717,96 @@ func getArticleQueryByStatus(status constanta.ArticleVersionStatus) string {
 	return fmt.Sprintf(q, column, column, int8(status))
 }
 
-func (ar *ArticleRepo) GetArticles(ctx context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, error) {
+// GetArticles returns the requested page and the total of rows matching the filters.
+// The total is estimated by the planner when CountMode is EstimatedCount.
+func (ar *ArticleRepo) GetArticles(ctx context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, int64, error) {
 	unionQuery := []string{}
 	for _, v := range req.Status {
 		q := getArticleQueryByStatus(v)
 		unionQuery = append(unionQuery, q)
 	}
 
-	query := "select * from (" + strings.Join(unionQuery, " UNION ALL ") + ")" +
+	filterArgs := []any{
+		// pq.Array(req.Status),
+		pq.Array(req.CreatedBy),
+		pq.Array(req.UpdatedBy),
+		req.Search,
+		sql.NullInt64{Int64: req.CategoryID, Valid: req.CategoryID != 0},
+	}
+	args := append(slices.Clone(filterArgs), req.Limit)
+
+	filterQuery := "select * from (" + strings.Join(unionQuery, " UNION ALL ") + ")" +
 		` WHERE
 			(created_by = ANY($1) OR $1 IS NULL)
 		AND 
 			(updated_by = ANY($2) OR $2 IS NULL)
 		AND 
 			(title ILIKE '%' || $3 || '%' OR $3 IS NULL)
-		ORDER BY ` + req.OrderClause + ` LIMIT $4 OFFSET $5;`
+		AND
+			(article_id IN (
+				SELECT ac.article_id FROM article_categories ac
+				JOIN categories c ON ac.category_id = c.id
+				JOIN categories root ON root.id = $4
+				WHERE c.path LIKE root.path || '%'
+			) OR $4 IS NULL)`
+
+	exactCount := req.CountMode == constanta.ExactCount
+
+	// the window count runs before the keyset condition and the limit,
+	// so it holds the total of rows matching the filters
+	query := "select * from (" + filterQuery + ")"
+	if exactCount {
+		query = "select * from (select *, COUNT(*) OVER () AS total_count from (" + filterQuery + "))"
+	}
 
-	offset := req.Limit * (req.Page - 1)
+	offset := 0
+	if req.Cursor != nil {
+		keysetClause, keysetArgs := getKeysetClause(req.Sorts, req.Cursor, len(args)+1)
+		args = append(args, keysetArgs...)
+		query += ` WHERE ` + keysetClause + ` ORDER BY ` + getKeysetOrderClause(req.Sorts, req.Cursor.Backward) + ` LIMIT $5;`
+	} else {
+		offset = req.Limit * (req.Page - 1)
+		args = append(args, offset)
+		query += ` ORDER BY ` + req.OrderClause + ` LIMIT $5 OFFSET $6;`
+	}
 
-	rows, err := ar.db.QueryContext(ctx,
-		query,
-		// pq.Array(req.Status),
-		pq.Array(req.CreatedBy),
-		pq.Array(req.UpdatedBy),
-		req.Search,
-		req.Limit,
-		offset,
-	)
+	rows, err := ar.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
+	var total int64
 	var articleVersions []entity.ArticleVersion
 	for rows.Next() {
 		var articleVersion entity.ArticleVersion
 		updatedAt := sql.NullTime{}
-		if err := rows.Scan(
+		dest := []any{
 			&articleVersion.ArticleVersionID,
 			&articleVersion.ArticleID,
 			&articleVersion.Title,
 			&articleVersion.Body,
+			&articleVersion.BodyFormat,
+			blocksColumn{&articleVersion.Blocks},
 			&articleVersion.Version,
 			&articleVersion.Status,
 			&articleVersion.TagRelationShipScore,
+			&articleVersion.Slug,
+			&articleVersion.Excerpt,
+			&articleVersion.SEOTitle,
+			&articleVersion.SEODescription,
+			&articleVersion.CanonicalURL,
+			&articleVersion.OGImage,
 			&articleVersion.CreatedBy,
 			&articleVersion.CreatedAt,
 			&articleVersion.UpdatedBy,
 			&updatedAt,
-		); err != nil {
-			return nil, err
+		}
+		if exactCount {
+			dest = append(dest, &total)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, 0, err
 		}
 		if updatedAt.Valid {
 			articleVersion.UpdatedAt = &updatedAt.Time
@@ -570,7 +814,99 @@ func (ar *ArticleRepo) GetArticles(ctx context.Context, req entity.GetArticlesQu
 		articleVersions = append(articleVersions, articleVersion)
 	}
 
-	return articleVersions, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// backward pages are queried in reverse order
+	if req.Cursor != nil && req.Cursor.Backward {
+		slices.Reverse(articleVersions)
+	}
+
+	switch {
+	case !exactCount:
+		total, err = ar.estimateRows(ctx, filterQuery, filterArgs...)
+	case len(articleVersions) == 0 && (offset > 0 || req.Cursor != nil):
+		// the window count is not available when the page is empty
+		err = ar.db.QueryRowContext(ctx, "select COUNT(*) from ("+filterQuery+");", filterArgs...).Scan(&total)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articleVersions, total, nil
+}
+
+// estimateRows returns the number of rows the planner expects the query to return.
+// It is much cheaper than COUNT(*) but can be off, especially with filters.
+func (ar *ArticleRepo) estimateRows(ctx context.Context, query string, args ...any) (int64, error) {
+	var rawPlan []byte
+	if err := ar.db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&rawPlan); err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(rawPlan, &plans); err != nil {
+		return 0, err
+	}
+
+	if len(plans) == 0 {
+		return 0, nil
+	}
+
+	return int64(plans[0].Plan.PlanRows), nil
+}
+
+// getSortColumn maps the sort key into the column used for ordering.
+// Nullable columns are coalesced so they can be compared with the cursor.
+func getSortColumn(key string) string {
+	if key == "updated_at" {
+		return "COALESCE(updated_at, '-infinity')"
+	}
+
+	return key
+}
+
+func getKeysetOrderClause(sorts []entity.SortField, backward bool) string {
+	orders := make([]string, len(sorts))
+	for i, sort := range sorts {
+		direction := "asc"
+		if sort.Desc != backward {
+			direction = "desc"
+		}
+		orders[i] = getSortColumn(sort.Key) + " " + direction
+	}
+
+	return strings.Join(orders, ", ")
+}
+
+// getKeysetClause builds the condition selecting the rows after (or before) the cursor.
+// For sorts (a desc, b asc) it produces: (a < $x) OR (a = $x AND b > $y)
+func getKeysetClause(sorts []entity.SortField, cursor *entity.Cursor, argIndex int) (string, []any) {
+	args := make([]any, len(sorts))
+	conditions := make([]string, len(sorts))
+	for i, sort := range sorts {
+		args[i] = cursor.Values[i]
+
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = $%d", getSortColumn(sorts[j].Key), argIndex+j))
+		}
+
+		operator := ">"
+		if sort.Desc != cursor.Backward {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s $%d", getSortColumn(sort.Key), operator, argIndex+i))
+
+		conditions[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+
+	return strings.Join(conditions, " OR "), args
 }
 
 const (
@@ -612,3 +948,166 @@ func (ar *ArticleRepo) UpdateArticleVersionRelationshipScore(ctx context.Context
 
 	return nil
 }
+
+const (
+	// slugs that are published or were published by other articles
+	getTakenSlugsQuery = `SELECT slug FROM article_versions
+		WHERE status = $1 AND article_id <> $2 AND (slug = $3 OR slug LIKE $3 || '-%')
+		UNION
+		SELECT slug FROM article_slugs
+		WHERE article_id <> $2 AND (slug = $3 OR slug LIKE $3 || '-%');`
+)
+
+// GetTakenSlugs returns the slugs starting with the given slug that are used by other articles.
+func (ar *ArticleRepo) GetTakenSlugs(ctx context.Context, slug string, articleID int64) ([]string, error) {
+	rows, err := ar.db.QueryContext(ctx, getTakenSlugsQuery, constanta.Published, articleID, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slugs []string
+	for rows.Next() {
+		var takenSlug string
+		if err := rows.Scan(&takenSlug); err != nil {
+			return nil, err
+		}
+		slugs = append(slugs, takenSlug)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return slugs, nil
+}
+
+const (
+	// the slug is looked up in the published versions first, then in the slug history
+	getArticleIDBySlugQuery = `SELECT a.id, av.slug
+		FROM articles a
+		JOIN article_versions av ON av.id = a.published_version_id
+		WHERE a.id = COALESCE(
+			(SELECT article_id FROM article_versions WHERE slug = $1 AND status = $2 LIMIT 1),
+			(SELECT article_id FROM article_slugs WHERE slug = $1)
+		);`
+)
+
+// GetArticleIDBySlug returns the article owning the slug and the slug of its published version.
+func (ar *ArticleRepo) GetArticleIDBySlug(ctx context.Context, slug string) (int64, string, error) {
+	var articleID int64
+	var currentSlug string
+	err := ar.db.QueryRowContext(ctx, getArticleIDBySlugQuery, slug, constanta.Published).Scan(&articleID, &currentSlug)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return articleID, currentSlug, nil
+}
+
+const (
+	// the categories and the tags of every article are included, a renamed category or tag changes the read.
+	// Versions are only deleted with their article.
+	getArticleCacheValidatorQuery = `SELECT
+		format('%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s',
+			a.updated_at, a.published_version_id, a.drafted_version_id, a.archived_version_id, v.changed_at,
+			ac.total, ac.changed_at, c.total, c.changed_at, t.total, t.changed_at),
+		GREATEST(a.created_at, a.updated_at, v.changed_at)
+	FROM articles a
+	CROSS JOIN LATERAL (
+		SELECT MAX(COALESCE(updated_at, created_at)) AS changed_at FROM article_versions
+		WHERE id IN (a.published_version_id, a.drafted_version_id, a.archived_version_id)
+	) v
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS total, MAX(created_at) AS changed_at FROM article_categories WHERE article_id = a.id
+	) ac
+	CROSS JOIN (SELECT COUNT(*) AS total, MAX(COALESCE(updated_at, created_at)) AS changed_at FROM categories) c
+	CROSS JOIN (SELECT COUNT(*) AS total, MAX(COALESCE(updated_at, created_at)) AS changed_at FROM tags) t
+	WHERE a.id = $1`
+
+	// the count of the articles changes when one is deleted, the categories of an article are replaced on every change
+	getArticlesCacheValidatorQuery = `SELECT
+		format('%s|%s|%s|%s|%s|%s|%s|%s|%s',
+			a.total, a.changed_at, av.changed_at, ac.total, ac.changed_at, c.total, c.changed_at, t.total, t.changed_at)
+	FROM (SELECT COUNT(*) AS total, MAX(COALESCE(updated_at, created_at)) AS changed_at FROM articles) a
+	CROSS JOIN (SELECT MAX(COALESCE(updated_at, created_at)) AS changed_at FROM article_versions) av
+	CROSS JOIN (SELECT COUNT(*) AS total, MAX(created_at) AS changed_at FROM article_categories) ac
+	CROSS JOIN (SELECT COUNT(*) AS total, MAX(COALESCE(updated_at, created_at)) AS changed_at FROM categories) c
+	CROSS JOIN (SELECT COUNT(*) AS total, MAX(COALESCE(updated_at, created_at)) AS changed_at FROM tags) t`
+)
+
+// GetArticleCacheValidator returns the validator of the detail of the article, without reading its versions.
+func (ar *ArticleRepo) GetArticleCacheValidator(ctx context.Context, articleID int64) (*entity.CacheValidator, error) {
+	validator := &entity.CacheValidator{}
+	err := ar.db.QueryRowContext(ctx, getArticleCacheValidatorQuery, articleID).Scan(&validator.Key, &validator.LastModified)
+	if err != nil {
+		return nil, err
+	}
+
+	return validator, nil
+}
+
+// GetArticlesCacheValidator returns the validator of every list of the articles, the query of the list is added by the caller.
+func (ar *ArticleRepo) GetArticlesCacheValidator(ctx context.Context) (*entity.CacheValidator, error) {
+	validator := &entity.CacheValidator{}
+	err := ar.db.QueryRowContext(ctx, getArticlesCacheValidatorQuery).Scan(&validator.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return validator, nil
+}
+
+const (
+	getPublishedArticleVersionsQuery = `SELECT
+		av.id,
+		av.article_id,
+		av.title,
+		av.slug,
+		av.excerpt,
+		av.tag_relationship_score,
+		av.created_at,
+		av.updated_at,
+		COALESCE(ARRAY_AGG(avt.tag_name ORDER BY avt.tag_name) FILTER (WHERE avt.tag_name IS NOT NULL), '{}')
+	FROM articles a
+	JOIN article_versions av ON av.id = a.published_version_id
+	LEFT JOIN article_version_tags avt ON avt.article_version_id = av.id
+	GROUP BY av.id;`
+)
+
+// GetPublishedArticleVersions returns the published version of every article with its tags, without the body.
+func (ar *ArticleRepo) GetPublishedArticleVersions(ctx context.Context) ([]entity.ArticleVersion, error) {
+	rows, err := ar.db.QueryContext(ctx, getPublishedArticleVersionsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []entity.ArticleVersion
+	for rows.Next() {
+		var version entity.ArticleVersion
+		var tags []string
+		updatedAt := sql.NullTime{}
+		if err := rows.Scan(
+			&version.ArticleVersionID,
+			&version.ArticleID,
+			&version.Title,
+			&version.Slug,
+			&version.Excerpt,
+			&version.TagRelationShipScore,
+			&version.CreatedAt,
+			&updatedAt,
+			pq.Array(&tags),
+		); err != nil {
+			return nil, err
+		}
+		if updatedAt.Valid {
+			version.UpdatedAt = &updatedAt.Time
+		}
+		version.Status = constanta.Published
+		version.Tags = entity.NewTags(tags...)
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type (
+	ArticleLockRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewArticleLockRepo(db *sql.DB) *ArticleLockRepo {
+	return &ArticleLockRepo{
+		db: db,
+	}
+}
+
+const (
+	// the lock is renewed by its holder and taken over when it is expired, otherwise no row is returned
+	acquireArticleLockQuery = `WITH acquired AS (
+		INSERT INTO article_locks (article_id, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (article_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			expires_at = EXCLUDED.expires_at,
+			created_at = CASE WHEN article_locks.user_id = EXCLUDED.user_id THEN article_locks.created_at ELSE NOW() END
+		WHERE article_locks.user_id = EXCLUDED.user_id OR article_locks.expires_at <= NOW()
+		RETURNING article_id, user_id, expires_at, created_at
+	)
+	SELECT a.article_id, a.user_id, u.name, a.expires_at, a.created_at
+	FROM acquired a
+	JOIN users u ON u.id = a.user_id`
+)
+
+// AcquireArticleLock locks the article for the user until expiresAt. It returns sql.ErrNoRows
+// when another user holds an active lock of the article.
+func (lr *ArticleLockRepo) AcquireArticleLock(ctx context.Context, articleID int64, userID uuid.UUID, expiresAt time.Time) (*entity.ArticleLock, error) {
+	lock := &entity.ArticleLock{}
+	err := lr.db.QueryRowContext(ctx, acquireArticleLockQuery, articleID, userID, expiresAt).Scan(
+		&lock.ArticleID,
+		&lock.UserID,
+		&lock.UserName,
+		&lock.ExpiresAt,
+		&lock.CreatedAt,
+	)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+		return nil, errs.NotFound{Message: "article"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+const (
+	getArticleLockQuery = `SELECT l.article_id, l.user_id, u.name, l.expires_at, l.created_at
+	FROM article_locks l
+	JOIN users u ON u.id = l.user_id
+	WHERE l.article_id = $1 AND l.expires_at > NOW()`
+)
+
+// GetArticleLock returns sql.ErrNoRows when the article has no active lock.
+func (lr *ArticleLockRepo) GetArticleLock(ctx context.Context, articleID int64) (*entity.ArticleLock, error) {
+	lock := &entity.ArticleLock{}
+	err := lr.db.QueryRowContext(ctx, getArticleLockQuery, articleID).Scan(
+		&lock.ArticleID,
+		&lock.UserID,
+		&lock.UserName,
+		&lock.ExpiresAt,
+		&lock.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+const (
+	releaseArticleLockQuery = `DELETE FROM article_locks WHERE article_id = $1 AND user_id = $2 AND expires_at > NOW()`
+	deleteArticleLockQuery  = `DELETE FROM article_locks WHERE article_id = $1 AND expires_at > NOW()`
+)
+
+// ReleaseArticleLock removes the lock of its holder, it returns sql.ErrNoRows when the user does not hold an active lock.
+func (lr *ArticleLockRepo) ReleaseArticleLock(ctx context.Context, articleID int64, userID uuid.UUID) error {
+	return lr.deleteArticleLock(ctx, releaseArticleLockQuery, articleID, userID)
+}
+
+// DeleteArticleLock removes the lock of any holder, it returns sql.ErrNoRows when the article has no active lock.
+func (lr *ArticleLockRepo) DeleteArticleLock(ctx context.Context, articleID int64) error {
+	return lr.deleteArticleLock(ctx, deleteArticleLockQuery, articleID)
+}
+
+func (lr *ArticleLockRepo) deleteArticleLock(ctx context.Context, query string, args ...any) error {
+	res, err := lr.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var articleLockTestColumns = []string{"article_id", "user_id", "name", "expires_at", "created_at"}
+
+func TestArticleLockRepo_AcquireArticleLock(t *testing.T) {
+	userID := uuid.New()
+	expiresAt := time.Now().Add(5 * time.Minute)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    *entity.ArticleLock
+		wantErr error
+	}{
+		{
+			name: "acquired",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(acquireArticleLockQuery)).
+					WithArgs(int64(1), userID, expiresAt).
+					WillReturnRows(sqlmock.NewRows(articleLockTestColumns).AddRow(1, userID, "writer", expiresAt, now))
+			},
+			want: &entity.ArticleLock{ArticleID: 1, UserID: userID, UserName: "writer", ExpiresAt: expiresAt, CreatedAt: now},
+		},
+		{
+			name: "held by another user",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(acquireArticleLockQuery)).
+					WithArgs(int64(1), userID, expiresAt).
+					WillReturnRows(sqlmock.NewRows(articleLockTestColumns))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "article not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(acquireArticleLockQuery)).
+					WithArgs(int64(1), userID, expiresAt).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode})
+			},
+			wantErr: errs.NotFound{Message: "article"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewArticleLockRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+
+			got, err := repo.AcquireArticleLock(context.Background(), 1, userID, expiresAt)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArticleLockRepo_ReleaseArticleLock(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "released",
+			affected: 1,
+		},
+		{
+			name:    "not the holder",
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewArticleLockRepo(db)
+			defer db.Close()
+			mock.ExpectExec(regexp.QuoteMeta(releaseArticleLockQuery)).
+				WithArgs(int64(1), userID).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			err := repo.ReleaseArticleLock(context.Background(), 1, userID)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArticleLockRepo_DeleteArticleLock(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewArticleLockRepo(db)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteArticleLockQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.DeleteArticleLock(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
@@ -26,12 +27,25 @@ func TestArticleRepo_CreateArticle(t *testing.T) {
 			mock: func(m sqlmock.Sqlmock) {
 				m.ExpectBegin()
 				m.ExpectQuery(regexp.QuoteMeta(createArticleQuery)).WithArgs(uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
-				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", int64(1), constanta.Published, uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
 				m.ExpectExec(regexp.QuoteMeta(updateLatestArticleVersionQuery)).WithArgs(uuid.Nil, int64(2), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventArticleCreated, int64(2), int64(0)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectCommit()
 			},
 			wantErr: false,
 		},
+		{
+			name: "negative case - the article is rolled back when its event fails",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(createArticleQuery)).WithArgs(uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.ExpectExec(regexp.QuoteMeta(updateLatestArticleVersionQuery)).WithArgs(uuid.Nil, int64(2), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventArticleCreated, int64(2), int64(0)).WillReturnError(errors.New("insert error"))
+				m.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "negative case - create article fails on article insert",
 			mock: func(m sqlmock.Sqlmock) {
@@ -50,7 +64,8 @@ func TestArticleRepo_CreateArticle(t *testing.T) {
 			defer db.Close()
 			repo := NewArticleRepo(db)
 			article := entity.Article{CreatedBy: uuid.Nil, VersionSequence: 1}
-			articleVersion := entity.ArticleVersion{Title: "title", Body: "body", Version: 1, Status: constanta.Published, CreatedBy: uuid.Nil}
+			articleVersion := entity.ArticleVersion{Title: "title", Body: "body", BodyFormat: constanta.Markdown, Version: 1, Status: constanta.Published, CreatedBy: uuid.Nil}
+			articleVersion.SetMetadata(entity.ArticleMetadata{Slug: "title"})
 			tt.mock(mock)
 			_, _, err = repo.CreateArticle(context.Background(), article, articleVersion)
 			if tt.wantErr {
@@ -73,6 +88,10 @@ func TestArticleRepo_DeleteArticle(t *testing.T) {
 			name: "positive case - delete article successfully",
 			mock: func(m sqlmock.Sqlmock) {
 				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(insertArticleDeletedEventQuery)).WithArgs(constanta.EventArticleDeleted, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(regexp.QuoteMeta(getArticlePublishedVersionIdQuery)).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"published_version_id"}).AddRow(int64(3)))
+				m.ExpectExec(regexp.QuoteMeta(changeTagStatsQuery)).WithArgs(int64(3), -1).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(changeTagPairStatsQuery)).WithArgs(int64(3), -1).WillReturnResult(sqlmock.NewResult(0, 1))
 				m.ExpectExec(regexp.QuoteMeta(resetArticlePublishedAndDraftedToNullQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectExec(regexp.QuoteMeta(deleteArticleVersionsByArticleIdQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectExec(regexp.QuoteMeta(deleteArticleByArticleIdQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -84,6 +103,8 @@ func TestArticleRepo_DeleteArticle(t *testing.T) {
 			name: "negative case - delete article fails on first exec",
 			mock: func(m sqlmock.Sqlmock) {
 				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(insertArticleDeletedEventQuery)).WithArgs(constanta.EventArticleDeleted, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(regexp.QuoteMeta(getArticlePublishedVersionIdQuery)).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"published_version_id"}).AddRow(nil))
 				m.ExpectExec(regexp.QuoteMeta(resetArticlePublishedAndDraftedToNullQuery)).WithArgs(int64(1)).WillReturnError(errors.New("delete error"))
 				m.ExpectRollback()
 			},
@@ -119,11 +140,12 @@ func TestArticleRepo_GetArticleVersionWithIDAndArticleID(t *testing.T) {
 		{
 			name: "positive case - get article version successfully",
 			mock: func(m sqlmock.Sqlmock) {
-				row := sqlmock.NewRows([]string{"id", "article_id", "title", "body", "version", "status", "tag_relationship_score", "created_by", "created_at", "updated_by", "updated_at"}).
-					AddRow(int64(2), int64(1), "title", "body", int64(1), constanta.Published, 0.0, uuid.Nil, time.Now(), uuid.Nil, nil)
+				row := sqlmock.NewRows([]string{"id", "article_id", "title", "body", "body_format", "blocks", "version", "status", "tag_relationship_score", "slug", "excerpt", "seo_title", "seo_description", "canonical_url", "og_image", "created_by", "created_at", "updated_by", "updated_at"}).
+					AddRow(int64(2), int64(1), "title", "body", constanta.Markdown, []byte(`{"schema_version":1,"blocks":[{"id":"a1","type":"paragraph","text":"body"}]}`), int64(1), constanta.Published, 0.0, "title", "body", "", "", "", "", uuid.Nil, time.Now(), uuid.Nil, nil)
 				m.ExpectQuery(regexp.QuoteMeta(getArticleVersionWithIDAndArticleIDQuery)).WithArgs(int64(1), int64(2)).WillReturnRows(row)
 			},
-			want:    &entity.ArticleVersion{ArticleVersionID: 2, ArticleID: 1, Title: "title", Body: "body", Version: 1, Status: constanta.Published, TagRelationShipScore: 0.0, CreatedBy: uuid.Nil},
+			want: &entity.ArticleVersion{ArticleVersionID: 2, ArticleID: 1, Title: "title", Body: "body", Version: 1, Status: constanta.Published, TagRelationShipScore: 0.0, CreatedBy: uuid.Nil,
+				Blocks: &entity.BlockDocument{SchemaVersion: 1, Blocks: []entity.Block{{ID: "a1", Type: constanta.ParagraphBlock, Text: "body"}}}},
 			wantErr: false,
 		},
 		{
@@ -148,6 +170,7 @@ func TestArticleRepo_GetArticleVersionWithIDAndArticleID(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want.ArticleVersionID, got.ArticleVersionID)
+				assert.Equal(t, tt.want.Blocks, got.Blocks)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
@@ -167,6 +190,9 @@ func TestArticleRepo_UpdateArticleStatus(t *testing.T) {
 				m.ExpectExec(regexp.QuoteMeta(updateArticleVersionQuery)).WithArgs(constanta.Archived, uuid.Nil, int64(1), int64(2)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectExec(regexp.QuoteMeta(updateArticleArchivedIdQuery)).WithArgs(int64(2), uuid.Nil, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectExec(regexp.QuoteMeta(updateArticlePublishedIdQuery)).WithArgs(nil, uuid.Nil, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(changeTagStatsQuery)).WithArgs(int64(2), -1).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(changeTagPairStatsQuery)).WithArgs(int64(2), -1).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventVersionArchived, int64(2), int64(0)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectCommit()
 			},
 			wantErr: false,
@@ -199,27 +225,113 @@ func TestArticleRepo_UpdateArticleStatus(t *testing.T) {
 	}
 }
 
+func TestArticleRepo_UpdateArticleStatus_Publish(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	repo := NewArticleRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM article_versions WHERE article_id = $1 AND status = $2 LIMIT 1")).WithArgs(int64(1), constanta.Published).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+	mock.ExpectExec(regexp.QuoteMeta(updateArticleVersionWithStatusPublishedIntoArchivedQuery)).WithArgs(constanta.Archived, uuid.Nil, int64(1), constanta.Published).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(updateArticleArchivedIdQuery)).WithArgs(int64(2), uuid.Nil, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(changeTagStatsQuery)).WithArgs(int64(2), -1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(changeTagPairStatsQuery)).WithArgs(int64(2), -1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(updateArticleVersionQuery)).WithArgs(constanta.Published, uuid.Nil, int64(1), int64(3)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(updateArticlePublishedIdQuery)).WithArgs(int64(3), uuid.Nil, int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(upsertArticleSlugQuery)).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(incrementTagUsageDailyQuery)).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(changeTagStatsQuery)).WithArgs(int64(3), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(changeTagPairStatsQuery)).WithArgs(int64(3), 1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM article_versions WHERE status=$1 AND article_id = $2 ORDER BY version DESC LIMIT 1")).WithArgs(constanta.Draft, int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE articles SET drafted_version_id=NULL WHERE id=$1")).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+	// the replaced version is given for the previous slug
+	mock.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventVersionPublished, int64(3), int64(2)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.UpdateArticleStatus(context.Background(), 1, 3, constanta.Published, constanta.Draft, uuid.Nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestArticleRepo_CreateArticleVersion(t *testing.T) {
 	tests := []struct {
-		name    string
-		mock    func(sqlmock.Sqlmock)
-		wantErr bool
+		name                  string
+		mediaIDs              []int64
+		commentsFromVersionID int64
+		mock                  func(sqlmock.Sqlmock)
+		wantErr               bool
 	}{
 		{
 			name: "positive case - create article version successfully",
 			mock: func(m sqlmock.Sqlmock) {
 				m.ExpectBegin()
-				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", int64(1), constanta.Published, uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.ExpectQuery(regexp.QuoteMeta(getActiveArticleLockOfUserQuery)).WithArgs(int64(1), uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
 				m.ExpectExec(regexp.QuoteMeta(updateLatestArticleVersionQuery)).WithArgs(uuid.Nil, int64(2), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventVersionCreated, int64(2), int64(0)).WillReturnResult(sqlmock.NewResult(1, 1))
 				m.ExpectCommit()
 			},
 			wantErr: false,
 		},
+		{
+			name:     "positive case - create article version with media",
+			mediaIDs: []int64{5},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(getActiveArticleLockOfUserQuery)).WithArgs(int64(1), uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.ExpectExec(regexp.QuoteMeta(createArticleVersionMediaQuery)).WithArgs(int64(2), int64(5)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(updateLatestArticleVersionQuery)).WithArgs(uuid.Nil, int64(2), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventVersionCreated, int64(2), int64(0)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:                  "positive case - carry the unresolved comments",
+			commentsFromVersionID: 1,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(getActiveArticleLockOfUserQuery)).WithArgs(int64(1), uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.ExpectExec(regexp.QuoteMeta(updateLatestArticleVersionQuery)).WithArgs(uuid.Nil, int64(2), int64(1), int64(1)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta(carryArticleCommentThreadsQuery)).WithArgs(int64(1), int64(2)).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(carryArticleCommentRepliesQuery)).WithArgs(int64(1), int64(2)).WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec(regexp.QuoteMeta(insertArticleVersionEventQuery)).WithArgs(constanta.EventVersionCreated, int64(2), int64(0)).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:     "negative case - media does not exist",
+			mediaIDs: []int64{5},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(getActiveArticleLockOfUserQuery)).WithArgs(int64(1), uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+				m.ExpectExec(regexp.QuoteMeta(createArticleVersionMediaQuery)).WithArgs(int64(2), int64(5)).WillReturnError(&pq.Error{Code: foreignKeyViolationCode})
+				m.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative case - the lock is not held by the user",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(getActiveArticleLockOfUserQuery)).WithArgs(int64(1), uuid.Nil).WillReturnError(sql.ErrNoRows)
+				m.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "negative case - create article version fails",
 			mock: func(m sqlmock.Sqlmock) {
 				m.ExpectBegin()
-				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", int64(1), constanta.Published, uuid.Nil).WillReturnError(errors.New("insert error"))
+				m.ExpectQuery(regexp.QuoteMeta(getActiveArticleLockOfUserQuery)).WithArgs(int64(1), uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(createArticleVersionQuery)).WithArgs(int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, uuid.Nil, "title", "body", "", "", "", "").WillReturnError(errors.New("insert error"))
 				m.ExpectRollback()
 			},
 			wantErr: true,
@@ -231,7 +343,8 @@ func TestArticleRepo_CreateArticleVersion(t *testing.T) {
 			assert.NoError(t, err)
 			defer db.Close()
 			repo := NewArticleRepo(db)
-			articleVersion := entity.ArticleVersion{ArticleID: 1, Title: "title", Body: "body", Version: 1, Status: constanta.Published, CreatedBy: uuid.Nil}
+			articleVersion := entity.ArticleVersion{ArticleID: 1, Title: "title", Body: "body", BodyFormat: constanta.Markdown, Version: 1, Status: constanta.Published, CreatedBy: uuid.Nil, MediaIDs: tt.mediaIDs, CommentsFromVersionID: tt.commentsFromVersionID}
+			articleVersion.SetMetadata(entity.ArticleMetadata{Slug: "title"})
 			tt.mock(mock)
 			_, err = repo.CreateArticleVersion(context.Background(), articleVersion)
 			if tt.wantErr {
@@ -299,8 +412,8 @@ func TestArticleRepo_GetArticleVersionsWithArticleIDAndStatuses(t *testing.T) {
 		{
 			name: "positive case - get article versions successfully",
 			mock: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "article_id", "title", "body", "version", "status", "tag_relationship_score", "created_by", "created_at", "updated_by", "updated_at"}).
-					AddRow(int64(2), int64(1), "title", "body", int64(1), constanta.Published, 0.0, uuid.Nil, time.Now(), uuid.Nil, time.Now())
+				rows := sqlmock.NewRows([]string{"id", "article_id", "title", "body", "body_format", "blocks", "version", "status", "tag_relationship_score", "slug", "excerpt", "seo_title", "seo_description", "canonical_url", "og_image", "created_by", "created_at", "updated_by", "updated_at"}).
+					AddRow(int64(2), int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, 0.0, "title", "body", "", "", "", "", uuid.Nil, time.Now(), uuid.Nil, time.Now())
 				m.ExpectQuery(regexp.QuoteMeta(getArticleVersionsWithArticleIDAndStatusesQuery)).WithArgs(int64(1), pq.Array([]constanta.ArticleVersionStatus{constanta.Published})).WillReturnRows(rows)
 			},
 			want:    []entity.ArticleVersion{{ArticleVersionID: 2, ArticleID: 1, Title: "title", Body: "body", Version: 1, Status: constanta.Published, TagRelationShipScore: 0.0, CreatedBy: uuid.Nil}},
@@ -417,5 +530,271 @@ func TestArticleRepo_UpdateArticleVersionRelationshipScore(t *testing.T) {
 	}
 }
 
-// Note: For brevity, GetArticles is omitted here, as it requires more complex query and mock setup.
-// You can request it specifically if needed.
+func TestArticleRepo_GetArticles(t *testing.T) {
+	columns := []string{"article_version_id", "article_id", "title", "body", "body_format", "blocks", "version", "status", "tag_relationship_score", "slug", "excerpt", "seo_title", "seo_description", "canonical_url", "og_image", "created_by", "created_at", "updated_by", "updated_at"}
+
+	tests := []struct {
+		name      string
+		req       entity.GetArticlesQueryServiceParams
+		mock      func(sqlmock.Sqlmock)
+		wantLen   int
+		wantTotal int64
+		wantErr   bool
+	}{
+		{
+			name: "positive case - exact count from window function",
+			req:  entity.GetArticlesQueryServiceParams{Status: []constanta.ArticleVersionStatus{constanta.Published}, OrderClause: "created_at desc", Limit: 10, Page: 1},
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(columns, "total_count")).
+					AddRow(int64(1), int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, 0.0, "title", "body", "", "", "", "", uuid.Nil, time.Now(), uuid.Nil, nil, int64(25))
+				m.ExpectQuery(`COUNT\(\*\) OVER \(\)(.|\n)*LIMIT \$5 OFFSET \$6`).WillReturnRows(rows)
+			},
+			wantLen:   1,
+			wantTotal: 25,
+		},
+		{
+			name: "positive case - empty page falls back to count query",
+			req:  entity.GetArticlesQueryServiceParams{Status: []constanta.ArticleVersionStatus{constanta.Published}, OrderClause: "created_at desc", Limit: 10, Page: 4},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`COUNT\(\*\) OVER \(\)`).WillReturnRows(sqlmock.NewRows(append(columns, "total_count")))
+				m.ExpectQuery(`select COUNT\(\*\) from`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(25)))
+			},
+			wantLen:   0,
+			wantTotal: 25,
+		},
+		{
+			name: "positive case - estimated count from the planner",
+			req:  entity.GetArticlesQueryServiceParams{Status: []constanta.ArticleVersionStatus{constanta.Published}, OrderClause: "created_at desc", Limit: 10, Page: 1, CountMode: constanta.EstimatedCount},
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(int64(1), int64(1), "title", "body", constanta.Markdown, nil, int64(1), constanta.Published, 0.0, "title", "body", "", "", "", "", uuid.Nil, time.Now(), uuid.Nil, nil)
+				m.ExpectQuery(`LIMIT \$5 OFFSET \$6`).WillReturnRows(rows)
+				m.ExpectQuery(`EXPLAIN \(FORMAT JSON\)`).WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow([]byte(`[{"Plan": {"Plan Rows": 1200}}]`)))
+			},
+			wantLen:   1,
+			wantTotal: 1200,
+		},
+		{
+			name: "negative case - query returns error",
+			req:  entity.GetArticlesQueryServiceParams{Status: []constanta.ArticleVersionStatus{constanta.Published}, OrderClause: "created_at desc", Limit: 10, Page: 1},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`select`).WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+			assert.NoError(t, err)
+			defer db.Close()
+			repo := NewArticleRepo(db)
+			tt.mock(mock)
+			got, total, err := repo.GetArticles(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.wantLen)
+				assert.Equal(t, tt.wantTotal, total)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArticleRepo_getKeysetClause(t *testing.T) {
+	sorts := []entity.SortField{{Key: "updated_at", Desc: true}, {Key: "article_version_id", Desc: true}}
+
+	tests := []struct {
+		name      string
+		cursor    *entity.Cursor
+		wantQuery string
+		wantOrder string
+	}{
+		{
+			name:      "forward",
+			cursor:    &entity.Cursor{Values: []string{"-infinity", "3"}},
+			wantQuery: "(COALESCE(updated_at, '-infinity') < $5) OR (COALESCE(updated_at, '-infinity') = $5 AND article_version_id < $6)",
+			wantOrder: "COALESCE(updated_at, '-infinity') desc, article_version_id desc",
+		},
+		{
+			name:      "backward",
+			cursor:    &entity.Cursor{Values: []string{"-infinity", "3"}, Backward: true},
+			wantQuery: "(COALESCE(updated_at, '-infinity') > $5) OR (COALESCE(updated_at, '-infinity') = $5 AND article_version_id > $6)",
+			wantOrder: "COALESCE(updated_at, '-infinity') asc, article_version_id asc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := getKeysetClause(sorts, tt.cursor, 5)
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, []any{"-infinity", "3"}, args)
+			assert.Equal(t, tt.wantOrder, getKeysetOrderClause(sorts, tt.cursor.Backward))
+		})
+	}
+}
+
+func TestArticleRepo_GetTakenSlugs(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "positive case - get taken slugs successfully",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"slug"}).AddRow("title").AddRow("title-2")
+				m.ExpectQuery(regexp.QuoteMeta(getTakenSlugsQuery)).WithArgs(constanta.Published, int64(1), "title").WillReturnRows(rows)
+			},
+			want:    []string{"title", "title-2"},
+			wantErr: false,
+		},
+		{
+			name: "negative case - query returns error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getTakenSlugsQuery)).WithArgs(constanta.Published, int64(1), "title").WillReturnError(errors.New("query error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			repo := NewArticleRepo(db)
+			tt.mock(mock)
+			got, err := repo.GetTakenSlugs(context.Background(), "title", 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArticleRepo_GetArticleIDBySlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		mock     func(sqlmock.Sqlmock)
+		wantID   int64
+		wantSlug string
+		wantErr  bool
+	}{
+		{
+			name: "positive case - old slug returns the current slug",
+			mock: func(m sqlmock.Sqlmock) {
+				row := sqlmock.NewRows([]string{"id", "slug"}).AddRow(int64(1), "new-title")
+				m.ExpectQuery(regexp.QuoteMeta(getArticleIDBySlugQuery)).WithArgs("old-title", constanta.Published).WillReturnRows(row)
+			},
+			wantID:   1,
+			wantSlug: "new-title",
+			wantErr:  false,
+		},
+		{
+			name: "negative case - slug not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getArticleIDBySlugQuery)).WithArgs("old-title", constanta.Published).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			repo := NewArticleRepo(db)
+			tt.mock(mock)
+			gotID, gotSlug, err := repo.GetArticleIDBySlug(context.Background(), "old-title")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, gotID)
+				assert.Equal(t, tt.wantSlug, gotSlug)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArticleRepo_GetArticleCacheValidator(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	repo := NewArticleRepo(db)
+
+	lastModified := time.Date(2025, 8, 28, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(getArticleCacheValidatorQuery)).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "last_modified"}).AddRow("2025-08-28|2||", lastModified))
+	mock.ExpectQuery(regexp.QuoteMeta(getArticleCacheValidatorQuery)).WithArgs(int64(2)).WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.GetArticleCacheValidator(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.CacheValidator{Key: "2025-08-28|2||", LastModified: lastModified}, got)
+
+	_, err = repo.GetArticleCacheValidator(context.Background(), 2)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRepo_GetArticlesCacheValidator(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	repo := NewArticleRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getArticlesCacheValidatorQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("3|2025-08-28"))
+
+	got, err := repo.GetArticlesCacheValidator(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.CacheValidator{Key: "3|2025-08-28"}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRepo_GetPublishedArticleVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "article_id", "title", "slug", "excerpt", "tag_relationship_score", "created_at", "updated_at", "tags"}).
+		AddRow(int64(3), int64(1), "Go", "go", "about go", 0.5, createdAt, createdAt, "{cms,go}").
+		AddRow(int64(4), int64(2), "Untagged", "untagged", "", 0.0, createdAt, nil, "{}")
+	mock.ExpectQuery(regexp.QuoteMeta(getPublishedArticleVersionsQuery)).WillReturnRows(rows)
+
+	repo := NewArticleRepo(db)
+	versions, err := repo.GetPublishedArticleVersions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ArticleVersion{
+		{
+			ArticleVersionID:     3,
+			ArticleID:            1,
+			Title:                "Go",
+			Status:               constanta.Published,
+			Tags:                 entity.NewTags("cms", "go"),
+			TagRelationShipScore: 0.5,
+			ArticleMetadata:      entity.ArticleMetadata{Slug: "go", Excerpt: "about go"},
+			CreatedAt:            createdAt,
+			UpdatedAt:            &createdAt,
+		},
+		{
+			ArticleVersionID: 4,
+			ArticleID:        2,
+			Title:            "Untagged",
+			Status:           constanta.Published,
+			Tags:             []entity.Tag{},
+			ArticleMetadata:  entity.ArticleMetadata{Slug: "untagged"},
+			CreatedAt:        createdAt,
+		},
+	}, versions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,280 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/lib/pq"
+)
+
+type (
+	CategoryRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewCategoryRepo(db *sql.DB) *CategoryRepo {
+	return &CategoryRepo{
+		db: db,
+	}
+}
+
+const (
+	// the id is taken first, so the path which contains it is written with the row.
+	// The path and the depth are derived from the parent
+	createCategoryQuery = `WITH new_category AS (SELECT nextval(pg_get_serial_sequence('categories', 'id')) AS id)
+		INSERT INTO categories
+		(id, parent_id, "name", slug, "path", "depth", "position", created_by)
+		SELECT
+			id, $1, $2, $3,
+			COALESCE((SELECT "path" FROM categories WHERE id = $1), '/') || id || '/',
+			COALESCE((SELECT "depth" + 1 FROM categories WHERE id = $1), 0),
+			$4, $5
+		FROM new_category RETURNING id;`
+)
+
+func (cr *CategoryRepo) CreateCategory(ctx context.Context, category entity.Category) (int64, error) {
+	var id int64
+	err := cr.db.QueryRowContext(ctx, createCategoryQuery,
+		category.ParentID,
+		category.Name,
+		category.Slug,
+		category.Position,
+		category.CreatedBy,
+	).Scan(&id)
+	if err != nil {
+		return 0, categoryError(err)
+	}
+
+	return id, nil
+}
+
+const (
+	categoryColumns = `id, parent_id, "name", slug, "path", "depth", "position", created_by, created_at, updated_at`
+
+	getCategoryWithIDQuery = `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+
+	// siblings are next to each other, ordered by their position
+	getCategoriesQuery = `SELECT ` + categoryColumns + ` FROM categories
+		WHERE "path" LIKE $1 || '%'
+		ORDER BY "depth", "position", "name"`
+
+	getCategoriesWithIDsQuery = `SELECT ` + categoryColumns + ` FROM categories
+		WHERE id = ANY($1)
+		ORDER BY "depth"`
+)
+
+func (cr *CategoryRepo) GetCategoryWithID(ctx context.Context, id int64) (*entity.Category, error) {
+	category, err := scanCategory(cr.db.QueryRowContext(ctx, getCategoryWithIDQuery, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetCategories returns the subtree of the category with the given path, or every category when the path is empty.
+func (cr *CategoryRepo) GetCategories(ctx context.Context, path string) ([]entity.Category, error) {
+	return cr.getCategories(ctx, getCategoriesQuery, path)
+}
+
+// GetCategoriesWithIDs returns the categories ordered from the root.
+func (cr *CategoryRepo) GetCategoriesWithIDs(ctx context.Context, ids []int64) ([]entity.Category, error) {
+	return cr.getCategories(ctx, getCategoriesWithIDsQuery, pq.Array(ids))
+}
+
+func (cr *CategoryRepo) getCategories(ctx context.Context, query string, args ...any) ([]entity.Category, error) {
+	rows, err := cr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []entity.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *category)
+	}
+
+	return categories, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCategory scans the category columns, followed by the extra columns into extra.
+func scanCategory(row scanner, extra ...any) (*entity.Category, error) {
+	var category entity.Category
+	var parentID sql.NullInt64
+	var updatedAt sql.NullTime
+	dest := []any{
+		&category.ID,
+		&parentID,
+		&category.Name,
+		&category.Slug,
+		&category.Path,
+		&category.Depth,
+		&category.Position,
+		&category.CreatedBy,
+		&category.CreatedAt,
+		&updatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		category.ParentID = &parentID.Int64
+	}
+	if updatedAt.Valid {
+		category.UpdatedAt = &updatedAt.Time
+	}
+
+	return &category, nil
+}
+
+const (
+	// the category is included, because its path starts with its own path
+	moveCategoryQuery = `UPDATE categories
+		SET "path" = $1 || SUBSTRING("path" FROM LENGTH($2) + 1), "depth" = "depth" + $3
+		WHERE "path" LIKE $2 || '%'`
+
+	updateCategoryQuery = `UPDATE categories
+		SET parent_id=$1, "name"=$2, slug=$3, "position"=$4 WHERE id=$5`
+)
+
+// UpdateCategory updates the category. When the path of the category differs from oldPath,
+// the category is moved with its descendants.
+func (cr *CategoryRepo) UpdateCategory(ctx context.Context, category entity.Category, oldPath string) error {
+	return runInTx(ctx, cr.db, func(tx *sql.Tx) error {
+		if category.Path != oldPath {
+			// a root path such as /1/ has two slashes
+			oldDepth := strings.Count(oldPath, "/") - 2
+			_, err := tx.ExecContext(ctx, moveCategoryQuery, category.Path, oldPath, category.Depth-oldDepth)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, updateCategoryQuery,
+			category.ParentID,
+			category.Name,
+			category.Slug,
+			category.Position,
+			category.ID,
+		)
+		if err != nil {
+			return categoryError(err)
+		}
+
+		return nil
+	})
+}
+
+const (
+	deleteCategoryQuery = `DELETE FROM categories WHERE id = $1`
+)
+
+func (cr *CategoryRepo) DeleteCategory(ctx context.Context, id int64) error {
+	_, err := cr.db.ExecContext(ctx, deleteCategoryQuery, id)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+		if pqErr.Constraint == "categories_parent_id_fkey" {
+			return errs.Conflict{Message: "category has subcategories"}
+		}
+		return errs.Conflict{Message: "category is used by articles"}
+	}
+
+	return err
+}
+
+// categoryError translates the constraint violations of the categories table.
+func categoryError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolationCode:
+			return errs.AlreadyExist{Name: "category with the same slug under the parent"}
+		case foreignKeyViolationCode:
+			return errs.NotFound{Message: "parent category"}
+		}
+	}
+
+	return err
+}
+
+const (
+	deleteArticleCategoriesQuery = `DELETE FROM article_categories WHERE article_id = $1`
+
+	createArticleCategoriesQuery = `INSERT INTO article_categories
+		(article_id, category_id, is_primary)
+		SELECT $1, category_id, category_id = $3 FROM UNNEST($2::INT[]) AS category_id`
+)
+
+// SetArticleCategories replaces the categories of the article.
+// The primary category must also be in categoryIDs.
+func (cr *CategoryRepo) SetArticleCategories(ctx context.Context, articleID int64, primaryCategoryID int64, categoryIDs []int64) error {
+	return runInTx(ctx, cr.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, deleteArticleCategoriesQuery, articleID)
+		if err != nil {
+			return err
+		}
+
+		if len(categoryIDs) == 0 {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, createArticleCategoriesQuery, articleID, pq.Array(categoryIDs), primaryCategoryID)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+			if pqErr.Constraint == "article_categories_article_id_fkey" {
+				return errs.NotFound{Message: "article"}
+			}
+			return errs.ValidationError{Message: "category not found"}
+		}
+
+		return err
+	})
+}
+
+const (
+	getArticleCategoriesQuery = `SELECT
+			c.id, c.parent_id, c."name", c.slug, c."path", c."depth", c."position", c.created_by, c.created_at, c.updated_at,
+			ac.is_primary
+		FROM article_categories ac
+		JOIN categories c ON ac.category_id = c.id
+		WHERE ac.article_id = $1
+		ORDER BY ac.is_primary DESC, c."path"`
+)
+
+// GetArticleCategories returns the categories of the article, the primary category first.
+func (cr *CategoryRepo) GetArticleCategories(ctx context.Context, articleID int64) ([]entity.ArticleCategory, error) {
+	rows, err := cr.db.QueryContext(ctx, getArticleCategoriesQuery, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []entity.ArticleCategory
+	for rows.Next() {
+		var isPrimary bool
+		category, err := scanCategory(rows, &isPrimary)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, entity.ArticleCategory{
+			Category:  *category,
+			IsPrimary: isPrimary,
+		})
+	}
+
+	return categories, rows.Err()
+}
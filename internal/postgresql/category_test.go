@@ -0,0 +1,334 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var categoryTestColumns = []string{"id", "parent_id", "name", "slug", "path", "depth", "position", "created_by", "created_at", "updated_at"}
+
+func TestCategoryRepo_CreateCategory(t *testing.T) {
+	parentID := int64(1)
+	category := entity.Category{
+		ParentID:  &parentID,
+		Name:      "Politics",
+		Slug:      "politics",
+		Position:  2,
+		CreatedBy: uuid.New(),
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    int64
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(createCategoryQuery)).
+					WithArgs(category.ParentID, category.Name, category.Slug, category.Position, category.CreatedBy).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+			},
+			want: 4,
+		},
+		{
+			name: "slug is taken",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(createCategoryQuery)).
+					WillReturnError(&pq.Error{Code: uniqueViolationCode})
+			},
+			wantErr: errs.AlreadyExist{Name: "category with the same slug under the parent"},
+		},
+		{
+			name: "parent not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(createCategoryQuery)).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode})
+			},
+			wantErr: errs.NotFound{Message: "parent category"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewCategoryRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			got, err := repo.CreateCategory(context.Background(), category)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryRepo_GetCategories(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []entity.Category
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(categoryTestColumns).
+					AddRow(1, nil, "News", "news", "/1/", 0, 0, userID, now, nil).
+					AddRow(4, 1, "Politics", "politics", "/1/4/", 1, 0, userID, now, now)
+				m.ExpectQuery(regexp.QuoteMeta(getCategoriesQuery)).
+					WithArgs("/1/").
+					WillReturnRows(rows)
+			},
+			want: []entity.Category{
+				{ID: 1, Name: "News", Slug: "news", Path: "/1/", CreatedBy: userID, CreatedAt: now},
+				{ID: 4, ParentID: func() *int64 { id := int64(1); return &id }(), Name: "Politics", Slug: "politics", Path: "/1/4/", Depth: 1, CreatedBy: userID, CreatedAt: now, UpdatedAt: &now},
+			},
+		},
+		{
+			name: "fail",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getCategoriesQuery)).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewCategoryRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			got, err := repo.GetCategories(context.Background(), "/1/")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryRepo_UpdateCategory(t *testing.T) {
+	parentID := int64(2)
+	category := entity.Category{ID: 4, ParentID: &parentID, Name: "Politics", Slug: "politics", Path: "/2/4/", Depth: 1, Position: 1}
+
+	tests := []struct {
+		name    string
+		oldPath string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name:    "success without moving",
+			oldPath: "/2/4/",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(updateCategoryQuery)).
+					WithArgs(category.ParentID, category.Name, category.Slug, category.Position, category.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:    "success moving the subtree",
+			oldPath: "/1/3/4/",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(moveCategoryQuery)).
+					WithArgs("/2/4/", "/1/3/4/", -1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec(regexp.QuoteMeta(updateCategoryQuery)).
+					WithArgs(category.ParentID, category.Name, category.Slug, category.Position, category.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:    "slug is taken",
+			oldPath: "/2/4/",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(updateCategoryQuery)).
+					WillReturnError(&pq.Error{Code: uniqueViolationCode})
+				m.ExpectRollback()
+			},
+			wantErr: errs.AlreadyExist{Name: "category with the same slug under the parent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewCategoryRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			err := repo.UpdateCategory(context.Background(), category, tt.oldPath)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryRepo_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteCategoryQuery)).
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "has subcategories",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteCategoryQuery)).
+					WithArgs(int64(1)).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode, Constraint: "categories_parent_id_fkey"})
+			},
+			wantErr: errs.Conflict{Message: "category has subcategories"},
+		},
+		{
+			name: "used by articles",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteCategoryQuery)).
+					WithArgs(int64(1)).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode, Constraint: "article_categories_category_id_fkey"})
+			},
+			wantErr: errs.Conflict{Message: "category is used by articles"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewCategoryRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			err := repo.DeleteCategory(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryRepo_SetArticleCategories(t *testing.T) {
+	tests := []struct {
+		name        string
+		categoryIDs []int64
+		mock        func(sqlmock.Sqlmock)
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			categoryIDs: []int64{4, 5},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(deleteArticleCategoriesQuery)).
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(createArticleCategoriesQuery)).
+					WithArgs(int64(1), pq.Array([]int64{4, 5}), int64(4)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "success removing every category",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(deleteArticleCategoriesQuery)).
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:        "category not found",
+			categoryIDs: []int64{4, 5},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(deleteArticleCategoriesQuery)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(createArticleCategoriesQuery)).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode, Constraint: "article_categories_category_id_fkey"})
+				m.ExpectRollback()
+			},
+			wantErr: errs.ValidationError{Message: "category not found"},
+		},
+		{
+			name:        "article not found",
+			categoryIDs: []int64{4, 5},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(deleteArticleCategoriesQuery)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(createArticleCategoriesQuery)).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode, Constraint: "article_categories_article_id_fkey"})
+				m.ExpectRollback()
+			},
+			wantErr: errs.NotFound{Message: "article"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewCategoryRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			err := repo.SetArticleCategories(context.Background(), 1, 4, tt.categoryIDs)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryRepo_GetArticleCategories(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	db, mock, _ := sqlmock.New()
+	repo := NewCategoryRepo(db)
+	defer db.Close()
+
+	rows := sqlmock.NewRows(append(categoryTestColumns, "is_primary")).
+		AddRow(4, 1, "Politics", "politics", "/1/4/", 1, 0, userID, now, nil, true).
+		AddRow(7, nil, "Opinion", "opinion", "/7/", 0, 1, userID, now, nil, false)
+	mock.ExpectQuery(regexp.QuoteMeta(getArticleCategoriesQuery)).
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	got, err := repo.GetArticleCategories(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.True(t, got[0].IsPrimary)
+	assert.Equal(t, int64(1), *got[0].ParentID)
+	assert.Equal(t, "/1/4/", got[0].Path)
+	assert.False(t, got[1].IsPrimary)
+	assert.Nil(t, got[1].ParentID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
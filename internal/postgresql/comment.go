@@ -0,0 +1,337 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type (
+	CommentRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewCommentRepo(db *sql.DB) *CommentRepo {
+	return &CommentRepo{
+		db: db,
+	}
+}
+
+const (
+	createArticleCommentQuery = `INSERT INTO article_comments
+		(article_id, article_version_id, parent_id, anchor_field, anchor_start, anchor_end, anchor_text, body, mentions, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+)
+
+// CreateArticleComment saves the comment with its comment.created event.
+func (cr *CommentRepo) CreateArticleComment(ctx context.Context, comment entity.ArticleComment) (int64, error) {
+	var (
+		anchorField            sql.NullString
+		anchorStart, anchorEnd sql.NullInt64
+		anchorText             sql.NullString
+		parentID               sql.NullInt64
+		commentID              int64
+	)
+	if comment.Anchor != nil {
+		anchorField = sql.NullString{String: string(comment.Anchor.Field), Valid: true}
+		anchorStart = sql.NullInt64{Int64: int64(comment.Anchor.Start), Valid: true}
+		anchorEnd = sql.NullInt64{Int64: int64(comment.Anchor.End), Valid: true}
+		anchorText = sql.NullString{String: comment.Anchor.Text, Valid: true}
+	}
+	if comment.ParentID != 0 {
+		parentID = sql.NullInt64{Int64: comment.ParentID, Valid: true}
+	}
+
+	err := runInTx(ctx, cr.db, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, createArticleCommentQuery,
+			comment.ArticleID,
+			comment.ArticleVersionID,
+			parentID,
+			anchorField,
+			anchorStart,
+			anchorEnd,
+			anchorText,
+			comment.Body,
+			pq.Array(uuidStrings(comment.Mentions)),
+			comment.CreatedBy,
+		).Scan(&commentID); err != nil {
+			return err
+		}
+
+		return insertOutboxEvent(ctx, tx, constanta.EventCommentCreated, entity.CommentEventData{
+			CommentID:        commentID,
+			ArticleID:        comment.ArticleID,
+			ArticleVersionID: comment.ArticleVersionID,
+			ParentID:         comment.ParentID,
+			CreatedBy:        comment.CreatedBy,
+			Mentions:         comment.Mentions,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return commentID, nil
+}
+
+// uuidStrings writes the ids as text, so an empty list is an empty array instead of null.
+func uuidStrings(ids []uuid.UUID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+
+	return values
+}
+
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+const (
+	articleCommentColumns = `c.id, c.article_id, c.article_version_id, c.parent_id, c.anchor_field, c.anchor_start, c.anchor_end, c.anchor_text,
+		c.body, c.mentions, c.resolved_by, c.resolved_at, c.carried_from_id, c.created_by, u.name, c.created_at, c.updated_at`
+
+	getArticleCommentQuery = `SELECT ` + articleCommentColumns + `
+		FROM article_comments c JOIN users u ON u.id = c.created_by
+		WHERE c.id = $1`
+	// the threads are ordered by their first comment, and the replies follow it
+	getArticleCommentsQuery = `SELECT ` + articleCommentColumns + `
+		FROM article_comments c JOIN users u ON u.id = c.created_by
+		WHERE c.article_version_id = $1
+		ORDER BY c.created_at, c.id`
+)
+
+// GetArticleComment returns sql.ErrNoRows when the comment does not exist.
+func (cr *CommentRepo) GetArticleComment(ctx context.Context, id int64) (*entity.ArticleComment, error) {
+	rows, err := cr.db.QueryContext(ctx, getArticleCommentQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments, err := scanArticleComments(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(comments) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &comments[0], nil
+}
+
+// GetArticleComments returns every comment of the version in the order of their creation.
+func (cr *CommentRepo) GetArticleComments(ctx context.Context, articleVersionID int64) ([]entity.ArticleComment, error) {
+	rows, err := cr.db.QueryContext(ctx, getArticleCommentsQuery, articleVersionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanArticleComments(rows)
+}
+
+func scanArticleComments(rows *sql.Rows) ([]entity.ArticleComment, error) {
+	comments := []entity.ArticleComment{}
+	for rows.Next() {
+		var (
+			comment                entity.ArticleComment
+			parentID, carriedFrom  sql.NullInt64
+			anchorField            sql.NullString
+			anchorStart, anchorEnd sql.NullInt64
+			anchorText             sql.NullString
+			mentions               []string
+			resolvedBy             uuid.NullUUID
+		)
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.ArticleID,
+			&comment.ArticleVersionID,
+			&parentID,
+			&anchorField,
+			&anchorStart,
+			&anchorEnd,
+			&anchorText,
+			&comment.Body,
+			pq.Array(&mentions),
+			&resolvedBy,
+			&comment.ResolvedAt,
+			&carriedFrom,
+			&comment.CreatedBy,
+			&comment.CreatedByName,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		comment.ParentID = parentID.Int64
+		comment.CarriedFromID = carriedFrom.Int64
+		if resolvedBy.Valid {
+			comment.ResolvedBy = &resolvedBy.UUID
+		}
+
+		if anchorField.Valid {
+			comment.Anchor = &entity.CommentAnchor{
+				Field:    constanta.CommentAnchorField(anchorField.String),
+				Start:    int(anchorStart.Int64),
+				End:      int(anchorEnd.Int64),
+				Text:     anchorText.String,
+				Outdated: !anchorStart.Valid,
+			}
+		}
+
+		mentionIDs, err := parseUUIDs(mentions)
+		if err != nil {
+			return nil, err
+		}
+		comment.Mentions = mentionIDs
+
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+const (
+	resolveArticleCommentQuery   = `UPDATE article_comments SET resolved_by = $2, resolved_at = NOW() WHERE id = $1 AND parent_id IS NULL`
+	unresolveArticleCommentQuery = `UPDATE article_comments SET resolved_by = NULL, resolved_at = NULL WHERE id = $1 AND parent_id IS NULL`
+)
+
+// ResolveArticleComment resolves the thread of the first comment, it returns sql.ErrNoRows when the comment is not the first of a thread.
+func (cr *CommentRepo) ResolveArticleComment(ctx context.Context, id int64, resolvedBy uuid.UUID) error {
+	return cr.updateArticleComment(ctx, resolveArticleCommentQuery, id, resolvedBy)
+}
+
+// UnresolveArticleComment opens the thread of the first comment again.
+func (cr *CommentRepo) UnresolveArticleComment(ctx context.Context, id int64) error {
+	return cr.updateArticleComment(ctx, unresolveArticleCommentQuery, id)
+}
+
+func (cr *CommentRepo) updateArticleComment(ctx context.Context, query string, args ...any) error {
+	res, err := cr.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const (
+	getUserNamesQuery = `SELECT id, name FROM users WHERE id = ANY($1)`
+	getUserRolesQuery = `SELECT id, role FROM users WHERE id = ANY($1)`
+)
+
+// GetUserNames returns the names of the users which exist.
+func (cr *CommentRepo) GetUserNames(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	names := make(map[uuid.UUID]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	rows, err := cr.db.QueryContext(ctx, getUserNamesQuery, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+
+	return names, rows.Err()
+}
+
+// GetUserRoles returns the roles of the users which exist.
+func (cr *CommentRepo) GetUserRoles(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]entity.UserRole, error) {
+	roles := make(map[uuid.UUID]entity.UserRole, len(ids))
+	if len(ids) == 0 {
+		return roles, nil
+	}
+
+	rows, err := cr.db.QueryContext(ctx, getUserRolesQuery, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			role entity.UserRole
+		)
+		if err := rows.Scan(&id, &role); err != nil {
+			return nil, err
+		}
+		roles[id] = role
+	}
+
+	return roles, rows.Err()
+}
+
+const (
+	// the anchor stays when its text is still at the same range, otherwise it moves to the first occurrence of the text.
+	// It is outdated when the text is not in the new version, an outdated anchor is found again when the text comes back.
+	carryArticleCommentThreadsQuery = `INSERT INTO article_comments
+		(article_id, article_version_id, anchor_field, anchor_start, anchor_end, anchor_text, body, mentions, carried_from_id, created_by, created_at)
+		SELECT c.article_id, nv.id, c.anchor_field, a.anchor_start, a.anchor_start + char_length(c.anchor_text), c.anchor_text,
+			c.body, c.mentions, c.id, c.created_by, c.created_at
+		FROM article_comments c
+		JOIN article_versions nv ON nv.id = $2
+		CROSS JOIN LATERAL (SELECT CASE c.anchor_field WHEN 'title' THEN nv.title ELSE nv.body END AS text) f
+		CROSS JOIN LATERAL (SELECT CASE
+			WHEN c.anchor_start IS NOT NULL AND substr(f.text, c.anchor_start + 1, c.anchor_end - c.anchor_start) = c.anchor_text THEN c.anchor_start
+			WHEN strpos(f.text, c.anchor_text) > 0 THEN strpos(f.text, c.anchor_text) - 1
+		END AS anchor_start) a
+		WHERE c.article_version_id = $1 AND c.parent_id IS NULL AND c.resolved_at IS NULL
+		ORDER BY c.id`
+	carryArticleCommentRepliesQuery = `INSERT INTO article_comments
+		(article_id, article_version_id, parent_id, body, mentions, carried_from_id, created_by, created_at)
+		SELECT r.article_id, p.article_version_id, p.id, r.body, r.mentions, r.id, r.created_by, r.created_at
+		FROM article_comments r
+		JOIN article_comments p ON p.carried_from_id = r.parent_id AND p.article_version_id = $2
+		WHERE r.article_version_id = $1
+		ORDER BY r.id`
+)
+
+// carryArticleComments copies the unresolved threads of a version to the new version in its transaction,
+// the resolved threads stay with the version they were resolved in.
+func carryArticleComments(ctx context.Context, tx *sql.Tx, fromVersionID, toVersionID int64) error {
+	if _, err := tx.ExecContext(ctx, carryArticleCommentThreadsQuery, fromVersionID, toVersionID); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, carryArticleCommentRepliesQuery, fromVersionID, toVersionID)
+	return err
+}
@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var articleCommentTestColumns = []string{"id", "article_id", "article_version_id", "parent_id", "anchor_field", "anchor_start", "anchor_end", "anchor_text",
+	"body", "mentions", "resolved_by", "resolved_at", "carried_from_id", "created_by", "name", "created_at", "updated_at"}
+
+func TestCommentRepo_CreateArticleComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewCommentRepo(db)
+	defer db.Close()
+
+	createdBy := uuid.New()
+	mentioned := uuid.New()
+	comment := entity.ArticleComment{
+		ArticleID:        1,
+		ArticleVersionID: 2,
+		Anchor:           &entity.CommentAnchor{Field: constanta.CommentAnchorBody, Start: 0, End: 5, Text: "hello"},
+		Body:             "typo",
+		Mentions:         []uuid.UUID{mentioned},
+		CreatedBy:        createdBy,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(createArticleCommentQuery)).
+		WithArgs(int64(1), int64(2), sql.NullInt64{}, sql.NullString{String: "body", Valid: true}, sql.NullInt64{Int64: 0, Valid: true}, sql.NullInt64{Int64: 5, Valid: true},
+			sql.NullString{String: "hello", Valid: true}, "typo", pq.Array([]string{mentioned.String()}), createdBy).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(insertOutboxEventQuery)).
+		WithArgs(constanta.EventCommentCreated, []byte(`{"comment_id":3,"article_id":1,"article_version_id":2,"created_by":"`+createdBy.String()+`","mentions":["`+mentioned.String()+`"]}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	got, err := repo.CreateArticleComment(context.Background(), comment)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentRepo_GetArticleComments(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewCommentRepo(db)
+	defer db.Close()
+
+	createdBy := uuid.New()
+	mentioned := uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(getArticleCommentsQuery)).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(articleCommentTestColumns).
+			AddRow(3, 1, 2, nil, "title", nil, nil, "old title", "rename it", "{"+mentioned.String()+"}", nil, nil, 1, createdBy, "writer", now, nil).
+			AddRow(4, 1, 2, 3, nil, nil, nil, nil, "done", "{}", nil, nil, nil, createdBy, "writer", now, nil))
+
+	got, err := repo.GetArticleComments(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ArticleComment{
+		{
+			ID: 3, ArticleID: 1, ArticleVersionID: 2,
+			Anchor:   &entity.CommentAnchor{Field: constanta.CommentAnchorTitle, Text: "old title", Outdated: true},
+			Body:     "rename it",
+			Mentions: []uuid.UUID{mentioned}, CarriedFromID: 1,
+			CreatedBy: createdBy, CreatedByName: "writer", CreatedAt: now,
+		},
+		{
+			ID: 4, ArticleID: 1, ArticleVersionID: 2, ParentID: 3,
+			Body:      "done",
+			CreatedBy: createdBy, CreatedByName: "writer", CreatedAt: now,
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentRepo_ResolveArticleComment(t *testing.T) {
+	resolvedBy := uuid.New()
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "resolved",
+			affected: 1,
+		},
+		{
+			name:    "not the first comment of a thread",
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewCommentRepo(db)
+			defer db.Close()
+			mock.ExpectExec(regexp.QuoteMeta(resolveArticleCommentQuery)).
+				WithArgs(int64(3), resolvedBy).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			assert.Equal(t, tt.wantErr, repo.ResolveArticleComment(context.Background(), 3, resolvedBy))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCommentRepo_GetUserNames(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewCommentRepo(db)
+	defer db.Close()
+
+	known, unknown := uuid.New(), uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(getUserNamesQuery)).
+		WithArgs(pq.Array([]string{known.String(), unknown.String()})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(known, "editor"))
+
+	got, err := repo.GetUserNames(context.Background(), []uuid.UUID{known, unknown})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]string{known: "editor"}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentRepo_GetUserRoles(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewCommentRepo(db)
+	defer db.Close()
+
+	editor := entity.NewUserRole("editor", constanta.ReadDraftedAndArchivedArticle)
+	known, unknown := uuid.New(), uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(getUserRolesQuery)).
+		WithArgs(pq.Array([]string{known.String(), unknown.String()})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow(known, editor.GetValue()))
+
+	got, err := repo.GetUserRoles(context.Background(), []uuid.UUID{known, unknown})
+	assert.NoError(t, err)
+	assert.True(t, got[known].HasPermission(constanta.ReadDraftedAndArchivedArticle))
+	assert.NotContains(t, got, unknown)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
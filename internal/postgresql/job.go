@@ -0,0 +1,189 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+)
+
+type (
+	JobRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewJobRepo(db *sql.DB) *JobRepo {
+	return &JobRepo{
+		db: db,
+	}
+}
+
+const (
+	jobColumns = `id, "type", payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at`
+
+	createJobQuery = `INSERT INTO jobs ("type", payload, status, max_attempts, run_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+)
+
+func (jr *JobRepo) CreateJob(ctx context.Context, job entity.Job) (int64, error) {
+	var id int64
+	err := jr.db.QueryRowContext(ctx, createJobQuery,
+		job.Type,
+		[]byte(job.Payload),
+		job.Status,
+		job.MaxAttempts,
+		job.RunAt,
+	).Scan(&id)
+
+	return id, err
+}
+
+const (
+	// the locked jobs are claimed by another worker, so every worker gets different jobs
+	claimJobsQuery = `UPDATE jobs SET status = $1, attempts = attempts + 1, locked_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs WHERE status = $2 AND run_at <= NOW()
+			ORDER BY run_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+)
+
+// ClaimJobs marks the due pending jobs as running and returns them.
+func (jr *JobRepo) ClaimJobs(ctx context.Context, limit int) ([]entity.Job, error) {
+	rows, err := jr.db.QueryContext(ctx, claimJobsQuery, constanta.JobRunning, constanta.JobPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+const (
+	completeJobQuery = `UPDATE jobs SET status = $1, locked_at = NULL, last_error = '' WHERE id = $2`
+	retryJobQuery    = `UPDATE jobs SET status = $1, locked_at = NULL, run_at = $2, last_error = $3 WHERE id = $4`
+	killJobQuery     = `UPDATE jobs SET status = $1, locked_at = NULL, last_error = $2 WHERE id = $3`
+)
+
+func (jr *JobRepo) CompleteJob(ctx context.Context, id int64) error {
+	return jr.updateJob(ctx, completeJobQuery, constanta.JobSucceeded, id)
+}
+
+// RetryJob makes the job pending again, it is claimed after runAt.
+func (jr *JobRepo) RetryJob(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+	return jr.updateJob(ctx, retryJobQuery, constanta.JobPending, runAt, lastError, id)
+}
+
+// KillJob moves the job into the dead state, it is not claimed anymore.
+func (jr *JobRepo) KillJob(ctx context.Context, id int64, lastError string) error {
+	return jr.updateJob(ctx, killJobQuery, constanta.JobDead, lastError, id)
+}
+
+func (jr *JobRepo) updateJob(ctx context.Context, query string, args ...any) error {
+	res, err := jr.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const (
+	// the worker of a running job stopped before finishing it, a job which stops its worker on every attempt becomes dead
+	requeueStaleJobsQuery = `UPDATE jobs SET
+			status = CASE WHEN attempts >= max_attempts THEN $1 ELSE $2 END,
+			locked_at = NULL,
+			last_error = CASE WHEN attempts >= max_attempts
+				THEN 'the worker stopped before finishing the last attempt of the job'
+				ELSE 'the worker stopped before finishing the job' END
+		WHERE status = $3 AND locked_at < $4`
+)
+
+// RequeueStaleJobs makes the jobs which are running since before lockedBefore pending again.
+// The stopped attempt is still counted, so the jobs without attempts left are moved into the dead state instead.
+func (jr *JobRepo) RequeueStaleJobs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	res, err := jr.db.ExecContext(ctx, requeueStaleJobsQuery, constanta.JobDead, constanta.JobPending, constanta.JobRunning, lockedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+const (
+	getJobsQuery = `SELECT ` + jobColumns + ` FROM jobs
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR "type" = $2) AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4`
+	getJobCountsQuery = `SELECT status, COUNT(*) FROM jobs GROUP BY status`
+)
+
+func (jr *JobRepo) GetJobs(ctx context.Context, filter entity.JobFilter) ([]entity.Job, error) {
+	rows, err := jr.db.QueryContext(ctx, getJobsQuery, filter.Status, filter.Type, filter.BeforeID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// GetJobCounts returns the number of jobs in every status.
+func (jr *JobRepo) GetJobCounts(ctx context.Context) (map[constanta.JobStatus]int64, error) {
+	rows, err := jr.db.QueryContext(ctx, getJobCountsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[constanta.JobStatus]int64)
+	for rows.Next() {
+		var status constanta.JobStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func scanJobs(rows *sql.Rows) ([]entity.Job, error) {
+	var jobs []entity.Job
+	for rows.Next() {
+		var job entity.Job
+		var payload []byte
+		if err := rows.Scan(
+			&job.ID,
+			&job.Type,
+			&payload,
+			&job.Status,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.RunAt,
+			&job.LastError,
+			&job.CreatedAt,
+			&job.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		job.Payload = payload
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
@@ -0,0 +1,135 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobTestColumns = []string{"id", "type", "payload", "status", "attempts", "max_attempts", "run_at", "last_error", "created_at", "updated_at"}
+
+func TestJobRepo_CreateJob(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewJobRepo(db)
+	defer db.Close()
+
+	job, err := entity.NewJob(constanta.JobCalculateTagStats, nil)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(createJobQuery)).
+		WithArgs(constanta.JobCalculateTagStats, []byte("null"), constanta.JobPending, entity.DefaultJobMaxAttempts, job.RunAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	got, err := repo.CreateJob(context.Background(), *job)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobRepo_ClaimJobs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewJobRepo(db)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(claimJobsQuery)).
+		WithArgs(constanta.JobRunning, constanta.JobPending, 2).
+		WillReturnRows(sqlmock.NewRows(jobTestColumns).
+			AddRow(1, constanta.JobCalculateArticleTagRelation, []byte(`{"ArticleVersionID":3}`), constanta.JobRunning, 1, 5, now, "", now, nil))
+
+	got, err := repo.ClaimJobs(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Job{{
+		ID:          1,
+		Type:        constanta.JobCalculateArticleTagRelation,
+		Payload:     json.RawMessage(`{"ArticleVersionID":3}`),
+		Status:      constanta.JobRunning,
+		Attempts:    1,
+		MaxAttempts: 5,
+		RunAt:       now,
+		CreatedAt:   now,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobRepo_RetryJob(t *testing.T) {
+	runAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(retryJobQuery)).
+					WithArgs(constanta.JobPending, runAt, "db down", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(retryJobQuery)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewJobRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			err := repo.RetryJob(context.Background(), 1, runAt, "db down")
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestJobRepo_RequeueStaleJobs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewJobRepo(db)
+	defer db.Close()
+
+	// the stale jobs without attempts left are killed instead of being claimed forever
+	assert.Contains(t, requeueStaleJobsQuery, "CASE WHEN attempts >= max_attempts THEN $1 ELSE $2 END")
+
+	lockedBefore := time.Now().Add(-5 * time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta(requeueStaleJobsQuery)).
+		WithArgs(constanta.JobDead, constanta.JobPending, constanta.JobRunning, lockedBefore).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	got, err := repo.RequeueStaleJobs(context.Background(), lockedBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobRepo_GetJobCounts(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewJobRepo(db)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(getJobCountsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow(constanta.JobSucceeded, 8).
+			AddRow(constanta.JobDead, 1))
+
+	got, err := repo.GetJobCounts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[constanta.JobStatus]int64{constanta.JobSucceeded: 8, constanta.JobDead: 1}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
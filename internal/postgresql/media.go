@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/lib/pq"
+)
+
+type (
+	MediaRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewMediaRepo(db *sql.DB) *MediaRepo {
+	return &MediaRepo{
+		db: db,
+	}
+}
+
+const (
+	foreignKeyViolationCode = "23503"
+
+	createMediaQuery = `INSERT INTO media
+		(storage_key, file_name, mime_type, "size", checksum, width, height, created_by)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
+)
+
+func (mr *MediaRepo) CreateMedia(ctx context.Context, media entity.Media) (int64, error) {
+	var id int64
+	err := mr.db.QueryRowContext(ctx, createMediaQuery,
+		media.StorageKey,
+		media.FileName,
+		media.MimeType,
+		media.Size,
+		media.Checksum,
+		media.Width,
+		media.Height,
+		media.CreatedBy,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+const (
+	getMediaWithIDQuery = `SELECT
+		id,
+		storage_key,
+		file_name,
+		mime_type,
+		"size",
+		checksum,
+		width,
+		height,
+		created_by,
+		created_at
+	FROM media WHERE id=$1`
+)
+
+func (mr *MediaRepo) GetMediaWithID(ctx context.Context, id int64) (*entity.Media, error) {
+	media := &entity.Media{}
+	err := mr.db.QueryRowContext(ctx, getMediaWithIDQuery, id).Scan(
+		&media.ID,
+		&media.StorageKey,
+		&media.FileName,
+		&media.MimeType,
+		&media.Size,
+		&media.Checksum,
+		&media.Width,
+		&media.Height,
+		&media.CreatedBy,
+		&media.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+const (
+	getMediaWithIDsQuery = `SELECT
+		id,
+		storage_key,
+		file_name,
+		mime_type,
+		"size",
+		checksum,
+		width,
+		height,
+		created_by,
+		created_at
+	FROM media WHERE id = ANY($1) ORDER BY id`
+)
+
+func (mr *MediaRepo) GetMediaWithIDs(ctx context.Context, ids []int64) ([]entity.Media, error) {
+	rows, err := mr.db.QueryContext(ctx, getMediaWithIDsQuery, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var medias []entity.Media
+	for rows.Next() {
+		var media entity.Media
+		if err := rows.Scan(
+			&media.ID,
+			&media.StorageKey,
+			&media.FileName,
+			&media.MimeType,
+			&media.Size,
+			&media.Checksum,
+			&media.Width,
+			&media.Height,
+			&media.CreatedBy,
+			&media.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		medias = append(medias, media)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return medias, nil
+}
+
+const (
+	deleteMediaQuery = `DELETE FROM media WHERE id=$1`
+)
+
+// DeleteMedia fails with errs.Conflict while an article version still uses the media.
+func (mr *MediaRepo) DeleteMedia(ctx context.Context, id int64) error {
+	_, err := mr.db.ExecContext(ctx, deleteMediaQuery, id)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+		return errs.Conflict{Message: "media is used by article versions"}
+	}
+
+	return err
+}
@@ -0,0 +1,223 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaRepo_CreateMedia(t *testing.T) {
+	media := entity.Media{
+		StorageKey: "2025/08/a.png",
+		FileName:   "a.png",
+		MimeType:   "image/png",
+		Size:       10,
+		Checksum:   "abc",
+		Width:      2,
+		Height:     3,
+		CreatedBy:  uuid.New(),
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(createMediaQuery)).
+					WithArgs(media.StorageKey, media.FileName, media.MimeType, media.Size, media.Checksum, media.Width, media.Height, media.CreatedBy).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+			},
+			want: 4,
+		},
+		{
+			name: "fail",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(createMediaQuery)).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewMediaRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			got, err := repo.CreateMedia(context.Background(), media)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMediaRepo_GetMediaWithID(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    *entity.Media
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "storage_key", "file_name", "mime_type", "size", "checksum", "width", "height", "created_by", "created_at"}).
+					AddRow(1, "key", "a.png", "image/png", 10, "abc", 2, 3, userID, now)
+				m.ExpectQuery(regexp.QuoteMeta(getMediaWithIDQuery)).
+					WithArgs(int64(1)).
+					WillReturnRows(rows)
+			},
+			want: &entity.Media{
+				ID:         1,
+				StorageKey: "key",
+				FileName:   "a.png",
+				MimeType:   "image/png",
+				Size:       10,
+				Checksum:   "abc",
+				Width:      2,
+				Height:     3,
+				CreatedBy:  userID,
+				CreatedAt:  now,
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getMediaWithIDQuery)).
+					WithArgs(int64(1)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewMediaRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			got, err := repo.GetMediaWithID(context.Background(), 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMediaRepo_DeleteMedia(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteMediaQuery)).
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "used by article versions",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteMediaQuery)).
+					WithArgs(int64(1)).
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode})
+			},
+			wantErr: errs.Conflict{Message: "media is used by article versions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewMediaRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			err := repo.DeleteMedia(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMediaRepo_GetMediaWithIDs(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []entity.Media
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "storage_key", "file_name", "mime_type", "size", "checksum", "width", "height", "created_by", "created_at"}).
+					AddRow(1, "key1", "a.png", "image/png", 10, "abc", 2, 3, userID, now).
+					AddRow(2, "key2", "b.png", "image/png", 20, "def", 4, 5, userID, now)
+				m.ExpectQuery(regexp.QuoteMeta(getMediaWithIDsQuery)).
+					WithArgs(pq.Array([]int64{1, 2})).
+					WillReturnRows(rows)
+			},
+			want: []entity.Media{
+				{ID: 1, StorageKey: "key1", FileName: "a.png", MimeType: "image/png", Size: 10, Checksum: "abc", Width: 2, Height: 3, CreatedBy: userID, CreatedAt: now},
+				{ID: 2, StorageKey: "key2", FileName: "b.png", MimeType: "image/png", Size: 20, Checksum: "def", Width: 4, Height: 5, CreatedBy: userID, CreatedAt: now},
+			},
+		},
+		{
+			name: "fail",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getMediaWithIDsQuery)).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewMediaRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			got, err := repo.GetMediaWithIDs(context.Background(), []int64{1, 2})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type (
+	NotificationRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewNotificationRepo(db *sql.DB) *NotificationRepo {
+	return &NotificationRepo{
+		db: db,
+	}
+}
+
+const (
+	// the writers are the creator of the article and of its versions, the editor who changed the status is not notified
+	getArticleVersionAudienceQuery = `SELECT av.article_id, av.id, 0, av.title, av.version, av.updated_by, COALESCE(u.name, ''),
+		ARRAY(
+			SELECT w.user_id FROM (
+				SELECT a.created_by AS user_id FROM articles a WHERE a.id = av.article_id
+				UNION
+				SELECT v.created_by FROM article_versions v WHERE v.article_id = av.article_id
+			) w
+			WHERE w.user_id IS DISTINCT FROM av.updated_by
+			ORDER BY w.user_id
+		)
+		FROM article_versions av LEFT JOIN users u ON u.id = av.updated_by
+		WHERE av.id = $1`
+	// the writer of the version and the users in the thread of the comment, without the commenter
+	getCommentAudienceQuery = `SELECT c.article_id, c.article_version_id, c.id, av.title, av.version, c.created_by, u.name,
+		ARRAY(
+			SELECT w.user_id FROM (
+				SELECT av.created_by AS user_id
+				UNION
+				SELECT t.created_by FROM article_comments t
+				WHERE t.id = COALESCE(c.parent_id, c.id) OR t.parent_id = COALESCE(c.parent_id, c.id)
+			) w
+			WHERE w.user_id <> c.created_by
+			ORDER BY w.user_id
+		)
+		FROM article_comments c
+		JOIN article_versions av ON av.id = c.article_version_id
+		JOIN users u ON u.id = c.created_by
+		WHERE c.id = $1`
+)
+
+// GetArticleVersionAudience returns sql.ErrNoRows when the version does not exist.
+func (nr *NotificationRepo) GetArticleVersionAudience(ctx context.Context, articleVersionID int64) (*entity.NotificationAudience, error) {
+	return nr.getAudience(ctx, getArticleVersionAudienceQuery, articleVersionID)
+}
+
+// GetCommentAudience returns sql.ErrNoRows when the comment does not exist.
+func (nr *NotificationRepo) GetCommentAudience(ctx context.Context, commentID int64) (*entity.NotificationAudience, error) {
+	return nr.getAudience(ctx, getCommentAudienceQuery, commentID)
+}
+
+func (nr *NotificationRepo) getAudience(ctx context.Context, query string, id int64) (*entity.NotificationAudience, error) {
+	var (
+		audience   entity.NotificationAudience
+		actorID    uuid.NullUUID
+		recipients []string
+	)
+	if err := nr.db.QueryRowContext(ctx, query, id).Scan(
+		&audience.ArticleID,
+		&audience.ArticleVersionID,
+		&audience.CommentID,
+		&audience.Title,
+		&audience.Version,
+		&actorID,
+		&audience.ActorName,
+		pq.Array(&recipients),
+	); err != nil {
+		return nil, err
+	}
+
+	if actorID.Valid {
+		audience.ActorID = &actorID.UUID
+	}
+
+	var err error
+	if audience.Recipients, err = parseUUIDs(recipients); err != nil {
+		return nil, err
+	}
+
+	return &audience, nil
+}
+
+const (
+	// a relayed event does not notify the user again
+	createNotificationQuery = `INSERT INTO notifications
+		(user_id, type, event_id, article_id, article_version_id, comment_id, actor_id, message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, event_id) DO NOTHING
+		RETURNING id, created_at`
+)
+
+// CreateNotifications returns the notifications which are created, without the notifications of an event which already exist.
+func (nr *NotificationRepo) CreateNotifications(ctx context.Context, notifications []entity.Notification) ([]entity.Notification, error) {
+	var created []entity.Notification
+	err := runInTx(ctx, nr.db, func(tx *sql.Tx) error {
+		created = nil
+		for _, notification := range notifications {
+			var (
+				articleVersionID, commentID sql.NullInt64
+				actorID                     uuid.NullUUID
+			)
+			if notification.ArticleVersionID != 0 {
+				articleVersionID = sql.NullInt64{Int64: notification.ArticleVersionID, Valid: true}
+			}
+			if notification.CommentID != 0 {
+				commentID = sql.NullInt64{Int64: notification.CommentID, Valid: true}
+			}
+			if notification.ActorID != nil {
+				actorID = uuid.NullUUID{UUID: *notification.ActorID, Valid: true}
+			}
+
+			err := tx.QueryRowContext(ctx, createNotificationQuery,
+				notification.UserID,
+				notification.Type,
+				notification.EventID,
+				notification.ArticleID,
+				articleVersionID,
+				commentID,
+				actorID,
+				notification.Message,
+			).Scan(&notification.ID, &notification.CreatedAt)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			created = append(created, notification)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+const (
+	notificationColumns = `n.id, n.user_id, n.type, n.event_id, n.article_id, n.article_version_id, n.comment_id,
+		n.actor_id, COALESCE(a.name, ''), n.message, n.read_at, n.created_at`
+
+	getNotificationsQuery = `SELECT ` + notificationColumns + `
+		FROM notifications n LEFT JOIN users a ON a.id = n.actor_id
+		WHERE n.user_id = $1 AND (NOT $2 OR n.read_at IS NULL) AND ($3 = 0 OR n.id < $3)
+		ORDER BY n.id DESC
+		LIMIT $4`
+	countUnreadNotificationsQuery = `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+	getNotificationEmailQuery     = `SELECT ` + notificationColumns + `, u.name, u.email
+		FROM notifications n LEFT JOIN users a ON a.id = n.actor_id JOIN users u ON u.id = n.user_id
+		WHERE n.id = $1`
+)
+
+func (nr *NotificationRepo) GetNotifications(ctx context.Context, filter entity.NotificationFilter) ([]entity.Notification, error) {
+	rows, err := nr.db.QueryContext(ctx, getNotificationsQuery, filter.UserID, filter.Unread, filter.BeforeID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []entity.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, *notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (nr *NotificationRepo) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := nr.db.QueryRowContext(ctx, countUnreadNotificationsQuery, userID).Scan(&count)
+
+	return count, err
+}
+
+// GetNotificationEmail returns sql.ErrNoRows when the notification does not exist.
+func (nr *NotificationRepo) GetNotificationEmail(ctx context.Context, id int64) (*entity.NotificationEmail, error) {
+	var email entity.NotificationEmail
+	notification, err := scanNotification(nr.db.QueryRowContext(ctx, getNotificationEmailQuery, id), &email.UserName, &email.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+	email.Notification = *notification
+
+	return &email, nil
+}
+
+// scanNotification scans the notification columns, followed by the extra columns into extra.
+func scanNotification(row scanner, extra ...any) (*entity.Notification, error) {
+	var notification entity.Notification
+	var articleVersionID, commentID sql.NullInt64
+	var actorID uuid.NullUUID
+	dest := []any{
+		&notification.ID,
+		&notification.UserID,
+		&notification.Type,
+		&notification.EventID,
+		&notification.ArticleID,
+		&articleVersionID,
+		&commentID,
+		&actorID,
+		&notification.ActorName,
+		&notification.Message,
+		&notification.ReadAt,
+		&notification.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	notification.ArticleVersionID = articleVersionID.Int64
+	notification.CommentID = commentID.Int64
+	if actorID.Valid {
+		notification.ActorID = &actorID.UUID
+	}
+
+	return &notification, nil
+}
+
+const (
+	// read_at keeps the first read
+	markNotificationReadQuery       = `UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id = $1 AND user_id = $2`
+	markAllNotificationsReadQuery   = `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`
+	getNotificationPreferenceQuery  = `SELECT user_id, delivery, muted_types, updated_at FROM notification_preferences WHERE user_id = ANY($1)`
+	saveNotificationPreferenceQuery = `INSERT INTO notification_preferences (user_id, delivery, muted_types)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET delivery = EXCLUDED.delivery, muted_types = EXCLUDED.muted_types`
+)
+
+// MarkNotificationRead returns sql.ErrNoRows when the notification is not a notification of the user.
+func (nr *NotificationRepo) MarkNotificationRead(ctx context.Context, id int64, userID uuid.UUID) error {
+	res, err := nr.db.ExecContext(ctx, markNotificationReadQuery, id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead returns the number of the notifications which were not read.
+func (nr *NotificationRepo) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	res, err := nr.db.ExecContext(ctx, markAllNotificationsReadQuery, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// GetNotificationPreferences returns the saved preferences of the users, a user without a saved preference is not in the map.
+func (nr *NotificationRepo) GetNotificationPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]entity.NotificationPreference, error) {
+	preferences := make(map[uuid.UUID]entity.NotificationPreference, len(userIDs))
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	rows, err := nr.db.QueryContext(ctx, getNotificationPreferenceQuery, pq.Array(uuidStrings(userIDs)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			preference entity.NotificationPreference
+			mutedTypes []string
+		)
+		if err := rows.Scan(&preference.UserID, &preference.Delivery, pq.Array(&mutedTypes), &preference.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		preference.MutedTypes = make([]constanta.NotificationType, 0, len(mutedTypes))
+		for _, mutedType := range mutedTypes {
+			preference.MutedTypes = append(preference.MutedTypes, constanta.NotificationType(mutedType))
+		}
+		preferences[preference.UserID] = preference
+	}
+
+	return preferences, rows.Err()
+}
+
+func (nr *NotificationRepo) SaveNotificationPreference(ctx context.Context, preference entity.NotificationPreference) error {
+	mutedTypes := make([]string, 0, len(preference.MutedTypes))
+	for _, mutedType := range preference.MutedTypes {
+		mutedTypes = append(mutedTypes, string(mutedType))
+	}
+
+	_, err := nr.db.ExecContext(ctx, saveNotificationPreferenceQuery, preference.UserID, preference.Delivery, pq.Array(mutedTypes))
+	return err
+}
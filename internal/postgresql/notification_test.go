@@ -0,0 +1,159 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var notificationTestColumns = []string{"id", "user_id", "type", "event_id", "article_id", "article_version_id", "comment_id",
+	"actor_id", "name", "message", "read_at", "created_at"}
+
+func TestNotificationRepo_GetArticleVersionAudience(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewNotificationRepo(db)
+	defer db.Close()
+
+	editor, writer := uuid.New(), uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(getArticleVersionAudienceQuery)).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "id", "comment_id", "title", "version", "updated_by", "name", "recipients"}).
+			AddRow(1, 2, 0, "hello", 3, editor, "editor", "{"+writer.String()+"}"))
+
+	got, err := repo.GetArticleVersionAudience(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.NotificationAudience{
+		ArticleID: 1, ArticleVersionID: 2, Title: "hello", Version: 3,
+		ActorID: &editor, ActorName: "editor",
+		Recipients: []uuid.UUID{writer},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepo_CreateNotifications(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewNotificationRepo(db)
+	defer db.Close()
+
+	actor, first, second := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	notifications := []entity.Notification{
+		{UserID: first, Type: constanta.NotificationMention, EventID: 7, ArticleID: 1, ArticleVersionID: 2, CommentID: 3, ActorID: &actor, Message: "mentioned"},
+		{UserID: second, Type: constanta.NotificationComment, EventID: 7, ArticleID: 1, ArticleVersionID: 2, CommentID: 3, ActorID: &actor, Message: "commented"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(createNotificationQuery)).
+		WithArgs(first, constanta.NotificationMention, int64(7), int64(1), sql.NullInt64{Int64: 2, Valid: true}, sql.NullInt64{Int64: 3, Valid: true},
+			uuid.NullUUID{UUID: actor, Valid: true}, "mentioned").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(10, now))
+	// the notification of the second user was created by a previous relay of the event
+	mock.ExpectQuery(regexp.QuoteMeta(createNotificationQuery)).
+		WithArgs(second, constanta.NotificationComment, int64(7), int64(1), sql.NullInt64{Int64: 2, Valid: true}, sql.NullInt64{Int64: 3, Valid: true},
+			uuid.NullUUID{UUID: actor, Valid: true}, "commented").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectCommit()
+
+	got, err := repo.CreateNotifications(context.Background(), notifications)
+	assert.NoError(t, err)
+	created := notifications[0]
+	created.ID = 10
+	created.CreatedAt = now
+	assert.Equal(t, []entity.Notification{created}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepo_GetNotifications(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewNotificationRepo(db)
+	defer db.Close()
+
+	userID, actor := uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(getNotificationsQuery)).
+		WithArgs(userID, true, int64(20), 10).
+		WillReturnRows(sqlmock.NewRows(notificationTestColumns).
+			AddRow(12, userID, "version.published", 8, 1, 2, nil, actor, "editor", "editor published version 3 of \"hello\"", nil, now).
+			AddRow(11, userID, "comment", 7, 1, 2, 3, nil, "", "someone commented on version 3 of \"hello\"", nil, now))
+
+	got, err := repo.GetNotifications(context.Background(), entity.NotificationFilter{UserID: userID, Unread: true, BeforeID: 20, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Notification{
+		{
+			ID: 12, UserID: userID, Type: constanta.NotificationVersionPublished, EventID: 8, ArticleID: 1, ArticleVersionID: 2,
+			ActorID: &actor, ActorName: "editor", Message: "editor published version 3 of \"hello\"", CreatedAt: now,
+		},
+		{
+			ID: 11, UserID: userID, Type: constanta.NotificationComment, EventID: 7, ArticleID: 1, ArticleVersionID: 2, CommentID: 3,
+			Message: "someone commented on version 3 of \"hello\"", CreatedAt: now,
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepo_MarkNotificationRead(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "read",
+			affected: 1,
+		},
+		{
+			name:    "notification of another user",
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewNotificationRepo(db)
+			defer db.Close()
+			mock.ExpectExec(regexp.QuoteMeta(markNotificationReadQuery)).
+				WithArgs(int64(3), userID).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			assert.Equal(t, tt.wantErr, repo.MarkNotificationRead(context.Background(), 3, userID))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationRepo_NotificationPreferences(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewNotificationRepo(db)
+	defer db.Close()
+
+	saved, other := uuid.New(), uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(getNotificationPreferenceQuery)).
+		WithArgs(pq.Array([]string{saved.String(), other.String()})).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "delivery", "muted_types", "updated_at"}).
+			AddRow(saved, "email", "{comment}", nil))
+
+	got, err := repo.GetNotificationPreferences(context.Background(), []uuid.UUID{saved, other})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]entity.NotificationPreference{
+		saved: {UserID: saved, Delivery: constanta.NotificationDeliveryEmail, MutedTypes: []constanta.NotificationType{constanta.NotificationComment}},
+	}, got)
+
+	mock.ExpectExec(regexp.QuoteMeta(saveNotificationPreferenceQuery)).
+		WithArgs(saved, constanta.NotificationDeliveryInApp, pq.Array([]string{})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.SaveNotificationPreference(context.Background(), entity.DefaultNotificationPreference(saved)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,185 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/lib/pq"
+)
+
+type (
+	OutboxRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{
+		db: db,
+	}
+}
+
+const (
+	insertOutboxEventQuery = `INSERT INTO outbox (event_type, payload) VALUES ($1, $2)`
+
+	// the data of the version is read in the transaction, after the change of the status.
+	// previous_slug is the slug of the version $3 which was replaced, only when it is another slug.
+	insertArticleVersionEventQuery = `INSERT INTO outbox (event_type, payload)
+		SELECT $1, jsonb_strip_nulls(jsonb_build_object(
+			'article_id', av.article_id,
+			'article_version_id', av.id,
+			'version', av.version,
+			'title', av.title,
+			'slug', NULLIF(av.slug, ''),
+			'previous_slug', (SELECT NULLIF(slug, '') FROM article_versions WHERE id = $3 AND slug <> av.slug),
+			'tags', (SELECT jsonb_agg(tag_name ORDER BY tag_name) FROM article_version_tags WHERE article_version_id = av.id)
+		))
+		FROM article_versions av WHERE av.id = $2`
+	// the slug of the published version is read before the versions are deleted
+	insertArticleDeletedEventQuery = `INSERT INTO outbox (event_type, payload)
+		SELECT $1, jsonb_strip_nulls(jsonb_build_object('article_id', a.id, 'slug', NULLIF(av.slug, '')))
+		FROM articles a LEFT JOIN article_versions av ON av.id = a.published_version_id
+		WHERE a.id = $2`
+)
+
+// insertOutboxEvent saves the event in the transaction of its change, so it is published only when the change is committed.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, eventType constanta.EventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, insertOutboxEventQuery, eventType, payload)
+	return err
+}
+
+// insertArticleVersionEvent saves the event of the version, previousVersionID is the published version it replaced or zero.
+func insertArticleVersionEvent(ctx context.Context, tx *sql.Tx, eventType constanta.EventType, articleVersionID, previousVersionID int64) error {
+	_, err := tx.ExecContext(ctx, insertArticleVersionEventQuery, eventType, articleVersionID, previousVersionID)
+	return err
+}
+
+const (
+	outboxColumns = `id, event_type, payload, attempts, max_attempts, created_at`
+
+	// the locked events are claimed by another relay, an event locked before $1 belongs to a relay which stopped.
+	// An event of an article waits for the earlier events of the article which are not published or dead yet,
+	// so at most one event of an article is claimed at a time, even when an earlier one is retried.
+	claimOutboxEventsQuery = `WITH claimed AS (
+			UPDATE outbox SET attempts = attempts + 1, locked_at = NOW()
+			WHERE id IN (
+				SELECT id FROM outbox o
+				WHERE published_at IS NULL AND dead_at IS NULL AND run_at <= NOW() AND (locked_at IS NULL OR locked_at < $1)
+					AND NOT EXISTS (
+						SELECT 1 FROM outbox earlier
+						WHERE earlier.payload->>'article_id' = o.payload->>'article_id' AND earlier.id < o.id
+							AND earlier.published_at IS NULL AND earlier.dead_at IS NULL
+					)
+				ORDER BY id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + outboxColumns + `
+		)
+		SELECT ` + outboxColumns + `,
+			ARRAY(SELECT subscriber FROM outbox_deliveries WHERE event_id = claimed.id ORDER BY subscriber)
+		FROM claimed ORDER BY id`
+)
+
+// ClaimOutboxEvents locks the due events which are not published yet and returns them in the order of their changes.
+// The events without an article are not held back by the other events.
+func (or *OutboxRepo) ClaimOutboxEvents(ctx context.Context, lockedBefore time.Time, limit int) ([]entity.OutboxEvent, error) {
+	rows, err := or.db.QueryContext(ctx, claimOutboxEventsQuery, lockedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []entity.OutboxEvent
+	for rows.Next() {
+		var event entity.OutboxEvent
+		var payload []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&payload,
+			&event.Attempts,
+			&event.MaxAttempts,
+			&event.CreatedAt,
+			pq.Array(&event.Delivered),
+		); err != nil {
+			return nil, err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+const (
+	completeOutboxEventQuery = `UPDATE outbox SET published_at = NOW(), locked_at = NULL, last_error = '' WHERE id = $1`
+	retryOutboxEventQuery    = `UPDATE outbox SET locked_at = NULL, run_at = $1, last_error = $2 WHERE id = $3`
+	killOutboxEventQuery     = `UPDATE outbox SET dead_at = NOW(), locked_at = NULL, last_error = $1 WHERE id = $2`
+)
+
+func (or *OutboxRepo) CompleteOutboxEvent(ctx context.Context, id int64) error {
+	return or.updateOutboxEvent(ctx, completeOutboxEventQuery, id)
+}
+
+// RetryOutboxEvent unlocks the event, it is claimed again after runAt.
+func (or *OutboxRepo) RetryOutboxEvent(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+	return or.updateOutboxEvent(ctx, retryOutboxEventQuery, runAt, lastError, id)
+}
+
+// KillOutboxEvent moves the event into the dead state, it is not claimed anymore.
+func (or *OutboxRepo) KillOutboxEvent(ctx context.Context, id int64, lastError string) error {
+	return or.updateOutboxEvent(ctx, killOutboxEventQuery, lastError, id)
+}
+
+func (or *OutboxRepo) updateOutboxEvent(ctx context.Context, query string, args ...any) error {
+	res, err := or.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const (
+	createOutboxDeliveryQuery = `INSERT INTO outbox_deliveries (event_id, subscriber) VALUES ($1, $2)
+		ON CONFLICT (event_id, subscriber) DO NOTHING`
+)
+
+// CreateOutboxDelivery records that the subscriber handled the event, it is not published to the subscriber again.
+func (or *OutboxRepo) CreateOutboxDelivery(ctx context.Context, eventID int64, subscriber string) error {
+	_, err := or.db.ExecContext(ctx, createOutboxDeliveryQuery, eventID, subscriber)
+	return err
+}
+
+const (
+	// the deliveries of the events are deleted with them, the dead events are kept
+	deletePublishedOutboxEventsQuery = `DELETE FROM outbox WHERE published_at < $1`
+)
+
+// DeletePublishedOutboxEvents removes the events which were published before the time.
+func (or *OutboxRepo) DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := or.db.ExecContext(ctx, deletePublishedOutboxEventsQuery, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,134 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepo_ClaimOutboxEvents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewOutboxRepo(db)
+	defer db.Close()
+
+	now := time.Now()
+	lockedBefore := now.Add(-5 * time.Minute)
+	mock.ExpectQuery(regexp.QuoteMeta(claimOutboxEventsQuery)).
+		WithArgs(lockedBefore, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "payload", "attempts", "max_attempts", "created_at", "delivered"}).
+			AddRow(1, constanta.EventArticleCreated, []byte(`{"article_id":1}`), 1, 10, now, "{}").
+			AddRow(2, constanta.EventVersionPublished, []byte(`{"article_id":2}`), 3, 10, now, "{cdn,webhook}"))
+
+	got, err := repo.ClaimOutboxEvents(context.Background(), lockedBefore, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.OutboxEvent{
+		{ID: 1, Type: constanta.EventArticleCreated, Payload: json.RawMessage(`{"article_id":1}`), Attempts: 1, MaxAttempts: 10, Delivered: []string{}, CreatedAt: now},
+		{ID: 2, Type: constanta.EventVersionPublished, Payload: json.RawMessage(`{"article_id":2}`), Attempts: 3, MaxAttempts: 10, Delivered: []string{"cdn", "webhook"}, CreatedAt: now},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_RetryOutboxEvent(t *testing.T) {
+	runAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(retryOutboxEventQuery)).
+					WithArgs(runAt, "db down", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(retryOutboxEventQuery)).
+					WithArgs(runAt, "db down", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewOutboxRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+
+			err := repo.RetryOutboxEvent(context.Background(), 1, runAt, "db down")
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOutboxRepo_CompleteOutboxEvent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewOutboxRepo(db)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(completeOutboxEventQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.CompleteOutboxEvent(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_DeletePublishedOutboxEvents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewOutboxRepo(db)
+	defer db.Close()
+
+	before := time.Now().Add(-7 * 24 * time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta(deletePublishedOutboxEventsQuery)).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	got, err := repo.DeletePublishedOutboxEvents(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_KillOutboxEvent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewOutboxRepo(db)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(killOutboxEventQuery)).
+		WithArgs("db down", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.KillOutboxEvent(context.Background(), 1, "db down"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_CreateOutboxDelivery(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewOutboxRepo(db)
+	defer db.Close()
+
+	// a delivery which is recorded again is ignored
+	mock.ExpectExec(regexp.QuoteMeta(createOutboxDeliveryQuery)).
+		WithArgs(int64(1), "webhook").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.CreateOutboxDelivery(context.Background(), 1, "webhook"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,10 +3,13 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/lib/pq"
 )
 
@@ -24,61 +27,329 @@ func NewTagRepo(db *sql.DB) *TagsRepo {
 
 const (
 	upsertTagQuery = `INSERT INTO tags ("name") VALUES ($1) ON CONFLICT (name) DO NOTHING`
+	// returns no row when the tag already exists
+	createTagQuery = upsertTagQuery + ` RETURNING "name"`
 )
 
+// UpsertTags creates the tags which do not exist yet, every created tag is sent as tag.created.
 func (u *TagsRepo) UpsertTags(ctx context.Context, names ...string) error {
-	preparedQuery, err := u.db.Prepare(upsertTagQuery)
+	return runInTx(ctx, u.db, func(tx *sql.Tx) error {
+		for _, name := range names {
+			err := tx.QueryRowContext(ctx, createTagQuery, name).Scan(&name)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := insertOutboxEvent(ctx, tx, constanta.EventTagCreated, entity.TagEventData{Name: name}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+const (
+	getTagsQuery = `SELECT name, display_name, description, color, slug FROM tags WHERE (name = ANY($1) OR $1 IS NULL)`
+)
+
+func (u *TagsRepo) GetTags(ctx context.Context, names ...string) ([]entity.Tag, error) {
+	rows, err := u.db.QueryContext(ctx, getTagsQuery, pq.Array(names))
 	if err != nil {
-		if err != nil {
-			return err
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []entity.Tag
+	for rows.Next() {
+		var tag entity.Tag
+		if err := rows.Scan(
+			&tag.Name,
+			&tag.DisplayName,
+			&tag.Description,
+			&tag.Color,
+			&tag.Slug,
+		); err != nil {
+			return nil, err
 		}
+		tags = append(tags, tag)
 	}
 
-	for _, v := range names {
-		_, err = preparedQuery.ExecContext(ctx, v)
-		if err != nil {
+	return tags, nil
+}
+
+const (
+	updateTagQuery = `UPDATE tags
+		SET name=$1, display_name=$2, description=$3, color=$4, slug=$5 WHERE name=$6`
+)
+
+// UpdateTag updates the metadata of the tag. A different name renames the tag in every article version.
+func (u *TagsRepo) UpdateTag(ctx context.Context, name string, tag entity.Tag) error {
+	res, err := u.db.ExecContext(ctx, updateTagQuery,
+		tag.Name,
+		tag.DisplayName,
+		tag.Description,
+		tag.Color,
+		tag.Slug,
+		name,
+	)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		if pqErr.Constraint == "tags_slug_index" {
+			return errs.AlreadyExist{Name: fmt.Sprintf("tag with slug %s", tag.Slug)}
+		}
+		return errs.AlreadyExist{Name: fmt.Sprintf("tag %s", tag.Name)}
+	}
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const (
+	// versions which already have the target keep a single row of it
+	mergeArticleVersionTagsQuery = `INSERT INTO article_version_tags (tag_name, article_version_id)
+		SELECT $1, article_version_id FROM article_version_tags WHERE tag_name = ANY($2)
+		ON CONFLICT (tag_name, article_version_id) DO NOTHING`
+	deleteMergedArticleVersionTagsQuery = `DELETE FROM article_version_tags WHERE tag_name = ANY($1)`
+	// the daily usage of the sources is deleted with them
+	mergeTagUsageDailyQuery = `INSERT INTO tag_usage_daily (tag_name, "day", "count")
+		SELECT $1, "day", SUM("count") FROM tag_usage_daily WHERE tag_name = ANY($2) GROUP BY "day"
+		ON CONFLICT (tag_name, "day") DO UPDATE SET "count" = tag_usage_daily."count" + EXCLUDED."count"`
+	moveTagSynonymsQuery         = `UPDATE tag_synonyms SET tag_name = $1 WHERE tag_name = ANY($2)`
+	deleteTagsQuery              = `DELETE FROM tags WHERE name = ANY($1)`
+	createMergedTagSynonymsQuery = `INSERT INTO tag_synonyms (alias, tag_name)
+		SELECT UNNEST($2::VARCHAR[]), $1
+		ON CONFLICT (alias) DO UPDATE SET tag_name = EXCLUDED.tag_name`
+)
+
+// MergeTags moves the article versions, the synonyms and the usage of the sources into the target and deletes the sources.
+// The sources become synonyms of the target. The target is created when it does not exist.
+func (u *TagsRepo) MergeTags(ctx context.Context, sources []string, target string) error {
+	return runInTx(ctx, u.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, upsertTagQuery, target); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, mergeArticleVersionTagsQuery, target, pq.Array(sources)); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, deleteMergedArticleVersionTagsQuery, pq.Array(sources)); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, moveTagSynonymsQuery, target, pq.Array(sources)); err != nil {
 			return err
 		}
+
+		if _, err := tx.ExecContext(ctx, mergeTagUsageDailyQuery, target, pq.Array(sources)); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, deleteTagsQuery, pq.Array(sources)); err != nil {
+			return err
+		}
+
+		// the statistics of the sources are deleted with them and the target has their versions now
+		if _, err := reconcileTagStats(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, createMergedTagSynonymsQuery, target, pq.Array(sources))
+		return err
+	})
+}
+
+const (
+	deleteTagQuery = `DELETE FROM tags WHERE name = $1`
+)
+
+// DeleteTag deletes the tag. A tag used by article versions cannot be deleted.
+func (u *TagsRepo) DeleteTag(ctx context.Context, name string) error {
+	res, err := u.db.ExecContext(ctx, deleteTagQuery, name)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+		return errs.Conflict{Message: "tag is used by article versions"}
+	}
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
 	}
 
 	return nil
 }
 
 const (
-	getTagsQuery = `SELECT name FROM tags WHERE (name IN ($1) OR $1 IS NULL)`
+	deleteUnusedTagsQuery = `DELETE FROM tags t
+		WHERE NOT EXISTS (SELECT 1 FROM article_version_tags avt WHERE avt.tag_name = t.name)`
 )
 
-func (u *TagsRepo) GetTags(ctx context.Context, names ...string) ([]string, error) {
-	rows, err := u.db.QueryContext(ctx, getTagsQuery, pq.Array(names))
+// DeleteUnusedTags deletes every tag which is not used by any article version and returns the number of deleted tags.
+func (u *TagsRepo) DeleteUnusedTags(ctx context.Context) (int64, error) {
+	res, err := u.db.ExecContext(ctx, deleteUnusedTagsQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+const (
+	getTagSynonymsQuery            = `SELECT alias, tag_name, created_at FROM tag_synonyms WHERE alias = ANY($1)`
+	getTagSynonymsWithTagNameQuery = `SELECT alias, tag_name, created_at FROM tag_synonyms WHERE tag_name = $1 ORDER BY alias`
+)
+
+// GetTagSynonyms returns the synonyms of the aliases, aliases without a synonym are skipped.
+func (u *TagsRepo) GetTagSynonyms(ctx context.Context, aliases ...string) ([]entity.TagSynonym, error) {
+	return u.getTagSynonyms(ctx, getTagSynonymsQuery, pq.Array(aliases))
+}
+
+// GetTagSynonymsWithTagName returns the synonyms of the tag ordered by alias.
+func (u *TagsRepo) GetTagSynonymsWithTagName(ctx context.Context, name string) ([]entity.TagSynonym, error) {
+	return u.getTagSynonyms(ctx, getTagSynonymsWithTagNameQuery, name)
+}
+
+func (u *TagsRepo) getTagSynonyms(ctx context.Context, query string, arg any) ([]entity.TagSynonym, error) {
+	rows, err := u.db.QueryContext(ctx, query, arg)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tags []string
+	var synonyms []entity.TagSynonym
 	for rows.Next() {
-		var tag string
-		if err := rows.Scan(&tag); err != nil {
+		var synonym entity.TagSynonym
+		if err := rows.Scan(
+			&synonym.Alias,
+			&synonym.TagName,
+			&synonym.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
-		tags = append(tags, tag)
+		synonyms = append(synonyms, synonym)
 	}
 
-	return tags, nil
+	return synonyms, rows.Err()
 }
 
-const getTagUsageQuery = `
-	SELECT t.name, COUNT(avt.tag_name) as usage_count, MAX(av.created_at) AS last_used
-	FROM tags t
-	LEFT JOIN article_version_tags avt ON t.name = avt.tag_name 
-	LEFT JOIN article_versions av ON avt.article_version_id = av.id
-	WHERE av.status = $1
-	GROUP BY t.name
-`
+const (
+	createTagSynonymsQuery = `INSERT INTO tag_synonyms (alias, tag_name) SELECT UNNEST($1::VARCHAR[]), $2`
+)
 
-func (u *TagsRepo) GetTagUsage(ctx context.Context) (map[string]entity.TagUsage, error) {
+// CreateTagSynonyms maps the aliases to the tag.
+func (u *TagsRepo) CreateTagSynonyms(ctx context.Context, name string, aliases []string) error {
+	_, err := u.db.ExecContext(ctx, createTagSynonymsQuery, pq.Array(aliases), name)
 
-	rows, err := u.db.QueryContext(ctx, getTagUsageQuery, constanta.Published)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolationCode:
+			return errs.AlreadyExist{Name: "one of the synonyms"}
+		case foreignKeyViolationCode:
+			return errs.NotFound{Message: fmt.Sprintf("tag %s", name)}
+		}
+	}
+
+	return err
+}
+
+const (
+	deleteTagSynonymQuery = `DELETE FROM tag_synonyms WHERE tag_name = $1 AND alias = $2`
+)
+
+// DeleteTagSynonym deletes the alias of the tag.
+func (u *TagsRepo) DeleteTagSynonym(ctx context.Context, name, alias string) error {
+	res, err := u.db.ExecContext(ctx, deleteTagSynonymQuery, name, alias)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// the statistics are changed with every publish, archive and delete of the article versions.
+// They are also reconciled periodically to fix the changes of the renamed and merged tags.
+const (
+	getTagUsageQuery         = `SELECT tag_name, usage_count, last_used FROM tag_stats WHERE usage_count > 0`
+	getTagPairFrequencyQuery = `SELECT tag_a, tag_b, "count" FROM tag_pair_stats WHERE "count" > 0`
+
+	changeTagStatsQuery = `INSERT INTO tag_stats (tag_name, usage_count, last_used)
+		SELECT avt.tag_name, $2, av.created_at
+		FROM article_version_tags avt
+		JOIN article_versions av ON av.id = avt.article_version_id
+		WHERE avt.article_version_id = $1
+		ON CONFLICT (tag_name) DO UPDATE SET usage_count = tag_stats.usage_count + EXCLUDED.usage_count,
+			last_used = GREATEST(tag_stats.last_used, EXCLUDED.last_used)`
+	changeTagPairStatsQuery = `INSERT INTO tag_pair_stats (tag_a, tag_b, "count")
+		SELECT a.tag_name, b.tag_name, $2
+		FROM article_version_tags a
+		JOIN article_version_tags b ON b.article_version_id = a.article_version_id AND a.tag_name < b.tag_name
+		WHERE a.article_version_id = $1
+		ON CONFLICT (tag_a, tag_b) DO UPDATE SET "count" = tag_pair_stats."count" + EXCLUDED."count"`
+
+	reconcileTagStatsQuery = `INSERT INTO tag_stats (tag_name, usage_count, last_used)
+		SELECT avt.tag_name, COUNT(*), MAX(av.created_at)
+		FROM article_version_tags avt
+		JOIN article_versions av ON av.id = avt.article_version_id
+		WHERE av.status = $1
+		GROUP BY avt.tag_name
+		ON CONFLICT (tag_name) DO UPDATE SET usage_count = EXCLUDED.usage_count, last_used = EXCLUDED.last_used
+		WHERE tag_stats.usage_count <> EXCLUDED.usage_count OR tag_stats.last_used IS DISTINCT FROM EXCLUDED.last_used`
+	// the rows counted down to zero are kept, they are used again when the tag is published
+	deleteStaleTagStatsQuery = `DELETE FROM tag_stats ts WHERE usage_count <> 0 AND NOT EXISTS (
+		SELECT 1 FROM article_version_tags avt
+		JOIN article_versions av ON av.id = avt.article_version_id
+		WHERE avt.tag_name = ts.tag_name AND av.status = $1)`
+	reconcileTagPairStatsQuery = `INSERT INTO tag_pair_stats (tag_a, tag_b, "count")
+		SELECT a.tag_name, b.tag_name, COUNT(*)
+		FROM article_version_tags a
+		JOIN article_version_tags b ON b.article_version_id = a.article_version_id AND a.tag_name < b.tag_name
+		JOIN article_versions av ON av.id = a.article_version_id
+		WHERE av.status = $1
+		GROUP BY a.tag_name, b.tag_name
+		ON CONFLICT (tag_a, tag_b) DO UPDATE SET "count" = EXCLUDED."count"
+		WHERE tag_pair_stats."count" <> EXCLUDED."count"`
+	// a renamed tag can reverse the order of its pairs, the pair is counted again in the right order
+	deleteStaleTagPairStatsQuery = `DELETE FROM tag_pair_stats tps WHERE tag_a >= tag_b OR ("count" <> 0 AND NOT EXISTS (
+		SELECT 1 FROM article_version_tags a
+		JOIN article_version_tags b ON b.article_version_id = a.article_version_id
+		JOIN article_versions av ON av.id = a.article_version_id
+		WHERE a.tag_name = tps.tag_a AND b.tag_name = tps.tag_b AND av.status = $1))`
+)
+
+// GetTagUsage returns the number of published versions using the tag and the last time it was used.
+func (u *TagsRepo) GetTagUsage(ctx context.Context) (map[string]entity.TagUsage, error) {
+	rows, err := u.db.QueryContext(ctx, getTagUsageQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -88,17 +359,194 @@ func (u *TagsRepo) GetTagUsage(ctx context.Context) (map[string]entity.TagUsage,
 	for rows.Next() {
 		var name string
 		var usageCount int
-		var lastUsed time.Time
+		var lastUsed sql.NullTime
 		if err := rows.Scan(&name, &usageCount, &lastUsed); err != nil {
 			return nil, err
 		}
 		counts[name] = entity.TagUsage{
 			Count:    usageCount,
-			LastUsed: lastUsed,
+			LastUsed: lastUsed.Time,
+		}
+	}
+
+	return counts, rows.Err()
+}
+
+// GetTagPairFrequency returns the number of published versions using both tags of the pair.
+// The names of the pair are in the order of the database collation.
+func (u *TagsRepo) GetTagPairFrequency(ctx context.Context) (map[[2]string]int, error) {
+	rows, err := u.db.QueryContext(ctx, getTagPairFrequencyQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	frequencies := make(map[[2]string]int)
+	for rows.Next() {
+		var pair [2]string
+		var count int
+		if err := rows.Scan(&pair[0], &pair[1], &count); err != nil {
+			return nil, err
+		}
+		frequencies[pair] = count
+	}
+
+	return frequencies, rows.Err()
+}
+
+// changeTagStats adds the delta to the usage of the tags of the article version and to their pairs.
+// The delta is 1 when the version is published and -1 when it stops being published.
+func changeTagStats(ctx context.Context, tx *sql.Tx, articleVersionID int64, delta int) error {
+	if _, err := tx.ExecContext(ctx, changeTagStatsQuery, articleVersionID, delta); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, changeTagPairStatsQuery, articleVersionID, delta)
+	return err
+}
+
+// ReconcileTagStats recounts the statistics from the published versions and returns the number of fixed rows.
+func (u *TagsRepo) ReconcileTagStats(ctx context.Context) (int64, error) {
+	var fixed int64
+	err := runInTx(ctx, u.db, func(tx *sql.Tx) error {
+		var err error
+		fixed, err = reconcileTagStats(ctx, tx)
+		return err
+	})
+
+	return fixed, err
+}
+
+func reconcileTagStats(ctx context.Context, tx *sql.Tx) (int64, error) {
+	var fixed int64
+	for _, query := range []string{
+		reconcileTagStatsQuery,
+		deleteStaleTagStatsQuery,
+		reconcileTagPairStatsQuery,
+		deleteStaleTagPairStatsQuery,
+	} {
+		res, err := tx.ExecContext(ctx, query, constanta.Published)
+		if err != nil {
+			return 0, err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		fixed += affected
+	}
+
+	return fixed, nil
+}
+
+const (
+	getTagUsageDailyQuery = `SELECT tag_name, "day", "count" FROM tag_usage_daily WHERE "day" >= $1`
+)
+
+// GetTagUsageDaily returns the daily usage of every tag since the day.
+func (u *TagsRepo) GetTagUsageDaily(ctx context.Context, since time.Time) ([]entity.TagUsageDaily, error) {
+	rows, err := u.db.QueryContext(ctx, getTagUsageDailyQuery, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usages []entity.TagUsageDaily
+	for rows.Next() {
+		var usage entity.TagUsageDaily
+		if err := rows.Scan(
+			&usage.TagName,
+			&usage.Day,
+			&usage.Count,
+		); err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+
+	return usages, rows.Err()
+}
+
+// the versions which are or were published count on the day they were first published
+const (
+	getTagStatsSeriesQuery = `SELECT date_trunc($2::TEXT, av.published_at AT TIME ZONE 'UTC') AS bucket, COUNT(*)
+		FROM article_version_tags avt
+		JOIN article_versions av ON av.id = avt.article_version_id
+		WHERE avt.tag_name = $1 AND av.published_at >= $3 AND av.published_at < $4
+		GROUP BY bucket
+		ORDER BY bucket`
+	getTagStatsCoOccurringTagsQuery = `SELECT other.tag_name, COUNT(*)
+		FROM article_version_tags avt
+		JOIN article_versions av ON av.id = avt.article_version_id
+		JOIN article_version_tags other ON other.article_version_id = avt.article_version_id AND other.tag_name <> avt.tag_name
+		WHERE avt.tag_name = $1 AND av.published_at >= $2 AND av.published_at < $3
+		GROUP BY other.tag_name
+		ORDER BY COUNT(*) DESC, other.tag_name
+		LIMIT $4`
+	getTagStatsTopAuthorsQuery = `SELECT u.id, u.name, COUNT(*)
+		FROM article_version_tags avt
+		JOIN article_versions av ON av.id = avt.article_version_id
+		JOIN users u ON u.id = av.created_by
+		WHERE avt.tag_name = $1 AND av.published_at >= $2 AND av.published_at < $3
+		GROUP BY u.id, u.name
+		ORDER BY COUNT(*) DESC, u.name
+		LIMIT $4`
+)
+
+// GetTagStats returns the usage of the tag per bucket, the tags used together with it and its top authors.
+func (u *TagsRepo) GetTagStats(ctx context.Context, filter entity.TagStatsFilter) (*entity.TagStats, error) {
+	stats := &entity.TagStats{}
+
+	rows, err := u.db.QueryContext(ctx, getTagStatsSeriesQuery, filter.TagName, filter.Interval, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket entity.TagStatsBucket
+		if err := rows.Scan(&bucket.Start, &bucket.Count); err != nil {
+			return nil, err
+		}
+		stats.Series = append(stats.Series, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := u.db.QueryContext(ctx, getTagStatsCoOccurringTagsQuery, filter.TagName, filter.From, filter.To, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var tag entity.TagCount
+		if err := tagRows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, err
+		}
+		stats.CoOccurringTags = append(stats.CoOccurringTags, tag)
+	}
+	if err := tagRows.Err(); err != nil {
+		return nil, err
+	}
+
+	authorRows, err := u.db.QueryContext(ctx, getTagStatsTopAuthorsQuery, filter.TagName, filter.From, filter.To, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer authorRows.Close()
+
+	for authorRows.Next() {
+		var author entity.TagAuthor
+		if err := authorRows.Scan(&author.ID, &author.Name, &author.Count); err != nil {
+			return nil, err
 		}
+		stats.TopAuthors = append(stats.TopAuthors, author)
 	}
 
-	return counts, nil
+	return stats, authorRows.Err()
 }
 
 const (
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
@@ -10,6 +11,8 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,25 +28,35 @@ func TestTagsRepo_UpsertTags(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "positive case - upsert tags successfully",
+			name: "positive case - only the new tag is sent as created",
 			args: args{names: []string{"go", "test"}},
 			mock: func(m sqlmock.Sqlmock) {
-				m.ExpectPrepare(regexp.QuoteMeta(upsertTagQuery)).
-					ExpectExec().
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(createTagQuery)).
 					WithArgs("go").
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				m.ExpectExec(regexp.QuoteMeta(upsertTagQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+				m.ExpectQuery(regexp.QuoteMeta(createTagQuery)).
 					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("test"))
+				m.ExpectExec(regexp.QuoteMeta(insertOutboxEventQuery)).
+					WithArgs(constanta.EventTagCreated, []byte(`{"name":"test"}`)).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
-			name: "negative case - prepare returns error",
+			name: "negative case - the event is rolled back with the tag",
 			args: args{names: []string{"fail"}},
 			mock: func(m sqlmock.Sqlmock) {
-				m.ExpectPrepare(regexp.QuoteMeta(upsertTagQuery)).
-					WillReturnError(errors.New("prepare error"))
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(createTagQuery)).
+					WithArgs("fail").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("fail"))
+				m.ExpectExec(regexp.QuoteMeta(insertOutboxEventQuery)).
+					WithArgs(constanta.EventTagCreated, []byte(`{"name":"fail"}`)).
+					WillReturnError(errors.New("insert error"))
+				m.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -76,21 +89,24 @@ func TestTagsRepo_GetTags(t *testing.T) {
 		name    string
 		args    args
 		mock    func(sqlmock.Sqlmock)
-		want    []string
+		want    []entity.Tag
 		wantErr bool
 	}{
 		{
 			name: "positive case - get tags successfully",
 			args: args{names: []string{"go", "test"}},
 			mock: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"name"}).
-					AddRow("go").
-					AddRow("test")
+				rows := sqlmock.NewRows([]string{"name", "display_name", "description", "color", "slug"}).
+					AddRow("go", "Go", "The Go language", "#00add8", "go").
+					AddRow("test", "", "", "", "")
 				m.ExpectQuery(regexp.QuoteMeta(getTagsQuery)).
 					WithArgs(pq.Array([]string{"go", "test"})).
 					WillReturnRows(rows)
 			},
-			want:    []string{"go", "test"},
+			want: []entity.Tag{
+				{Name: "go", DisplayName: "Go", Description: "The Go language", Color: "#00add8", Slug: "go"},
+				{Name: "test"},
+			},
 			wantErr: false,
 		},
 		{
@@ -136,11 +152,10 @@ func TestTagsRepo_GetTagUsage(t *testing.T) {
 		{
 			name: "positive case - get tag usage successfully",
 			mock: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"name", "usage_count", "last_used"}).
+				rows := sqlmock.NewRows([]string{"tag_name", "usage_count", "last_used"}).
 					AddRow("go", 2, time.Date(2025, 8, 11, 10, 0, 0, 0, time.UTC)).
 					AddRow("test", 1, time.Date(2025, 8, 10, 9, 0, 0, 0, time.UTC))
 				m.ExpectQuery(regexp.QuoteMeta(getTagUsageQuery)).
-					WithArgs(constanta.Published).
 					WillReturnRows(rows)
 			},
 			want: map[string]entity.TagUsage{
@@ -159,7 +174,6 @@ func TestTagsRepo_GetTagUsage(t *testing.T) {
 			name: "negative case - query returns error",
 			mock: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(regexp.QuoteMeta(getTagUsageQuery)).
-					WithArgs(constanta.Published).
 					WillReturnError(errors.New("query error"))
 			},
 			want:    nil,
@@ -187,6 +201,241 @@ func TestTagsRepo_GetTagUsage(t *testing.T) {
 	}
 }
 
+func TestTagsRepo_GetTagPairFrequency(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    map[[2]string]int
+		wantErr bool
+	}{
+		{
+			name: "positive case - get tag pair frequency successfully",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"tag_a", "tag_b", "count"}).
+					AddRow("cms", "go", 2).
+					AddRow("go", "test", 1)
+				m.ExpectQuery(regexp.QuoteMeta(getTagPairFrequencyQuery)).
+					WillReturnRows(rows)
+			},
+			want:    map[[2]string]int{{"cms", "go"}: 2, {"go", "test"}: 1},
+			wantErr: false,
+		},
+		{
+			name: "negative case - query returns error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getTagPairFrequencyQuery)).
+					WillReturnError(errors.New("query error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			got, err := repo.GetTagPairFrequency(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_ReconcileTagStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "positive case - reconcile tag stats successfully",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(reconcileTagStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(deleteStaleTagStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(reconcileTagPairStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(deleteStaleTagPairStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectCommit()
+			},
+			want:    6,
+			wantErr: false,
+		},
+		{
+			name: "negative case - rollback on error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(reconcileTagStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(deleteStaleTagStatsQuery)).WithArgs(constanta.Published).WillReturnError(errors.New("delete error"))
+				m.ExpectRollback()
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			got, err := repo.ReconcileTagStats(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_GetTagUsageDaily(t *testing.T) {
+	since := time.Date(2025, 8, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []entity.TagUsageDaily
+		wantErr bool
+	}{
+		{
+			name: "positive case - get tag usage daily successfully",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"tag_name", "day", "count"}).
+					AddRow("go", time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC), 2).
+					AddRow("test", time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), 1)
+				m.ExpectQuery(regexp.QuoteMeta(getTagUsageDailyQuery)).
+					WithArgs(since).
+					WillReturnRows(rows)
+			},
+			want: []entity.TagUsageDaily{
+				{TagName: "go", Day: time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC), Count: 2},
+				{TagName: "test", Day: time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), Count: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative case - query returns error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getTagUsageDailyQuery)).
+					WithArgs(since).
+					WillReturnError(errors.New("query error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			got, err := repo.GetTagUsageDaily(context.Background(), since)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_GetTagStats(t *testing.T) {
+	filter := entity.TagStatsFilter{
+		TagName:  "go",
+		Interval: constanta.TagStatsDay,
+		From:     time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2025, 8, 8, 0, 0, 0, 0, time.UTC),
+		Limit:    10,
+	}
+	authorID := uuid.New()
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    *entity.TagStats
+		wantErr bool
+	}{
+		{
+			name: "positive case - get tag stats successfully",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getTagStatsSeriesQuery)).
+					WithArgs("go", constanta.TagStatsDay, filter.From, filter.To).
+					WillReturnRows(sqlmock.NewRows([]string{"bucket", "count"}).
+						AddRow(time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC), 3))
+				m.ExpectQuery(regexp.QuoteMeta(getTagStatsCoOccurringTagsQuery)).
+					WithArgs("go", filter.From, filter.To, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"tag_name", "count"}).AddRow("cms", 2))
+				m.ExpectQuery(regexp.QuoteMeta(getTagStatsTopAuthorsQuery)).
+					WithArgs("go", filter.From, filter.To, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).AddRow(authorID, "editor", 3))
+			},
+			want: &entity.TagStats{
+				Series:          []entity.TagStatsBucket{{Start: time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC), Count: 3}},
+				CoOccurringTags: []entity.TagCount{{Name: "cms", Count: 2}},
+				TopAuthors:      []entity.TagAuthor{{ID: authorID, Name: "editor", Count: 3}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative case - authors query returns error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getTagStatsSeriesQuery)).
+					WithArgs("go", constanta.TagStatsDay, filter.From, filter.To).
+					WillReturnRows(sqlmock.NewRows([]string{"bucket", "count"}))
+				m.ExpectQuery(regexp.QuoteMeta(getTagStatsCoOccurringTagsQuery)).
+					WithArgs("go", filter.From, filter.To, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"tag_name", "count"}))
+				m.ExpectQuery(regexp.QuoteMeta(getTagStatsTopAuthorsQuery)).
+					WithArgs("go", filter.From, filter.To, 10).
+					WillReturnError(errors.New("query error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			got, err := repo.GetTagStats(context.Background(), filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestTagsRepo_GetArticleTags(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -244,3 +493,281 @@ func TestTagsRepo_GetArticleTags(t *testing.T) {
 		})
 	}
 }
+
+func TestTagsRepo_UpdateTag(t *testing.T) {
+	tag := entity.Tag{Name: "golang", DisplayName: "Go", Description: "The Go language", Color: "#00add8", Slug: "golang"}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "positive case - rename and update metadata",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(updateTagQuery)).
+					WithArgs(tag.Name, tag.DisplayName, tag.Description, tag.Color, tag.Slug, "go").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "negative case - tag not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(updateTagQuery)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "negative case - new name is taken",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(updateTagQuery)).
+					WillReturnError(&pq.Error{Code: uniqueViolationCode, Constraint: "tags_pkey"})
+			},
+			wantErr: errs.AlreadyExist{Name: "tag golang"},
+		},
+		{
+			name: "negative case - slug is taken",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(updateTagQuery)).
+					WillReturnError(&pq.Error{Code: uniqueViolationCode, Constraint: "tags_slug_index"})
+			},
+			wantErr: errs.AlreadyExist{Name: "tag with slug golang"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			err = repo.UpdateTag(context.Background(), "go", tag)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_MergeTags(t *testing.T) {
+	sources := []string{"golang", "go-lang"}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "positive case - merge tags",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(upsertTagQuery)).
+					WithArgs("go").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(mergeArticleVersionTagsQuery)).
+					WithArgs("go", pq.Array(sources)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec(regexp.QuoteMeta(deleteMergedArticleVersionTagsQuery)).
+					WithArgs(pq.Array(sources)).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				m.ExpectExec(regexp.QuoteMeta(moveTagSynonymsQuery)).
+					WithArgs("go", pq.Array(sources)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(mergeTagUsageDailyQuery)).
+					WithArgs("go", pq.Array(sources)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec(regexp.QuoteMeta(deleteTagsQuery)).
+					WithArgs(pq.Array(sources)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(regexp.QuoteMeta(reconcileTagStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteStaleTagStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(reconcileTagPairStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteStaleTagPairStatsQuery)).WithArgs(constanta.Published).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(createMergedTagSynonymsQuery)).
+					WithArgs("go", pq.Array(sources)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "negative case - rollback on error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(upsertTagQuery)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta(mergeArticleVersionTagsQuery)).
+					WillReturnError(errors.New("exec error"))
+				m.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			err = repo.MergeTags(context.Background(), sources, "go")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_DeleteTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "positive case - delete unused tag",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteTagQuery)).
+					WithArgs("typo").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "negative case - tag not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteTagQuery)).
+					WithArgs("typo").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "negative case - tag is used",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(deleteTagQuery)).
+					WithArgs("typo").
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode})
+			},
+			wantErr: errs.Conflict{Message: "tag is used by article versions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			err = repo.DeleteTag(context.Background(), "typo")
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_DeleteUnusedTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteUnusedTagsQuery)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := NewTagRepo(db)
+	deleted, err := repo.DeleteUnusedTags(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagsRepo_GetTagSynonyms(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(getTagSynonymsQuery)).
+		WithArgs(pq.Array([]string{"golang", "js"})).
+		WillReturnRows(sqlmock.NewRows([]string{"alias", "tag_name", "created_at"}).
+			AddRow("golang", "go", createdAt))
+
+	repo := NewTagRepo(db)
+	synonyms, err := repo.GetTagSynonyms(context.Background(), "golang", "js")
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.TagSynonym{{Alias: "golang", TagName: "go", CreatedAt: createdAt}}, synonyms)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagsRepo_CreateTagSynonyms(t *testing.T) {
+	aliases := []string{"golang", "go-lang"}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "positive case - create synonyms",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(createTagSynonymsQuery)).
+					WithArgs(pq.Array(aliases), "go").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "negative case - alias already exist",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(createTagSynonymsQuery)).
+					WithArgs(pq.Array(aliases), "go").
+					WillReturnError(&pq.Error{Code: uniqueViolationCode})
+			},
+			wantErr: errs.AlreadyExist{Name: "one of the synonyms"},
+		},
+		{
+			name: "negative case - tag not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(createTagSynonymsQuery)).
+					WithArgs(pq.Array(aliases), "go").
+					WillReturnError(&pq.Error{Code: foreignKeyViolationCode})
+			},
+			wantErr: errs.NotFound{Message: "tag go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.mock(mock)
+
+			repo := NewTagRepo(db)
+			err = repo.CreateTagSynonyms(context.Background(), "go", aliases)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagsRepo_DeleteTagSynonym(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteTagSynonymQuery)).
+		WithArgs("go", "golang").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewTagRepo(db)
+	err = repo.DeleteTagSynonym(context.Background(), "go", "golang")
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,272 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/lib/pq"
+)
+
+type (
+	WebhookRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewWebhookRepo(db *sql.DB) *WebhookRepo {
+	return &WebhookRepo{
+		db: db,
+	}
+}
+
+const (
+	webhookColumns = `id, url, secret, events, active, created_by, created_at, updated_at`
+
+	createWebhookQuery = `INSERT INTO webhooks (url, secret, events, active, created_by)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+)
+
+func (wr *WebhookRepo) CreateWebhook(ctx context.Context, webhook entity.Webhook) (int64, error) {
+	var id int64
+	err := wr.db.QueryRowContext(ctx, createWebhookQuery,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(eventNames(webhook.Events)),
+		webhook.Active,
+		webhook.CreatedBy,
+	).Scan(&id)
+
+	return id, err
+}
+
+const (
+	getWebhooksQuery = `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY id`
+	getWebhookQuery  = `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+	// the webhooks without events subscribe every event
+	getSubscribedWebhooksQuery = `SELECT ` + webhookColumns + ` FROM webhooks
+		WHERE active AND (cardinality(events) = 0 OR $1 = ANY(events))
+		ORDER BY id`
+)
+
+func (wr *WebhookRepo) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	rows, err := wr.db.QueryContext(ctx, getWebhooksQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+// GetWebhook returns sql.ErrNoRows when the webhook does not exist.
+func (wr *WebhookRepo) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	rows, err := wr.db.QueryContext(ctx, getWebhookQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks, err := scanWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &webhooks[0], nil
+}
+
+// GetSubscribedWebhooks returns the active webhooks which get the event.
+func (wr *WebhookRepo) GetSubscribedWebhooks(ctx context.Context, event constanta.EventType) ([]entity.Webhook, error) {
+	rows, err := wr.db.QueryContext(ctx, getSubscribedWebhooksQuery, event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+const (
+	updateWebhookQuery = `UPDATE webhooks SET url = $1, secret = $2, events = $3, active = $4 WHERE id = $5`
+	deleteWebhookQuery = `DELETE FROM webhooks WHERE id = $1`
+)
+
+// UpdateWebhook returns sql.ErrNoRows when the webhook does not exist.
+func (wr *WebhookRepo) UpdateWebhook(ctx context.Context, webhook entity.Webhook) error {
+	return wr.exec(ctx, updateWebhookQuery,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(eventNames(webhook.Events)),
+		webhook.Active,
+		webhook.ID,
+	)
+}
+
+// DeleteWebhook deletes the webhook with its deliveries, it returns sql.ErrNoRows when the webhook does not exist.
+func (wr *WebhookRepo) DeleteWebhook(ctx context.Context, id int64) error {
+	return wr.exec(ctx, deleteWebhookQuery, id)
+}
+
+const (
+	webhookDeliveryColumns = `id, webhook_id, event, payload, status, attempts, response_status, response_body, last_error, created_at, updated_at, delivered_at`
+
+	// an event which is published again does not create another delivery for the webhook
+	createWebhookDeliveryQuery = `INSERT INTO webhook_deliveries (webhook_id, outbox_event_id, event, payload, status)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+		ON CONFLICT (webhook_id, outbox_event_id) DO NOTHING
+		RETURNING id`
+	deleteWebhookDeliveryQuery = `DELETE FROM webhook_deliveries WHERE id = $1`
+	getWebhookDeliveryQuery    = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	getWebhookDeliveriesQuery  = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2) AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4`
+	// delivered_at is kept from the first successful attempt
+	recordWebhookDeliveryAttemptQuery = `UPDATE webhook_deliveries
+		SET status = $1, attempts = attempts + 1, response_status = $2, response_body = $3, last_error = $4,
+			delivered_at = CASE WHEN $1 = 'succeeded' THEN COALESCE(delivered_at, NOW()) ELSE delivered_at END
+		WHERE id = $5`
+)
+
+// CreateWebhookDelivery returns sql.ErrNoRows when the webhook already has a delivery of the event.
+func (wr *WebhookRepo) CreateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) (int64, error) {
+	var id int64
+	err := wr.db.QueryRowContext(ctx, createWebhookDeliveryQuery,
+		delivery.WebhookID,
+		delivery.OutboxEventID,
+		delivery.Event,
+		[]byte(delivery.Payload),
+		delivery.Status,
+	).Scan(&id)
+
+	return id, err
+}
+
+func (wr *WebhookRepo) DeleteWebhookDelivery(ctx context.Context, id int64) error {
+	return wr.exec(ctx, deleteWebhookDeliveryQuery, id)
+}
+
+// GetWebhookDelivery returns sql.ErrNoRows when the delivery does not exist.
+func (wr *WebhookRepo) GetWebhookDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	rows, err := wr.db.QueryContext(ctx, getWebhookDeliveryQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &deliveries[0], nil
+}
+
+func (wr *WebhookRepo) GetWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error) {
+	rows, err := wr.db.QueryContext(ctx, getWebhookDeliveriesQuery, filter.WebhookID, filter.Status, filter.BeforeID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWebhookDeliveries(rows)
+}
+
+// RecordWebhookDeliveryAttempt counts the attempt and keeps its result.
+func (wr *WebhookRepo) RecordWebhookDeliveryAttempt(ctx context.Context, id int64, attempt entity.WebhookDeliveryAttempt) error {
+	return wr.exec(ctx, recordWebhookDeliveryAttemptQuery,
+		attempt.Status,
+		attempt.ResponseStatus,
+		attempt.ResponseBody,
+		attempt.LastError,
+		id,
+	)
+}
+
+func (wr *WebhookRepo) exec(ctx context.Context, query string, args ...any) error {
+	res, err := wr.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanWebhooks(rows *sql.Rows) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	for rows.Next() {
+		var webhook entity.Webhook
+		var events []string
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.URL,
+			&webhook.Secret,
+			pq.Array(&events),
+			&webhook.Active,
+			&webhook.CreatedBy,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			webhook.Events = append(webhook.Events, constanta.EventType(event))
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func scanWebhookDeliveries(rows *sql.Rows) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		var payload []byte
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.Event,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.ResponseBody,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		delivery.Payload = payload
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// eventNames keeps the empty events as an empty array, a nil array would be stored as NULL.
+func eventNames(events []constanta.EventType) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+
+	return names
+}
@@ -0,0 +1,185 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var webhookTestColumns = []string{"id", "url", "secret", "events", "active", "created_by", "created_at", "updated_at"}
+
+var webhookDeliveryTestColumns = []string{"id", "webhook_id", "event", "payload", "status", "attempts", "response_status", "response_body", "last_error", "created_at", "updated_at", "delivered_at"}
+
+func TestWebhookRepo_CreateWebhook(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewWebhookRepo(db)
+	defer db.Close()
+
+	webhook := entity.Webhook{URL: "http://localhost:9000/hook", Secret: "secret", Active: true, CreatedBy: uuid.New()}
+	mock.ExpectQuery(regexp.QuoteMeta(createWebhookQuery)).
+		WithArgs(webhook.URL, webhook.Secret, pq.Array([]string{}), true, webhook.CreatedBy).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	got, err := repo.CreateWebhook(context.Background(), webhook)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_GetSubscribedWebhooks(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewWebhookRepo(db)
+	defer db.Close()
+
+	createdBy := uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(getSubscribedWebhooksQuery)).
+		WithArgs(constanta.EventArticleCreated).
+		WillReturnRows(sqlmock.NewRows(webhookTestColumns).
+			AddRow(1, "http://localhost:9000/hook", "secret", "{article.created,tag.created}", true, createdBy, now, nil).
+			AddRow(2, "http://localhost:9001/hook", "secret", "{}", true, createdBy, now, nil))
+
+	got, err := repo.GetSubscribedWebhooks(context.Background(), constanta.EventArticleCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Webhook{
+		{ID: 1, URL: "http://localhost:9000/hook", Secret: "secret", Events: []constanta.EventType{constanta.EventArticleCreated, constanta.EventTagCreated}, Active: true, CreatedBy: createdBy, CreatedAt: now},
+		{ID: 2, URL: "http://localhost:9001/hook", Secret: "secret", Active: true, CreatedBy: createdBy, CreatedAt: now},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_GetWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getWebhookQuery)).
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(webhookTestColumns).
+						AddRow(1, "http://localhost:9000/hook", "secret", "{}", false, uuid.New(), time.Now(), nil))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(getWebhookQuery)).
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(webhookTestColumns))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			repo := NewWebhookRepo(db)
+			defer db.Close()
+			tt.mock(mock)
+			got, err := repo.GetWebhook(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, int64(1), got.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookRepo_DeleteWebhook(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewWebhookRepo(db)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteWebhookQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.Equal(t, sql.ErrNoRows, repo.DeleteWebhook(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_GetWebhookDeliveries(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewWebhookRepo(db)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(getWebhookDeliveriesQuery)).
+		WithArgs(int64(1), constanta.WebhookDeliveryFailed, int64(10), 2).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveryTestColumns).
+			AddRow(9, 1, constanta.EventTagCreated, []byte(`{"name":"go"}`), constanta.WebhookDeliveryFailed, 2, 500, "oops", "webhook responded with 500", now, nil, nil))
+
+	got, err := repo.GetWebhookDeliveries(context.Background(), entity.WebhookDeliveryFilter{WebhookID: 1, Status: constanta.WebhookDeliveryFailed, BeforeID: 10, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.WebhookDelivery{{
+		ID:             9,
+		WebhookID:      1,
+		Event:          constanta.EventTagCreated,
+		Payload:        json.RawMessage(`{"name":"go"}`),
+		Status:         constanta.WebhookDeliveryFailed,
+		Attempts:       2,
+		ResponseStatus: 500,
+		ResponseBody:   "oops",
+		LastError:      "webhook responded with 500",
+		CreatedAt:      now,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_RecordWebhookDeliveryAttempt(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewWebhookRepo(db)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(recordWebhookDeliveryAttemptQuery)).
+		WithArgs(constanta.WebhookDeliverySucceeded, 204, "", "", int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.RecordWebhookDeliveryAttempt(context.Background(), 9, entity.WebhookDeliveryAttempt{Status: constanta.WebhookDeliverySucceeded, ResponseStatus: 204})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_CreateWebhookDelivery(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewWebhookRepo(db)
+	defer db.Close()
+
+	delivery := entity.WebhookDelivery{
+		WebhookID:     1,
+		OutboxEventID: 9,
+		Event:         constanta.EventTagCreated,
+		Payload:       json.RawMessage(`{"name":"go"}`),
+		Status:        constanta.WebhookDeliveryPending,
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(createWebhookDeliveryQuery)).
+		WithArgs(int64(1), int64(9), constanta.EventTagCreated, []byte(`{"name":"go"}`), constanta.WebhookDeliveryPending).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	// the webhook already has the delivery of the event
+	mock.ExpectQuery(regexp.QuoteMeta(createWebhookDeliveryQuery)).
+		WithArgs(int64(1), int64(9), constanta.EventTagCreated, []byte(`{"name":"go"}`), constanta.WebhookDeliveryPending).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	id, err := repo.CreateWebhookDelivery(context.Background(), delivery)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), id)
+
+	_, err = repo.CreateWebhookDelivery(context.Background(), delivery)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,184 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+)
+
+var (
+	// characters that are markdown syntax anywhere in the line
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `~`, `\~`,
+		`[`, `\[`, `]`, `\]`, `<`, `\<`, `|`, `\|`, `&`, `\&`,
+	)
+	// keeps the url inside <...> destination
+	markdownURLEscaper = strings.NewReplacer(`<`, `%3C`, `>`, `%3E`, ` `, `%20`, "\n", "")
+	// ordered list marker at the start of a line
+	orderedListMarkerRegex = regexp.MustCompile(`^\d+[.)]`)
+	backtickRunRegex       = regexp.MustCompile("`+")
+)
+
+// Image is the responsive data of a media library image.
+type Image struct {
+	Srcset        string
+	Width, Height int
+}
+
+// BlocksHTML renders the blocks into sanitized HTML.
+// Each block element has the id block-{id}, so readers can link to a block.
+// Images of the media library get their srcset and size from the images, when they are given.
+func BlocksHTML(doc entity.BlockDocument, images map[int64]Image) string {
+	var sb strings.Builder
+	for _, block := range doc.Blocks {
+		id := html.EscapeString("block-" + block.ID)
+		text := html.EscapeString(block.Text)
+
+		switch block.Type {
+		case constanta.ParagraphBlock:
+			fmt.Fprintf(&sb, `<p id="%s">%s</p>`, id, lineBreaks(text))
+		case constanta.HeadingBlock:
+			fmt.Fprintf(&sb, `<h%d id="%s">%s</h%d>`, block.Level, id, text, block.Level)
+		case constanta.ImageBlock:
+			fmt.Fprintf(&sb, `<figure id="%s"><img src="%s" alt="%s"`, id, html.EscapeString(imageURL(block)), html.EscapeString(block.Alt))
+			if image, ok := images[block.MediaID]; ok && block.MediaID > 0 {
+				if image.Srcset != "" {
+					fmt.Fprintf(&sb, ` srcset="%s"`, html.EscapeString(image.Srcset))
+				}
+				if image.Width > 0 && image.Height > 0 {
+					fmt.Fprintf(&sb, ` width="%d" height="%d"`, image.Width, image.Height)
+				}
+			}
+			sb.WriteString(">")
+			writeFigcaption(&sb, block.Caption)
+			sb.WriteString(`</figure>`)
+		case constanta.QuoteBlock:
+			fmt.Fprintf(&sb, `<figure id="%s"><blockquote><p>%s</p></blockquote>`, id, lineBreaks(text))
+			writeFigcaption(&sb, block.Caption)
+			sb.WriteString(`</figure>`)
+		case constanta.EmbedBlock:
+			label := block.Caption
+			if label == "" {
+				label = block.URL
+			}
+			fmt.Fprintf(&sb, `<figure id="%s" class="embed"><a href="%s">%s</a></figure>`, id, html.EscapeString(block.URL), html.EscapeString(label))
+		case constanta.CodeBlock:
+			if block.Language != "" {
+				fmt.Fprintf(&sb, `<pre id="%s"><code class="language-%s">%s</code></pre>`, id, html.EscapeString(block.Language), text)
+			} else {
+				fmt.Fprintf(&sb, `<pre id="%s"><code>%s</code></pre>`, id, text)
+			}
+		case constanta.CalloutBlock:
+			fmt.Fprintf(&sb, `<aside id="%s" class="callout callout-%s"><p>%s</p></aside>`, id, html.EscapeString(block.Variant), lineBreaks(text))
+		}
+		sb.WriteString("\n")
+	}
+
+	// the blocks are validated on create, the policy is the last line of defense
+	return policy.Sanitize(sb.String())
+}
+
+// BlocksMarkdown renders the blocks into markdown. The text of the blocks is escaped,
+// so it is rendered as it is written.
+func BlocksMarkdown(doc entity.BlockDocument) string {
+	parts := make([]string, 0, len(doc.Blocks))
+	for _, block := range doc.Blocks {
+		text := escapeMarkdown(block.Text)
+
+		switch block.Type {
+		case constanta.ParagraphBlock:
+			parts = append(parts, hardLineBreaks(text, ""))
+		case constanta.HeadingBlock:
+			parts = append(parts, strings.Repeat("#", block.Level)+" "+strings.ReplaceAll(text, "\n", " "))
+		case constanta.ImageBlock:
+			image := fmt.Sprintf("![%s](<%s>)", escapeMarkdown(block.Alt), markdownURLEscaper.Replace(imageURL(block)))
+			if block.Caption != "" {
+				image += "\n*" + escapeMarkdown(block.Caption) + "*"
+			}
+			parts = append(parts, image)
+		case constanta.QuoteBlock:
+			quote := "> " + hardLineBreaks(text, "> ")
+			if block.Caption != "" {
+				quote += "\n>\n> — " + escapeMarkdown(block.Caption)
+			}
+			parts = append(parts, quote)
+		case constanta.EmbedBlock:
+			label := block.Caption
+			if label == "" {
+				label = block.URL
+			}
+			parts = append(parts, fmt.Sprintf("[%s](<%s>)", escapeMarkdown(label), markdownURLEscaper.Replace(block.URL)))
+		case constanta.CodeBlock:
+			fence := codeFence(block.Text)
+			parts = append(parts, fence+block.Language+"\n"+block.Text+"\n"+fence)
+		case constanta.CalloutBlock:
+			label := "Info"
+			if block.Variant != "" {
+				label = strings.ToUpper(block.Variant[:1]) + block.Variant[1:]
+			}
+			parts = append(parts, "> **"+label+":** "+hardLineBreaks(text, "> "))
+		}
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// imageURL returns the url of the image, media library images are served by the API.
+func imageURL(block entity.Block) string {
+	if block.MediaID > 0 {
+		return entity.MediaPath(block.MediaID)
+	}
+
+	return block.URL
+}
+
+func escapeMarkdown(text string) string {
+	lines := strings.Split(markdownEscaper.Replace(text), "\n")
+	for i, line := range lines {
+		// indentation would turn the line into a code block
+		line = strings.TrimLeft(line, " \t")
+
+		switch {
+		case line == "":
+		case strings.ContainsRune("#>-+=", rune(line[0])):
+			// heading, quote, list, thematic break and setext underline
+			line = `\` + line
+		case orderedListMarkerRegex.MatchString(line):
+			marker := orderedListMarkerRegex.FindString(line)
+			line = marker[:len(marker)-1] + `\` + line[len(marker)-1:]
+		}
+
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// codeFence returns a fence that is longer than every backtick run of the code.
+func codeFence(code string) string {
+	longest := 0
+	for _, run := range backtickRunRegex.FindAllString(code, -1) {
+		longest = max(longest, len(run))
+	}
+
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// hardLineBreaks keeps the line breaks of the text, like the <br> of the HTML rendering.
+func hardLineBreaks(text, prefix string) string {
+	return strings.ReplaceAll(text, "\n", "\\\n"+prefix)
+}
+
+func lineBreaks(text string) string {
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+func writeFigcaption(sb *strings.Builder, caption string) {
+	if caption != "" {
+		fmt.Fprintf(sb, `<figcaption>%s</figcaption>`, html.EscapeString(caption))
+	}
+}
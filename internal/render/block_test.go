@@ -0,0 +1,79 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var testBlockDocument = entity.BlockDocument{
+	SchemaVersion: 1,
+	Blocks: []entity.Block{
+		{ID: "h1", Type: constanta.HeadingBlock, Text: "Title", Level: 2},
+		{ID: "p1", Type: constanta.ParagraphBlock, Text: "# not a heading *x*\nnext line"},
+		{ID: "i1", Type: constanta.ImageBlock, URL: "https://example.com/a.png", Alt: "a", Caption: "cap"},
+		{ID: "q1", Type: constanta.QuoteBlock, Text: "quote", Caption: "someone"},
+		{ID: "e1", Type: constanta.EmbedBlock, URL: "https://example.com/video"},
+		{ID: "c1", Type: constanta.CodeBlock, Text: "fmt.Println(\"```\")", Language: "go"},
+		{ID: "n1", Type: constanta.CalloutBlock, Text: "careful", Variant: "warning"},
+	},
+}
+
+func TestBlocksMarkdown(t *testing.T) {
+	want := "## Title\n\n" +
+		"\\# not a heading \\*x\\*\\\nnext line\n\n" +
+		"![a](<https://example.com/a.png>)\n*cap*\n\n" +
+		"> quote\n>\n> — someone\n\n" +
+		"[https://example.com/video](<https://example.com/video>)\n\n" +
+		"````go\nfmt.Println(\"```\")\n````\n\n" +
+		"> **Warning:** careful\n"
+
+	assert.Equal(t, want, BlocksMarkdown(testBlockDocument))
+}
+
+func TestBlocksMarkdown_EscapedTextIsRenderedAsWritten(t *testing.T) {
+	doc := entity.BlockDocument{SchemaVersion: 1, Blocks: []entity.Block{
+		{ID: "p1", Type: constanta.ParagraphBlock, Text: "- item\n1. one\n<b>bold</b> & [link](x)"},
+	}}
+
+	got, err := HTML(constanta.Markdown, BlocksMarkdown(doc))
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>- item<br>\n1. one<br>\n&lt;b&gt;bold&lt;/b&gt; &amp; [link](x)</p>\n", got)
+}
+
+func TestBlocksHTML(t *testing.T) {
+	want := `<h2 id="block-h1">Title</h2>` + "\n" +
+		`<p id="block-p1"># not a heading *x*<br>next line</p>` + "\n" +
+		`<figure id="block-i1"><img src="https://example.com/a.png" alt="a"><figcaption>cap</figcaption></figure>` + "\n" +
+		`<figure id="block-q1"><blockquote><p>quote</p></blockquote><figcaption>someone</figcaption></figure>` + "\n" +
+		`<figure id="block-e1" class="embed"><a href="https://example.com/video" rel="nofollow noopener" target="_blank">https://example.com/video</a></figure>` + "\n" +
+		`<pre id="block-c1"><code class="language-go">fmt.Println(&#34;` + "```" + `&#34;)</code></pre>` + "\n" +
+		`<aside id="block-n1" class="callout callout-warning"><p>careful</p></aside>` + "\n"
+
+	assert.Equal(t, want, BlocksHTML(testBlockDocument, nil))
+}
+
+func TestBlocksHTML_MediaImage(t *testing.T) {
+	doc := entity.BlockDocument{SchemaVersion: 1, Blocks: []entity.Block{
+		{ID: "i1", Type: constanta.ImageBlock, MediaID: 7, Alt: "a"},
+	}}
+
+	assert.Equal(t, `<figure id="block-i1"><img src="/media/7" alt="a"></figure>`+"\n", BlocksHTML(doc, nil))
+	assert.Equal(t, "![a](</media/7>)\n", BlocksMarkdown(doc))
+}
+
+func TestBlocksHTML_MediaImageSrcset(t *testing.T) {
+	doc := entity.BlockDocument{SchemaVersion: 1, Blocks: []entity.Block{
+		{ID: "i1", Type: constanta.ImageBlock, MediaID: 7, Alt: "a"},
+	}}
+	images := map[int64]Image{7: {Srcset: "/media/7/small 480w, /media/7 1000w", Width: 1000, Height: 500}}
+
+	want := `<figure id="block-i1"><img src="/media/7" alt="a" srcset="/media/7/small 480w, /media/7 1000w" width="1000" height="500"></figure>` + "\n"
+	assert.Equal(t, want, BlocksHTML(doc, images))
+}
+
+func TestSanitize_ForeignSrcsetIsRemoved(t *testing.T) {
+	assert.Equal(t, `<img src="/a.png">`, Sanitize(`<img src="/a.png" srcset="javascript:alert(1) 1w">`))
+}
@@ -0,0 +1,156 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// raw HTML inside the markdown is omitted, since goldmark is not configured with html.WithUnsafe
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// allowlist of the elements and attributes that can reach the readers
+	policy = newPolicy()
+
+	// removes every element, used to get the text of the body
+	textPolicy = bluemonday.StrictPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[a-zA-Z0-9_+-]+$`)).OnElements("code")
+	// embed and callout blocks
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^embed$`)).OnElements("figure")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^callout callout-(info|tip|warning|danger)$`)).OnElements("aside")
+	// GFM task lists
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	// srcset is not checked as an url by the policy, only the media library srcset is allowed
+	p.AllowAttrs("srcset").Matching(regexp.MustCompile(`^/media/\d+(/[a-z0-9-]+)? \d+w(, /media/\d+(/[a-z0-9-]+)? \d+w)*$`)).OnElements("img")
+	return p
+}
+
+// Sanitize removes the elements and attributes that are not in the allowlist.
+func Sanitize(body string) string {
+	return policy.Sanitize(body)
+}
+
+// SanitizeMarkdown cleans the raw HTML blocks and the inline HTML of the markdown with the allowlist policy,
+// so a raw read of the body does not return dangerous HTML. The markdown around them and the code are kept as written.
+func SanitizeMarkdown(body string) string {
+	source := []byte(body)
+
+	// the ranges are collected in the order of the document, so they do not overlap
+	type rawRange struct{ start, stop int }
+	var ranges []rawRange
+	doc := markdown.Parser().Parse(text.NewReader(source))
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch node := n.(type) {
+		case *ast.HTMLBlock:
+			lines := node.Lines()
+			if lines.Len() == 0 {
+				return ast.WalkSkipChildren, nil
+			}
+			stop := lines.At(lines.Len() - 1).Stop
+			if node.HasClosure() {
+				stop = node.ClosureLine.Stop
+			}
+			ranges = append(ranges, rawRange{lines.At(0).Start, stop})
+			return ast.WalkSkipChildren, nil
+		case *ast.RawHTML:
+			if node.Segments.Len() > 0 {
+				ranges = append(ranges, rawRange{node.Segments.At(0).Start, node.Segments.At(node.Segments.Len() - 1).Stop})
+			}
+			return ast.WalkSkipChildren, nil
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	if len(ranges) == 0 {
+		return body
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, r := range ranges {
+		sb.Write(source[last:r.start])
+		sb.WriteString(policy.Sanitize(string(source[r.start:r.stop])))
+		last = r.stop
+	}
+	sb.Write(source[last:])
+
+	return sb.String()
+}
+
+// HTML renders the body into sanitized HTML.
+func HTML(format constanta.BodyFormat, body string) (string, error) {
+	switch format {
+	case constanta.Markdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(body), &buf); err != nil {
+			return "", err
+		}
+		return policy.Sanitize(buf.String()), nil
+	case constanta.HTML:
+		return policy.Sanitize(body), nil
+	case constanta.Plain:
+		return plainToHTML(body), nil
+	default:
+		return "", fmt.Errorf("%s is not valid body format", format)
+	}
+}
+
+// Text returns the body without any markup. Used to generate the excerpt.
+func Text(format constanta.BodyFormat, body string) (string, error) {
+	if format == constanta.Plain {
+		return body, nil
+	}
+
+	rendered, err := HTML(format, body)
+	if err != nil {
+		return "", err
+	}
+
+	// keep the words of different blocks apart
+	rendered = strings.ReplaceAll(rendered, "<", " <")
+
+	text := html.UnescapeString(textPolicy.Sanitize(rendered))
+
+	return strings.Join(strings.Fields(text), " "), nil
+}
+
+// plainToHTML escapes the body and splits it into paragraphs on empty lines.
+func plainToHTML(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+
+	var sb strings.Builder
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		sb.WriteString("<p>")
+		sb.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>"))
+		sb.WriteString("</p>\n")
+	}
+
+	return sb.String()
+}
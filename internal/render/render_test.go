@@ -0,0 +1,106 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  constanta.BodyFormat
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "markdown is rendered",
+			format: constanta.Markdown,
+			body:   "# Title\n\nsome **bold** text",
+			want:   "<h1>Title</h1>\n<p>some <strong>bold</strong> text</p>\n",
+		},
+		{
+			name:   "raw html inside markdown is omitted",
+			format: constanta.Markdown,
+			body:   "hello <script>alert(1)</script>",
+			want:   "<p>hello alert(1)</p>\n",
+		},
+		{
+			name:   "javascript links are removed",
+			format: constanta.Markdown,
+			body:   "[click](javascript:alert(1))",
+			want:   "<p>click</p>\n",
+		},
+		{
+			name:   "html is sanitized",
+			format: constanta.HTML,
+			body:   `<p onclick="alert(1)">hello</p><script>alert(1)</script><img src="x" onerror="alert(1)">`,
+			want:   `<p>hello</p><img src="x">`,
+		},
+		{
+			name:   "plain is escaped",
+			format: constanta.Plain,
+			body:   "a <b>\nline\n\nnext",
+			want:   "<p>a &lt;b&gt;<br>line</p>\n<p>next</p>\n",
+		},
+		{
+			name:    "unknown format",
+			format:  constanta.BodyFormat("rtf"),
+			body:    "text",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HTML(tt.format, tt.body)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "html block",
+			body: "# Title\n\n<script>alert(1)</script>\n\ntext",
+			want: "# Title\n\n\n\ntext",
+		},
+		{
+			name: "inline html",
+			body: "hello <img src=\"x\" onerror=\"alert(1)\"> **world**",
+			want: "hello <img src=\"x\"> **world**",
+		},
+		{
+			name: "html in code is kept",
+			body: "`<script>`\n\n```\n<script>alert(1)</script>\n```",
+			want: "`<script>`\n\n```\n<script>alert(1)</script>\n```",
+		},
+		{
+			name: "markdown without html",
+			body: "a < b & **c**",
+			want: "a < b & **c**",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SanitizeMarkdown(tt.body))
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	got, err := Text(constanta.Markdown, "# Title\n\nsome **bold** & text")
+	assert.NoError(t, err)
+	assert.Equal(t, "Title some bold & text", got)
+}
@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
 	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/elangreza/content-management-system/internal/render"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
@@ -21,14 +25,19 @@ type (
 		UpdateStatusArticle(ctx context.Context, articleID, articleVersionID int64, status constanta.ArticleVersionStatus) error
 		CreateArticleVersionWithReferenceFromArticleID(ctx context.Context, articleID int64, req params.CreateArticleVersionRequest) (*params.CreateArticleVersionResponse, error)
 		CreateArticleVersionWithReferenceFromArticleIDAindVersionID(ctx context.Context, articleID int64, articleVersionID int64, req params.CreateArticleVersionRequest) (*params.CreateArticleVersionResponse, error)
-		GetArticleWithID(ctx context.Context, articleID int64) (*params.GetArticleDetailResponse, error)
+		GetArticleCacheValidator(ctx context.Context, articleID int64) (*entity.CacheValidator, error)
+		GetArticlesCacheValidator(ctx context.Context) (*entity.CacheValidator, error)
+		GetArticleWithID(ctx context.Context, articleID int64, validator entity.CacheValidator) (*params.GetArticleDetailResponse, error)
+		GetArticleIDBySlug(ctx context.Context, slug string) (int64, string, error)
 		GetArticleVersionWithIDAndArticleID(ctx context.Context, articleID int64, articleVersionID int64) (*params.ArticleVersionResponse, error)
 		GetArticleVersions(ctx context.Context, articleID int64) ([]params.ArticleVersionResponse, error)
-		GetArticles(ctx context.Context, req params.GetArticlesQueryParams) ([]params.ArticleVersionResponse, error)
+		GetArticles(ctx context.Context, req params.GetArticlesQueryParams) ([]params.ArticleVersionResponse, *params.PaginationResponse, error)
 	}
 
 	ArticleHandler struct {
-		svc ArticleService
+		svc        ArticleService
+		media      MediaService
+		categories CategoryService
 	}
 )
 
@@ -160,7 +169,7 @@ func (ah *ArticleHandler) UpdateArticleStatusHandler(w http.ResponseWriter, r *h
 // CreateNewArticleVersionWithReferenceFromArticleID
 //
 //	@Summary		Create a new article version with reference from an article ID
-//	@Description	Create a new article version with reference from an article ID with the given parameters
+//	@Description	Create a new article version with reference from an article ID with the given parameters. It is rejected when the user does not hold the edit lock of the article. The SEO fields which are empty keep the values of the reference version.
 //	@Tags			articles
 //	@Accept			json
 //	@Produce		json
@@ -170,6 +179,7 @@ func (ah *ArticleHandler) UpdateArticleStatusHandler(w http.ResponseWriter, r *h
 //	@Param			body			body		params.CreateArticleVersionRequest	true	"Create Article Version Request"
 //	@Success		201				{object}	params.CreateArticleVersionResponse
 //	@Failure		400				{object}	errs.ValidationError
+//	@Failure		409				{object}	errs.Conflict
 //	@Failure		500				{object}	string
 //	@Router			/articles/{articleID} [post]
 func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleID(w http.ResponseWriter, r *http.Request) {
@@ -187,6 +197,11 @@ func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleID(w ht
 		return
 	}
 
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
 	newArticleVersion, err := ah.svc.CreateArticleVersionWithReferenceFromArticleID(r.Context(), int64(articleID), body)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
@@ -199,7 +214,7 @@ func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleID(w ht
 // CreateNewArticleVersionWithReferenceFromArticleIDAndVersionID
 //
 //	@Summary		Create a new article version with reference from an article ID and version ID
-//	@Description	Create a new article version with reference from an article ID and version ID with the given parameters
+//	@Description	Create a new article version with reference from an article ID and version ID with the given parameters. It is rejected when the user does not hold the edit lock of the article. The SEO fields which are empty keep the values of the reference version.
 //	@Tags			articles
 //	@Accept			json
 //	@Produce		json
@@ -210,6 +225,7 @@ func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleID(w ht
 //	@Param			body				body		params.CreateArticleVersionRequest	true	"Create Article Version Request"
 //	@Success		201					{object}	params.CreateArticleVersionResponse
 //	@Failure		400					{object}	errs.ValidationError
+//	@Failure		409					{object}	errs.Conflict
 //	@Failure		500					{object}	string
 //	@Router			/articles/{articleID}/versions/{articleVersionID} [post]
 func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleIDAndVersionID(w http.ResponseWriter, r *http.Request) {
@@ -236,6 +252,11 @@ func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleIDAndVe
 		return
 	}
 
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
 	newArticleVersion, err := ah.svc.CreateArticleVersionWithReferenceFromArticleIDAindVersionID(r.Context(), int64(articleID), int64(articleVersionID), body)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
@@ -253,11 +274,17 @@ func (ah *ArticleHandler) CreateNewArticleVersionWithReferenceFromArticleIDAndVe
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			Authorization	header		string	false	"Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. "//	@Param	articleID	path	int	true	"Article ID"
-//	@Param			articleID		path		int		true	"Article ID"
-//	@Success		200				{object}	params.GetArticleDetailResponse
-//	@Failure		400				{object}	errs.ValidationError
-//	@Failure		500				{object}	object
+//	@Param			Authorization		header		string	false	"Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. "//	@Param	articleID	path	int	true	"Article ID"
+//	@Param			articleID			path		int		true	"Article ID"
+//	@Param			render				query		string	false	"raw (default) | html. html adds body_html with the sanitized HTML of the body"
+//	@Param			If-None-Match		header		string	false	"ETag of the cached response, 304 is returned when it is not changed"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached response, 304 is returned when it is not changed"
+//	@Success		200					{object}	params.GetArticleDetailResponse
+//	@Header			200					{string}	ETag			"Changes with the article, its versions, categories and tags, and with the query"
+//	@Header			200					{string}	Last-Modified	"Latest change of the article or one of its versions"
+//	@Success		304					{object}	object
+//	@Failure		400					{object}	errs.ValidationError
+//	@Failure		500					{object}	object
 //	@Router			/articles/{articleID} [get]
 func (ah *ArticleHandler) GetArticleDetailHandler(w http.ResponseWriter, r *http.Request) {
 	articleIDParam := chi.URLParam(r, "articleID")
@@ -269,13 +296,84 @@ func (ah *ArticleHandler) GetArticleDetailHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	articleDetail, err := ah.svc.GetArticleWithID(r.Context(), int64(articleID))
+	renderHTML, err := params.ParseRenderOption(r.URL.Query().Get("render"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ah.sendArticleDetail(w, r, int64(articleID), renderHTML)
+}
+
+// GetArticleBySlugHandler
+//
+//	@Summary		Get article detail by slug
+//	@Description	Get article detail with the given slug. Old slugs of the article are redirected to the current slug.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization		header		string	false	"Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles."
+//	@Param			slug				path		string	true	"Article slug"
+//	@Param			render				query		string	false	"raw (default) | html. html adds body_html with the sanitized HTML of the body"
+//	@Param			If-None-Match		header		string	false	"ETag of the cached response, 304 is returned when it is not changed"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified of the cached response, 304 is returned when it is not changed"
+//	@Success		200					{object}	params.GetArticleDetailResponse
+//	@Header			200					{string}	ETag			"Changes with the article, its versions, categories and tags, and with the query"
+//	@Header			200					{string}	Last-Modified	"Latest change of the article or one of its versions"
+//	@Success		301					{object}	object
+//	@Success		304					{object}	object
+//	@Failure		404					{object}	errs.NotFound
+//	@Failure		500					{object}	object
+//	@Router			/articles/by-slug/{slug} [get]
+func (ah *ArticleHandler) GetArticleBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	renderHTML, err := params.ParseRenderOption(r.URL.Query().Get("render"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	articleID, currentSlug, err := ah.svc.GetArticleIDBySlug(r.Context(), slug)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	sendSuccessResponse(w, http.StatusOK, articleDetail)
+	if currentSlug != slug {
+		target := &url.URL{Path: "/articles/by-slug/" + url.PathEscape(currentSlug), RawQuery: r.URL.RawQuery}
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		return
+	}
+
+	ah.sendArticleDetail(w, r, articleID, renderHTML)
+}
+
+// sendArticleDetail only builds the detail when the request does not have the read of its validator yet.
+func (ah *ArticleHandler) sendArticleDetail(w http.ResponseWriter, r *http.Request, articleID int64, renderHTML bool) {
+	validator, err := ah.svc.GetArticleCacheValidator(r.Context(), articleID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if notModified(w, r, *validator) {
+		return
+	}
+
+	articleDetail, err := ah.svc.GetArticleWithID(r.Context(), articleID, *validator)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := ah.prepareArticleDetail(r.Context(), renderHTML, articleDetail); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendCacheableResponse(w, r, map[string]any{"data": articleDetail}, *validator)
 }
 
 // GetArticleVersionWithIDAndArticleID
@@ -289,6 +387,7 @@ func (ah *ArticleHandler) GetArticleDetailHandler(w http.ResponseWriter, r *http
 //	@Param			Authorization		header		string	false	"Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. "//	@Param	articleID	path	int	true	"Article ID"
 //	@Param			articleID			path		int		true	"Article ID"
 //	@Param			articleVersionID	path		int		true	"Article Version ID"
+//	@Param			render				query		string	false	"raw (default) | html. html adds body_html with the sanitized HTML of the body"
 //	@Success		200					{object}	params.ArticleVersionResponse
 //	@Failure		400					{object}	errs.ValidationError
 //	@Failure		500					{object}	string
@@ -311,12 +410,23 @@ func (ah *ArticleHandler) GetArticleVersionWithIDAndArticleID(w http.ResponseWri
 		return
 	}
 
+	renderHTML, err := params.ParseRenderOption(r.URL.Query().Get("render"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
 	articleVersion, err := ah.svc.GetArticleVersionWithIDAndArticleID(r.Context(), int64(articleID), int64(articleVersionID))
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
 
+	if err := ah.prepareArticleVersions(r.Context(), renderHTML, articleVersion); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
 	sendSuccessResponse(w, http.StatusOK, articleVersion)
 }
 
@@ -330,6 +440,7 @@ func (ah *ArticleHandler) GetArticleVersionWithIDAndArticleID(w http.ResponseWri
 //	@Security		BearerAuth
 //	@Param			Authorization	header		string	false	"Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise, if the token is present and the user has permission to read drafted and archived articles, the token can be used to access draft, published, and archived articles. "
 //	@Param			articleID		path		int		true	"Article ID"
+//	@Param			render			query		string	false	"raw (default) | html. html adds body_html with the sanitized HTML of the body"
 //	@Success		200				{array}		params.ArticleVersionResponse
 //	@Failure		400				{object}	errs.ValidationError
 //	@Failure		500				{object}	object
@@ -343,12 +454,23 @@ func (ah *ArticleHandler) GetArticleVersionsHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	renderHTML, err := params.ParseRenderOption(r.URL.Query().Get("render"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
 	articleVersions, err := ah.svc.GetArticleVersions(r.Context(), int64(articleID))
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
 
+	if err := ah.prepareArticleVersions(r.Context(), renderHTML, articleVersionPointers(articleVersions)...); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
 	sendSuccessResponse(w, http.StatusOK, articleVersions)
 }
 
@@ -365,10 +487,19 @@ func (ah *ArticleHandler) GetArticleVersionsHandler(w http.ResponseWriter, r *ht
 //	@Param			sorts			query		[]string	false	"article_id:asc | article_id:desc |	article_version_id:asc | article_version_id:desc |	created_by:asc | created_by:desc |	updated_by:asc | updated_by:desc |	title:asc | title:desc |	status:asc | status:desc |	version:asc | version:desc | created_at:asc | created_at:desc | updated_at:asc | updated_at:desc | tag_relationship_score:asc | tag_relationship_score:desc"
 //	@Param			limit			query		int			false	"Limit"
 //	@Param			page			query		int			false	"Page number"
+//	@Param			after			query		string		false	"Cursor from pagination.next_cursor. Returns the articles after it, page is ignored"
+//	@Param			before			query		string		false	"Cursor from pagination.prev_cursor. Returns the articles before it, page is ignored"
+//	@Param			count			query		string		false	"exact (default) | estimated. Estimated total is cheaper for very large result sets"
+//	@Param			render			query		string		false	"raw (default) | html. html adds body_html with the sanitized HTML of the body"
 //	@Param			status			query		int			false	"Status 0 for draft, 1 for published, 2 for archived (comma-separated, integer values)"
 //	@Param			created_by		query		string		false	"Created by (comma-separated, UUID values)"
 //	@Param			updated_by		query		string		false	"Updated by (comma-separated, UUID values)"
+//	@Param			category		query		int			false	"Category ID. Returns the articles in the category or one of its subcategories"
+//	@Param			If-None-Match	header		string		false	"ETag of the cached response, 304 is returned when it is not changed"
 //	@Success		200				{array}		params.ArticleVersionResponse
+//	@Header			200				{string}	Link	"RFC 8288 links to the first, prev, next and last pages"
+//	@Header			200				{string}	ETag	"Changes with the article, its versions, categories and tags, and with the query"
+//	@Success		304				{object}	object
 //	@Failure		400				{object}	errs.ValidationError
 //	@Failure		500				{object}	object
 //	@Router			/articles [get]
@@ -378,15 +509,32 @@ func (ah *ArticleHandler) GetArticlesHandler(w http.ResponseWriter, r *http.Requ
 	sortQueries := r.URL.Query()["sorts"]
 	limitQuery := r.URL.Query().Get("limit")
 	pageQuery := r.URL.Query().Get("page")
+	afterQuery := r.URL.Query().Get("after")
+	beforeQuery := r.URL.Query().Get("before")
 
 	limit, _ := strconv.Atoi(limitQuery)
 	page, _ := strconv.Atoi(pageQuery)
+	countMode, err := params.ParseCountMode(r.URL.Query().Get("count"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	renderHTML, err := params.ParseRenderOption(r.URL.Query().Get("render"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
 	queryParams := &params.GetArticlesQueryParams{
 		Search: searchQuery,
 		PaginationParams: params.PaginationParams{
-			Sorts: sortQueries,
-			Limit: limit,
-			Page:  page,
+			Sorts:     sortQueries,
+			Limit:     limit,
+			Page:      page,
+			After:     afterQuery,
+			Before:    beforeQuery,
+			CountMode: countMode,
 		},
 	}
 
@@ -418,16 +566,122 @@ func (ah *ArticleHandler) GetArticlesHandler(w http.ResponseWriter, r *http.Requ
 		queryParams.UpdatedBy = append(queryParams.UpdatedBy, updatedBy)
 	}
 
+	if categoryQuery := r.URL.Query().Get("category"); categoryQuery != "" {
+		queryParams.CategoryID, err = strconv.ParseInt(categoryQuery, 10, 64)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "category must be an integer"})
+			return
+		}
+	}
+
 	if err := queryParams.Validate(); err != nil {
 		sendErrorResponse(w, http.StatusBadRequest, err)
 		return
 	}
 
-	articles, err := ah.svc.GetArticles(r.Context(), *queryParams)
+	validator, err := ah.svc.GetArticlesCacheValidator(r.Context())
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	sendSuccessResponse(w, http.StatusOK, articles)
+	if notModified(w, r, *validator) {
+		return
+	}
+
+	articles, pagination, err := ah.svc.GetArticles(r.Context(), *queryParams)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := ah.prepareArticleVersions(r.Context(), renderHTML, articleVersionPointers(articles)...); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if link := getLinkHeader(r.URL, pagination); link != "" {
+		w.Header().Set("Link", link)
+	}
+	sendCacheableResponse(w, r, map[string]any{"data": articles, "pagination": pagination}, *validator)
+}
+
+// prepareArticleDetail adds the categories of the article and prepares its versions.
+func (ah *ArticleHandler) prepareArticleDetail(ctx context.Context, renderHTML bool, articleDetail *params.GetArticleDetailResponse) error {
+	categories, err := ah.categories.GetArticleCategories(ctx, articleDetail.ID)
+	if err != nil {
+		return err
+	}
+	articleDetail.Categories = categories
+
+	return ah.prepareArticleVersions(ctx, renderHTML, articleDetail.DraftedVersion, articleDetail.PublishedVersion, articleDetail.ArchivedVersion)
+}
+
+// prepareArticleVersions adds the media library images of the versions,
+// and fills body_html with the sanitized HTML of the body when renderHTML is true.
+func (ah *ArticleHandler) prepareArticleVersions(ctx context.Context, renderHTML bool, articleVersions ...*params.ArticleVersionResponse) error {
+	// the images of every version are loaded at once
+	var mediaIDs []int64
+	for _, articleVersion := range articleVersions {
+		if articleVersion != nil {
+			mediaIDs = append(mediaIDs, articleVersionMediaIDs(articleVersion)...)
+		}
+	}
+	slices.Sort(mediaIDs)
+	mediaIDs = slices.Compact(mediaIDs)
+
+	images, err := ah.media.GetImages(ctx, mediaIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, articleVersion := range articleVersions {
+		if articleVersion == nil {
+			continue
+		}
+
+		renderImages := map[int64]render.Image{}
+		for _, mediaID := range articleVersionMediaIDs(articleVersion) {
+			if image, ok := images[mediaID]; ok {
+				articleVersion.Images = append(articleVersion.Images, image)
+				renderImages[mediaID] = render.Image{Srcset: image.Srcset, Width: image.Width, Height: image.Height}
+			}
+		}
+
+		if !renderHTML {
+			continue
+		}
+
+		// blocks keep their ids and layout, which are lost in the markdown body
+		if articleVersion.Blocks != nil {
+			articleVersion.BodyHTML = render.BlocksHTML(*articleVersion.Blocks, renderImages)
+			continue
+		}
+
+		bodyHTML, err := render.HTML(articleVersion.BodyFormat, articleVersion.Body)
+		if err != nil {
+			return err
+		}
+		articleVersion.BodyHTML = bodyHTML
+	}
+
+	return nil
+}
+
+// articleVersionMediaIDs returns the media used by the blocks, or referenced by the body.
+func articleVersionMediaIDs(articleVersion *params.ArticleVersionResponse) []int64 {
+	if articleVersion.Blocks != nil {
+		return articleVersion.Blocks.MediaIDs()
+	}
+
+	return entity.MediaIDsFromBody(articleVersion.Body)
+}
+
+func articleVersionPointers(articleVersions []params.ArticleVersionResponse) []*params.ArticleVersionResponse {
+	pointers := make([]*params.ArticleVersionResponse, len(articleVersions))
+	for i := range articleVersions {
+		pointers[i] = &articleVersions[i]
+	}
+
+	return pointers
 }
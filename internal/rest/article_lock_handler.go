@@ -0,0 +1,238 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	ArticleLockService interface {
+		LockArticle(ctx context.Context, articleID int64) (*params.ArticleLockResponse, error)
+		UnlockArticle(ctx context.Context, articleID int64) error
+		BreakArticleLock(ctx context.Context, articleID int64) error
+		GetArticleLock(ctx context.Context, articleID int64) (*params.ArticleLockResponse, error)
+		WatchArticle(ctx context.Context, articleID int64) (*entity.PresenceSubscription, error)
+		UnwatchArticle(sub *entity.PresenceSubscription)
+		GetArticlePresence(ctx context.Context, articleID int64) (*entity.ArticlePresence, error)
+	}
+
+	ArticleLockHandler struct {
+		svc ArticleLockService
+	}
+)
+
+func parseArticleID(r *http.Request) (int64, error) {
+	articleID, err := strconv.ParseInt(chi.URLParam(r, "articleID"), 10, 64)
+	if err != nil {
+		return 0, errors.New("error when parsing articleID")
+	}
+
+	return articleID, nil
+}
+
+// LockArticleHandler
+//
+//	@Summary		Lock an article
+//	@Description	Take the edit lock of the article, only the holder of the lock creates new versions until it expires. The holder calls it again to renew the lock. An expired lock is taken over by the next user.
+//	@Tags			articles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Success		200				{object}	params.ArticleLockResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.Conflict
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/lock [post]
+func (lh *ArticleLockHandler) LockArticleHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	lock, err := lh.svc.LockArticle(r.Context(), articleID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, lock)
+}
+
+// UnlockArticleHandler
+//
+//	@Summary		Unlock an article
+//	@Description	Release the edit lock of the article which is held by the user
+//	@Tags			articles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/lock [delete]
+func (lh *ArticleLockHandler) UnlockArticleHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := lh.svc.UnlockArticle(r.Context(), articleID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// BreakArticleLockHandler
+//
+//	@Summary		Break the lock of an article
+//	@Description	Remove the edit lock of the article which is held by any user
+//	@Tags			articles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION BreakArticleLock. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/lock/force [delete]
+func (lh *ArticleLockHandler) BreakArticleLockHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := lh.svc.BreakArticleLock(r.Context(), articleID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// GetArticleLockHandler
+//
+//	@Summary		Get the lock of an article
+//	@Description	Get the active edit lock of the article
+//	@Tags			articles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Success		200				{object}	params.ArticleLockResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/lock [get]
+func (lh *ArticleLockHandler) GetArticleLockHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	lock, err := lh.svc.GetArticleLock(r.Context(), articleID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, lock)
+}
+
+// StreamArticlePresenceHandler
+//
+//	@Summary		Stream the presence of an article
+//	@Description	Stream who is viewing the article and who holds its edit lock as server-sent events. The user is a viewer while the stream is open. A presence event is sent right away and after every change, its data is params.ArticlePresenceResponse. A comment is sent as heartbeat and the stream ends before the timeout of the request, the client reconnects. The viewers are only seen by the streams on the same instance of the application.
+//	@Tags			articles
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Success		200				{string}	string	"stream of presence events"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/presence [get]
+func (lh *ArticleLockHandler) StreamArticlePresenceHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	sub, err := lh.svc.WatchArticle(r.Context(), articleID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer lh.svc.UnwatchArticle(sub)
+
+	sw := newSSEWriter(w, r)
+	var last []byte
+	writePresence := func(presence entity.ArticlePresence) error {
+		data, err := json.Marshal(params.NewArticlePresenceResponse(presence))
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(data, last) {
+			return sw.writeHeartbeat()
+		}
+		last = data
+		return sw.write("event: presence\ndata: %s\n\n", data)
+	}
+
+	if err := sw.start(); err != nil {
+		return
+	}
+
+	end, stop := sw.end(r)
+	defer stop()
+
+	ticker := time.NewTicker(sw.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-end:
+			return
+		case <-ticker.C:
+			// an expired lock has no notification, so the presence is checked with every heartbeat
+			presence, err := lh.svc.GetArticlePresence(r.Context(), articleID)
+			if err != nil {
+				err = sw.writeHeartbeat()
+			} else {
+				err = writePresence(*presence)
+			}
+			if err != nil {
+				return
+			}
+		case presence, ok := <-sub.Updates:
+			// the server is shutting down, the client reconnects to another instance
+			if !ok {
+				return
+			}
+			if err := writePresence(presence); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	CategoryService interface {
+		CreateCategory(ctx context.Context, req params.CategoryRequest) (*params.CategoryResponse, error)
+		GetCategories(ctx context.Context) ([]params.CategoryResponse, error)
+		GetCategory(ctx context.Context, id int64) (*params.CategoryResponse, error)
+		UpdateCategory(ctx context.Context, id int64, req params.CategoryRequest) (*params.CategoryResponse, error)
+		DeleteCategory(ctx context.Context, id int64) error
+		SetArticleCategories(ctx context.Context, articleID int64, req params.SetArticleCategoriesRequest) (*params.ArticleCategoriesResponse, error)
+		GetArticleCategories(ctx context.Context, articleID int64) (*params.ArticleCategoriesResponse, error)
+	}
+
+	CategoryHandler struct {
+		svc CategoryService
+	}
+)
+
+// CreateCategoryHandler
+//
+//	@Summary		Create a category
+//	@Description	Create a category. Without parent_id the category is a root category.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string					true	"MUST HAVE PERMISSION ManageCategory. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			body			body		params.CategoryRequest	true	"Create Category Request"
+//	@Success		201				{object}	params.CategoryResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.AlreadyExist
+//	@Failure		500				{object}	APIError
+//	@Router			/categories [post]
+func (ch *CategoryHandler) CreateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.CategoryRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	category, err := ch.svc.CreateCategory(r.Context(), body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusCreated, category)
+}
+
+// GetCategoriesHandler
+//
+//	@Summary		Get the category tree
+//	@Description	Get every category as a tree. The root categories and the subcategories are ordered by their position.
+//	@Tags			categories
+//	@Produce		json
+//	@Success		200	{array}		params.CategoryResponse
+//	@Failure		500	{object}	APIError
+//	@Router			/categories [get]
+func (ch *CategoryHandler) GetCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	categories, err := ch.svc.GetCategories(r.Context())
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, categories)
+}
+
+// GetCategoryHandler
+//
+//	@Summary		Get a category
+//	@Description	Get a category with its subcategories
+//	@Tags			categories
+//	@Produce		json
+//	@Param			categoryID	path		int	true	"Category ID"
+//	@Success		200			{object}	params.CategoryResponse
+//	@Failure		400			{object}	errs.ValidationError
+//	@Failure		404			{object}	errs.NotFound
+//	@Failure		500			{object}	APIError
+//	@Router			/categories/{categoryID} [get]
+func (ch *CategoryHandler) GetCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing categoryID"))
+		return
+	}
+
+	category, err := ch.svc.GetCategory(r.Context(), categoryID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, category)
+}
+
+// UpdateCategoryHandler
+//
+//	@Summary		Update a category
+//	@Description	Replace the name, slug, parent and position of the category. Changing the parent moves the category with its subcategories.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string					true	"MUST HAVE PERMISSION ManageCategory. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			categoryID		path		int						true	"Category ID"
+//	@Param			body			body		params.CategoryRequest	true	"Update Category Request"
+//	@Success		200				{object}	params.CategoryResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.AlreadyExist
+//	@Failure		500				{object}	APIError
+//	@Router			/categories/{categoryID} [put]
+func (ch *CategoryHandler) UpdateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing categoryID"))
+		return
+	}
+
+	body := params.CategoryRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	category, err := ch.svc.UpdateCategory(r.Context(), categoryID, body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, category)
+}
+
+// DeleteCategoryHandler
+//
+//	@Summary		Delete a category
+//	@Description	Delete a category. A category with subcategories or articles cannot be deleted.
+//	@Tags			categories
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageCategory. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			categoryID		path		int		true	"Category ID"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.Conflict
+//	@Failure		500				{object}	APIError
+//	@Router			/categories/{categoryID} [delete]
+func (ch *CategoryHandler) DeleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing categoryID"))
+		return
+	}
+
+	if err := ch.svc.DeleteCategory(r.Context(), categoryID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// SetArticleCategoriesHandler
+//
+//	@Summary		Set the categories of an article
+//	@Description	Replace the primary and the secondary categories of the article. An empty body removes every category.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string								true	"MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int									true	"Article ID"
+//	@Param			body			body		params.SetArticleCategoriesRequest	true	"Set Article Categories Request"
+//	@Success		200				{object}	params.ArticleCategoriesResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/categories [put]
+func (ch *CategoryHandler) SetArticleCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := strconv.ParseInt(chi.URLParam(r, "articleID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing articleID"))
+		return
+	}
+
+	body := params.SetArticleCategoriesRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	categories, err := ch.svc.SetArticleCategories(r.Context(), articleID, body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, categories)
+}
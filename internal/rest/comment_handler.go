@@ -0,0 +1,200 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	CommentService interface {
+		CreateComment(ctx context.Context, articleID, articleVersionID int64, req params.CreateArticleCommentRequest) (*params.ArticleCommentResponse, error)
+		GetComments(ctx context.Context, articleID, articleVersionID int64, req params.GetArticleCommentsRequest) ([]params.ArticleCommentThreadResponse, error)
+		ResolveComment(ctx context.Context, articleID, commentID int64) error
+		UnresolveComment(ctx context.Context, articleID, commentID int64) error
+	}
+
+	CommentHandler struct {
+		svc CommentService
+	}
+)
+
+func parseArticleVersionID(r *http.Request) (int64, int64, error) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	articleVersionID, err := strconv.ParseInt(chi.URLParam(r, "articleVersionID"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("error when parsing articleVersionID")
+	}
+
+	return articleID, articleVersionID, nil
+}
+
+func parseCommentID(r *http.Request) (int64, int64, error) {
+	articleID, err := parseArticleID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	commentID, err := strconv.ParseInt(chi.URLParam(r, "commentID"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("error when parsing commentID")
+	}
+
+	return articleID, commentID, nil
+}
+
+// CreateCommentHandler
+//
+//	@Summary		Comment on an article version
+//	@Description	Start a thread on the version, optionally anchored to a character range [start, end) of the title or the body, or reply to the first comment of a thread with parent_id. The mentions are the ids of the users with permission ReadDraftedAndArchivedArticle. The unresolved threads are carried to a new version created from this version, an anchor follows its text and is outdated when the text is removed.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization		header		string								true	"MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID			path		int									true	"Article ID"
+//	@Param			articleVersionID	path		int									true	"Article Version ID"
+//	@Param			body				body		params.CreateArticleCommentRequest	true	"Create Comment Request"
+//	@Success		201					{object}	params.ArticleCommentResponse
+//	@Failure		400					{object}	errs.ValidationError
+//	@Failure		404					{object}	errs.NotFound
+//	@Failure		500					{object}	APIError
+//	@Router			/articles/{articleID}/versions/{articleVersionID}/comments [post]
+func (ch *CommentHandler) CreateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, articleVersionID, err := parseArticleVersionID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.CreateArticleCommentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	comment, err := ch.svc.CreateComment(r.Context(), articleID, articleVersionID, body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusCreated, comment)
+}
+
+// GetCommentsHandler
+//
+//	@Summary		Get the comments of an article version
+//	@Description	Get the threads of the version in the order of their creation, every thread has its replies
+//	@Tags			comments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization		header		string	true	"MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID			path		int		true	"Article ID"
+//	@Param			articleVersionID	path		int		true	"Article Version ID"
+//	@Param			resolved			query		bool	false	"only the resolved threads when true, only the unresolved threads when false"
+//	@Success		200					{array}		params.ArticleCommentThreadResponse
+//	@Failure		400					{object}	errs.ValidationError
+//	@Failure		404					{object}	errs.NotFound
+//	@Failure		500					{object}	APIError
+//	@Router			/articles/{articleID}/versions/{articleVersionID}/comments [get]
+func (ch *CommentHandler) GetCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, articleVersionID, err := parseArticleVersionID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req := params.GetArticleCommentsRequest{}
+	if raw := r.URL.Query().Get("resolved"); raw != "" {
+		resolved, err := strconv.ParseBool(raw)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid resolved"})
+			return
+		}
+		req.Resolved = &resolved
+	}
+
+	threads, err := ch.svc.GetComments(r.Context(), articleID, articleVersionID, req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, threads)
+}
+
+// ResolveCommentHandler
+//
+//	@Summary		Resolve a comment thread
+//	@Description	Resolve the thread of its first comment, a resolved thread is not carried to a new version
+//	@Tags			comments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Param			commentID		path		int		true	"ID of the first comment of the thread"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/comments/{commentID}/resolve [put]
+func (ch *CommentHandler) ResolveCommentHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, commentID, err := parseCommentID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ch.svc.ResolveComment(r.Context(), articleID, commentID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// UnresolveCommentHandler
+//
+//	@Summary		Unresolve a comment thread
+//	@Description	Open the thread of its first comment again
+//	@Tags			comments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ReadDraftedAndArchivedArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			articleID		path		int		true	"Article ID"
+//	@Param			commentID		path		int		true	"ID of the first comment of the thread"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/articles/{articleID}/comments/{commentID}/resolve [delete]
+func (ch *CommentHandler) UnresolveCommentHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, commentID, err := parseCommentID(r)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ch.svc.UnresolveComment(r.Context(), articleID, commentID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
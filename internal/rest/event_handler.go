@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+)
+
+type (
+	EventStreamService interface {
+		Subscribe(ctx context.Context, lastEventID int64) *entity.EventSubscription
+		Unsubscribe(sub *entity.EventSubscription)
+	}
+
+	EventHandler struct {
+		svc EventStreamService
+	}
+)
+
+// StreamEventsHandler streams the changes of the articles and the tags as server-sent events.
+//
+//	@Summary		Stream Events
+//	@Description	Stream the committed changes of the articles and the tags as server-sent events, the event name is the event type and the data is the same as the data of the webhooks. article.created, version.created and version.archived are only sent to the users with permission ReadDraftedAndArchivedArticle. A reconnect with Last-Event-ID gets the events it missed, a reset event is sent when some of them are not kept anymore, then the content must be reloaded. A comment is sent as heartbeat and the stream ends before the timeout of the request, the client reconnects.
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			Last-Event-ID	header		int		false	"id of the last received event"
+//	@Success		200				{string}	string	"stream of events"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Router			/events [get]
+func (eh *EventHandler) StreamEventsHandler(w http.ResponseWriter, r *http.Request) {
+	var lastEventID int64
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		var err error
+		lastEventID, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || lastEventID < 0 {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid Last-Event-ID"})
+			return
+		}
+	}
+
+	sub := eh.svc.Subscribe(r.Context(), lastEventID)
+	defer eh.svc.Unsubscribe(sub)
+
+	sw := newSSEWriter(w, r)
+	writeEvent := func(event entity.OutboxEvent) error {
+		return sw.write("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Payload)
+	}
+
+	if err := sw.start(); err != nil {
+		return
+	}
+
+	if sub.Reset {
+		if err := sw.write("event: reset\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+
+	for _, event := range sub.Backlog {
+		if err := writeEvent(event); err != nil {
+			return
+		}
+	}
+
+	end, stop := sw.end(r)
+	defer stop()
+
+	ticker := time.NewTicker(sw.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-end:
+			return
+		case <-ticker.C:
+			if err := sw.writeHeartbeat(); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			// the stream was too slow, the client resumes with its last event
+			if !ok {
+				return
+			}
+			if err := writeEvent(event); err != nil {
+				return
+			}
+		}
+	}
+}
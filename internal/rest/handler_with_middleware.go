@@ -12,6 +12,14 @@ func NewHandlerWithMiddleware(
 	authService AuthService,
 	articleService ArticleService,
 	tagService TagService,
+	mediaService MediaService,
+	categoryService CategoryService,
+	jobService JobService,
+	webhookService WebhookService,
+	eventStreamService EventStreamService,
+	articleLockService ArticleLockService,
+	commentService CommentService,
+	notificationService NotificationService,
 ) {
 
 	authMiddleware := AuthMiddleware{
@@ -27,23 +35,85 @@ func NewHandlerWithMiddleware(
 	}
 
 	articleHandler := ArticleHandler{
-		svc: articleService,
+		svc:        articleService,
+		media:      mediaService,
+		categories: categoryService,
 	}
 
 	tagHandler := TagHandler{
 		svc: tagService,
 	}
 
+	mediaHandler := MediaHandler{
+		svc: mediaService,
+	}
+
+	categoryHandler := CategoryHandler{
+		svc: categoryService,
+	}
+
+	jobHandler := JobHandler{
+		svc: jobService,
+	}
+
+	webhookHandler := WebhookHandler{
+		svc: webhookService,
+	}
+
+	eventHandler := EventHandler{
+		svc: eventStreamService,
+	}
+
+	articleLockHandler := ArticleLockHandler{
+		svc: articleLockService,
+	}
+
+	commentHandler := CommentHandler{
+		svc: commentService,
+	}
+
+	notificationHandler := NotificationHandler{
+		svc: notificationService,
+	}
+
 	publicRoute.Group(func(r chi.Router) {
 		r.Use(authMiddleware.MustAuthMiddleware())
 		r.Get("/profile", profileHandler.ProfileUserHandler)
 
+		// every user reads only their own notifications
+		r.Get("/notifications", notificationHandler.GetNotificationsHandler)
+		r.Put("/notifications/read", notificationHandler.MarkAllNotificationsReadHandler)
+		r.Put("/notifications/{notificationID}/read", notificationHandler.MarkNotificationReadHandler)
+		r.Get("/notifications/preferences", notificationHandler.GetNotificationPreferenceHandler)
+		r.Put("/notifications/preferences", notificationHandler.UpdateNotificationPreferenceHandler)
+
+		r.Group(func(rEvents chi.Router) {
+			rEvents.Use(articleMiddleware.CanSeeDraftOrArchivedArticle())
+			rEvents.Get("/events", eventHandler.StreamEventsHandler)
+		})
+
+		// anyone who can read the drafts can review them
+		r.Group(func(rReadDraftPermission chi.Router) {
+			rReadDraftPermission.Use(authMiddleware.MustHavePermission(constanta.ReadDraftedAndArchivedArticle))
+			rReadDraftPermission.Get("/articles/{articleID}/versions/{articleVersionID}/comments", commentHandler.GetCommentsHandler)
+			rReadDraftPermission.Post("/articles/{articleID}/versions/{articleVersionID}/comments", commentHandler.CreateCommentHandler)
+			rReadDraftPermission.Put("/articles/{articleID}/comments/{commentID}/resolve", commentHandler.ResolveCommentHandler)
+			rReadDraftPermission.Delete("/articles/{articleID}/comments/{commentID}/resolve", commentHandler.UnresolveCommentHandler)
+			rReadDraftPermission.Get("/articles/{articleID}/presence", articleLockHandler.StreamArticlePresenceHandler)
+		})
+
 		r.Group(func(rCreateArticle chi.Router) {
 			rCreateArticle.Use(authMiddleware.MustHavePermission(sharevar.ContentWriter.GetPermissions()...))
 			rCreateArticle.Post("/articles", articleHandler.CreateArticleHandler)
 			rCreateArticle.Post("/articles/{articleID}", articleHandler.CreateNewArticleVersionWithReferenceFromArticleID)
 			rCreateArticle.Post("/articles/{articleID}/versions/{articleVersionID}", articleHandler.CreateNewArticleVersionWithReferenceFromArticleIDAndVersionID)
 			rCreateArticle.Post("/tags", tagHandler.CreateTagHandler)
+			rCreateArticle.Post("/media", mediaHandler.UploadMediaHandler)
+			rCreateArticle.Delete("/media/{mediaID}", mediaHandler.DeleteMediaHandler)
+			rCreateArticle.Put("/articles/{articleID}/categories", categoryHandler.SetArticleCategoriesHandler)
+			rCreateArticle.Post("/articles/{articleID}/lock", articleLockHandler.LockArticleHandler)
+			rCreateArticle.Get("/articles/{articleID}/lock", articleLockHandler.GetArticleLockHandler)
+			rCreateArticle.Delete("/articles/{articleID}/lock", articleLockHandler.UnlockArticleHandler)
 		})
 
 		r.Group(func(rDeletePermission chi.Router) {
@@ -55,16 +125,66 @@ func NewHandlerWithMiddleware(
 			rUpdateStatusPermission.Use(authMiddleware.MustHavePermission(constanta.UpdateStatusArticle))
 			rUpdateStatusPermission.Put("/articles/{articleID}/versions/{articleVersionID}/status", articleHandler.UpdateArticleStatusHandler)
 		})
+
+		r.Group(func(rBreakArticleLockPermission chi.Router) {
+			rBreakArticleLockPermission.Use(authMiddleware.MustHavePermission(constanta.BreakArticleLock))
+			rBreakArticleLockPermission.Delete("/articles/{articleID}/lock/force", articleLockHandler.BreakArticleLockHandler)
+		})
+
+		r.Group(func(rManageCategoryPermission chi.Router) {
+			rManageCategoryPermission.Use(authMiddleware.MustHavePermission(constanta.ManageCategory))
+			rManageCategoryPermission.Post("/categories", categoryHandler.CreateCategoryHandler)
+			rManageCategoryPermission.Put("/categories/{categoryID}", categoryHandler.UpdateCategoryHandler)
+			rManageCategoryPermission.Delete("/categories/{categoryID}", categoryHandler.DeleteCategoryHandler)
+		})
+
+		r.Group(func(rManageTagPermission chi.Router) {
+			rManageTagPermission.Use(authMiddleware.MustHavePermission(constanta.ManageTag))
+			rManageTagPermission.Post("/tags/merge", tagHandler.MergeTagsHandler)
+			rManageTagPermission.Put("/tags/{name}", tagHandler.UpdateTagHandler)
+			rManageTagPermission.Delete("/tags/{name}", tagHandler.DeleteTagHandler)
+			rManageTagPermission.Delete("/tags", tagHandler.DeleteUnusedTagsHandler)
+			rManageTagPermission.Post("/tags/{name}/synonyms", tagHandler.CreateTagSynonymsHandler)
+			rManageTagPermission.Delete("/tags/{name}/synonyms/{alias}", tagHandler.DeleteTagSynonymHandler)
+		})
+
+		r.Group(func(rManageJobPermission chi.Router) {
+			rManageJobPermission.Use(authMiddleware.MustHavePermission(constanta.ManageJob))
+			rManageJobPermission.Get("/admin/jobs", jobHandler.GetJobsHandler)
+		})
+
+		r.Group(func(rManageWebhookPermission chi.Router) {
+			rManageWebhookPermission.Use(authMiddleware.MustHavePermission(constanta.ManageWebhook))
+			rManageWebhookPermission.Post("/webhooks", webhookHandler.CreateWebhookHandler)
+			rManageWebhookPermission.Get("/webhooks", webhookHandler.GetWebhooksHandler)
+			rManageWebhookPermission.Get("/webhooks/{webhookID}", webhookHandler.GetWebhookHandler)
+			rManageWebhookPermission.Put("/webhooks/{webhookID}", webhookHandler.UpdateWebhookHandler)
+			rManageWebhookPermission.Delete("/webhooks/{webhookID}", webhookHandler.DeleteWebhookHandler)
+			rManageWebhookPermission.Post("/webhooks/{webhookID}/ping", webhookHandler.PingWebhookHandler)
+			rManageWebhookPermission.Get("/webhooks/{webhookID}/deliveries", webhookHandler.GetWebhookDeliveriesHandler)
+			rManageWebhookPermission.Post("/webhooks/deliveries/{deliveryID}/redeliver", webhookHandler.RedeliverWebhookDeliveryHandler)
+		})
 	})
 
 	publicRoute.Group(func(r chi.Router) {
 		r.Use(authMiddleware.OptionalAuthMiddleware())
 		r.Use(articleMiddleware.CanSeeDraftOrArchivedArticle())
+		r.Get("/articles/by-slug/{slug}", articleHandler.GetArticleBySlugHandler)
 		r.Get("/articles/{articleID}", articleHandler.GetArticleDetailHandler)
 		r.Get("/articles/{articleID}/versions", articleHandler.GetArticleVersionsHandler)
+		r.Get("/articles/{articleID}/related", tagHandler.GetRelatedArticlesHandler)
 		r.Get("/articles/{articleID}/versions/{articleVersionID}", articleHandler.GetArticleVersionWithIDAndArticleID)
 		r.Get("/articles", articleHandler.GetArticlesHandler)
 		r.Get("/tags", tagHandler.GetTagsHandler)
+		r.Get("/tags/suggest", tagHandler.SuggestTagsHandler)
+		r.Get("/tags/trending", tagHandler.GetTrendingTagsHandler)
 		r.Get("/tags/{name}", tagHandler.GetTagHandler)
+		r.Get("/tags/{name}/synonyms", tagHandler.GetTagSynonymsHandler)
+		r.Get("/tags/{name}/stats", tagHandler.GetTagStatsHandler)
 	})
+
+	publicRoute.Get("/categories", categoryHandler.GetCategoriesHandler)
+	publicRoute.Get("/categories/{categoryID}", categoryHandler.GetCategoryHandler)
+	publicRoute.Get("/media/{mediaID}", mediaHandler.GetMediaHandler)
+	publicRoute.Get("/media/{mediaID}/{preset}", mediaHandler.GetMediaDerivativeHandler)
 }
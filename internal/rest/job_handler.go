@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+)
+
+type (
+	JobService interface {
+		GetJobs(ctx context.Context, req params.GetJobsRequest) (*params.GetJobsResponse, error)
+	}
+
+	JobHandler struct {
+		svc JobService
+	}
+)
+
+// GetJobsHandler lists the background jobs.
+//
+//	@Summary		Get Jobs
+//	@Description	List the background jobs from the newest one with the number of jobs in every status. Use next_before_id as before to get the next page.
+//	@Tags			jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageJob. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			status			query		string	false	"pending, running, succeeded or dead"
+//	@Param			type			query		string	false	"job type, for example tag.calculate_stats"
+//	@Param			before			query		int		false	"id of the last job of the previous page"
+//	@Param			limit			query		int		false	"default 20, max 100"
+//	@Success		200				{object}	params.GetJobsResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
+//	@Router			/admin/jobs [get]
+func (jh *JobHandler) GetJobsHandler(w http.ResponseWriter, r *http.Request) {
+	req := params.GetJobsRequest{
+		Status: constanta.JobStatus(r.URL.Query().Get("status")),
+		Type:   constanta.JobType(r.URL.Query().Get("type")),
+	}
+
+	if before := r.URL.Query().Get("before"); before != "" {
+		var err error
+		req.BeforeID, err = strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid before"})
+			return
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, err := jh.svc.GetJobs(r.Context(), req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, jobs)
+}
@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	MediaService interface {
+		UploadMedia(ctx context.Context, fileName string, body io.Reader) (*params.MediaResponse, error)
+		GetMedia(ctx context.Context, id int64) (*params.MediaResponse, io.ReadSeekCloser, error)
+		DeleteMedia(ctx context.Context, id int64) error
+		GetMediaDerivative(ctx context.Context, id int64, preset string) (*params.MediaDerivativeResponse, io.ReadSeekCloser, error)
+		GetImages(ctx context.Context, ids []int64) (map[int64]params.ImageResponse, error)
+	}
+
+	MediaHandler struct {
+		svc MediaService
+	}
+)
+
+// UploadMediaHandler
+//
+//	@Summary		Upload media
+//	@Description	Upload an image into the media library. The type is detected from the content, only jpeg, png, gif and webp are accepted.
+//	@Tags			media
+//	@Accept			mpfd
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			file			formData	file	true	"Image file"
+//	@Success		201				{object}	params.MediaResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
+//	@Router			/media [post]
+func (mh *MediaHandler) UploadMediaHandler(w http.ResponseWriter, r *http.Request) {
+	// the parts are streamed, only the file part is buffered by the service, up to the max size
+	reader, err := r.MultipartReader()
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "body must be multipart/form-data"})
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "file is required"})
+			return
+		}
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		media, err := mh.svc.UploadMedia(r.Context(), part.FileName(), part)
+		part.Close()
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusCreated, media)
+		return
+	}
+}
+
+// GetMediaHandler
+//
+//	@Summary		Get media content
+//	@Description	Stream the content of the media. Range requests are supported.
+//	@Tags			media
+//	@Produce		image/jpeg,image/png,image/gif,image/webp
+//	@Param			mediaID	path		int		true	"Media ID"
+//	@Param			Range	header		string	false	"Byte range, for example bytes=0-1023"
+//	@Success		200		{file}		file
+//	@Success		206		{file}		file
+//	@Failure		404		{object}	errs.NotFound
+//	@Failure		416		{string}	string
+//	@Failure		500		{object}	APIError
+//	@Router			/media/{mediaID} [get]
+func (mh *MediaHandler) GetMediaHandler(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := strconv.ParseInt(chi.URLParam(r, "mediaID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing mediaID"))
+		return
+	}
+
+	media, content, err := mh.svc.GetMedia(r.Context(), mediaID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer content.Close()
+
+	// the content of a media never changes, the checksum is a strong validator
+	serveMediaContent(w, r, media.MimeType, `"`+media.Checksum+`"`, media.FileName, media.CreatedAt, content)
+}
+
+// GetMediaDerivativeHandler
+//
+//	@Summary		Get media derivative
+//	@Description	Stream a resized, cropped or converted version of the image, as the preset describes. The derivative is generated on the first request. Range requests are supported.
+//	@Tags			media
+//	@Produce		image/jpeg,image/png,image/webp
+//	@Param			mediaID	path		int		true	"Media ID"
+//	@Param			preset	path		string	true	"Preset name, for example thumbnail, small, medium, large or og"
+//	@Param			Range	header		string	false	"Byte range, for example bytes=0-1023"
+//	@Success		200		{file}		file
+//	@Success		206		{file}		file
+//	@Failure		404		{object}	errs.NotFound
+//	@Failure		500		{object}	APIError
+//	@Router			/media/{mediaID}/{preset} [get]
+func (mh *MediaHandler) GetMediaDerivativeHandler(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := strconv.ParseInt(chi.URLParam(r, "mediaID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing mediaID"))
+		return
+	}
+
+	derivative, content, err := mh.svc.GetMediaDerivative(r.Context(), mediaID, chi.URLParam(r, "preset"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer content.Close()
+
+	serveMediaContent(w, r, derivative.MimeType, derivative.ETag, derivative.FileName, derivative.CreatedAt, content)
+}
+
+func serveMediaContent(w http.ResponseWriter, r *http.Request, mimeType, etag, fileName string, modTime time.Time, content io.ReadSeeker) {
+	w.Header().Set("Content-Type", mimeType)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": fileName}))
+
+	http.ServeContent(w, r, "", modTime, content)
+}
+
+// DeleteMediaHandler
+//
+//	@Summary		Delete media
+//	@Description	Delete the media. Only the uploader can delete it, and only while no article version uses it.
+//	@Tags			media
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION CreateArticle. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			mediaID			path		int		true	"Media ID"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		403				{object}	errs.Forbidden
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.Conflict
+//	@Failure		500				{object}	APIError
+//	@Router			/media/{mediaID} [delete]
+func (mh *MediaHandler) DeleteMediaHandler(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := strconv.ParseInt(chi.URLParam(r, "mediaID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing mediaID"))
+		return
+	}
+
+	if err := mh.svc.DeleteMedia(r.Context(), mediaID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
@@ -0,0 +1,190 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	NotificationService interface {
+		GetNotifications(ctx context.Context, req params.GetNotificationsRequest) (*params.GetNotificationsResponse, error)
+		MarkNotificationRead(ctx context.Context, id int64) error
+		MarkAllNotificationsRead(ctx context.Context) (*params.MarkNotificationsReadResponse, error)
+		GetNotificationPreference(ctx context.Context) (*params.NotificationPreferenceResponse, error)
+		UpdateNotificationPreference(ctx context.Context, req params.NotificationPreferenceRequest) (*params.NotificationPreferenceResponse, error)
+	}
+
+	NotificationHandler struct {
+		svc NotificationService
+	}
+)
+
+// GetNotificationsHandler
+//
+//	@Summary		Get my notifications
+//	@Description	List the notifications of the user from the newest one. The writers of an article are notified when its version is published or archived, the writer of a version and the users in a thread are notified of a comment, and a mentioned user is notified of the mention. Use next_before_id as before to get the next page.
+//	@Tags			notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			unread			query		bool	false	"only the notifications which are not read"
+//	@Param			before			query		int		false	"id of the last notification of the previous page"
+//	@Param			limit			query		int		false	"default 20, max 100"
+//	@Success		200				{object}	params.GetNotificationsResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
+//	@Router			/notifications [get]
+func (nh *NotificationHandler) GetNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		req params.GetNotificationsRequest
+		err error
+	)
+
+	if unread := r.URL.Query().Get("unread"); unread != "" {
+		req.Unread, err = strconv.ParseBool(unread)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid unread"})
+			return
+		}
+	}
+
+	if before := r.URL.Query().Get("before"); before != "" {
+		req.BeforeID, err = strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid before"})
+			return
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	notifications, err := nh.svc.GetNotifications(r.Context(), req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, notifications)
+}
+
+// MarkNotificationReadHandler
+//
+//	@Summary	Mark a notification as read
+//	@Tags		notifications
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Authorization	header		string	true	"Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param		notificationID	path		int		true	"Notification ID"
+//	@Success	200				{string}	string	"ok"
+//	@Failure	400				{object}	errs.ValidationError
+//	@Failure	404				{object}	errs.NotFound
+//	@Failure	500				{object}	APIError
+//	@Router		/notifications/{notificationID}/read [put]
+func (nh *NotificationHandler) MarkNotificationReadHandler(w http.ResponseWriter, r *http.Request) {
+	notificationID, err := strconv.ParseInt(chi.URLParam(r, "notificationID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing notificationID"))
+		return
+	}
+
+	if err := nh.svc.MarkNotificationRead(r.Context(), notificationID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// MarkAllNotificationsReadHandler
+//
+//	@Summary	Mark every notification as read
+//	@Tags		notifications
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Authorization	header		string	true	"Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Success	200				{object}	params.MarkNotificationsReadResponse
+//	@Failure	500				{object}	APIError
+//	@Router		/notifications/read [put]
+func (nh *NotificationHandler) MarkAllNotificationsReadHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := nh.svc.MarkAllNotificationsRead(r.Context())
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, res)
+}
+
+// GetNotificationPreferenceHandler
+//
+//	@Summary		Get my notification preferences
+//	@Description	A user who has not saved the preferences gets every notification in the app only
+//	@Tags			notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Success		200				{object}	params.NotificationPreferenceResponse
+//	@Failure		500				{object}	APIError
+//	@Router			/notifications/preferences [get]
+func (nh *NotificationHandler) GetNotificationPreferenceHandler(w http.ResponseWriter, r *http.Request) {
+	preference, err := nh.svc.GetNotificationPreference(r.Context())
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, preference)
+}
+
+// UpdateNotificationPreferenceHandler
+//
+//	@Summary		Update my notification preferences
+//	@Description	Choose in_app to only see the notifications in the app, or email to also get them by email. The notifications of the muted types are not created. The email delivery can only be chosen when email_available is true.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string									true	"Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			body			body		params.NotificationPreferenceRequest	true	"Notification Preference Request"
+//	@Success		200				{object}	params.NotificationPreferenceResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
+//	@Router			/notifications/preferences [put]
+func (nh *NotificationHandler) UpdateNotificationPreferenceHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.NotificationPreferenceRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	preference, err := nh.svc.UpdateNotificationPreference(r.Context(), body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, preference)
+}
@@ -1,12 +1,23 @@
 package rest
 
 import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
 	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
 )
 
 func sendSuccessResponse(w http.ResponseWriter, status int, res any) {
@@ -21,6 +32,146 @@ func sendSuccessResponse(w http.ResponseWriter, status int, res any) {
 	json.NewEncoder(w).Encode(map[string]any{"data": res})
 }
 
+// sendCSVResponse sends the records as a csv attachment.
+func sendCSVResponse(w http.ResponseWriter, status int, fileName string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(status)
+
+	csv.NewWriter(w).WriteAll(records)
+}
+
+func sendListResponse(w http.ResponseWriter, r *http.Request, status int, res any, pagination *params.PaginationResponse) {
+	if link := getLinkHeader(r.URL, pagination); link != "" {
+		w.Header().Set("Link", link)
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(map[string]any{"data": res, "pagination": pagination})
+}
+
+const (
+	// anonymous reads only see published articles, the cdn keeps them longer because it is purged on publish
+	publicCacheControl = "public, max-age=60, s-maxage=300"
+	// signed in reads can contain drafts, the browser revalidates them with the etag
+	privateCacheControl = "private, no-cache"
+)
+
+// setCacheHeaders sets the etag of the validator and a Cache-Control for the principal.
+// The etag also covers the query and the permission of the user, because they change the read of the same validator.
+func setCacheHeaders(w http.ResponseWriter, r *http.Request, validator entity.CacheValidator) string {
+	canReadDraftedAndArchivedArticle, _ := r.Context().Value(constanta.LocalUserCanReadDraftedAndArchivedArticle).(bool)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%t|%s", validator.Key, canReadDraftedAndArchivedArticle, r.URL.RawQuery)))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Vary", "Authorization")
+	if r.Context().Value(constanta.LocalUserID) != nil {
+		w.Header().Set("Cache-Control", privateCacheControl)
+	} else {
+		w.Header().Set("Cache-Control", publicCacheControl)
+	}
+	if !validator.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", validator.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	return etag
+}
+
+// notModified sends 304 without body when the request already has the read of the validator,
+// it is checked before the read is built.
+func notModified(w http.ResponseWriter, r *http.Request, validator entity.CacheValidator) bool {
+	etag := setCacheHeaders(w, r, validator)
+	if !isNotModified(r, etag, validator.LastModified) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// sendCacheableResponse sends the read with the etag of its validator.
+func sendCacheableResponse(w http.ResponseWriter, r *http.Request, res map[string]any, validator entity.CacheValidator) {
+	setCacheHeaders(w, r, validator)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// isNotModified checks If-None-Match first, If-Modified-Since is only used without it.
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	// the header only has second precision
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// getLinkHeader builds the RFC 8288 Link header for the list response.
+// Cursor links are preferred, page links are used when the request is page based.
+func getLinkHeader(reqURL *url.URL, pagination *params.PaginationResponse) string {
+	if pagination == nil {
+		return ""
+	}
+
+	link := func(rel string, set map[string]string) string {
+		query := reqURL.Query()
+		for _, key := range []string{"page", "after", "before"} {
+			query.Del(key)
+		}
+		for key, val := range set {
+			query.Set(key, val)
+		}
+
+		target := url.URL{Path: reqURL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf("<%s>; rel=\"%s\"", target.String(), rel)
+	}
+
+	var links []string
+	if pagination.Page == 0 {
+		links = append(links, link("first", nil))
+		if pagination.PrevCursor != "" {
+			links = append(links, link("prev", map[string]string{"before": pagination.PrevCursor}))
+		}
+		if pagination.NextCursor != "" {
+			links = append(links, link("next", map[string]string{"after": pagination.NextCursor}))
+		}
+		return strings.Join(links, ", ")
+	}
+
+	links = append(links, link("first", map[string]string{"page": "1"}))
+	if pagination.Page > 1 {
+		links = append(links, link("prev", map[string]string{"page": strconv.Itoa(pagination.Page - 1)}))
+	}
+	if pagination.HasNext {
+		links = append(links, link("next", map[string]string{"page": strconv.Itoa(pagination.Page + 1)}))
+	}
+	if !pagination.TotalEstimated && pagination.Total > 0 && pagination.Limit > 0 {
+		lastPage := (pagination.Total + int64(pagination.Limit) - 1) / int64(pagination.Limit)
+		links = append(links, link("last", map[string]string{"page": strconv.FormatInt(lastPage, 10)}))
+	}
+
+	return strings.Join(links, ", ")
+}
+
 type APIError struct {
 	Message string `json:"error"`
 }
@@ -40,6 +191,14 @@ func sendErrorResponse(w http.ResponseWriter, status int, err error) {
 		slog.Error("handler", "service", err.Error())
 		status = errs.NotFound{}.HttpStatusCode()
 		apiErr.Message = err.Error()
+	case errors.As(err, &errs.Conflict{}):
+		slog.Error("handler", "service", err.Error())
+		status = errs.Conflict{}.HttpStatusCode()
+		apiErr.Message = err.Error()
+	case errors.As(err, &errs.Forbidden{}):
+		slog.Error("handler", "service", err.Error())
+		status = errs.Forbidden{}.HttpStatusCode()
+		apiErr.Message = err.Error()
 	case errors.As(err, &errs.ValidationError{}):
 		slog.Error("handler", "request", err.Error())
 		status = errs.ValidationError{}.HttpStatusCode()
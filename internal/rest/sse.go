@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	eventStreamHeartbeat = 15 * time.Second
+	// the browser reconnects after this delay when the stream ends
+	eventStreamRetry = 3 * time.Second
+)
+
+// sseWriter writes a stream of server-sent events. Every write moves the write deadline of the server,
+// and the heartbeat comes before the deadline.
+type sseWriter struct {
+	w            http.ResponseWriter
+	rc           *http.ResponseController
+	writeTimeout time.Duration
+	heartbeat    time.Duration
+}
+
+func newSSEWriter(w http.ResponseWriter, r *http.Request) *sseWriter {
+	sw := &sseWriter{
+		w:         w,
+		rc:        http.NewResponseController(w),
+		heartbeat: eventStreamHeartbeat,
+	}
+
+	if srv, ok := r.Context().Value(http.ServerContextKey).(*http.Server); ok && srv.WriteTimeout > 0 {
+		sw.writeTimeout = srv.WriteTimeout
+		sw.heartbeat = min(sw.heartbeat, sw.writeTimeout/2)
+	}
+
+	return sw
+}
+
+// start sends the headers of the stream and the reconnect delay.
+func (sw *sseWriter) start() error {
+	sw.w.Header().Set("Content-Type", "text/event-stream")
+	sw.w.Header().Set("Cache-Control", "no-cache")
+	sw.w.Header().Set("Connection", "keep-alive")
+	// the proxies must not buffer the stream
+	sw.w.Header().Set("X-Accel-Buffering", "no")
+
+	return sw.write("retry: %d\n\n", eventStreamRetry.Milliseconds())
+}
+
+func (sw *sseWriter) write(format string, args ...any) error {
+	if sw.writeTimeout > 0 {
+		if err := sw.rc.SetWriteDeadline(time.Now().Add(sw.writeTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(sw.w, format, args...); err != nil {
+		return err
+	}
+
+	return sw.rc.Flush()
+}
+
+func (sw *sseWriter) writeHeartbeat() error {
+	return sw.write(": heartbeat\n\n")
+}
+
+// end fires before the timeout of the request, so the client reconnects instead of getting an error.
+// It is nil when the request has no deadline.
+func (sw *sseWriter) end(r *http.Request) (<-chan time.Time, func()) {
+	deadline, ok := r.Context().Deadline()
+	if !ok {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(time.Until(deadline) - sw.heartbeat)
+	return timer.C, func() { timer.Stop() }
+}
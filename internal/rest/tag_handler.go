@@ -3,9 +3,14 @@ package rest
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/elangreza/content-management-system/internal/constanta"
 	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/elangreza/content-management-system/internal/params"
 	"github.com/go-chi/chi/v5"
@@ -14,8 +19,19 @@ import (
 type (
 	TagService interface {
 		CreateTag(ctx context.Context, tagNames ...string) error
-		GetTags(ctx context.Context, req params.GetTagsRequest) ([]params.GetTagResponse, error)
+		GetTags(ctx context.Context, req params.GetTagsRequest) ([]params.GetTagResponse, *params.PaginationResponse, error)
 		GetTag(ctx context.Context, tagName string) (*params.GetTagResponse, error)
+		UpdateTag(ctx context.Context, tagName string, req params.UpdateTagRequest) (*params.GetTagResponse, error)
+		MergeTags(ctx context.Context, req params.MergeTagsRequest) (*params.GetTagResponse, error)
+		DeleteTag(ctx context.Context, tagName string) error
+		DeleteUnusedTags(ctx context.Context) (*params.DeleteUnusedTagsResponse, error)
+		GetTagSynonyms(ctx context.Context, tagName string) ([]params.TagSynonymResponse, error)
+		CreateTagSynonyms(ctx context.Context, tagName string, req params.CreateTagSynonymsRequest) ([]params.TagSynonymResponse, error)
+		DeleteTagSynonym(ctx context.Context, tagName, alias string) error
+		SuggestTags(ctx context.Context, req params.GetTagSuggestionsRequest) ([]params.TagSuggestionResponse, error)
+		GetTrendingTags(ctx context.Context, req params.GetTrendingTagsRequest) ([]params.TrendingTagResponse, error)
+		GetTagStats(ctx context.Context, tagName string, req params.GetTagStatsRequest) (*params.TagStatsResponse, error)
+		GetRelatedArticles(ctx context.Context, articleID int64, limit int) ([]params.RelatedArticleResponse, error)
 	}
 
 	TagHandler struct {
@@ -26,7 +42,7 @@ type (
 // CreateTagHandler creates a new tag.
 //
 //	@Summary		Create Tag
-//	@Description	Create a new tag with the provided names.
+//	@Description	Create a new tag with the provided names. The names are normalized and the aliases are replaced with their canonical tag.
 //	@Tags			Tags
 //	@Accept			json
 //	@Produce		json
@@ -69,7 +85,11 @@ func (ah *TagHandler) CreateTagHandler(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Param			Authorization	header		string	false	"Fill with bearer and token. The token can be accessed via api /auth/login. If authorization is not provided, the default behavior is showing only published articles. Otherwise if the token is appered and user habe a permission to read drafted and archiver article, the token can be used to accessing draft, published, and archived articles. "
 //	@Param			sort			query		string	false	"Sort by usage_count:asc | usage_count:desc | trending_score:asc | trending_score:desc | name:asc | name:desc | last_used:asc | last_used:desc"
+//	@Param			limit			query		int		false	"Limit. All tags are returned when limit, after and before are empty"
+//	@Param			after			query		string	false	"Cursor from pagination.next_cursor"
+//	@Param			before			query		string	false	"Cursor from pagination.prev_cursor"
 //	@Success		200				{array}		params.GetTagResponse
+//	@Header			200				{string}	Link	"RFC 8288 links to the first, prev and next pages"
 //	@Failure		400				{object}	errs.ValidationError
 //	@Failure		500				{object}	APIError
 //	@Router			/tags [get]
@@ -97,12 +117,107 @@ func (ah *TagHandler) GetTagsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
 	req := params.GetTagsRequest{
 		SortValue: sortValue[0],
 		Direction: sortValue[1],
+		Limit:     limit,
+		After:     r.URL.Query().Get("after"),
+		Before:    r.URL.Query().Get("before"),
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tags, pagination, err := ah.svc.GetTags(r.Context(), req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendListResponse(w, r, http.StatusOK, tags, pagination)
+}
+
+// SuggestTagsHandler suggests tags for an article being edited.
+//
+//	@Summary		Suggest Tags
+//	@Description	Rank the used tags by how often they occur with the current tags of the article, how well they match the typed prefix and their trending score. Without q the related and trending tags are suggested.
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			tags	query		string	false	"comma separated current tags of the article, for example go,cms"
+//	@Param			q		query		string	false	"prefix of the tag being typed"
+//	@Param			limit	query		int		false	"default 10, max 50"
+//	@Success		200		{array}		params.TagSuggestionResponse
+//	@Failure		400		{object}	errs.ValidationError
+//	@Failure		500		{object}	APIError
+//	@Router			/tags/suggest [get]
+func (ah *TagHandler) SuggestTagsHandler(w http.ResponseWriter, r *http.Request) {
+	req := params.GetTagSuggestionsRequest{
+		Query: r.URL.Query().Get("q"),
+	}
+
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		req.Tags = strings.Split(tags, ",")
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	suggestions, err := ah.svc.SuggestTags(r.Context(), req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
 	}
 
-	tags, err := ah.svc.GetTags(r.Context(), req)
+	sendSuccessResponse(w, http.StatusOK, suggestions)
+}
+
+// GetTrendingTagsHandler lists the trending tags.
+//
+//	@Summary		Get Trending Tags
+//	@Description	List the tags published in the window ordered by their trending score. Every publish adds a use on its day and the older days decay with the configured half life.
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			window	query		string	false	"window in days, for example 7d. default TAG_TRENDING_WINDOW, max 90d"
+//	@Param			limit	query		int		false	"default 10, max 100"
+//	@Success		200		{array}		params.TrendingTagResponse
+//	@Failure		400		{object}	errs.ValidationError
+//	@Failure		500		{object}	APIError
+//	@Router			/tags/trending [get]
+func (ah *TagHandler) GetTrendingTagsHandler(w http.ResponseWriter, r *http.Request) {
+	req := params.GetTrendingTagsRequest{
+		Window: r.URL.Query().Get("window"),
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tags, err := ah.svc.GetTrendingTags(r.Context(), req)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
 		return
@@ -114,7 +229,7 @@ func (ah *TagHandler) GetTagsHandler(w http.ResponseWriter, r *http.Request) {
 // GetTagHandler retrieves a specific tag by name.
 //
 //	@Summary		Get Tag
-//	@Description	Retrieve a specific tag by name.
+//	@Description	Retrieve a specific tag by name. A name which is not found is normalized and its alias is resolved, so Golang finds the tag go when golang is its alias.
 //	@Tags			Tags
 //	@Accept			json
 //	@Produce		json
@@ -145,3 +260,359 @@ func (ah *TagHandler) GetTagHandler(w http.ResponseWriter, r *http.Request) {
 
 	sendSuccessResponse(w, http.StatusOK, tags)
 }
+
+// GetTagStatsHandler returns the usage statistics of a tag.
+//
+//	@Summary		Get Tag Statistics
+//	@Description	Count the versions using the tag by the day they were first published, per day, week or month, with the tags used together with it and the authors using it the most in the range. Set format=csv or the Accept header text/csv to download the statistics as csv.
+//	@Tags			Tags
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			name		path		string	true	"Tag name"
+//	@Param			from		query		string	false	"first day, for example 2025-08-01. default 30 days before to"
+//	@Param			to			query		string	false	"last day, for example 2025-08-31. default today"
+//	@Param			interval	query		string	false	"day, week or month. default day"
+//	@Param			limit		query		int		false	"number of the co-occurring tags and the authors, default 10, max 50"
+//	@Param			format		query		string	false	"json or csv. default json"
+//	@Success		200			{object}	params.TagStatsResponse
+//	@Failure		400			{object}	errs.ValidationError
+//	@Failure		404			{object}	errs.NotFound
+//	@Failure		500			{object}	APIError
+//	@Router			/tags/{name}/stats [get]
+func (ah *TagHandler) GetTagStatsHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(chi.URLParam(r, "name"))
+	if name == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "tag name is required"})
+		return
+	}
+
+	query := r.URL.Query()
+	req := params.GetTagStatsRequest{
+		Interval: constanta.TagStatsInterval(query.Get("interval")),
+	}
+
+	var err error
+	if from := query.Get("from"); from != "" {
+		req.From, err = time.Parse(time.DateOnly, from)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "from must be a date, for example 2025-08-01"})
+			return
+		}
+	}
+
+	if to := query.Get("to"); to != "" {
+		req.To, err = time.Parse(time.DateOnly, to)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "to must be a date, for example 2025-08-31"})
+			return
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	format := query.Get("format")
+	if format == "" && strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		format = "csv"
+	}
+	if format != "" && format != "json" && format != "csv" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "format must be json or csv"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := ah.svc.GetTagStats(r.Context(), name, req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format == "csv" {
+		fileName := fmt.Sprintf("tag-%s-stats-%s-%s.csv", stats.Name, stats.From.Format(time.DateOnly), stats.To.Format(time.DateOnly))
+		sendCSVResponse(w, http.StatusOK, fileName, stats.CSVRecords())
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, stats)
+}
+
+// UpdateTagHandler renames a tag and updates its metadata.
+//
+//	@Summary		Update Tag
+//	@Description	Update the display name, description, color and slug of the tag. A different name renames the tag in every article version.
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string					true	"MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			name			path		string					true	"Tag name"
+//	@Param			body			body		params.UpdateTagRequest	true	"Update Tag Request"
+//	@Success		200				{object}	params.GetTagResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.AlreadyExist
+//	@Failure		500				{object}	APIError
+//	@Router			/tags/{name} [put]
+func (ah *TagHandler) UpdateTagHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(chi.URLParam(r, "name"))
+	if name == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "tag name is required"})
+		return
+	}
+
+	body := params.UpdateTagRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := ah.svc.UpdateTag(r.Context(), name, body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, tag)
+}
+
+// MergeTagsHandler merges tags into one tag.
+//
+//	@Summary		Merge Tags
+//	@Description	Replace the source tags with the target tag in every article version, then delete the source tags. The source tags become synonyms of the target tag. The target tag is created when it does not exist.
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string					true	"MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			body			body		params.MergeTagsRequest	true	"Merge Tags Request"
+//	@Success		200				{object}	params.GetTagResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/tags/merge [post]
+func (ah *TagHandler) MergeTagsHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.MergeTagsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := ah.svc.MergeTags(r.Context(), body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, tag)
+}
+
+// DeleteTagHandler deletes an unused tag.
+//
+//	@Summary		Delete Tag
+//	@Description	Delete a tag which is not used by any article version.
+//	@Tags			Tags
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			name			path		string	true	"Tag name"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.Conflict
+//	@Failure		500				{object}	APIError
+//	@Router			/tags/{name} [delete]
+func (ah *TagHandler) DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(chi.URLParam(r, "name"))
+	if name == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "tag name is required"})
+		return
+	}
+
+	if err := ah.svc.DeleteTag(r.Context(), name); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// DeleteUnusedTagsHandler deletes every unused tag.
+//
+//	@Summary		Delete Unused Tags
+//	@Description	Delete every tag which is not used by any article version.
+//	@Tags			Tags
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Success		200				{object}	params.DeleteUnusedTagsResponse
+//	@Failure		500				{object}	APIError
+//	@Router			/tags [delete]
+func (ah *TagHandler) DeleteUnusedTagsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := ah.svc.DeleteUnusedTags(r.Context())
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, res)
+}
+
+// GetTagSynonymsHandler retrieves the synonyms of a tag.
+//
+//	@Summary		Get Tag Synonyms
+//	@Description	Retrieve the aliases which are replaced with the tag when they are written.
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			name	path		string	true	"Tag name"
+//	@Success		200		{array}		params.TagSynonymResponse
+//	@Failure		400		{object}	errs.ValidationError
+//	@Failure		404		{object}	errs.NotFound
+//	@Failure		500		{object}	APIError
+//	@Router			/tags/{name}/synonyms [get]
+func (ah *TagHandler) GetTagSynonymsHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(chi.URLParam(r, "name"))
+	if name == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "tag name is required"})
+		return
+	}
+
+	synonyms, err := ah.svc.GetTagSynonyms(r.Context(), name)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, synonyms)
+}
+
+// CreateTagSynonymsHandler adds synonyms to a tag.
+//
+//	@Summary		Create Tag Synonyms
+//	@Description	Map the aliases to the tag. The aliases are normalized, an existing tag cannot become an alias and must be merged instead.
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string							true	"MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			name			path		string							true	"Tag name"
+//	@Param			body			body		params.CreateTagSynonymsRequest	true	"Create Tag Synonyms Request"
+//	@Success		201				{array}		params.TagSynonymResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		409				{object}	errs.AlreadyExist
+//	@Failure		500				{object}	APIError
+//	@Router			/tags/{name}/synonyms [post]
+func (ah *TagHandler) CreateTagSynonymsHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(chi.URLParam(r, "name"))
+	if name == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "tag name is required"})
+		return
+	}
+
+	body := params.CreateTagSynonymsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	synonyms, err := ah.svc.CreateTagSynonyms(r.Context(), name, body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusCreated, synonyms)
+}
+
+// DeleteTagSynonymHandler deletes a synonym of a tag.
+//
+//	@Summary		Delete Tag Synonym
+//	@Description	Delete the alias of the tag. The tags which are already written are kept.
+//	@Tags			Tags
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageTag. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			name			path		string	true	"Tag name"
+//	@Param			alias			path		string	true	"Alias"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/tags/{name}/synonyms/{alias} [delete]
+func (ah *TagHandler) DeleteTagSynonymHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(chi.URLParam(r, "name"))
+	alias := strings.TrimSpace(chi.URLParam(r, "alias"))
+	if name == "" || alias == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "tag name and alias are required"})
+		return
+	}
+
+	if err := ah.svc.DeleteTagSynonym(r.Context(), name, alias); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// GetRelatedArticlesHandler retrieves the published articles related to an article.
+//
+//	@Summary		Get Related Articles
+//	@Description	Rank the other published articles by the tags they share with the article, weighted by how rare the tags are, then by their recency and their tag relationship score. The ranking is refreshed every 10 seconds.
+//	@Tags			articles
+//	@Produce		json
+//	@Param			articleID	path		int	true	"Article ID"
+//	@Param			limit		query		int	false	"default 5, max 20"
+//	@Success		200			{array}		params.RelatedArticleResponse
+//	@Failure		400			{object}	errs.ValidationError
+//	@Failure		404			{object}	errs.NotFound
+//	@Failure		500			{object}	APIError
+//	@Router			/articles/{articleID}/related [get]
+func (ah *TagHandler) GetRelatedArticlesHandler(w http.ResponseWriter, r *http.Request) {
+	articleID, err := strconv.ParseInt(chi.URLParam(r, "articleID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing articleID"))
+		return
+	}
+
+	limit := 5
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		limit, err = strconv.Atoi(rawLimit)
+		if err != nil || limit < 1 || limit > 20 {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "limit must be between 1 and 20"})
+			return
+		}
+	}
+
+	related, err := ah.svc.GetRelatedArticles(r.Context(), articleID, limit)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, related)
+}
@@ -0,0 +1,307 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	WebhookService interface {
+		CreateWebhook(ctx context.Context, req params.WebhookRequest) (*params.WebhookResponse, error)
+		GetWebhooks(ctx context.Context) ([]params.WebhookResponse, error)
+		GetWebhook(ctx context.Context, id int64) (*params.WebhookResponse, error)
+		UpdateWebhook(ctx context.Context, id int64, req params.WebhookRequest) (*params.WebhookResponse, error)
+		DeleteWebhook(ctx context.Context, id int64) error
+		PingWebhook(ctx context.Context, id int64) (*params.WebhookDeliveryResponse, error)
+		GetWebhookDeliveries(ctx context.Context, webhookID int64, req params.GetWebhookDeliveriesRequest) (*params.GetWebhookDeliveriesResponse, error)
+		RedeliverWebhookDelivery(ctx context.Context, deliveryID int64) (*params.WebhookDeliveryResponse, error)
+	}
+
+	WebhookHandler struct {
+		svc WebhookService
+	}
+)
+
+// CreateWebhookHandler
+//
+//	@Summary		Create a webhook
+//	@Description	Subscribe a url to the events: article.created, version.created, version.published, version.archived, article.deleted, tag.created and comment.created. Without events every event is sent. Every delivery is signed with the secret in the X-Webhook-Signature header as sha256={hex of HMAC-SHA256 of "{X-Webhook-Timestamp}.{body}"}. A random secret is generated when it is empty, it is only shown in this response.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string					true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			body			body		params.WebhookRequest	true	"Create Webhook Request"
+//	@Success		201				{object}	params.WebhookResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks [post]
+func (wh *WebhookHandler) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.WebhookRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := wh.svc.CreateWebhook(r.Context(), body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusCreated, webhook)
+}
+
+// GetWebhooksHandler
+//
+//	@Summary		Get webhooks
+//	@Description	List every webhook without its secret
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Success		200				{array}		params.WebhookResponse
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks [get]
+func (wh *WebhookHandler) GetWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := wh.svc.GetWebhooks(r.Context())
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, webhooks)
+}
+
+// GetWebhookHandler
+//
+//	@Summary		Get a webhook
+//	@Description	Get a webhook without its secret
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			webhookID		path		int		true	"Webhook ID"
+//	@Success		200				{object}	params.WebhookResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks/{webhookID} [get]
+func (wh *WebhookHandler) GetWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing webhookID"))
+		return
+	}
+
+	webhook, err := wh.svc.GetWebhook(r.Context(), webhookID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, webhook)
+}
+
+// UpdateWebhookHandler
+//
+//	@Summary		Update a webhook
+//	@Description	Replace the url, events and active of the webhook. The secret is kept when it is empty. A disabled webhook does not get new deliveries, it can still be pinged and redelivered.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string					true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			webhookID		path		int						true	"Webhook ID"
+//	@Param			body			body		params.WebhookRequest	true	"Update Webhook Request"
+//	@Success		200				{object}	params.WebhookResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks/{webhookID} [put]
+func (wh *WebhookHandler) UpdateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing webhookID"))
+		return
+	}
+
+	body := params.WebhookRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := wh.svc.UpdateWebhook(r.Context(), webhookID, body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, webhook)
+}
+
+// DeleteWebhookHandler
+//
+//	@Summary		Delete a webhook
+//	@Description	Delete a webhook with its deliveries
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			webhookID		path		int		true	"Webhook ID"
+//	@Success		200				{string}	string	"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks/{webhookID} [delete]
+func (wh *WebhookHandler) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing webhookID"))
+		return
+	}
+
+	if err := wh.svc.DeleteWebhook(r.Context(), webhookID); err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
+
+// PingWebhookHandler
+//
+//	@Summary		Ping a webhook
+//	@Description	Send a ping event to the webhook, the result is shown in its deliveries. It can be used to test a local receiver.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			webhookID		path		int		true	"Webhook ID"
+//	@Success		202				{object}	params.WebhookDeliveryResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks/{webhookID}/ping [post]
+func (wh *WebhookHandler) PingWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing webhookID"))
+		return
+	}
+
+	delivery, err := wh.svc.PingWebhook(r.Context(), webhookID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusAccepted, delivery)
+}
+
+// GetWebhookDeliveriesHandler
+//
+//	@Summary		Get webhook deliveries
+//	@Description	List the deliveries of the webhook from the newest one with the result of their last attempt. Use next_before_id as before to get the next page.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			webhookID		path		int		true	"Webhook ID"
+//	@Param			status			query		string	false	"pending, succeeded or failed"
+//	@Param			before			query		int		false	"id of the last delivery of the previous page"
+//	@Param			limit			query		int		false	"default 20, max 100"
+//	@Success		200				{object}	params.GetWebhookDeliveriesResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks/{webhookID}/deliveries [get]
+func (wh *WebhookHandler) GetWebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing webhookID"))
+		return
+	}
+
+	req := params.GetWebhookDeliveriesRequest{
+		Status: constanta.WebhookDeliveryStatus(r.URL.Query().Get("status")),
+	}
+
+	if before := r.URL.Query().Get("before"); before != "" {
+		req.BeforeID, err = strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid before"})
+			return
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	deliveries, err := wh.svc.GetWebhookDeliveries(r.Context(), webhookID, req)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, deliveries)
+}
+
+// RedeliverWebhookDeliveryHandler
+//
+//	@Summary		Redeliver a webhook delivery
+//	@Description	Send the event of the delivery again in a new delivery, the attempts of the original delivery are kept
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Authorization	header		string	true	"MUST HAVE PERMISSION ManageWebhook. Fill with bearer and token. The token can be accessed via api /auth/login."
+//	@Param			deliveryID		path		int		true	"Delivery ID"
+//	@Success		202				{object}	params.WebhookDeliveryResponse
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		404				{object}	errs.NotFound
+//	@Failure		500				{object}	APIError
+//	@Router			/webhooks/deliveries/{deliveryID}/redeliver [post]
+func (wh *WebhookHandler) RedeliverWebhookDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	deliveryID, err := strconv.ParseInt(chi.URLParam(r, "deliveryID"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errors.New("error when parsing deliveryID"))
+		return
+	}
+
+	delivery, err := wh.svc.RedeliverWebhookDelivery(r.Context(), deliveryID)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusAccepted, delivery)
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/google/uuid"
+)
+
+type (
+	articleLockRepo interface {
+		AcquireArticleLock(ctx context.Context, articleID int64, userID uuid.UUID, expiresAt time.Time) (*entity.ArticleLock, error)
+		GetArticleLock(ctx context.Context, articleID int64) (*entity.ArticleLock, error)
+		ReleaseArticleLock(ctx context.Context, articleID int64, userID uuid.UUID) error
+		DeleteArticleLock(ctx context.Context, articleID int64) error
+	}
+
+	presenceWatcher struct {
+		updates chan entity.ArticlePresence
+		viewer  entity.ArticleViewer
+	}
+
+	// ArticleLockService keeps the edit locks of the articles and shows who is viewing or editing them.
+	ArticleLockService struct {
+		articleLockRepo articleLockRepo
+		userRepo        userRepo
+		ttl             time.Duration
+		mu              sync.Mutex
+		watchers        map[int64]map[*entity.PresenceSubscription]*presenceWatcher
+	}
+)
+
+const DefaultArticleLockTTL = 5 * time.Minute
+
+// NewArticleLockService locks the articles for ttl, the holder renews its lock before it expires.
+// The viewers are kept in memory, so every instance of the application only sees the viewers connected to it.
+func NewArticleLockService(articleLockRepo articleLockRepo, userRepo userRepo, ttl time.Duration) *ArticleLockService {
+	if ttl <= 0 {
+		ttl = DefaultArticleLockTTL
+	}
+
+	return &ArticleLockService{
+		articleLockRepo: articleLockRepo,
+		userRepo:        userRepo,
+		ttl:             ttl,
+		watchers:        make(map[int64]map[*entity.PresenceSubscription]*presenceWatcher),
+	}
+}
+
+// => POST /articles/{articleID}/lock
+func (ls *ArticleLockService) LockArticle(ctx context.Context, articleID int64) (*params.ArticleLockResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	lock, err := ls.articleLockRepo.AcquireArticleLock(ctx, articleID, userID, time.Now().Add(ls.ttl))
+	if errors.Is(err, sql.ErrNoRows) {
+		holder, err := ls.articleLockRepo.GetArticleLock(ctx, articleID)
+		if err != nil {
+			// the lock of the other user expired after the lock was tried
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errs.Conflict{Message: "the article is being locked, try again"}
+			}
+			return nil, err
+		}
+		return nil, lockedError(holder)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ls.notify(ctx, articleID)
+
+	res := params.NewArticleLockResponse(*lock)
+	return &res, nil
+}
+
+// => DELETE /articles/{articleID}/lock
+func (ls *ArticleLockService) UnlockArticle(ctx context.Context, articleID int64) error {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return errors.New("error when parsing userID")
+	}
+
+	if err := ls.articleLockRepo.ReleaseArticleLock(ctx, articleID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound{Message: "lock of the user"}
+		}
+		return err
+	}
+
+	ls.notify(ctx, articleID)
+
+	return nil
+}
+
+// => DELETE /articles/{articleID}/lock/force
+func (ls *ArticleLockService) BreakArticleLock(ctx context.Context, articleID int64) error {
+	if err := ls.articleLockRepo.DeleteArticleLock(ctx, articleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound{Message: "lock"}
+		}
+		return err
+	}
+
+	ls.notify(ctx, articleID)
+
+	return nil
+}
+
+// => GET /articles/{articleID}/lock
+func (ls *ArticleLockService) GetArticleLock(ctx context.Context, articleID int64) (*params.ArticleLockResponse, error) {
+	lock, err := ls.articleLockRepo.GetArticleLock(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: "lock"}
+		}
+		return nil, err
+	}
+
+	res := params.NewArticleLockResponse(*lock)
+	return &res, nil
+}
+
+// CheckArticleLock returns errs.Conflict when the user does not hold the active lock of the article.
+// The repository checks the lock again in the transaction of the version.
+func (ls *ArticleLockService) CheckArticleLock(ctx context.Context, articleID int64, userID uuid.UUID) error {
+	lock, err := ls.articleLockRepo.GetArticleLock(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.Conflict{Message: "the article must be locked by the user to create a version"}
+		}
+		return err
+	}
+
+	if lock.UserID != userID {
+		return lockedError(lock)
+	}
+
+	return nil
+}
+
+func lockedError(lock *entity.ArticleLock) error {
+	return errs.Conflict{Message: fmt.Sprintf("the article is locked by %s until %s", lock.UserName, lock.ExpiresAt.Format(time.RFC3339))}
+}
+
+// WatchArticle adds the user as a viewer of the article until UnwatchArticle is called.
+// The subscription gets the presence right away and after every change, only the latest presence is kept when it is not read.
+func (ls *ArticleLockService) WatchArticle(ctx context.Context, articleID int64) (*entity.PresenceSubscription, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	user, err := ls.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan entity.ArticlePresence, 1)
+	sub := &entity.PresenceSubscription{ArticleID: articleID, Updates: updates}
+
+	ls.mu.Lock()
+	if ls.watchers[articleID] == nil {
+		ls.watchers[articleID] = make(map[*entity.PresenceSubscription]*presenceWatcher)
+	}
+	ls.watchers[articleID][sub] = &presenceWatcher{
+		updates: updates,
+		viewer:  entity.ArticleViewer{UserID: user.ID, Name: user.Name},
+	}
+	ls.mu.Unlock()
+
+	ls.notify(ctx, articleID)
+
+	return sub, nil
+}
+
+// UnwatchArticle removes the viewer, the other viewers of the article are notified.
+func (ls *ArticleLockService) UnwatchArticle(sub *entity.PresenceSubscription) {
+	ls.mu.Lock()
+	watchers := ls.watchers[sub.ArticleID]
+	_, ok := watchers[sub]
+	delete(watchers, sub)
+	remaining := len(watchers)
+	if remaining == 0 {
+		delete(ls.watchers, sub.ArticleID)
+	}
+	ls.mu.Unlock()
+
+	if ok && remaining > 0 {
+		// the request of the viewer is already done
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		ls.notify(ctx, sub.ArticleID)
+	}
+}
+
+// Close ends the presence streams, so the server does not wait for them on shutdown.
+func (ls *ArticleLockService) Close() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for articleID, watchers := range ls.watchers {
+		for _, watcher := range watchers {
+			close(watcher.updates)
+		}
+		delete(ls.watchers, articleID)
+	}
+}
+
+// GetArticlePresence returns the viewers of the article sorted by their names and its active lock.
+func (ls *ArticleLockService) GetArticlePresence(ctx context.Context, articleID int64) (*entity.ArticlePresence, error) {
+	presence := &entity.ArticlePresence{ArticleID: articleID}
+
+	lock, err := ls.articleLockRepo.GetArticleLock(ctx, articleID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	presence.Lock = lock
+
+	ls.mu.Lock()
+	for _, watcher := range ls.watchers[articleID] {
+		// a user who opens the article twice is a single viewer
+		if !slices.ContainsFunc(presence.Viewers, func(viewer entity.ArticleViewer) bool { return viewer.UserID == watcher.viewer.UserID }) {
+			presence.Viewers = append(presence.Viewers, watcher.viewer)
+		}
+	}
+	ls.mu.Unlock()
+
+	slices.SortFunc(presence.Viewers, func(a, b entity.ArticleViewer) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.UserID.String(), b.UserID.String()))
+	})
+
+	return presence, nil
+}
+
+// notify sends the presence of the article to its viewers, a presence which is not read yet is replaced.
+func (ls *ArticleLockService) notify(ctx context.Context, articleID int64) {
+	presence, err := ls.GetArticlePresence(ctx, articleID)
+	if err != nil {
+		// the viewers get the presence on their next refresh
+		return
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for _, watcher := range ls.watchers[articleID] {
+		select {
+		case <-watcher.updates:
+		default:
+		}
+		watcher.updates <- *presence
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_article_lock_repo.go -package=service_mock . articleLockRepo
+
+func TestArticleLockService_LockArticle(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+	expiresAt := time.Date(2025, 8, 24, 9, 0, 0, 0, time.UTC)
+	lock := &entity.ArticleLock{ArticleID: 1, UserID: userID, UserName: "writer", ExpiresAt: expiresAt}
+	otherLock := &entity.ArticleLock{ArticleID: 1, UserID: uuid.New(), UserName: "editor", ExpiresAt: expiresAt}
+
+	tests := []struct {
+		name    string
+		prepare func(*service_mock.MockarticleLockRepo)
+		want    *params.ArticleLockResponse
+		wantErr error
+	}{
+		{
+			name: "locked",
+			prepare: func(repo *service_mock.MockarticleLockRepo) {
+				repo.EXPECT().AcquireArticleLock(gomock.Any(), int64(1), userID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, articleID int64, userID uuid.UUID, expiresAt time.Time) (*entity.ArticleLock, error) {
+						assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, time.Second)
+						return lock, nil
+					})
+				// the presence of the viewers
+				repo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(lock, nil)
+			},
+			want: &params.ArticleLockResponse{ArticleID: 1, UserID: userID, UserName: "writer", ExpiresAt: expiresAt},
+		},
+		{
+			name: "held by another user",
+			prepare: func(repo *service_mock.MockarticleLockRepo) {
+				repo.EXPECT().AcquireArticleLock(gomock.Any(), int64(1), userID, gomock.Any()).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(otherLock, nil)
+			},
+			wantErr: errs.Conflict{Message: "the article is locked by editor until 2025-08-24T09:00:00Z"},
+		},
+		{
+			name: "the lock of another user expired meanwhile",
+			prepare: func(repo *service_mock.MockarticleLockRepo) {
+				repo.EXPECT().AcquireArticleLock(gomock.Any(), int64(1), userID, gomock.Any()).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.Conflict{Message: "the article is being locked, try again"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockArticleLockRepo := service_mock.NewMockarticleLockRepo(ctrl)
+			s := NewArticleLockService(mockArticleLockRepo, service_mock.NewMockuserRepo(ctrl), time.Minute)
+			tt.prepare(mockArticleLockRepo)
+
+			got, err := s.LockArticle(ctx, 1)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestArticleLockService_CheckArticleLock(t *testing.T) {
+	userID := uuid.New()
+	expiresAt := time.Date(2025, 8, 24, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		lock    *entity.ArticleLock
+		lockErr error
+		wantErr error
+	}{
+		{
+			name:    "not locked",
+			lockErr: sql.ErrNoRows,
+			wantErr: errs.Conflict{Message: "the article must be locked by the user to create a version"},
+		},
+		{
+			name: "locked by the user",
+			lock: &entity.ArticleLock{ArticleID: 1, UserID: userID, UserName: "writer", ExpiresAt: expiresAt},
+		},
+		{
+			name:    "locked by another user",
+			lock:    &entity.ArticleLock{ArticleID: 1, UserID: uuid.New(), UserName: "editor", ExpiresAt: expiresAt},
+			wantErr: errs.Conflict{Message: "the article is locked by editor until 2025-08-24T09:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockArticleLockRepo := service_mock.NewMockarticleLockRepo(ctrl)
+			s := NewArticleLockService(mockArticleLockRepo, service_mock.NewMockuserRepo(ctrl), 0)
+			mockArticleLockRepo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(tt.lock, tt.lockErr)
+
+			assert.Equal(t, tt.wantErr, s.CheckArticleLock(context.Background(), 1, userID))
+		})
+	}
+}
+
+func TestArticleLockService_Presence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleLockRepo := service_mock.NewMockarticleLockRepo(ctrl)
+	mockUserRepo := service_mock.NewMockuserRepo(ctrl)
+	s := NewArticleLockService(mockArticleLockRepo, mockUserRepo, 0)
+
+	writer := entity.ArticleViewer{UserID: uuid.New(), Name: "writer"}
+	editor := entity.ArticleViewer{UserID: uuid.New(), Name: "editor"}
+	lock := &entity.ArticleLock{ArticleID: 1, UserID: editor.UserID, UserName: editor.Name}
+	for _, viewer := range []entity.ArticleViewer{writer, editor} {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), viewer.UserID).Return(&entity.User{ID: viewer.UserID, Name: viewer.Name}, nil).AnyTimes()
+	}
+
+	mockArticleLockRepo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows).Times(2)
+	writerSub, err := s.WatchArticle(context.WithValue(context.Background(), constanta.LocalUserID, writer.UserID), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ArticlePresence{ArticleID: 1, Viewers: []entity.ArticleViewer{writer}}, <-writerSub.Updates)
+
+	editorCtx := context.WithValue(context.Background(), constanta.LocalUserID, editor.UserID)
+	editorSub, err := s.WatchArticle(editorCtx, 1)
+	assert.NoError(t, err)
+	want := entity.ArticlePresence{ArticleID: 1, Viewers: []entity.ArticleViewer{editor, writer}}
+	assert.Equal(t, want, <-writerSub.Updates)
+	assert.Equal(t, want, <-editorSub.Updates)
+
+	// the viewers see the lock of the editor
+	mockArticleLockRepo.EXPECT().AcquireArticleLock(gomock.Any(), int64(1), editor.UserID, gomock.Any()).Return(lock, nil)
+	mockArticleLockRepo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(lock, nil)
+	_, err = s.LockArticle(editorCtx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, lock, (<-writerSub.Updates).Lock)
+
+	// the same user in another tab is a single viewer
+	mockArticleLockRepo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(lock, nil).Times(2)
+	secondSub, err := s.WatchArticle(editorCtx, 1)
+	assert.NoError(t, err)
+	s.UnwatchArticle(editorSub)
+	assert.Equal(t, entity.ArticlePresence{ArticleID: 1, Viewers: []entity.ArticleViewer{editor, writer}, Lock: lock}, <-writerSub.Updates)
+	<-secondSub.Updates
+
+	mockArticleLockRepo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(lock, nil)
+	s.UnwatchArticle(secondSub)
+	assert.Equal(t, entity.ArticlePresence{ArticleID: 1, Viewers: []entity.ArticleViewer{writer}, Lock: lock}, <-writerSub.Updates)
+
+	// the last viewer leaves without a notification
+	s.UnwatchArticle(writerSub)
+	assert.Empty(t, s.watchers)
+	// removing it again does nothing
+	s.UnwatchArticle(writerSub)
+}
+
+func TestArticleLockService_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleLockRepo := service_mock.NewMockarticleLockRepo(ctrl)
+	mockUserRepo := service_mock.NewMockuserRepo(ctrl)
+	s := NewArticleLockService(mockArticleLockRepo, mockUserRepo, 0)
+
+	userID := uuid.New()
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&entity.User{ID: userID, Name: "writer"}, nil)
+	mockArticleLockRepo.EXPECT().GetArticleLock(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+	sub, err := s.WatchArticle(context.WithValue(context.Background(), constanta.LocalUserID, userID), 1)
+	assert.NoError(t, err)
+	<-sub.Updates
+
+	s.Close()
+
+	_, ok := <-sub.Updates
+	assert.False(t, ok)
+	// the stream is removed without a notification
+	s.UnwatchArticle(sub)
+}
@@ -1,16 +1,21 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
 	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/elangreza/content-management-system/internal/render"
 	"github.com/google/uuid"
 )
 
@@ -23,25 +28,53 @@ type (
 		CreateArticleVersion(ctx context.Context, articleVersion entity.ArticleVersion) (int64, error)
 		GetArticleWithID(ctx context.Context, articleID int64) (*entity.Article, error)
 		GetArticleVersionsWithArticleIDAndStatuses(ctx context.Context, ArticleID int64, status ...constanta.ArticleVersionStatus) ([]entity.ArticleVersion, error)
-		GetArticles(ctx context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, error)
+		GetArticles(ctx context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, int64, error)
 		GetTagsWithArticleVersionID(ctx context.Context, articleVersionID int64) ([]entity.Tag, error)
 		UpdateArticleVersionRelationshipScore(ctx context.Context, articleVersionID int64, relationshipScore float64) error
+		GetTakenSlugs(ctx context.Context, slug string, articleID int64) ([]string, error)
+		GetArticleIDBySlug(ctx context.Context, slug string) (int64, string, error)
+		GetArticleCacheValidator(ctx context.Context, articleID int64) (*entity.CacheValidator, error)
+		GetArticlesCacheValidator(ctx context.Context) (*entity.CacheValidator, error)
+		GetPublishedArticleVersions(ctx context.Context) ([]entity.ArticleVersion, error)
 	}
 
-	tagTrigger interface {
-		CreateTagTrigger(name constanta.TagServiceAction, payload any)
+	tagNormalizer interface {
+		NormalizeTags(ctx context.Context, names ...string) ([]string, error)
+	}
+
+	articleLocker interface {
+		CheckArticleLock(ctx context.Context, articleID int64, userID uuid.UUID) error
 	}
 
 	ArticleService struct {
-		articleRepo articleRepo
-		tagTrigger  tagTrigger
+		articleRepo   articleRepo
+		tagNormalizer tagNormalizer
+		articleLocker articleLocker
+		// detail of the articles as seen by the users who cannot read the drafted and archived versions
+		publishedArticles cached[publishedArticle]
+	}
+
+	// publishedArticle is only used for the validator it was built for,
+	// so a detail which is changed in the ttl is not sent with the validator of the change.
+	publishedArticle struct {
+		Validator string
+		Detail    params.GetArticleDetailResponse
 	}
 )
 
-func NewArticleService(articleRepo articleRepo, tagTrigger tagTrigger) *ArticleService {
+// a renamed tag or a new relationship score is shown after this ttl,
+// the write paths of the articles invalidate the detail immediately
+const publishedArticleCacheTTL = time.Minute
+
+// NewArticleService saves the changes of the articles with their events, the tag calculations, the CDN purges
+// and the webhooks subscribe to the events in the outbox, so they only run for the committed changes.
+// A new version of an article which is locked by another user is rejected.
+func NewArticleService(articleRepo articleRepo, tagNormalizer tagNormalizer, articleLocker articleLocker, cacheStore cacheStore) *ArticleService {
 	return &ArticleService{
-		articleRepo: articleRepo,
-		tagTrigger:  tagTrigger,
+		articleRepo:       articleRepo,
+		tagNormalizer:     tagNormalizer,
+		articleLocker:     articleLocker,
+		publishedArticles: newCached[publishedArticle](cacheStore, "article", publishedArticleCacheTTL),
 	}
 }
 
@@ -52,18 +85,30 @@ func (as *ArticleService) CreateArticle(ctx context.Context, req params.CreateAr
 		return nil, errors.New("error when parsing userID")
 	}
 
+	var err error
+	req.Tags, err = as.tagNormalizer.NormalizeTags(ctx, req.Tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := req.ArticleMetadata.ToEntity()
+	metadata.Slug, err = as.resolveSlug(ctx, metadata.Slug, req.Title, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	article := entity.NewArticle(req.Title, req.Body, userID)
 	articleVersion := entity.NewArticleVersion(article.ID, req.Title, req.Body, userID, 1, req.Tags)
+	if err := setContent(articleVersion, req.BodyFormat, req.Blocks, metadata); err != nil {
+		return nil, err
+	}
+	articleVersion.MediaIDs = usedMediaIDs(req.MediaIDs, req.Blocks)
+
 	articleID, articleVersionID, err := as.articleRepo.CreateArticle(ctx, *article, *articleVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	as.tagTrigger.CreateTagTrigger(constanta.CalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{
-		Tags:             articleVersion.Tags,
-		ArticleVersionID: articleVersionID,
-	})
-
 	return &params.CreateArticleResponse{
 		ArticleID:        articleID,
 		ArticleVersionID: articleVersionID,
@@ -77,7 +122,7 @@ func (as *ArticleService) DeleteArticle(ctx context.Context, articleID int64) er
 		return err
 	}
 
-	as.tagTrigger.CreateTagTrigger(constanta.CalculateTagUsageAndPairFrequency, nil)
+	as.publishedArticles.delete(ctx, articleID)
 
 	return nil
 }
@@ -105,23 +150,13 @@ func (as *ArticleService) UpdateStatusArticle(ctx context.Context, articleID, ar
 		return errs.ValidationError{Message: "status cannot be downgraded"}
 	}
 
-	articleTags, err := as.articleRepo.GetTagsWithArticleVersionID(ctx, articleVersionID)
-	if err != nil {
+	if err := as.articleRepo.UpdateArticleStatus(ctx, articleID, articleVersionID, reqStatus, articleVersion.Status, userID); err != nil {
 		return err
 	}
 
-	if reqStatus == constanta.Published {
-		as.tagTrigger.CreateTagTrigger(constanta.CalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{
-			Tags:             articleTags,
-			ArticleVersionID: articleVersionID,
-		})
-	}
+	as.publishedArticles.delete(ctx, articleID)
 
-	if reqStatus == constanta.Archived {
-		as.tagTrigger.CreateTagTrigger(constanta.CalculateTagUsageAndPairFrequency, nil)
-	}
-
-	return as.articleRepo.UpdateArticleStatus(ctx, articleID, articleVersionID, reqStatus, articleVersion.Status, userID)
+	return nil
 }
 
 // => PUT /articles/{articleID}
@@ -139,6 +174,10 @@ func (as *ArticleService) CreateArticleVersionWithReferenceFromArticleID(ctx con
 		return nil, err
 	}
 
+	if err := as.articleLocker.CheckArticleLock(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+
 	// get the latest version ID if article has a drafted version
 	var articleVersionID int64
 	if article.DraftedVersionID != 0 {
@@ -150,36 +189,41 @@ func (as *ArticleService) CreateArticleVersionWithReferenceFromArticleID(ctx con
 		articleVersionID = article.PublishedVersionID
 	}
 
-	// if articleVersionID is existing, check if the new version is the same as the current version
+	var articleVersion *entity.ArticleVersion
 	if articleVersionID != 0 {
-		articleVersion, err := as.articleRepo.GetArticleVersionWithIDAndArticleID(ctx, articleID, articleVersionID)
+		articleVersion, err = as.articleRepo.GetArticleVersionWithIDAndArticleID(ctx, articleID, articleVersionID)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	version := article.VersionSequence + 1
+	newArticleVersion, err := as.newArticleVersion(ctx, articleID, version, userID, req, articleVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// if articleVersionID is existing, check if the new version is the same as the current version
+	if articleVersionID != 0 {
+		// the review continues on the new version
+		newArticleVersion.CommentsFromVersionID = articleVersionID
 
 		tags, err := as.articleRepo.GetTagsWithArticleVersionID(ctx, articleVersionID)
 		if err != nil {
 			return nil, err
 		}
 
-		slices.Sort(req.Tags)
-
-		if articleVersion.Title == req.Title && articleVersion.Body == req.Body && reflect.DeepEqual(tags, entity.NewTags(req.Tags...)) {
-			return nil, errs.ValidationError{Message: "title, tags and body cannot be the same as the current version"}
+		if articleVersion.Title == req.Title && articleVersion.Body == newArticleVersion.Body && articleVersion.BodyFormat == newArticleVersion.BodyFormat && reflect.DeepEqual(articleVersion.Blocks, newArticleVersion.Blocks) && reflect.DeepEqual(tags, newArticleVersion.Tags) && articleVersion.ArticleMetadata == newArticleVersion.ArticleMetadata {
+			return nil, errs.ValidationError{Message: "title, tags, body, body format and metadata cannot be the same as the current version"}
 		}
 	}
 
-	version := article.VersionSequence + 1
-	newArticleVersion := entity.NewArticleVersion(articleID, req.Title, req.Body, userID, version, req.Tags)
 	newArticleVersionID, err := as.articleRepo.CreateArticleVersion(ctx, *newArticleVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	as.tagTrigger.CreateTagTrigger(constanta.CalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{
-		Tags:             newArticleVersion.Tags,
-		ArticleVersionID: newArticleVersionID,
-	})
+	as.publishedArticles.delete(ctx, articleID)
 
 	return &params.CreateArticleVersionResponse{
 		ArticleVersionID: newArticleVersionID,
@@ -201,6 +245,10 @@ func (as *ArticleService) CreateArticleVersionWithReferenceFromArticleIDAindVers
 		return nil, err
 	}
 
+	if err := as.articleLocker.CheckArticleLock(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+
 	articleVersion, err := as.articleRepo.GetArticleVersionWithIDAndArticleID(ctx, articleID, articleVersionID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -214,32 +262,147 @@ func (as *ArticleService) CreateArticleVersionWithReferenceFromArticleIDAindVers
 		return nil, err
 	}
 
-	slices.Sort(req.Tags)
+	version := article.VersionSequence + 1
+	newArticleVersion, err := as.newArticleVersion(ctx, articleID, version, userID, req, articleVersion)
+	if err != nil {
+		return nil, err
+	}
 
-	if articleVersion.Title == req.Title && articleVersion.Body == req.Body && reflect.DeepEqual(tags, entity.NewTags(req.Tags...)) {
-		return nil, errs.ValidationError{Message: "title, tags and body cannot be the same as the current version"}
+	if articleVersion.Title == req.Title && articleVersion.Body == newArticleVersion.Body && articleVersion.BodyFormat == newArticleVersion.BodyFormat && reflect.DeepEqual(articleVersion.Blocks, newArticleVersion.Blocks) && reflect.DeepEqual(tags, newArticleVersion.Tags) && articleVersion.ArticleMetadata == newArticleVersion.ArticleMetadata {
+		return nil, errs.ValidationError{Message: "title, tags, body, body format and metadata cannot be the same as the current version"}
 	}
 
-	version := article.VersionSequence + 1
-	newArticleVersion := entity.NewArticleVersion(articleID, req.Title, req.Body, userID, version, req.Tags)
+	// the review continues on the new version
+	newArticleVersion.CommentsFromVersionID = articleVersionID
+
 	newArticleVersionID, err := as.articleRepo.CreateArticleVersion(ctx, *newArticleVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	as.tagTrigger.CreateTagTrigger(constanta.CalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{
-		Tags:             newArticleVersion.Tags,
-		ArticleVersionID: newArticleVersionID,
-	})
+	as.publishedArticles.delete(ctx, articleID)
 
 	return &params.CreateArticleVersionResponse{
 		ArticleVersionID: newArticleVersionID,
 	}, nil
 }
 
-// => POST /articles/{id}
-func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64) (*params.GetArticleDetailResponse, error) {
-	article, err := as.articleRepo.GetArticleWithID(ctx, articleID)
+// newArticleVersion builds the next version of the article from the request.
+// The tags of the version are normalized and sorted. The SEO metadata which is not in the request
+// is copied from the reference version, reference is nil when the article has no version to start from.
+func (as *ArticleService) newArticleVersion(ctx context.Context, articleID, version int64, userID uuid.UUID, req params.CreateArticleVersionRequest, reference *entity.ArticleVersion) (*entity.ArticleVersion, error) {
+	var err error
+	req.Tags, err = as.tagNormalizer.NormalizeTags(ctx, req.Tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := req.ArticleMetadata.ToEntity()
+	if reference != nil {
+		metadata.SEOTitle = cmp.Or(metadata.SEOTitle, reference.SEOTitle)
+		metadata.SEODescription = cmp.Or(metadata.SEODescription, reference.SEODescription)
+		metadata.CanonicalURL = cmp.Or(metadata.CanonicalURL, reference.CanonicalURL)
+		metadata.OGImage = cmp.Or(metadata.OGImage, reference.OGImage)
+	}
+	metadata.Slug, err = as.resolveSlug(ctx, metadata.Slug, req.Title, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	articleVersion := entity.NewArticleVersion(articleID, req.Title, req.Body, userID, version, req.Tags)
+	if err := setContent(articleVersion, req.BodyFormat, req.Blocks, metadata); err != nil {
+		return nil, err
+	}
+	articleVersion.MediaIDs = usedMediaIDs(req.MediaIDs, req.Blocks)
+
+	return articleVersion, nil
+}
+
+// setContent sets the body format, the blocks and the metadata of the version.
+// HTML body and the raw HTML inside markdown are cleaned with the allowlist policy, so dangerous HTML is never stored.
+// The body of the blocks is their markdown rendering, so body readers keep working.
+func setContent(articleVersion *entity.ArticleVersion, format constanta.BodyFormat, blocks *entity.BlockDocument, metadata entity.ArticleMetadata) error {
+	if blocks != nil {
+		articleVersion.Blocks = blocks
+		articleVersion.Body = render.BlocksMarkdown(*blocks)
+		format = constanta.Markdown
+	}
+
+	articleVersion.BodyFormat = format
+
+	if format == constanta.HTML && articleVersion.Body != "" {
+		articleVersion.Body = render.Sanitize(articleVersion.Body)
+		if strings.TrimSpace(articleVersion.Body) == "" {
+			return errs.ValidationError{Message: "body does not contain any allowed html"}
+		}
+	}
+
+	if format == constanta.Markdown {
+		articleVersion.Body = render.SanitizeMarkdown(articleVersion.Body)
+	}
+
+	// the excerpt is generated from the text, without the markup
+	if metadata.Excerpt == "" {
+		text, err := render.Text(format, articleVersion.Body)
+		if err != nil {
+			return err
+		}
+		metadata.Excerpt = entity.NewExcerpt(text)
+	}
+
+	articleVersion.SetMetadata(metadata)
+
+	return nil
+}
+
+// usedMediaIDs merges the requested media with the media of the image blocks, without duplicates.
+func usedMediaIDs(ids []int64, blocks *entity.BlockDocument) []int64 {
+	ids = slices.Clone(ids)
+	if blocks != nil {
+		ids = append(ids, blocks.MediaIDs()...)
+	}
+
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// resolveSlug checks that the requested slug is not used by other articles.
+// When the slug is not requested, it is generated from the title and
+// suffixed with a number until it is free.
+func (as *ArticleService) resolveSlug(ctx context.Context, requestedSlug, title string, articleID int64) (string, error) {
+	slug := requestedSlug
+	if slug == "" {
+		slug = entity.NewSlug(title)
+	}
+
+	if slug == "" {
+		slug = "article"
+	}
+
+	takenSlugs, err := as.articleRepo.GetTakenSlugs(ctx, slug, articleID)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(takenSlugs, slug) {
+		return slug, nil
+	}
+
+	if requestedSlug != "" {
+		return "", errs.AlreadyExist{Name: fmt.Sprintf("slug %s", requestedSlug)}
+	}
+
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", slug, i)
+		if !slices.Contains(takenSlugs, candidate) {
+			return candidate, nil
+		}
+	}
+}
+
+// GetArticleCacheValidator is checked before the detail of the article is built.
+func (as *ArticleService) GetArticleCacheValidator(ctx context.Context, articleID int64) (*entity.CacheValidator, error) {
+	validator, err := as.articleRepo.GetArticleCacheValidator(ctx, articleID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.NotFound{Message: "article"}
@@ -247,11 +410,37 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 		return nil, err
 	}
 
+	return validator, nil
+}
+
+// GetArticlesCacheValidator is checked before the list of the articles is built.
+func (as *ArticleService) GetArticlesCacheValidator(ctx context.Context) (*entity.CacheValidator, error) {
+	return as.articleRepo.GetArticlesCacheValidator(ctx)
+}
+
+// => GET /articles/{id}
+// The validator is the one returned by GetArticleCacheValidator for the request.
+func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64, validator entity.CacheValidator) (*params.GetArticleDetailResponse, error) {
 	userCanReadDraftedAndArchivedArticle, ok := ctx.Value(constanta.LocalUserCanReadDraftedAndArchivedArticle).(bool)
 	if !ok {
 		return nil, errors.New("error when parsing user permission")
 	}
 
+	// only the published view is cached, the editors always read the current versions
+	if !userCanReadDraftedAndArchivedArticle {
+		if cachedArticle, ok := as.publishedArticles.get(ctx, articleID); ok && cachedArticle.Validator == validator.Key {
+			return &cachedArticle.Detail, nil
+		}
+	}
+
+	article, err := as.articleRepo.GetArticleWithID(ctx, articleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound{Message: "article"}
+		}
+		return nil, err
+	}
+
 	var draftedVersionResponse *params.ArticleVersionResponse
 	if article.DraftedVersionID != 0 && userCanReadDraftedAndArchivedArticle {
 		draftedVersion, err := as.articleRepo.GetArticleVersionWithIDAndArticleID(ctx, article.ID, article.DraftedVersionID)
@@ -274,6 +463,8 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 			ArticleVersionID:     draftedVersion.ArticleVersionID,
 			Title:                draftedVersion.Title,
 			Body:                 draftedVersion.Body,
+			BodyFormat:           draftedVersion.BodyFormat,
+			Blocks:               draftedVersion.Blocks,
 			Version:              draftedVersion.Version,
 			Status:               int8(draftedVersion.Status),
 			CreatedBy:            draftedVersion.CreatedBy,
@@ -282,6 +473,7 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 			UpdatedAt:            draftedVersion.UpdatedAt,
 			Tags:                 stringTags,
 			TagRelationShipScore: draftedVersion.TagRelationShipScore,
+			ArticleMetadata:      params.NewArticleMetadata(draftedVersion.ArticleMetadata),
 		}
 	}
 
@@ -307,6 +499,8 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 			ArticleVersionID:     archivedVersion.ArticleVersionID,
 			Title:                archivedVersion.Title,
 			Body:                 archivedVersion.Body,
+			BodyFormat:           archivedVersion.BodyFormat,
+			Blocks:               archivedVersion.Blocks,
 			Version:              archivedVersion.Version,
 			Status:               int8(archivedVersion.Status),
 			CreatedBy:            archivedVersion.CreatedBy,
@@ -315,6 +509,7 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 			UpdatedAt:            archivedVersion.UpdatedAt,
 			Tags:                 stringTags,
 			TagRelationShipScore: archivedVersion.TagRelationShipScore,
+			ArticleMetadata:      params.NewArticleMetadata(archivedVersion.ArticleMetadata),
 		}
 	}
 
@@ -340,6 +535,8 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 			ArticleVersionID:     publishedVersion.ArticleVersionID,
 			Title:                publishedVersion.Title,
 			Body:                 publishedVersion.Body,
+			BodyFormat:           publishedVersion.BodyFormat,
+			Blocks:               publishedVersion.Blocks,
 			Version:              publishedVersion.Version,
 			Status:               int8(publishedVersion.Status),
 			CreatedBy:            publishedVersion.CreatedBy,
@@ -348,6 +545,7 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 			UpdatedAt:            publishedVersion.UpdatedAt,
 			Tags:                 stringTags,
 			TagRelationShipScore: publishedVersion.TagRelationShipScore,
+			ArticleMetadata:      params.NewArticleMetadata(publishedVersion.ArticleMetadata),
 		}
 	}
 
@@ -359,7 +557,7 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 		return nil, errs.ValidationError{Message: "this article has no published or drafted or archived version"}
 	}
 
-	return &params.GetArticleDetailResponse{
+	articleDetail := &params.GetArticleDetailResponse{
 		ID:               article.ID,
 		DraftedVersion:   draftedVersionResponse,
 		PublishedVersion: publishedVersionResponse,
@@ -368,7 +566,27 @@ func (as *ArticleService) GetArticleWithID(ctx context.Context, articleID int64)
 		CreatedBy:        article.CreatedBy,
 		UpdatedAt:        article.UpdatedAt,
 		UpdatedBy:        article.UpdatedBy,
-	}, nil
+	}
+
+	if !userCanReadDraftedAndArchivedArticle {
+		as.publishedArticles.set(ctx, articleID, publishedArticle{Validator: validator.Key, Detail: *articleDetail})
+	}
+
+	return articleDetail, nil
+}
+
+// => GET /articles/by-slug/{slug}
+// The slug of the published version is returned, when it is not the given slug the caller redirects to it.
+func (as *ArticleService) GetArticleIDBySlug(ctx context.Context, slug string) (int64, string, error) {
+	articleID, currentSlug, err := as.articleRepo.GetArticleIDBySlug(ctx, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, "", errs.NotFound{Message: fmt.Sprintf("article with slug %s", slug)}
+		}
+		return 0, "", err
+	}
+
+	return articleID, currentSlug, nil
 }
 
 // => GET /articles/{id}/versions/{id}
@@ -400,6 +618,8 @@ func (as *ArticleService) GetArticleVersionWithIDAndArticleID(ctx context.Contex
 		ArticleVersionID:     articleVersion.ArticleVersionID,
 		Title:                articleVersion.Title,
 		Body:                 articleVersion.Body,
+		BodyFormat:           articleVersion.BodyFormat,
+		Blocks:               articleVersion.Blocks,
 		Version:              articleVersion.Version,
 		Status:               int8(articleVersion.Status),
 		CreatedBy:            articleVersion.CreatedBy,
@@ -408,6 +628,7 @@ func (as *ArticleService) GetArticleVersionWithIDAndArticleID(ctx context.Contex
 		UpdatedAt:            articleVersion.UpdatedAt,
 		Tags:                 stringTags,
 		TagRelationShipScore: articleVersion.TagRelationShipScore,
+		ArticleMetadata:      params.NewArticleMetadata(articleVersion.ArticleMetadata),
 	}, nil
 }
 
@@ -437,6 +658,8 @@ func (as *ArticleService) GetArticleVersions(ctx context.Context, articleID int6
 			ArticleVersionID:     articleVersion.ArticleVersionID,
 			Title:                articleVersion.Title,
 			Body:                 articleVersion.Body,
+			BodyFormat:           articleVersion.BodyFormat,
+			Blocks:               articleVersion.Blocks,
 			Version:              articleVersion.Version,
 			Status:               int8(articleVersion.Status),
 			CreatedBy:            articleVersion.CreatedBy,
@@ -444,6 +667,7 @@ func (as *ArticleService) GetArticleVersions(ctx context.Context, articleID int6
 			UpdatedBy:            articleVersion.UpdatedBy,
 			UpdatedAt:            articleVersion.UpdatedAt,
 			TagRelationShipScore: articleVersion.TagRelationShipScore,
+			ArticleMetadata:      params.NewArticleMetadata(articleVersion.ArticleMetadata),
 		}
 	}
 
@@ -451,31 +675,72 @@ func (as *ArticleService) GetArticleVersions(ctx context.Context, articleID int6
 }
 
 // => GET /articles
-func (as *ArticleService) GetArticles(ctx context.Context, req params.GetArticlesQueryParams) ([]params.ArticleVersionResponse, error) {
+func (as *ArticleService) GetArticles(ctx context.Context, req params.GetArticlesQueryParams) ([]params.ArticleVersionResponse, *params.PaginationResponse, error) {
 
 	userCanReadDraftedAndArchivedArticle, ok := ctx.Value(constanta.LocalUserCanReadDraftedAndArchivedArticle).(bool)
 	if !ok {
-		return nil, errors.New("error when parsing user permission")
+		return nil, nil, errors.New("error when parsing user permission")
 	}
 
 	if !userCanReadDraftedAndArchivedArticle {
 		req.Status = []constanta.ArticleVersionStatus{constanta.Published}
 	}
 
-	articleVersions, err := as.articleRepo.GetArticles(ctx, entity.GetArticlesQueryServiceParams{
+	cursor := req.GetCursor()
+
+	// fetch one more row to know whether there is another page
+	articleVersions, total, err := as.articleRepo.GetArticles(ctx, entity.GetArticlesQueryServiceParams{
 		Search:      req.Search,
 		Status:      req.Status,
 		CreatedBy:   req.CreatedBy,
 		UpdatedBy:   req.UpdatedBy,
+		CategoryID:  req.CategoryID,
 		OrderClause: req.GetOrderClause(),
-		Limit:       req.Limit,
+		Limit:       req.Limit + 1,
 		Page:        req.Page,
+		Sorts:       req.GetSortFields(),
+		Cursor:      cursor,
+		CountMode:   req.CountMode,
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	backward := cursor != nil && cursor.Backward
+	hasMore := len(articleVersions) > req.Limit
+	if hasMore {
+		if backward {
+			articleVersions = articleVersions[1:]
+		} else {
+			articleVersions = articleVersions[:req.Limit]
+		}
+	}
+
+	pagination := &params.PaginationResponse{
+		Total:          total,
+		TotalEstimated: req.CountMode == constanta.EstimatedCount,
+		Limit:          req.Limit,
+	}
+	if cursor == nil {
+		pagination.Page = req.Page
 	}
 
+	if len(articleVersions) > 0 {
+		sorts := req.GetSortFields()
+		first := articleVersions[0].CursorValues(sorts)
+		last := articleVersions[len(articleVersions)-1].CursorValues(sorts)
+
+		if hasMore || backward {
+			pagination.NextCursor = params.EncodeCursor(req.GetOrderClause(), last)
+		}
+
+		if (backward && hasMore) || (!backward && (cursor != nil || req.Page > 1)) {
+			pagination.PrevCursor = params.EncodeCursor(req.GetOrderClause(), first)
+		}
+	}
+	pagination.HasNext = pagination.NextCursor != ""
+
 	res := make([]params.ArticleVersionResponse, len(articleVersions))
 	for i, articleVersion := range articleVersions {
 		res[i] = params.ArticleVersionResponse{
@@ -483,6 +748,8 @@ func (as *ArticleService) GetArticles(ctx context.Context, req params.GetArticle
 			ArticleVersionID:     articleVersion.ArticleVersionID,
 			Title:                articleVersion.Title,
 			Body:                 articleVersion.Body,
+			BodyFormat:           articleVersion.BodyFormat,
+			Blocks:               articleVersion.Blocks,
 			Version:              articleVersion.Version,
 			Status:               int8(articleVersion.Status),
 			CreatedBy:            articleVersion.CreatedBy,
@@ -490,8 +757,9 @@ func (as *ArticleService) GetArticles(ctx context.Context, req params.GetArticle
 			UpdatedBy:            articleVersion.UpdatedBy,
 			UpdatedAt:            articleVersion.UpdatedAt,
 			TagRelationShipScore: articleVersion.TagRelationShipScore,
+			ArticleMetadata:      params.NewArticleMetadata(articleVersion.ArticleMetadata),
 		}
 	}
 
-	return res, nil
+	return res, pagination, nil
 }
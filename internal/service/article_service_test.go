@@ -4,26 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
 	"testing"
 
+	"github.com/elangreza/content-management-system/internal/cache"
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/elangreza/content-management-system/internal/params"
 	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
 //go:generate mockgen -destination=mock/mock_article_repo.go -package=service_mock . articleRepo
-//go:generate mockgen -destination=mock/mock_tag_trigger.go -package=service_mock . tagTrigger
+//go:generate mockgen -destination=mock/mock_tag_normalizer.go -package=service_mock . tagNormalizer
+//go:generate mockgen -destination=mock/mock_article_locker.go -package=service_mock . articleLocker
 
 func TestArticleService_CreateArticle(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	testUserID := uuid.New()
 	testTags := []string{"go", "cms"}
@@ -31,6 +36,22 @@ func TestArticleService_CreateArticle(t *testing.T) {
 
 	article := entity.NewArticle("Test Title", "Test Body", testUserID)
 	articleVersion := entity.NewArticleVersion(article.ID, "Test Title", "Test Body", testUserID, 1, testTags)
+	articleVersion.BodyFormat = constanta.Markdown
+	articleVersion.SetMetadata(entity.ArticleMetadata{Slug: "test-title"})
+	suffixedArticleVersion := entity.NewArticleVersion(article.ID, "Test Title", "Test Body", testUserID, 1, testTags)
+	suffixedArticleVersion.BodyFormat = constanta.Markdown
+	suffixedArticleVersion.SetMetadata(entity.ArticleMetadata{Slug: "test-title-3"})
+	sanitizedArticleVersion := entity.NewArticleVersion(article.ID, "Test Title", "<p>Test Body</p>", testUserID, 1, testTags)
+	sanitizedArticleVersion.BodyFormat = constanta.HTML
+	sanitizedArticleVersion.SetMetadata(entity.ArticleMetadata{Slug: "test-title", Excerpt: "Test Body"})
+	sanitizedMarkdownArticleVersion := entity.NewArticleVersion(article.ID, "Test Title", "Test Body\n\n\n", testUserID, 1, testTags)
+	sanitizedMarkdownArticleVersion.BodyFormat = constanta.Markdown
+	sanitizedMarkdownArticleVersion.SetMetadata(entity.ArticleMetadata{Slug: "test-title", Excerpt: "Test Body"})
+	blocks := &entity.BlockDocument{SchemaVersion: 1, Blocks: []entity.Block{{ID: "p1", Type: constanta.ParagraphBlock, Text: "Test *Body*"}}}
+	blocksArticleVersion := entity.NewArticleVersion(article.ID, "Test Title", "Test \\*Body\\*\n", testUserID, 1, testTags)
+	blocksArticleVersion.BodyFormat = constanta.Markdown
+	blocksArticleVersion.Blocks = blocks
+	blocksArticleVersion.SetMetadata(entity.ArticleMetadata{Slug: "test-title", Excerpt: "Test *Body*"})
 
 	tests := []struct {
 		name    string
@@ -42,27 +63,141 @@ func TestArticleService_CreateArticle(t *testing.T) {
 		{
 			name: "success",
 			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return(nil, nil)
 				mockArticleRepo.EXPECT().CreateArticle(gomock.Any(), *article, *articleVersion).Return(int64(1), int64(2), nil)
-				mockTagTrigger.EXPECT().CreateTagTrigger(gomock.Any(), gomock.Any())
 			},
 			ctx: ctx,
 			input: params.CreateArticleRequest{
-				Title: "Test Title",
-				Body:  "Test Body",
-				Tags:  testTags,
+				Title:      "Test Title",
+				Body:       "Test Body",
+				BodyFormat: constanta.Markdown,
+				Tags:       testTags,
 			},
 			wantErr: false,
 		},
+		{
+			name: "success with suffixed slug",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return([]string{"test-title", "test-title-2"}, nil)
+				mockArticleRepo.EXPECT().CreateArticle(gomock.Any(), *article, *suffixedArticleVersion).Return(int64(1), int64(2), nil)
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:      "Test Title",
+				Body:       "Test Body",
+				BodyFormat: constanta.Markdown,
+				Tags:       testTags,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with sanitized html body",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return(nil, nil)
+				mockArticleRepo.EXPECT().CreateArticle(gomock.Any(), *article, *sanitizedArticleVersion).Return(int64(1), int64(2), nil)
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:      "Test Title",
+				Body:       `<p onclick="alert(1)">Test Body</p><script>alert(1)</script>`,
+				BodyFormat: constanta.HTML,
+				Tags:       testTags,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with sanitized html inside markdown body",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return(nil, nil)
+				mockArticleRepo.EXPECT().CreateArticle(gomock.Any(), *article, *sanitizedMarkdownArticleVersion).Return(int64(1), int64(2), nil)
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:      "Test Title",
+				Body:       "Test Body\n\n<script>alert(1)</script>\n",
+				BodyFormat: constanta.Markdown,
+				Tags:       testTags,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with blocks",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return(nil, nil)
+				mockArticleRepo.EXPECT().CreateArticle(gomock.Any(), *article, *blocksArticleVersion).Return(int64(1), int64(2), nil)
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:      "Test Title",
+				BodyFormat: constanta.Markdown,
+				Blocks:     blocks,
+				Tags:       testTags,
+			},
+			wantErr: false,
+		},
+		{
+			name: "html body without allowed html",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return(nil, nil)
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:      "Test Title",
+				Body:       `<script>alert(1)</script>`,
+				BodyFormat: constanta.HTML,
+				Tags:       testTags,
+			},
+			wantErr: true,
+		},
+		{
+			name: "requested slug is taken",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "taken", int64(0)).Return([]string{"taken"}, nil)
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:           "Test Title",
+				Body:            "Test Body",
+				BodyFormat:      constanta.Markdown,
+				Tags:            testTags,
+				ArticleMetadata: params.ArticleMetadata{Slug: "taken"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not valid tag",
+			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "c$").Return(nil, errs.ValidationError{Message: "not valid tag"})
+			},
+			ctx: ctx,
+			input: params.CreateArticleRequest{
+				Title:      "Test Title",
+				Body:       "Test Body",
+				BodyFormat: constanta.Markdown,
+				Tags:       []string{"go", "c$"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "error from repo",
 			prepare: func() {
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "test-title", int64(0)).Return(nil, nil)
 				mockArticleRepo.EXPECT().CreateArticle(gomock.Any(), *article, *articleVersion).Return(int64(0), int64(0), errors.New("repo error"))
 			},
 			ctx: ctx,
 			input: params.CreateArticleRequest{
-				Title: "Test Title",
-				Body:  "Test Body",
-				Tags:  testTags,
+				Title:      "Test Title",
+				Body:       "Test Body",
+				BodyFormat: constanta.Markdown,
+				Tags:       testTags,
 			},
 			wantErr: true,
 		},
@@ -84,8 +219,8 @@ func TestArticleService_DeleteArticle(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	tests := []struct {
 		name    string
@@ -97,7 +232,6 @@ func TestArticleService_DeleteArticle(t *testing.T) {
 			name: "success",
 			prepare: func() {
 				mockArticleRepo.EXPECT().DeleteArticle(gomock.Any(), int64(1)).Return(nil)
-				mockTagTrigger.EXPECT().CreateTagTrigger(gomock.Any(), gomock.Any())
 			},
 			input:   1,
 			wantErr: false,
@@ -128,14 +262,13 @@ func TestArticleService_UpdateStatusArticle(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	testUserID := uuid.New()
 	ctx := context.WithValue(context.Background(), constanta.LocalUserID, testUserID)
 
-	articleVersion := &entity.ArticleVersion{Status: constanta.Draft}
-	articleTags := []entity.Tag{{Name: "go"}}
+	articleVersion := &entity.ArticleVersion{Status: constanta.Draft, ArticleMetadata: entity.ArticleMetadata{Slug: "hello-world"}}
 
 	tests := []struct {
 		name    string
@@ -147,8 +280,6 @@ func TestArticleService_UpdateStatusArticle(t *testing.T) {
 			name: "success",
 			prepare: func() {
 				mockArticleRepo.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(1)).Return(articleVersion, nil)
-				mockArticleRepo.EXPECT().GetTagsWithArticleVersionID(gomock.Any(), int64(1)).Return(articleTags, nil)
-				mockTagTrigger.EXPECT().CreateTagTrigger(gomock.Any(), gomock.Any())
 				mockArticleRepo.EXPECT().UpdateArticleStatus(gomock.Any(), int64(1), int64(1), constanta.Published, constanta.Draft, testUserID).Return(nil)
 			},
 			ctx:     ctx,
@@ -180,8 +311,9 @@ func TestArticleService_CreateArticleVersionWithReferenceFromArticleID(t *testin
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	mockArticleLocker := service_mock.NewMockarticleLocker(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, mockArticleLocker, cache.NewMemoryCache(0))
 
 	testUserID := uuid.New()
 	testTags := []string{"go", "cms"}
@@ -201,14 +333,27 @@ func TestArticleService_CreateArticleVersionWithReferenceFromArticleID(t *testin
 			name: "success",
 			prepare: func() {
 				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+				mockArticleLocker.EXPECT().CheckArticleLock(gomock.Any(), int64(1), testUserID).Return(nil)
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "v2", int64(1)).Return(nil, nil)
 				mockArticleRepo.EXPECT().CreateArticleVersion(gomock.Any(), gomock.Any()).Return(int64(2), nil)
-				mockTagTrigger.EXPECT().CreateTagTrigger(gomock.Any(), gomock.Any())
 			},
 			ctx:     ctx,
 			inputID: 1,
-			input:   params.CreateArticleVersionRequest{Title: "v2", Body: "b2", Tags: testTags},
+			input:   params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags},
 			wantErr: false,
 		},
+		{
+			name: "locked by another user",
+			prepare: func() {
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+				mockArticleLocker.EXPECT().CheckArticleLock(gomock.Any(), int64(1), testUserID).Return(errs.Conflict{Message: "the article is locked"})
+			},
+			ctx:     ctx,
+			inputID: 1,
+			input:   params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags},
+			wantErr: true,
+		},
 		{
 			name: "not found",
 			prepare: func() {
@@ -216,7 +361,7 @@ func TestArticleService_CreateArticleVersionWithReferenceFromArticleID(t *testin
 			},
 			ctx:     ctx,
 			inputID: 2,
-			input:   params.CreateArticleVersionRequest{Title: "v2", Body: "b2", Tags: testTags},
+			input:   params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags},
 			wantErr: true,
 		},
 	}
@@ -237,14 +382,17 @@ func TestArticleService_CreateArticleVersionWithReferenceFromArticleIDAindVersio
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	mockArticleLocker := service_mock.NewMockarticleLocker(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, mockArticleLocker, cache.NewMemoryCache(0))
 
 	testUserID := uuid.New()
 	testTags := []string{"go", "cms"}
 	ctx := context.WithValue(context.Background(), constanta.LocalUserID, testUserID)
 	article := &entity.Article{ID: 1, VersionSequence: 1}
 	articleVersion := &entity.ArticleVersion{Title: "v2", Body: "b2"}
+	currentArticleVersion := &entity.ArticleVersion{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown}
+	currentArticleVersion.SetMetadata(entity.ArticleMetadata{Slug: "v2"})
 
 	tests := []struct {
 		name       string
@@ -259,17 +407,73 @@ func TestArticleService_CreateArticleVersionWithReferenceFromArticleIDAindVersio
 			name: "success",
 			prepare: func() {
 				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+				mockArticleLocker.EXPECT().CheckArticleLock(gomock.Any(), int64(1), testUserID).Return(nil)
 				mockArticleRepo.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(1)).Return(articleVersion, nil)
 				mockArticleRepo.EXPECT().GetTagsWithArticleVersionID(gomock.Any(), int64(1)).Return([]entity.Tag{}, nil)
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "v2", int64(1)).Return(nil, nil)
 				mockArticleRepo.EXPECT().CreateArticleVersion(gomock.Any(), gomock.Any()).Return(int64(2), nil)
-				mockTagTrigger.EXPECT().CreateTagTrigger(gomock.Any(), gomock.Any())
 			},
 			ctx:        ctx,
 			inputID:    1,
 			inputVerID: 1,
-			input:      params.CreateArticleVersionRequest{Title: "v2", Body: "b2", Tags: testTags},
+			input:      params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags},
 			wantErr:    false,
 		},
+		{
+			name: "keeps the seo metadata of the reference version",
+			prepare: func() {
+				seoArticleVersion := &entity.ArticleVersion{Title: "v1", Body: "b1"}
+				seoArticleVersion.SetMetadata(entity.ArticleMetadata{Slug: "v1", SEOTitle: "seo title", SEODescription: "seo description", CanonicalURL: "https://cms.test/v1", OGImage: "https://cms.test/v1.png"})
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+				mockArticleLocker.EXPECT().CheckArticleLock(gomock.Any(), int64(1), testUserID).Return(nil)
+				mockArticleRepo.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(4)).Return(seoArticleVersion, nil)
+				mockArticleRepo.EXPECT().GetTagsWithArticleVersionID(gomock.Any(), int64(4)).Return([]entity.Tag{}, nil)
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "go", "cms").Return(testTags, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "v2", int64(1)).Return(nil, nil)
+				mockArticleRepo.EXPECT().CreateArticleVersion(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, articleVersion entity.ArticleVersion) (int64, error) {
+					assert.Equal(t, "seo title", articleVersion.SEOTitle)
+					assert.Equal(t, "a new description", articleVersion.SEODescription)
+					assert.Equal(t, "https://cms.test/v1", articleVersion.CanonicalURL)
+					assert.Equal(t, "https://cms.test/v1.png", articleVersion.OGImage)
+					return 5, nil
+				})
+			},
+			ctx:        ctx,
+			inputID:    1,
+			inputVerID: 4,
+			input: params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags,
+				ArticleMetadata: params.ArticleMetadata{SEODescription: "a new description"}},
+			wantErr: false,
+		},
+		{
+			name: "same as the current version after normalizing the tags",
+			prepare: func() {
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+				mockArticleLocker.EXPECT().CheckArticleLock(gomock.Any(), int64(1), testUserID).Return(nil)
+				mockArticleRepo.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(3)).Return(currentArticleVersion, nil)
+				mockArticleRepo.EXPECT().GetTagsWithArticleVersionID(gomock.Any(), int64(3)).Return(entity.NewTags("cms", "go"), nil)
+				mockTagNormalizer.EXPECT().NormalizeTags(gomock.Any(), "Go", " CMS ").Return([]string{"cms", "go"}, nil)
+				mockArticleRepo.EXPECT().GetTakenSlugs(gomock.Any(), "v2", int64(1)).Return(nil, nil)
+			},
+			ctx:        ctx,
+			inputID:    1,
+			inputVerID: 3,
+			input:      params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: []string{"Go", " CMS "}},
+			wantErr:    true,
+		},
+		{
+			name: "locked by another user",
+			prepare: func() {
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+				mockArticleLocker.EXPECT().CheckArticleLock(gomock.Any(), int64(1), testUserID).Return(errs.Conflict{Message: "the article is locked"})
+			},
+			ctx:        ctx,
+			inputID:    1,
+			inputVerID: 1,
+			input:      params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags},
+			wantErr:    true,
+		},
 		{
 			name: "not found",
 			prepare: func() {
@@ -278,7 +482,7 @@ func TestArticleService_CreateArticleVersionWithReferenceFromArticleIDAindVersio
 			ctx:        ctx,
 			inputID:    2,
 			inputVerID: 2,
-			input:      params.CreateArticleVersionRequest{Title: "v2", Body: "b2", Tags: testTags},
+			input:      params.CreateArticleVersionRequest{Title: "v2", Body: "b2", BodyFormat: constanta.Markdown, Tags: testTags},
 			wantErr:    true,
 		},
 	}
@@ -299,8 +503,8 @@ func TestArticleService_GetArticleWithID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	article := &entity.Article{ID: 1, DraftedVersionID: 1, PublishedVersionID: 1, ArchivedVersionID: 1}
 	version := &entity.ArticleVersion{ArticleID: 1, ArticleVersionID: 1, Title: "t", Body: "b", Version: 1, Status: constanta.Draft, Tags: []entity.Tag{{Name: "go"}}}
@@ -339,7 +543,7 @@ func TestArticleService_GetArticleWithID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			_, err := service.GetArticleWithID(tt.ctx, tt.inputID)
+			_, err := service.GetArticleWithID(tt.ctx, tt.inputID, entity.CacheValidator{Key: "v1"})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetArticleWithID() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -347,13 +551,136 @@ func TestArticleService_GetArticleWithID(t *testing.T) {
 	}
 }
 
+func TestArticleService_GetArticleWithID_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
+
+	article := &entity.Article{ID: 1, PublishedVersionID: 3}
+	version := &entity.ArticleVersion{ArticleID: 1, ArticleVersionID: 3, Title: "t", Body: "b", Version: 3, Status: constanta.Published}
+	publicCtx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, false)
+	editorCtx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, true)
+	expectArticle := func() {
+		mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(article, nil)
+		mockArticleRepo.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(3)).Return(version, nil)
+		mockArticleRepo.EXPECT().GetTagsWithArticleVersionID(gomock.Any(), int64(3)).Return([]entity.Tag{{Name: "go"}}, nil)
+	}
+
+	validator := entity.CacheValidator{Key: "v1"}
+
+	// the second public request is read from the cache
+	expectArticle()
+	first, err := service.GetArticleWithID(publicCtx, 1, validator)
+	assert.NoError(t, err)
+	second, err := service.GetArticleWithID(publicCtx, 1, validator)
+	assert.NoError(t, err)
+	assert.Equal(t, first.PublishedVersion.Tags, second.PublishedVersion.Tags)
+	assert.Equal(t, first.PublishedVersion.ArticleVersionID, second.PublishedVersion.ArticleVersionID)
+
+	// the cached detail of another validator is built again
+	expectArticle()
+	_, err = service.GetArticleWithID(publicCtx, 1, entity.CacheValidator{Key: "v2"})
+	assert.NoError(t, err)
+
+	// the editors do not use the cache
+	expectArticle()
+	_, err = service.GetArticleWithID(editorCtx, 1, validator)
+	assert.NoError(t, err)
+
+	// a delete invalidates the detail
+	mockArticleRepo.EXPECT().DeleteArticle(gomock.Any(), int64(1)).Return(nil)
+	assert.NoError(t, service.DeleteArticle(publicCtx, 1))
+
+	mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+	_, err = service.GetArticleWithID(publicCtx, 1, validator)
+	assert.Equal(t, errs.NotFound{Message: "article"}, err)
+}
+
+func TestArticleService_GetArticleCacheValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
+
+	validator := &entity.CacheValidator{Key: "v1"}
+	mockArticleRepo.EXPECT().GetArticleCacheValidator(gomock.Any(), int64(1)).Return(validator, nil)
+	mockArticleRepo.EXPECT().GetArticleCacheValidator(gomock.Any(), int64(2)).Return(nil, sql.ErrNoRows)
+
+	got, err := service.GetArticleCacheValidator(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, validator, got)
+
+	_, err = service.GetArticleCacheValidator(context.Background(), 2)
+	assert.Equal(t, errs.NotFound{Message: "article"}, err)
+}
+
+func TestArticleService_GetArticleIDBySlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
+
+	tests := []struct {
+		name     string
+		prepare  func()
+		slug     string
+		wantID   int64
+		wantSlug string
+		wantErr  error
+	}{
+		{
+			name: "success",
+			prepare: func() {
+				mockArticleRepo.EXPECT().GetArticleIDBySlug(gomock.Any(), "t").Return(int64(1), "t", nil)
+			},
+			slug:     "t",
+			wantID:   1,
+			wantSlug: "t",
+		},
+		{
+			name: "old slug returns the current slug",
+			prepare: func() {
+				mockArticleRepo.EXPECT().GetArticleIDBySlug(gomock.Any(), "old-t").Return(int64(1), "t", nil)
+			},
+			slug:     "old-t",
+			wantID:   1,
+			wantSlug: "t",
+		},
+		{
+			name: "not found",
+			prepare: func() {
+				mockArticleRepo.EXPECT().GetArticleIDBySlug(gomock.Any(), "missing").Return(int64(0), "", sql.ErrNoRows)
+			},
+			slug:    "missing",
+			wantErr: errs.NotFound{Message: "article with slug missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			gotID, gotSlug, err := service.GetArticleIDBySlug(context.Background(), tt.slug)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.Equal(t, tt.wantSlug, gotSlug)
+		})
+	}
+}
+
 func TestArticleService_GetArticleVersionWithIDAndArticleID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	version := &entity.ArticleVersion{ArticleID: 1, ArticleVersionID: 1, Title: "t", Body: "b", Version: 1, Status: constanta.Draft, Tags: []entity.Tag{{Name: "go"}}}
 	ctx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, true)
@@ -404,8 +731,8 @@ func TestArticleService_GetArticleVersions(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	ctx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, true)
 	articleVersions := []entity.ArticleVersion{{ArticleID: 1, ArticleVersionID: 1, Title: "t", Body: "b", Version: 1, Status: constanta.Draft}}
@@ -453,8 +780,8 @@ func TestArticleService_GetArticles(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
-	mockTagTrigger := service_mock.NewMocktagTrigger(ctrl)
-	service := NewArticleService(mockArticleRepo, mockTagTrigger)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
 
 	ctx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, true)
 	query := params.GetArticlesQueryParams{Search: "test"}
@@ -470,7 +797,7 @@ func TestArticleService_GetArticles(t *testing.T) {
 		{
 			name: "success",
 			prepare: func() {
-				mockArticleRepo.EXPECT().GetArticles(gomock.Any(), gomock.Any()).Return(articleVersions, nil)
+				mockArticleRepo.EXPECT().GetArticles(gomock.Any(), gomock.Any()).Return(articleVersions, int64(1), nil)
 			},
 			ctx:     ctx,
 			input:   query,
@@ -479,7 +806,7 @@ func TestArticleService_GetArticles(t *testing.T) {
 		{
 			name: "repo error",
 			prepare: func() {
-				mockArticleRepo.EXPECT().GetArticles(gomock.Any(), gomock.Any()).Return(nil, errors.New("repo error"))
+				mockArticleRepo.EXPECT().GetArticles(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("repo error"))
 			},
 			ctx:     ctx,
 			input:   query,
@@ -490,10 +817,109 @@ func TestArticleService_GetArticles(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			_, err := service.GetArticles(tt.ctx, tt.input)
+			_, _, err := service.GetArticles(tt.ctx, tt.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetArticles() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestArticleService_GetArticles_Cursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagNormalizer := service_mock.NewMocktagNormalizer(ctrl)
+	service := NewArticleService(mockArticleRepo, mockTagNormalizer, nil, cache.NewMemoryCache(0))
+
+	ctx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, false)
+	articleVersions := []entity.ArticleVersion{
+		{ArticleID: 3, ArticleVersionID: 3, Status: constanta.Published},
+		{ArticleID: 2, ArticleVersionID: 2, Status: constanta.Published},
+		{ArticleID: 1, ArticleVersionID: 1, Status: constanta.Published},
+	}
+
+	t.Run("first page has next cursor only", func(t *testing.T) {
+		query := params.GetArticlesQueryParams{PaginationParams: params.PaginationParams{Limit: 2, Sorts: []string{"article_id:desc"}}}
+		if err := query.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		mockArticleRepo.EXPECT().GetArticles(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, int64, error) {
+				if req.Limit != 3 {
+					t.Errorf("GetArticles() limit = %v, want 3", req.Limit)
+				}
+				return articleVersions, int64(len(articleVersions)), nil
+			})
+
+		got, pagination, err := service.GetArticles(ctx, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Errorf("GetArticles() len = %v, want 2", len(got))
+		}
+		if pagination.PrevCursor != "" {
+			t.Errorf("GetArticles() prev cursor = %v, want empty", pagination.PrevCursor)
+		}
+		if want := (params.PaginationResponse{Total: 3, Page: 1, Limit: 2, HasNext: true, NextCursor: pagination.NextCursor}); *pagination != want {
+			t.Errorf("GetArticles() pagination = %+v, want %+v", *pagination, want)
+		}
+
+		values, err := params.DecodeCursor(pagination.NextCursor, query.GetOrderClause())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"2", "2"}; !slices.Equal(values, want) {
+			t.Errorf("GetArticles() next cursor = %v, want %v", values, want)
+		}
+	})
+
+	t.Run("next page uses the cursor", func(t *testing.T) {
+		query := params.GetArticlesQueryParams{PaginationParams: params.PaginationParams{
+			Limit: 2,
+			Sorts: []string{"article_id:desc"},
+			After: params.EncodeCursor("article_id desc, article_version_id desc", []string{"2", "2"}),
+		}}
+		if err := query.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		mockArticleRepo.EXPECT().GetArticles(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, int64, error) {
+				if req.Cursor == nil || req.Cursor.Backward {
+					t.Errorf("GetArticles() cursor = %v, want forward cursor", req.Cursor)
+				}
+				return articleVersions[2:], int64(len(articleVersions)), nil
+			})
+
+		got, pagination, err := service.GetArticles(ctx, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 {
+			t.Errorf("GetArticles() len = %v, want 1", len(got))
+		}
+		if pagination.NextCursor != "" {
+			t.Errorf("GetArticles() next cursor = %v, want empty", pagination.NextCursor)
+		}
+		if pagination.PrevCursor == "" {
+			t.Errorf("GetArticles() prev cursor is empty")
+		}
+		if pagination.HasNext || pagination.Page != 0 || pagination.Total != 3 {
+			t.Errorf("GetArticles() pagination = %+v, want last cursor page of 3", *pagination)
+		}
+	})
+
+	t.Run("cursor with different sorts is rejected", func(t *testing.T) {
+		query := params.GetArticlesQueryParams{PaginationParams: params.PaginationParams{
+			Sorts: []string{"title:asc"},
+			After: params.EncodeCursor("article_id desc, article_version_id desc", []string{"2", "2"}),
+		}}
+		if err := query.Validate(); err == nil {
+			t.Errorf("Validate() error = nil, want error")
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
@@ -30,13 +31,23 @@ type (
 	AuthService struct {
 		UserRepo  userRepo
 		TokenRepo tokenRepo
+		// the role value of a user and the user of a token are read on every authenticated request
+		userRoles cached[int64]
+		tokens    cached[uuid.UUID]
 	}
 )
 
-func NewAuthService(userRepo userRepo, tokenRepo tokenRepo) *AuthService {
+const (
+	userRoleCacheTTL = time.Minute
+	tokenCacheTTL    = 5 * time.Minute
+)
+
+func NewAuthService(userRepo userRepo, tokenRepo tokenRepo, cacheStore cacheStore) *AuthService {
 	return &AuthService{
 		UserRepo:  userRepo,
 		TokenRepo: tokenRepo,
+		userRoles: newCached[int64](cacheStore, "user_role", userRoleCacheTTL),
+		tokens:    newCached[uuid.UUID](cacheStore, "token", tokenCacheTTL),
 	}
 }
 
@@ -77,6 +88,9 @@ func (as *AuthService) LoginUser(ctx context.Context, req params.LoginUserReques
 		return "", errs.InvalidCredential{}
 	}
 
+	// a changed role is read again after the next login, or after its ttl
+	as.userRoles.delete(ctx, user.ID)
+
 	token, err := as.TokenRepo.GetTokenByUserID(ctx, user.ID)
 	if err != nil && err != sql.ErrNoRows {
 		return "", err
@@ -99,6 +113,8 @@ func (as *AuthService) LoginUser(ctx context.Context, req params.LoginUserReques
 		return "", err
 	}
 
+	as.tokens.set(ctx, token.ID, user.ID)
+
 	return token.Token, nil
 }
 
@@ -110,6 +126,11 @@ func (as *AuthService) ProcessToken(ctx context.Context, reqToken string) (uuid.
 		return uuid.UUID{}, err
 	}
 
+	// the expiry is checked with the signature above, so a cached token is not used after it expires
+	if userID, ok := as.tokens.get(ctx, tokenID); ok {
+		return userID, nil
+	}
+
 	token, err = as.TokenRepo.GetTokenByTokenID(ctx, tokenID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -118,10 +139,20 @@ func (as *AuthService) ProcessToken(ctx context.Context, reqToken string) (uuid.
 		return uuid.UUID{}, err
 	}
 
+	as.tokens.set(ctx, tokenID, token.UserID)
+
 	return token.UserID, nil
 }
 
 func (as *AuthService) GetUserRoleByUserID(ctx context.Context, id uuid.UUID) (*entity.UserRole, error) {
+	if val, ok := as.userRoles.get(ctx, id); ok {
+		userRole := &entity.UserRole{}
+		if err := userRole.Scan(val); err != nil {
+			return nil, err
+		}
+		return userRole, nil
+	}
+
 	userRole, err := as.UserRepo.GetUserRoleByUserID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -129,5 +160,8 @@ func (as *AuthService) GetUserRoleByUserID(ctx context.Context, id uuid.UUID) (*
 		}
 		return nil, err
 	}
+
+	as.userRoles.set(ctx, id, userRole.GetValue())
+
 	return userRole, nil
 }
@@ -5,9 +5,12 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/elangreza/content-management-system/internal/cache"
+	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
 	"github.com/elangreza/content-management-system/internal/params"
 	mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/elangreza/content-management-system/internal/sharevar"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -66,10 +69,7 @@ func TestAuthService_RegisterUser(t *testing.T) {
 			if tt.prepare != nil {
 				tt.prepare(f)
 			}
-			svc := &AuthService{
-				UserRepo:  f.userRepo,
-				TokenRepo: f.tokenRepo,
-			}
+			svc := NewAuthService(f.userRepo, f.tokenRepo, cache.NewMemoryCache(0))
 			err := svc.RegisterUser(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -131,10 +131,7 @@ func TestAuthService_LoginUser(t *testing.T) {
 			if tt.prepare != nil {
 				tt.prepare(f)
 			}
-			svc := &AuthService{
-				UserRepo:  f.userRepo,
-				TokenRepo: f.tokenRepo,
-			}
+			svc := NewAuthService(f.userRepo, f.tokenRepo, cache.NewMemoryCache(0))
 			_, err := svc.LoginUser(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -176,9 +173,7 @@ func TestAuthService_ProcessToken(t *testing.T) {
 			if tt.prepare != nil {
 				tt.prepare(f)
 			}
-			svc := &AuthService{
-				TokenRepo: f.tokenRepo,
-			}
+			svc := NewAuthService(nil, f.tokenRepo, cache.NewMemoryCache(0))
 			_, err := svc.ProcessToken(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -229,9 +224,7 @@ func TestAuthService_GetUserRoleByUserID(t *testing.T) {
 			if tt.prepare != nil {
 				tt.prepare(f)
 			}
-			svc := &AuthService{
-				UserRepo: f.userRepo,
-			}
+			svc := NewAuthService(f.userRepo, nil, cache.NewMemoryCache(0))
 			_, err := svc.GetUserRoleByUserID(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -241,3 +234,45 @@ func TestAuthService_GetUserRoleByUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_GetUserRoleByUserID_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mock.NewMockuserRepo(ctrl)
+	tokenRepo := mock.NewMocktokenRepo(ctrl)
+	svc := NewAuthService(userRepo, tokenRepo, cache.NewMemoryCache(0))
+
+	userID := uuid.New()
+	userRole := &entity.UserRole{}
+	assert.NoError(t, userRole.Scan(sharevar.Editor.GetValue()))
+
+	// the second request is read from the cache
+	userRepo.EXPECT().GetUserRoleByUserID(gomock.Any(), userID).Return(userRole, nil).Times(1)
+	for range 2 {
+		got, err := svc.GetUserRoleByUserID(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.True(t, got.HasPermission(constanta.ManageTag))
+	}
+
+	// login reads the role again
+	user, err := entity.NewUser("editor@cms.test", "password", "editor")
+	assert.NoError(t, err)
+	user.ID = userID
+	userRepo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+	tokenRepo.EXPECT().GetTokenByUserID(gomock.Any(), userID).Return(nil, sql.ErrNoRows)
+	tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
+	token, err := svc.LoginUser(context.Background(), params.LoginUserRequest{Email: user.Email, Password: "password"})
+	assert.NoError(t, err)
+
+	contentWriter := &entity.UserRole{}
+	assert.NoError(t, contentWriter.Scan(sharevar.ContentWriter.GetValue()))
+	userRepo.EXPECT().GetUserRoleByUserID(gomock.Any(), userID).Return(contentWriter, nil)
+	got, err := svc.GetUserRoleByUserID(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.False(t, got.HasPermission(constanta.ManageTag))
+
+	// the token of the login is cached, so it is processed without the repository
+	gotUserID, err := svc.ProcessToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, gotUserID)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/cache"
+)
+
+type (
+	cacheStore interface {
+		Get(ctx context.Context, key string) ([]byte, error)
+		Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+		Delete(ctx context.Context, keys ...string) error
+	}
+
+	// cached keeps the values of one kind as JSON under its prefix. The errors of the store are only logged,
+	// the caller loads the value from the database when it is not found.
+	cached[T any] struct {
+		store  cacheStore
+		prefix string
+		ttl    time.Duration
+	}
+)
+
+func newCached[T any](store cacheStore, prefix string, ttl time.Duration) cached[T] {
+	return cached[T]{
+		store:  store,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+func (c cached[T]) key(id any) string {
+	return fmt.Sprintf("%s:%v", c.prefix, id)
+}
+
+func (c cached[T]) get(ctx context.Context, id any) (T, bool) {
+	var value T
+	raw, err := c.store.Get(ctx, c.key(id))
+	if err != nil {
+		if !errors.Is(err, cache.ErrNotFound) {
+			slog.Warn("failed to get cache", "key", c.key(id), "error", err)
+		}
+		return value, false
+	}
+
+	if err := json.Unmarshal(raw, &value); err != nil {
+		slog.Warn("failed to decode cache", "key", c.key(id), "error", err)
+		return value, false
+	}
+
+	return value, true
+}
+
+func (c cached[T]) set(ctx context.Context, id any, value T) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		slog.Warn("failed to encode cache", "key", c.key(id), "error", err)
+		return
+	}
+
+	if err := c.store.Set(ctx, c.key(id), raw, c.ttl); err != nil {
+		slog.Warn("failed to set cache", "key", c.key(id), "error", err)
+	}
+}
+
+// delete is called after a write, a failed delete is only logged because the entry expires after the ttl.
+func (c cached[T]) delete(ctx context.Context, ids ...any) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, c.key(id))
+	}
+
+	if err := c.store.Delete(ctx, keys...); err != nil {
+		slog.Warn("failed to delete cache", "keys", keys, "error", err)
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/google/uuid"
+)
+
+type (
+	categoryRepo interface {
+		CreateCategory(ctx context.Context, category entity.Category) (int64, error)
+		GetCategoryWithID(ctx context.Context, id int64) (*entity.Category, error)
+		GetCategories(ctx context.Context, path string) ([]entity.Category, error)
+		GetCategoriesWithIDs(ctx context.Context, ids []int64) ([]entity.Category, error)
+		UpdateCategory(ctx context.Context, category entity.Category, oldPath string) error
+		DeleteCategory(ctx context.Context, id int64) error
+		SetArticleCategories(ctx context.Context, articleID int64, primaryCategoryID int64, categoryIDs []int64) error
+		GetArticleCategories(ctx context.Context, articleID int64) ([]entity.ArticleCategory, error)
+	}
+
+	CategoryService struct {
+		categoryRepo categoryRepo
+	}
+)
+
+func NewCategoryService(categoryRepo categoryRepo) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// => POST /categories
+func (cs *CategoryService) CreateCategory(ctx context.Context, req params.CategoryRequest) (*params.CategoryResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	id, err := cs.categoryRepo.CreateCategory(ctx, entity.Category{
+		ParentID:  req.ParentID,
+		Name:      req.Name,
+		Slug:      req.Slug,
+		Position:  req.Position,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.GetCategory(ctx, id)
+}
+
+// => GET /categories
+func (cs *CategoryService) GetCategories(ctx context.Context) ([]params.CategoryResponse, error) {
+	categories, err := cs.categoryRepo.GetCategories(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return params.NewCategoryTree(categories), nil
+}
+
+// => GET /categories/{id}
+// The category is returned with its subcategories.
+func (cs *CategoryService) GetCategory(ctx context.Context, id int64) (*params.CategoryResponse, error) {
+	category, err := cs.getCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subtree, err := cs.categoryRepo.GetCategories(ctx, category.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range params.NewCategoryTree(subtree) {
+		if node.ID == id {
+			return &node, nil
+		}
+	}
+
+	// the category is deleted after it is read
+	return nil, errs.NotFound{Message: "category"}
+}
+
+// => PUT /categories/{id}
+// Changing the parent moves the category with its subcategories.
+func (cs *CategoryService) UpdateCategory(ctx context.Context, id int64, req params.CategoryRequest) (*params.CategoryResponse, error) {
+	category, err := cs.getCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPath := category.Path
+	if !equalParentID(category.ParentID, req.ParentID) {
+		// without a parent the category becomes a root category
+		parent := &entity.Category{Depth: -1}
+		if req.ParentID != nil {
+			parent, err = cs.categoryRepo.GetCategoryWithID(ctx, *req.ParentID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return nil, errs.NotFound{Message: "parent category"}
+				}
+				return nil, err
+			}
+
+			if category.Contains(*parent) {
+				return nil, errs.ValidationError{Message: "category cannot be moved under itself or its subcategories"}
+			}
+		}
+
+		category.Path = entity.CategoryPath(parent.Path, category.ID)
+		category.Depth = parent.Depth + 1
+	}
+
+	category.ParentID = req.ParentID
+	category.Name = req.Name
+	category.Slug = req.Slug
+	category.Position = req.Position
+
+	if err := cs.categoryRepo.UpdateCategory(ctx, *category, oldPath); err != nil {
+		return nil, err
+	}
+
+	return cs.GetCategory(ctx, id)
+}
+
+// => DELETE /categories/{id}
+// Only a category without subcategories and articles can be deleted.
+func (cs *CategoryService) DeleteCategory(ctx context.Context, id int64) error {
+	if _, err := cs.getCategory(ctx, id); err != nil {
+		return err
+	}
+
+	return cs.categoryRepo.DeleteCategory(ctx, id)
+}
+
+// => PUT /articles/{id}/categories
+func (cs *CategoryService) SetArticleCategories(ctx context.Context, articleID int64, req params.SetArticleCategoriesRequest) (*params.ArticleCategoriesResponse, error) {
+	if err := cs.categoryRepo.SetArticleCategories(ctx, articleID, req.PrimaryCategoryID, req.CategoryIDs()); err != nil {
+		return nil, err
+	}
+
+	return cs.GetArticleCategories(ctx, articleID)
+}
+
+// GetArticleCategories returns the categories of the article with the breadcrumbs of the primary category.
+func (cs *CategoryService) GetArticleCategories(ctx context.Context, articleID int64) (*params.ArticleCategoriesResponse, error) {
+	categories, err := cs.categoryRepo.GetArticleCategories(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &params.ArticleCategoriesResponse{
+		SecondaryCategories: []params.CategoryResponse{},
+		Breadcrumbs:         []params.BreadcrumbResponse{},
+	}
+
+	var primary *entity.Category
+	for _, category := range categories {
+		if category.IsPrimary {
+			primary = &category.Category
+			primaryResponse := params.NewCategoryResponse(category.Category)
+			res.PrimaryCategory = &primaryResponse
+			continue
+		}
+		res.SecondaryCategories = append(res.SecondaryCategories, params.NewCategoryResponse(category.Category))
+	}
+
+	if primary == nil {
+		return res, nil
+	}
+
+	ancestors, err := cs.categoryRepo.GetCategoriesWithIDs(ctx, primary.PathIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ancestor := range ancestors {
+		res.Breadcrumbs = append(res.Breadcrumbs, params.BreadcrumbResponse{
+			ID:   ancestor.ID,
+			Name: ancestor.Name,
+			Slug: ancestor.Slug,
+		})
+	}
+
+	return res, nil
+}
+
+func (cs *CategoryService) getCategory(ctx context.Context, id int64) (*entity.Category, error) {
+	category, err := cs.categoryRepo.GetCategoryWithID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound{Message: "category"}
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func equalParentID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
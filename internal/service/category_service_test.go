@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_category_repo.go -package=service_mock . categoryRepo
+
+func int64Pointer(val int64) *int64 {
+	return &val
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+	req := params.CategoryRequest{Name: "Local", Slug: "local", ParentID: int64Pointer(4), Position: 1}
+
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MockcategoryRepo)
+		want    *params.CategoryResponse
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().CreateCategory(gomock.Any(), entity.Category{
+					ParentID:  req.ParentID,
+					Name:      "Local",
+					Slug:      "local",
+					Position:  1,
+					CreatedBy: userID,
+				}).Return(int64(9), nil)
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(9)).Return(&entity.Category{ID: 9, ParentID: req.ParentID, Name: "Local", Slug: "local", Path: "/1/4/9/", Depth: 2, Position: 1}, nil)
+				repo.EXPECT().GetCategories(gomock.Any(), "/1/4/9/").Return([]entity.Category{
+					{ID: 9, ParentID: req.ParentID, Name: "Local", Slug: "local", Path: "/1/4/9/", Depth: 2, Position: 1},
+				}, nil)
+			},
+			want: &params.CategoryResponse{ID: 9, ParentID: req.ParentID, Name: "Local", Slug: "local", Depth: 2, Position: 1},
+		},
+		{
+			name: "parent not found",
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(int64(0), errs.NotFound{Message: "parent category"})
+			},
+			wantErr: errs.NotFound{Message: "parent category"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCategoryRepo := service_mock.NewMockcategoryRepo(ctrl)
+			s := NewCategoryService(mockCategoryRepo)
+			tt.setup(mockCategoryRepo)
+
+			got, err := s.CreateCategory(ctx, req)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCategoryService_GetCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCategoryRepo := service_mock.NewMockcategoryRepo(ctrl)
+	s := NewCategoryService(mockCategoryRepo)
+
+	mockCategoryRepo.EXPECT().GetCategories(gomock.Any(), "").Return([]entity.Category{
+		{ID: 1, Name: "News", Path: "/1/"},
+		{ID: 2, Name: "Sport", Path: "/2/", Position: 1},
+		{ID: 4, ParentID: int64Pointer(1), Name: "Politics", Path: "/1/4/", Depth: 1},
+		{ID: 5, ParentID: int64Pointer(1), Name: "Economy", Path: "/1/5/", Depth: 1, Position: 1},
+		{ID: 9, ParentID: int64Pointer(4), Name: "Local", Path: "/1/4/9/", Depth: 2},
+	}, nil)
+
+	got, err := s.GetCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "News", got[0].Name)
+	assert.Equal(t, "Sport", got[1].Name)
+	assert.Len(t, got[0].Children, 2)
+	assert.Equal(t, "Politics", got[0].Children[0].Name)
+	assert.Equal(t, "Economy", got[0].Children[1].Name)
+	assert.Equal(t, "Local", got[0].Children[0].Children[0].Name)
+	assert.Empty(t, got[1].Children)
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	category := func() *entity.Category {
+		return &entity.Category{ID: 4, ParentID: int64Pointer(1), Name: "Politics", Slug: "politics", Path: "/1/4/", Depth: 1}
+	}
+
+	tests := []struct {
+		name    string
+		req     params.CategoryRequest
+		setup   func(*service_mock.MockcategoryRepo)
+		wantErr error
+	}{
+		{
+			name: "success renaming",
+			req:  params.CategoryRequest{Name: "Politic", Slug: "politic", ParentID: int64Pointer(1), Position: 3},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(category(), nil).Times(2)
+				repo.EXPECT().UpdateCategory(gomock.Any(), entity.Category{ID: 4, ParentID: int64Pointer(1), Name: "Politic", Slug: "politic", Path: "/1/4/", Depth: 1, Position: 3}, "/1/4/").Return(nil)
+				repo.EXPECT().GetCategories(gomock.Any(), "/1/4/").Return([]entity.Category{*category()}, nil)
+			},
+		},
+		{
+			name: "success moving under another parent",
+			req:  params.CategoryRequest{Name: "Politics", Slug: "politics", ParentID: int64Pointer(7)},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(category(), nil)
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(7)).Return(&entity.Category{ID: 7, Path: "/2/7/", Depth: 1}, nil)
+				repo.EXPECT().UpdateCategory(gomock.Any(), entity.Category{ID: 4, ParentID: int64Pointer(7), Name: "Politics", Slug: "politics", Path: "/2/7/4/", Depth: 2}, "/1/4/").Return(nil)
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(&entity.Category{ID: 4, Path: "/2/7/4/"}, nil)
+				repo.EXPECT().GetCategories(gomock.Any(), "/2/7/4/").Return([]entity.Category{{ID: 4, Path: "/2/7/4/"}}, nil)
+			},
+		},
+		{
+			name: "success moving to the root",
+			req:  params.CategoryRequest{Name: "Politics", Slug: "politics"},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(category(), nil)
+				repo.EXPECT().UpdateCategory(gomock.Any(), entity.Category{ID: 4, Name: "Politics", Slug: "politics", Path: "/4/"}, "/1/4/").Return(nil)
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(&entity.Category{ID: 4, Path: "/4/"}, nil)
+				repo.EXPECT().GetCategories(gomock.Any(), "/4/").Return([]entity.Category{{ID: 4, Path: "/4/"}}, nil)
+			},
+		},
+		{
+			name: "moving under its subcategory",
+			req:  params.CategoryRequest{Name: "Politics", Slug: "politics", ParentID: int64Pointer(9)},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(category(), nil)
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(9)).Return(&entity.Category{ID: 9, Path: "/1/4/9/", Depth: 2}, nil)
+			},
+			wantErr: errs.ValidationError{Message: "category cannot be moved under itself or its subcategories"},
+		},
+		{
+			name: "moving under itself",
+			req:  params.CategoryRequest{Name: "Politics", Slug: "politics", ParentID: int64Pointer(4)},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(category(), nil).Times(2)
+			},
+			wantErr: errs.ValidationError{Message: "category cannot be moved under itself or its subcategories"},
+		},
+		{
+			name: "parent not found",
+			req:  params.CategoryRequest{Name: "Politics", Slug: "politics", ParentID: int64Pointer(8)},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(category(), nil)
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(8)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "parent category"},
+		},
+		{
+			name: "category not found",
+			req:  params.CategoryRequest{Name: "Politics", Slug: "politics"},
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "category"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCategoryRepo := service_mock.NewMockcategoryRepo(ctrl)
+			s := NewCategoryService(mockCategoryRepo)
+			tt.setup(mockCategoryRepo)
+
+			_, err := s.UpdateCategory(context.Background(), 4, tt.req)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MockcategoryRepo)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(&entity.Category{ID: 4}, nil)
+				repo.EXPECT().DeleteCategory(gomock.Any(), int64(4)).Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "category"},
+		},
+		{
+			name: "has subcategories",
+			setup: func(repo *service_mock.MockcategoryRepo) {
+				repo.EXPECT().GetCategoryWithID(gomock.Any(), int64(4)).Return(&entity.Category{ID: 4}, nil)
+				repo.EXPECT().DeleteCategory(gomock.Any(), int64(4)).Return(errs.Conflict{Message: "category has subcategories"})
+			},
+			wantErr: errs.Conflict{Message: "category has subcategories"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCategoryRepo := service_mock.NewMockcategoryRepo(ctrl)
+			s := NewCategoryService(mockCategoryRepo)
+			tt.setup(mockCategoryRepo)
+
+			err := s.DeleteCategory(context.Background(), 4)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCategoryService_SetArticleCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCategoryRepo := service_mock.NewMockcategoryRepo(ctrl)
+	s := NewCategoryService(mockCategoryRepo)
+
+	local := entity.Category{ID: 9, ParentID: int64Pointer(4), Name: "Local", Slug: "local", Path: "/1/4/9/", Depth: 2}
+	opinion := entity.Category{ID: 7, Name: "Opinion", Slug: "opinion", Path: "/7/"}
+
+	mockCategoryRepo.EXPECT().SetArticleCategories(gomock.Any(), int64(1), int64(9), []int64{9, 7}).Return(nil)
+	mockCategoryRepo.EXPECT().GetArticleCategories(gomock.Any(), int64(1)).Return([]entity.ArticleCategory{
+		{Category: local, IsPrimary: true},
+		{Category: opinion},
+	}, nil)
+	mockCategoryRepo.EXPECT().GetCategoriesWithIDs(gomock.Any(), []int64{1, 4, 9}).Return([]entity.Category{
+		{ID: 1, Name: "News", Slug: "news"},
+		{ID: 4, Name: "Politics", Slug: "politics"},
+		local,
+	}, nil)
+
+	got, err := s.SetArticleCategories(context.Background(), 1, params.SetArticleCategoriesRequest{
+		PrimaryCategoryID:    9,
+		SecondaryCategoryIDs: []int64{7},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), got.PrimaryCategory.ID)
+	assert.Equal(t, []params.CategoryResponse{params.NewCategoryResponse(opinion)}, got.SecondaryCategories)
+	assert.Equal(t, []params.BreadcrumbResponse{
+		{ID: 1, Name: "News", Slug: "news"},
+		{ID: 4, Name: "Politics", Slug: "politics"},
+		{ID: 9, Name: "Local", Slug: "local"},
+	}, got.Breadcrumbs)
+}
+
+func TestCategoryService_GetArticleCategories_WithoutCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCategoryRepo := service_mock.NewMockcategoryRepo(ctrl)
+	s := NewCategoryService(mockCategoryRepo)
+
+	mockCategoryRepo.EXPECT().GetArticleCategories(gomock.Any(), int64(1)).Return(nil, nil)
+
+	got, err := s.GetArticleCategories(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Nil(t, got.PrimaryCategory)
+	assert.Empty(t, got.SecondaryCategories)
+	assert.Empty(t, got.Breadcrumbs)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/elangreza/content-management-system/internal/cdn"
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+)
+
+type (
+	CDNService struct {
+		jobs jobQueue
+		// nil when no CDN is configured
+		purger cdn.Purger
+	}
+)
+
+// NewCDNService purges through the job queue, so a CDN which is down does not fail the write and is retried.
+func NewCDNService(jobs jobQueue, events eventSubscriber, purger cdn.Purger) *CDNService {
+	cs := &CDNService{
+		jobs:   jobs,
+		purger: purger,
+	}
+
+	jobs.Register(constanta.JobPurgeCDN, cs.purgeJob)
+
+	// publishing replaces the public version and archiving removes it, a new draft is not public
+	events.Subscribe(constanta.EventVersionPublished, "cdn", cs.purgeArticleEvent)
+	events.Subscribe(constanta.EventVersionArchived, "cdn", cs.purgeArticleEvent)
+	events.Subscribe(constanta.EventArticleDeleted, "cdn", cs.purgeArticleEvent)
+
+	return cs
+}
+
+// purgeArticleEvent invalidates the public reads which contain the article: its detail by id, by slug and by the slug
+// of the version it replaced, and the lists.
+func (cs *CDNService) purgeArticleEvent(ctx context.Context, event entity.OutboxEvent) error {
+	if cs.purger == nil {
+		return nil
+	}
+
+	var data entity.ArticleEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("invalid payload for %s event: %w", event.Type, err)
+	}
+
+	paths := []string{fmt.Sprintf("/articles/%d", data.ArticleID), "/articles"}
+	if data.Slug != "" {
+		paths = append(paths, "/articles/by-slug/"+url.PathEscape(data.Slug))
+	}
+	// the previous slug is redirected to the new one from now on
+	if data.PreviousSlug != "" {
+		paths = append(paths, "/articles/by-slug/"+url.PathEscape(data.PreviousSlug))
+	}
+
+	return cs.jobs.Enqueue(ctx, constanta.JobPurgeCDN, entity.PurgeCDNPayload{Paths: paths})
+}
+
+func (cs *CDNService) purgeJob(ctx context.Context, rawPayload json.RawMessage) error {
+	var payload entity.PurgeCDNPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s job: %w", constanta.JobPurgeCDN, err)
+	}
+
+	// the CDN was configured when the job was enqueued, but not anymore
+	if cs.purger == nil {
+		return nil
+	}
+
+	return cs.purger.Purge(ctx, payload.Paths)
+}
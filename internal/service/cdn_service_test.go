@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type purgeFunc func(ctx context.Context, paths []string) error
+
+func (f purgeFunc) Purge(ctx context.Context, paths []string) error {
+	return f(ctx, paths)
+}
+
+func TestCDNService_purgeArticleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Register(constanta.JobPurgeCDN, gomock.Any())
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	var purged []string
+	s := NewCDNService(mockJobQueue, mockEventSubscriber, purgeFunc(func(ctx context.Context, paths []string) error {
+		purged = paths
+		return nil
+	}))
+
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobPurgeCDN, entity.PurgeCDNPayload{
+		Paths: []string{"/articles/3", "/articles", "/articles/by-slug/hello%20world"},
+	}).Return(nil)
+	err := s.purgeArticleEvent(context.Background(), entity.OutboxEvent{
+		Type:    constanta.EventVersionPublished,
+		Payload: []byte(`{"article_id":3,"article_version_id":4,"slug":"hello world"}`),
+	})
+	assert.NoError(t, err)
+
+	// the replaced version had another slug
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobPurgeCDN, entity.PurgeCDNPayload{
+		Paths: []string{"/articles/3", "/articles", "/articles/by-slug/hello-world", "/articles/by-slug/hello"},
+	}).Return(nil)
+	err = s.purgeArticleEvent(context.Background(), entity.OutboxEvent{
+		Type:    constanta.EventVersionPublished,
+		Payload: []byte(`{"article_id":3,"article_version_id":5,"slug":"hello-world","previous_slug":"hello"}`),
+	})
+	assert.NoError(t, err)
+
+	payload, _ := json.Marshal(entity.PurgeCDNPayload{Paths: []string{"/articles/3", "/articles"}})
+	assert.NoError(t, s.purgeJob(context.Background(), payload))
+	assert.Equal(t, []string{"/articles/3", "/articles"}, purged)
+}
+
+func TestCDNService_purgeJob_Failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Register(constanta.JobPurgeCDN, gomock.Any())
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	s := NewCDNService(mockJobQueue, mockEventSubscriber, purgeFunc(func(ctx context.Context, paths []string) error {
+		return errors.New("cdn down")
+	}))
+
+	// the error is returned, so the job is retried
+	err := s.purgeJob(context.Background(), []byte(`{"Paths":["/articles"]}`))
+	assert.EqualError(t, err, "cdn down")
+}
+
+func TestCDNService_WithoutPurger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Register(constanta.JobPurgeCDN, gomock.Any())
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	s := NewCDNService(mockJobQueue, mockEventSubscriber, nil)
+
+	// nothing is enqueued
+	err := s.purgeArticleEvent(context.Background(), entity.OutboxEvent{Type: constanta.EventArticleDeleted, Payload: []byte(`{"article_id":3}`)})
+	assert.NoError(t, err)
+	assert.NoError(t, s.purgeJob(context.Background(), []byte(`{"Paths":["/articles"]}`)))
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/google/uuid"
+)
+
+type (
+	commentRepo interface {
+		CreateArticleComment(ctx context.Context, comment entity.ArticleComment) (int64, error)
+		GetArticleComment(ctx context.Context, id int64) (*entity.ArticleComment, error)
+		GetArticleComments(ctx context.Context, articleVersionID int64) ([]entity.ArticleComment, error)
+		ResolveArticleComment(ctx context.Context, id int64, resolvedBy uuid.UUID) error
+		UnresolveArticleComment(ctx context.Context, id int64) error
+		GetUserNames(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error)
+		GetUserRoles(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]entity.UserRole, error)
+	}
+
+	CommentService struct {
+		commentRepo commentRepo
+		articleRepo articleRepo
+	}
+)
+
+// NewCommentService keeps the review discussion of the versions. The unresolved threads are carried
+// to a new version by the article repository, in the transaction of the version.
+func NewCommentService(commentRepo commentRepo, articleRepo articleRepo) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// => POST /articles/{articleID}/versions/{articleVersionID}/comments
+func (cs *CommentService) CreateComment(ctx context.Context, articleID, articleVersionID int64, req params.CreateArticleCommentRequest) (*params.ArticleCommentResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	articleVersion, err := cs.getArticleVersion(ctx, articleID, articleVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := entity.ArticleComment{
+		ArticleID:        articleID,
+		ArticleVersionID: articleVersionID,
+		ParentID:         req.ParentID,
+		Body:             req.Body,
+		Mentions:         req.Mentions,
+		CreatedBy:        userID,
+	}
+
+	if req.ParentID != 0 {
+		parent, err := cs.commentRepo.GetArticleComment(ctx, req.ParentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errs.ValidationError{Message: "parent comment is not found"}
+			}
+			return nil, err
+		}
+
+		// the replies are not nested, so a thread is resolved as a whole
+		if parent.ArticleVersionID != articleVersionID || parent.ParentID != 0 {
+			return nil, errs.ValidationError{Message: "parent_id must be the first comment of a thread of the same version"}
+		}
+	}
+
+	if req.Anchor != nil {
+		text := articleVersion.Body
+		if req.Anchor.Field == constanta.CommentAnchorTitle {
+			text = articleVersion.Title
+		}
+
+		comment.Anchor, ok = entity.NewCommentAnchor(req.Anchor.Field, text, req.Anchor.Start, req.Anchor.End)
+		if !ok {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("anchor is outside of the %s of the version", req.Anchor.Field)}
+		}
+	}
+
+	userNames, err := cs.commentRepo.GetUserNames(ctx, req.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mention := range req.Mentions {
+		if _, ok := userNames[mention]; !ok {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("mentioned user %s is not found", mention)}
+		}
+	}
+
+	// the notification of a mention carries the title of the version, which can be a draft
+	userRoles, err := cs.commentRepo.GetUserRoles(ctx, req.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mention := range req.Mentions {
+		if !userRoles[mention].HasPermission(constanta.ReadDraftedAndArchivedArticle) {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("mentioned user %s cannot read the drafted and archived articles", mention)}
+		}
+	}
+
+	commentID, err := cs.commentRepo.CreateArticleComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := cs.commentRepo.GetArticleComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := params.NewArticleCommentResponse(*created, userNames)
+	return &res, nil
+}
+
+// => GET /articles/{articleID}/versions/{articleVersionID}/comments
+func (cs *CommentService) GetComments(ctx context.Context, articleID, articleVersionID int64, req params.GetArticleCommentsRequest) ([]params.ArticleCommentThreadResponse, error) {
+	if _, err := cs.getArticleVersion(ctx, articleID, articleVersionID); err != nil {
+		return nil, err
+	}
+
+	comments, err := cs.commentRepo.GetArticleComments(ctx, articleVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentions []uuid.UUID
+	for _, comment := range comments {
+		mentions = append(mentions, comment.Mentions...)
+	}
+
+	userNames, err := cs.commentRepo.GetUserNames(ctx, mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first comment of a thread comes before its replies
+	threads := []params.ArticleCommentThreadResponse{}
+	threadIndex := make(map[int64]int)
+	for _, comment := range comments {
+		if comment.ParentID == 0 {
+			if req.Resolved != nil && *req.Resolved != comment.IsResolved() {
+				continue
+			}
+
+			threadIndex[comment.ID] = len(threads)
+			threads = append(threads, params.ArticleCommentThreadResponse{
+				ArticleCommentResponse: params.NewArticleCommentResponse(comment, userNames),
+				Resolved:               comment.IsResolved(),
+				ResolvedBy:             comment.ResolvedBy,
+				ResolvedAt:             comment.ResolvedAt,
+				Replies:                []params.ArticleCommentResponse{},
+			})
+			continue
+		}
+
+		if i, ok := threadIndex[comment.ParentID]; ok {
+			threads[i].Replies = append(threads[i].Replies, params.NewArticleCommentResponse(comment, userNames))
+		}
+	}
+
+	return threads, nil
+}
+
+// => PUT /articles/{articleID}/comments/{commentID}/resolve
+func (cs *CommentService) ResolveComment(ctx context.Context, articleID, commentID int64) error {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return errors.New("error when parsing userID")
+	}
+
+	if err := cs.getThread(ctx, articleID, commentID); err != nil {
+		return err
+	}
+
+	return cs.commentRepo.ResolveArticleComment(ctx, commentID, userID)
+}
+
+// => DELETE /articles/{articleID}/comments/{commentID}/resolve
+func (cs *CommentService) UnresolveComment(ctx context.Context, articleID, commentID int64) error {
+	if err := cs.getThread(ctx, articleID, commentID); err != nil {
+		return err
+	}
+
+	return cs.commentRepo.UnresolveArticleComment(ctx, commentID)
+}
+
+// getThread checks that the comment of the article is the first comment of a thread.
+func (cs *CommentService) getThread(ctx context.Context, articleID, commentID int64) error {
+	comment, err := cs.commentRepo.GetArticleComment(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound{Message: "comment"}
+		}
+		return err
+	}
+
+	if comment.ArticleID != articleID {
+		return errs.NotFound{Message: "comment"}
+	}
+
+	if comment.ParentID != 0 {
+		return errs.ValidationError{Message: "only the first comment of a thread can be resolved"}
+	}
+
+	return nil
+}
+
+func (cs *CommentService) getArticleVersion(ctx context.Context, articleID, articleVersionID int64) (*entity.ArticleVersion, error) {
+	articleVersion, err := cs.articleRepo.GetArticleVersionWithIDAndArticleID(ctx, articleID, articleVersionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: "either article or article version"}
+		}
+		return nil, err
+	}
+
+	return articleVersion, nil
+}
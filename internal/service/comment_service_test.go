@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_comment_repo.go -package=service_mock . commentRepo
+
+func TestCommentService_CreateComment(t *testing.T) {
+	userID := uuid.New()
+	mentioned := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+	articleVersion := &entity.ArticleVersion{ArticleVersionID: 2, ArticleID: 1, Title: "Héllo world", Body: "body"}
+	editorRole := entity.NewUserRole("editor", constanta.ReadDraftedAndArchivedArticle)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		req     params.CreateArticleCommentRequest
+		prepare func(*service_mock.MockcommentRepo, *service_mock.MockarticleRepo)
+		want    *params.ArticleCommentResponse
+		wantErr error
+	}{
+		{
+			name: "anchored to the characters of the title",
+			req: params.CreateArticleCommentRequest{
+				Body:     "capitalize it",
+				Anchor:   &params.CommentAnchorRequest{Field: constanta.CommentAnchorTitle, Start: 6, End: 11},
+				Mentions: []uuid.UUID{mentioned},
+			},
+			prepare: func(comments *service_mock.MockcommentRepo, articles *service_mock.MockarticleRepo) {
+				articles.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(articleVersion, nil)
+				comments.EXPECT().GetUserNames(gomock.Any(), []uuid.UUID{mentioned}).Return(map[uuid.UUID]string{mentioned: "editor"}, nil)
+				comments.EXPECT().GetUserRoles(gomock.Any(), []uuid.UUID{mentioned}).Return(map[uuid.UUID]entity.UserRole{mentioned: editorRole}, nil)
+				comment := entity.ArticleComment{
+					ArticleID:        1,
+					ArticleVersionID: 2,
+					Anchor:           &entity.CommentAnchor{Field: constanta.CommentAnchorTitle, Start: 6, End: 11, Text: "world"},
+					Body:             "capitalize it",
+					Mentions:         []uuid.UUID{mentioned},
+					CreatedBy:        userID,
+				}
+				comments.EXPECT().CreateArticleComment(gomock.Any(), comment).Return(int64(3), nil)
+				comment.ID = 3
+				comment.CreatedByName = "writer"
+				comment.CreatedAt = now
+				comments.EXPECT().GetArticleComment(gomock.Any(), int64(3)).Return(&comment, nil)
+			},
+			want: &params.ArticleCommentResponse{
+				ID: 3, ArticleID: 1, ArticleVersionID: 2,
+				Anchor:    &params.CommentAnchorResponse{Field: constanta.CommentAnchorTitle, Start: 6, End: 11, Text: "world"},
+				Body:      "capitalize it",
+				Mentions:  []params.CommentMentionResponse{{UserID: mentioned, Name: "editor"}},
+				CreatedBy: userID, CreatedByName: "writer", CreatedAt: now,
+			},
+		},
+		{
+			name: "anchor outside of the body",
+			req: params.CreateArticleCommentRequest{
+				Body:   "typo",
+				Anchor: &params.CommentAnchorRequest{Field: constanta.CommentAnchorBody, Start: 2, End: 5},
+			},
+			prepare: func(comments *service_mock.MockcommentRepo, articles *service_mock.MockarticleRepo) {
+				articles.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(articleVersion, nil)
+			},
+			wantErr: errs.ValidationError{Message: "anchor is outside of the body of the version"},
+		},
+		{
+			name: "reply to a reply",
+			req:  params.CreateArticleCommentRequest{Body: "agreed", ParentID: 4},
+			prepare: func(comments *service_mock.MockcommentRepo, articles *service_mock.MockarticleRepo) {
+				articles.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(articleVersion, nil)
+				comments.EXPECT().GetArticleComment(gomock.Any(), int64(4)).Return(&entity.ArticleComment{ID: 4, ArticleVersionID: 2, ParentID: 3}, nil)
+			},
+			wantErr: errs.ValidationError{Message: "parent_id must be the first comment of a thread of the same version"},
+		},
+		{
+			name: "mentioned user not found",
+			req:  params.CreateArticleCommentRequest{Body: "look", Mentions: []uuid.UUID{mentioned}},
+			prepare: func(comments *service_mock.MockcommentRepo, articles *service_mock.MockarticleRepo) {
+				articles.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(articleVersion, nil)
+				comments.EXPECT().GetUserNames(gomock.Any(), []uuid.UUID{mentioned}).Return(map[uuid.UUID]string{}, nil)
+			},
+			wantErr: errs.ValidationError{Message: "mentioned user " + mentioned.String() + " is not found"},
+		},
+		{
+			name: "mentioned user cannot read the drafts",
+			req:  params.CreateArticleCommentRequest{Body: "look", Mentions: []uuid.UUID{mentioned}},
+			prepare: func(comments *service_mock.MockcommentRepo, articles *service_mock.MockarticleRepo) {
+				articles.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(articleVersion, nil)
+				comments.EXPECT().GetUserNames(gomock.Any(), []uuid.UUID{mentioned}).Return(map[uuid.UUID]string{mentioned: "reader"}, nil)
+				comments.EXPECT().GetUserRoles(gomock.Any(), []uuid.UUID{mentioned}).Return(map[uuid.UUID]entity.UserRole{mentioned: entity.NewUserRole("reader")}, nil)
+			},
+			wantErr: errs.ValidationError{Message: "mentioned user " + mentioned.String() + " cannot read the drafted and archived articles"},
+		},
+		{
+			name: "version not found",
+			req:  params.CreateArticleCommentRequest{Body: "look"},
+			prepare: func(comments *service_mock.MockcommentRepo, articles *service_mock.MockarticleRepo) {
+				articles.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "either article or article version"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCommentRepo := service_mock.NewMockcommentRepo(ctrl)
+			mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+			s := NewCommentService(mockCommentRepo, mockArticleRepo)
+			tt.prepare(mockCommentRepo, mockArticleRepo)
+
+			got, err := s.CreateComment(ctx, 1, 2, tt.req)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommentService_GetComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCommentRepo := service_mock.NewMockcommentRepo(ctrl)
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	s := NewCommentService(mockCommentRepo, mockArticleRepo)
+
+	resolvedAt := time.Now()
+	comments := []entity.ArticleComment{
+		{ID: 1, ArticleVersionID: 2, Body: "open"},
+		{ID: 2, ArticleVersionID: 2, Body: "resolved", ResolvedAt: &resolvedAt},
+		{ID: 3, ArticleVersionID: 2, ParentID: 1, Body: "reply"},
+		{ID: 4, ArticleVersionID: 2, ParentID: 2, Body: "reply of the resolved"},
+	}
+	resolved := false
+
+	mockArticleRepo.EXPECT().GetArticleVersionWithIDAndArticleID(gomock.Any(), int64(1), int64(2)).Return(&entity.ArticleVersion{}, nil)
+	mockCommentRepo.EXPECT().GetArticleComments(gomock.Any(), int64(2)).Return(comments, nil)
+	mockCommentRepo.EXPECT().GetUserNames(gomock.Any(), nil).Return(map[uuid.UUID]string{}, nil)
+
+	got, err := s.GetComments(context.Background(), 1, 2, params.GetArticleCommentsRequest{Resolved: &resolved})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, int64(1), got[0].ID)
+		assert.False(t, got[0].Resolved)
+		assert.Equal(t, []params.ArticleCommentResponse{params.NewArticleCommentResponse(comments[2], nil)}, got[0].Replies)
+	}
+}
+
+func TestCommentService_ResolveComment(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+
+	tests := []struct {
+		name    string
+		prepare func(*service_mock.MockcommentRepo)
+		wantErr error
+	}{
+		{
+			name: "resolved",
+			prepare: func(comments *service_mock.MockcommentRepo) {
+				comments.EXPECT().GetArticleComment(gomock.Any(), int64(3)).Return(&entity.ArticleComment{ID: 3, ArticleID: 1}, nil)
+				comments.EXPECT().ResolveArticleComment(gomock.Any(), int64(3), userID).Return(nil)
+			},
+		},
+		{
+			name: "comment of another article",
+			prepare: func(comments *service_mock.MockcommentRepo) {
+				comments.EXPECT().GetArticleComment(gomock.Any(), int64(3)).Return(&entity.ArticleComment{ID: 3, ArticleID: 9}, nil)
+			},
+			wantErr: errs.NotFound{Message: "comment"},
+		},
+		{
+			name: "reply",
+			prepare: func(comments *service_mock.MockcommentRepo) {
+				comments.EXPECT().GetArticleComment(gomock.Any(), int64(3)).Return(&entity.ArticleComment{ID: 3, ArticleID: 1, ParentID: 2}, nil)
+			},
+			wantErr: errs.ValidationError{Message: "only the first comment of a thread can be resolved"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCommentRepo := service_mock.NewMockcommentRepo(ctrl)
+			s := NewCommentService(mockCommentRepo, service_mock.NewMockarticleRepo(ctrl))
+			tt.prepare(mockCommentRepo)
+
+			assert.Equal(t, tt.wantErr, s.ResolveComment(ctx, 1, 3))
+		})
+	}
+}
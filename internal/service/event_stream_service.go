@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+)
+
+type (
+	eventStreamSubscriber struct {
+		events        chan entity.OutboxEvent
+		canReadDrafts bool
+	}
+
+	// EventStreamService sends the committed events to the open streams of the dashboards.
+	EventStreamService struct {
+		mu sync.Mutex
+		// the last events in the order of their relay, it is bounded by logSize
+		log     []entity.OutboxEvent
+		logSize int
+		// the log has every event after this id, it is -1 until the first event is relayed
+		since       int64
+		subscribers map[*entity.EventSubscription]*eventStreamSubscriber
+	}
+)
+
+const (
+	DefaultEventStreamLogSize = 1000
+	// a subscriber whose buffer is full is closed, so a slow stream does not hold back the relay
+	eventStreamBufferSize = 64
+)
+
+// NewEventStreamService keeps the last logSize events in memory, so a stream which reconnects gets the events it missed.
+// Every instance of the application only sees the events relayed by its own outbox relay, the relays share the events,
+// so the event stream needs a single instance.
+func NewEventStreamService(events eventSubscriber, logSize int) *EventStreamService {
+	if logSize <= 0 {
+		logSize = DefaultEventStreamLogSize
+	}
+
+	es := &EventStreamService{
+		logSize:     logSize,
+		since:       -1,
+		subscribers: make(map[*entity.EventSubscription]*eventStreamSubscriber),
+	}
+
+	for _, event := range constanta.Events {
+		events.Subscribe(event, "event_stream", es.appendEvent)
+	}
+
+	return es
+}
+
+// Subscribe opens a stream of the events which the user can read. The events after lastEventID which are still in the log
+// are returned as the backlog, lastEventID is zero for a new stream.
+func (es *EventStreamService) Subscribe(ctx context.Context, lastEventID int64) *entity.EventSubscription {
+	canReadDrafts, _ := ctx.Value(constanta.LocalUserCanReadDraftedAndArchivedArticle).(bool)
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	events := make(chan entity.OutboxEvent, eventStreamBufferSize)
+	sub := &entity.EventSubscription{Events: events}
+	if lastEventID > 0 {
+		sub.Reset = es.since < 0 || lastEventID < es.since
+		for _, event := range es.log {
+			if event.ID > lastEventID && canRead(event, canReadDrafts) {
+				sub.Backlog = append(sub.Backlog, event)
+			}
+		}
+	}
+
+	es.subscribers[sub] = &eventStreamSubscriber{
+		events:        events,
+		canReadDrafts: canReadDrafts,
+	}
+
+	return sub
+}
+
+// Unsubscribe closes the stream, it does nothing when the stream is already closed.
+func (es *EventStreamService) Unsubscribe(sub *entity.EventSubscription) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if subscriber, ok := es.subscribers[sub]; ok {
+		close(subscriber.events)
+		delete(es.subscribers, sub)
+	}
+}
+
+// Close ends the open streams, so the server does not wait for them on shutdown.
+// The clients reconnect to another instance with their last event.
+func (es *EventStreamService) Close() {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	for sub, subscriber := range es.subscribers {
+		close(subscriber.events)
+		delete(es.subscribers, sub)
+	}
+}
+
+// appendEvent logs the committed event and sends it to the streams which can read it.
+func (es *EventStreamService) appendEvent(ctx context.Context, event entity.OutboxEvent) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	// the outbox publishes an event again when another subscriber failed
+	if slices.ContainsFunc(es.log, func(logged entity.OutboxEvent) bool { return logged.ID == event.ID }) {
+		return nil
+	}
+
+	if es.since < 0 {
+		es.since = event.ID - 1
+	}
+
+	es.log = append(es.log, event)
+	if len(es.log) > es.logSize {
+		es.since = max(es.since, es.log[0].ID)
+		es.log = es.log[1:]
+	}
+
+	for sub, subscriber := range es.subscribers {
+		if !canRead(event, subscriber.canReadDrafts) {
+			continue
+		}
+
+		select {
+		case subscriber.events <- event:
+		default:
+			close(subscriber.events)
+			delete(es.subscribers, sub)
+		}
+	}
+
+	return nil
+}
+
+func canRead(event entity.OutboxEvent, canReadDrafts bool) bool {
+	return canReadDrafts || !event.Type.IsDraftOrArchived()
+}
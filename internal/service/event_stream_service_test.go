@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestEventStreamService(t *testing.T, logSize int) *EventStreamService {
+	ctrl := gomock.NewController(t)
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(len(constanta.Events))
+
+	return NewEventStreamService(mockEventSubscriber, logSize)
+}
+
+func TestEventStreamService_Subscribe(t *testing.T) {
+	editorCtx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, true)
+	readerCtx := context.WithValue(context.Background(), constanta.LocalUserCanReadDraftedAndArchivedArticle, false)
+
+	created := entity.OutboxEvent{ID: 1, Type: constanta.EventArticleCreated}
+	published := entity.OutboxEvent{ID: 2, Type: constanta.EventVersionPublished}
+	tagCreated := entity.OutboxEvent{ID: 4, Type: constanta.EventTagCreated}
+	versionCreated := entity.OutboxEvent{ID: 5, Type: constanta.EventVersionCreated}
+
+	s := newTestEventStreamService(t, 2)
+
+	// a resume before any event cannot know what it missed
+	assert.True(t, s.Subscribe(editorCtx, 3).Reset)
+
+	editor := s.Subscribe(editorCtx, 0)
+	reader := s.Subscribe(readerCtx, 0)
+
+	for _, event := range []entity.OutboxEvent{created, published, published, tagCreated, versionCreated} {
+		assert.NoError(t, s.appendEvent(context.Background(), event))
+	}
+
+	// the event which is published again is sent once
+	assert.Equal(t, []entity.OutboxEvent{created, published, tagCreated, versionCreated}, drain(editor.Events))
+	// the drafts are not sent to the user who cannot read them
+	assert.Equal(t, []entity.OutboxEvent{published, tagCreated}, drain(reader.Events))
+
+	// the log keeps the events after 2
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		lastEventID int64
+		want        *entity.EventSubscription
+	}{
+		{
+			name:        "resume from the log",
+			ctx:         editorCtx,
+			lastEventID: 4,
+			want:        &entity.EventSubscription{Backlog: []entity.OutboxEvent{versionCreated}},
+		},
+		{
+			name:        "resume from the event before the log",
+			ctx:         editorCtx,
+			lastEventID: 2,
+			want:        &entity.EventSubscription{Backlog: []entity.OutboxEvent{tagCreated, versionCreated}},
+		},
+		{
+			name:        "resume without the drafts",
+			ctx:         readerCtx,
+			lastEventID: 2,
+			want:        &entity.EventSubscription{Backlog: []entity.OutboxEvent{tagCreated}},
+		},
+		{
+			name:        "missed events are not in the log anymore",
+			ctx:         editorCtx,
+			lastEventID: 1,
+			want:        &entity.EventSubscription{Backlog: []entity.OutboxEvent{tagCreated, versionCreated}, Reset: true},
+		},
+		{
+			name:        "new stream",
+			ctx:         editorCtx,
+			lastEventID: 0,
+			want:        &entity.EventSubscription{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Subscribe(tt.ctx, tt.lastEventID)
+			defer s.Unsubscribe(got)
+			assert.Equal(t, tt.want.Backlog, got.Backlog)
+			assert.Equal(t, tt.want.Reset, got.Reset)
+		})
+	}
+}
+
+func TestEventStreamService_SlowSubscriber(t *testing.T) {
+	s := newTestEventStreamService(t, 0)
+	sub := s.Subscribe(context.Background(), 0)
+
+	for i := 1; i <= eventStreamBufferSize+1; i++ {
+		assert.NoError(t, s.appendEvent(context.Background(), entity.OutboxEvent{ID: int64(i), Type: constanta.EventTagCreated}))
+	}
+
+	// the buffered events are still read, then the stream is closed
+	assert.Len(t, drain(sub.Events), eventStreamBufferSize)
+	_, ok := <-sub.Events
+	assert.False(t, ok)
+
+	// closing it again does nothing
+	s.Unsubscribe(sub)
+}
+
+func TestEventStreamService_Close(t *testing.T) {
+	s := newTestEventStreamService(t, 0)
+	sub := s.Subscribe(context.Background(), 0)
+
+	s.Close()
+
+	_, ok := <-sub.Events
+	assert.False(t, ok)
+}
+
+// drain reads the buffered events without waiting for new ones.
+func drain(events <-chan entity.OutboxEvent) []entity.OutboxEvent {
+	var got []entity.OutboxEvent
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return got
+			}
+			got = append(got, event)
+		default:
+			return got
+		}
+	}
+}
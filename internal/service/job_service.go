@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/elangreza/content-management-system/internal/params"
+)
+
+type (
+	jobRepo interface {
+		CreateJob(ctx context.Context, job entity.Job) (int64, error)
+		ClaimJobs(ctx context.Context, limit int) ([]entity.Job, error)
+		CompleteJob(ctx context.Context, id int64) error
+		RetryJob(ctx context.Context, id int64, runAt time.Time, lastError string) error
+		KillJob(ctx context.Context, id int64, lastError string) error
+		RequeueStaleJobs(ctx context.Context, lockedBefore time.Time) (int64, error)
+		GetJobs(ctx context.Context, filter entity.JobFilter) ([]entity.Job, error)
+		GetJobCounts(ctx context.Context) (map[constanta.JobStatus]int64, error)
+	}
+
+	// JobHandler runs a job with its payload, the job is retried when it returns an error.
+	JobHandler func(ctx context.Context, payload json.RawMessage) error
+
+	JobService struct {
+		jobRepo   jobRepo
+		workers   int
+		mu        sync.RWMutex
+		handlers  map[constanta.JobType]JobHandler
+		lifecycle lifecycle
+	}
+)
+
+const (
+	jobPollInterval = time.Second
+	// a running job is requeued when its worker does not finish it in this time
+	jobStaleAfter  = 5 * time.Minute
+	jobTimeout     = time.Minute
+	jobBackoffBase = 2 * time.Second
+	jobBackoffMax  = 10 * time.Minute
+)
+
+var errNoJobHandler = errors.New("no handler for job type")
+
+func NewJobService(jobRepo jobRepo, workers int) *JobService {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	return &JobService{
+		jobRepo:  jobRepo,
+		workers:  workers,
+		handlers: make(map[constanta.JobType]JobHandler),
+	}
+}
+
+// Register sets the handler of the job type, the jobs without a handler become dead.
+func (js *JobService) Register(jobType constanta.JobType, handler func(ctx context.Context, payload json.RawMessage) error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.handlers[jobType] = handler
+}
+
+// Enqueue stores the job, so it is run even when the application restarts before it.
+func (js *JobService) Enqueue(ctx context.Context, jobType constanta.JobType, payload any) error {
+	job, err := entity.NewJob(jobType, payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = js.jobRepo.CreateJob(ctx, *job)
+	return err
+}
+
+// Start claims and runs the jobs in the background until Stop is called.
+func (js *JobService) Start(ctx context.Context) error {
+	return js.lifecycle.start(ctx, js.poll)
+}
+
+// Stop stops claiming jobs and waits for the running jobs, so they are not retried from the start.
+// The jobs which are still running when ctx is done are requeued after jobStaleAfter.
+func (js *JobService) Stop(ctx context.Context) error {
+	return js.lifecycle.stop(ctx)
+}
+
+// poll claims and runs the jobs until the context is canceled, then it waits for the running jobs.
+func (js *JobService) poll(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	slots := make(chan struct{}, js.workers)
+	lastRequeue := time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if time.Since(lastRequeue) > jobStaleAfter {
+			js.requeueStaleJobs(ctx)
+			lastRequeue = time.Now()
+		}
+
+		free := js.workers - len(slots)
+		if free == 0 {
+			continue
+		}
+
+		jobs, err := js.jobRepo.ClaimJobs(ctx, free)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				slog.Error("failed to claim jobs", "error", err)
+			}
+			continue
+		}
+
+		for _, job := range jobs {
+			slots <- struct{}{}
+			wg.Add(1)
+			go func(job entity.Job) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				js.process(job)
+			}(job)
+		}
+	}
+}
+
+func (js *JobService) requeueStaleJobs(ctx context.Context) {
+	requeued, err := js.jobRepo.RequeueStaleJobs(ctx, time.Now().Add(-jobStaleAfter))
+	if err != nil {
+		slog.Error("failed to requeue stale jobs", "error", err)
+		return
+	}
+
+	if requeued > 0 {
+		slog.Info("requeued stale jobs", "count", requeued)
+	}
+}
+
+// process runs the claimed job and records its result. The job is not canceled with the run context,
+// so a job is finished instead of being retried from the start after a shutdown.
+func (js *JobService) process(job entity.Job) {
+	err := js.run(job)
+
+	// the result is recorded even when the job used its whole timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch {
+	case err == nil:
+		err = js.jobRepo.CompleteJob(ctx, job.ID)
+	// retrying does not help the job without a handler
+	case job.Attempts >= job.MaxAttempts, errors.Is(err, errNoJobHandler):
+		slog.Error("job is dead", "id", job.ID, "type", job.Type, "attempts", job.Attempts, "error", err)
+		err = js.jobRepo.KillJob(ctx, job.ID, err.Error())
+	default:
+		slog.Warn("job failed, retrying", "id", job.ID, "type", job.Type, "attempts", job.Attempts, "error", err)
+		err = js.jobRepo.RetryJob(ctx, job.ID, time.Now().Add(jobBackoff(job.Attempts)), err.Error())
+	}
+
+	if err != nil {
+		slog.Error("failed to record the job result", "id", job.ID, "error", err)
+	}
+}
+
+func (js *JobService) run(job entity.Job) (err error) {
+	js.mu.RLock()
+	handler, ok := js.handlers[job.Type]
+	js.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w %s", errNoJobHandler, job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	return handler(ctx, job.Payload)
+}
+
+// jobBackoff returns the delay before the next attempt, it doubles after every attempt.
+func jobBackoff(attempts int) time.Duration {
+	backoff := jobBackoffBase
+	for i := 1; i < attempts && backoff < jobBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, jobBackoffMax)
+}
+
+// => GET /admin/jobs
+func (js *JobService) GetJobs(ctx context.Context, req params.GetJobsRequest) (*params.GetJobsResponse, error) {
+	jobs, err := js.jobRepo.GetJobs(ctx, entity.JobFilter{
+		Status:   req.Status,
+		Type:     req.Type,
+		BeforeID: req.BeforeID,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := js.jobRepo.GetJobCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &params.GetJobsResponse{
+		Counts: counts,
+		Jobs:   params.NewJobResponses(jobs),
+	}
+
+	if len(jobs) == req.Limit {
+		res.NextBeforeID = jobs[len(jobs)-1].ID
+	}
+
+	return res, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	"github.com/elangreza/content-management-system/internal/params"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_job_repo.go -package=service_mock . jobRepo
+
+func TestJobService_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobRepo := service_mock.NewMockjobRepo(ctrl)
+	s := NewJobService(mockJobRepo, 1)
+
+	mockJobRepo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job entity.Job) (int64, error) {
+		assert.Equal(t, constanta.JobCalculateArticleTagRelation, job.Type)
+		assert.JSONEq(t, `{"Tags":null,"ArticleVersionID":3}`, string(job.Payload))
+		assert.Equal(t, constanta.JobPending, job.Status)
+		assert.Equal(t, entity.DefaultJobMaxAttempts, job.MaxAttempts)
+		return 1, nil
+	})
+
+	err := s.Enqueue(context.Background(), constanta.JobCalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{ArticleVersionID: 3})
+	assert.NoError(t, err)
+}
+
+func TestJobService_process(t *testing.T) {
+	job := entity.Job{
+		ID:          7,
+		Type:        constanta.JobCalculateTagStats,
+		Payload:     json.RawMessage(`null`),
+		Status:      constanta.JobRunning,
+		Attempts:    2,
+		MaxAttempts: 3,
+	}
+
+	tests := []struct {
+		name    string
+		job     entity.Job
+		handler JobHandler
+		setup   func(*service_mock.MockjobRepo)
+	}{
+		{
+			name:    "success",
+			job:     job,
+			handler: func(ctx context.Context, payload json.RawMessage) error { return nil },
+			setup: func(repo *service_mock.MockjobRepo) {
+				repo.EXPECT().CompleteJob(gomock.Any(), int64(7)).Return(nil)
+			},
+		},
+		{
+			name:    "failed job is retried with backoff",
+			job:     job,
+			handler: func(ctx context.Context, payload json.RawMessage) error { return errors.New("db down") },
+			setup: func(repo *service_mock.MockjobRepo) {
+				repo.EXPECT().RetryJob(gomock.Any(), int64(7), gomock.Any(), "db down").DoAndReturn(
+					func(_ context.Context, _ int64, runAt time.Time, _ string) error {
+						assert.WithinDuration(t, time.Now().Add(4*time.Second), runAt, time.Second)
+						return nil
+					})
+			},
+		},
+		{
+			name: "failed job on the last attempt is dead",
+			job: func() entity.Job {
+				j := job
+				j.Attempts = 3
+				return j
+			}(),
+			handler: func(ctx context.Context, payload json.RawMessage) error { return errors.New("db down") },
+			setup: func(repo *service_mock.MockjobRepo) {
+				repo.EXPECT().KillJob(gomock.Any(), int64(7), "db down").Return(nil)
+			},
+		},
+		{
+			name:    "panicked job is retried",
+			job:     job,
+			handler: func(ctx context.Context, payload json.RawMessage) error { panic("boom") },
+			setup: func(repo *service_mock.MockjobRepo) {
+				repo.EXPECT().RetryJob(gomock.Any(), int64(7), gomock.Any(), "job panicked: boom").Return(nil)
+			},
+		},
+		{
+			name: "job without handler is dead",
+			job:  job,
+			setup: func(repo *service_mock.MockjobRepo) {
+				repo.EXPECT().KillJob(gomock.Any(), int64(7), "no handler for job type tag.calculate_stats").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockJobRepo := service_mock.NewMockjobRepo(ctrl)
+			s := NewJobService(mockJobRepo, 1)
+			if tt.handler != nil {
+				s.Register(constanta.JobCalculateTagStats, tt.handler)
+			}
+			tt.setup(mockJobRepo)
+
+			s.process(tt.job)
+		})
+	}
+}
+
+func TestJobService_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobRepo := service_mock.NewMockjobRepo(ctrl)
+	s := NewJobService(mockJobRepo, 1)
+
+	assert.NoError(t, s.Start(context.Background()))
+	assert.Equal(t, errAlreadyStarted, s.Start(context.Background()))
+	assert.NoError(t, s.Stop(context.Background()))
+}
+
+func Test_jobBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, jobBackoff(1))
+	assert.Equal(t, 4*time.Second, jobBackoff(2))
+	assert.Equal(t, 16*time.Second, jobBackoff(4))
+	assert.Equal(t, 10*time.Minute, jobBackoff(20))
+}
+
+func TestJobService_GetJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobRepo := service_mock.NewMockjobRepo(ctrl)
+	s := NewJobService(mockJobRepo, 1)
+
+	now := time.Now()
+	mockJobRepo.EXPECT().GetJobs(gomock.Any(), entity.JobFilter{Status: constanta.JobDead, BeforeID: 10, Limit: 2}).Return([]entity.Job{
+		{ID: 9, Type: constanta.JobCalculateTagStats, Status: constanta.JobDead, Attempts: 5, MaxAttempts: 5, RunAt: now, LastError: "db down", CreatedAt: now},
+		{ID: 4, Type: constanta.JobCalculateTagStats, Status: constanta.JobDead, Attempts: 5, MaxAttempts: 5, RunAt: now, LastError: "db down", CreatedAt: now},
+	}, nil)
+	mockJobRepo.EXPECT().GetJobCounts(gomock.Any()).Return(map[constanta.JobStatus]int64{constanta.JobDead: 2, constanta.JobSucceeded: 8}, nil)
+
+	got, err := s.GetJobs(context.Background(), params.GetJobsRequest{Status: constanta.JobDead, BeforeID: 10, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), got.NextBeforeID)
+	assert.Len(t, got.Jobs, 2)
+	assert.Equal(t, int64(2), got.Counts[constanta.JobDead])
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var errAlreadyStarted = errors.New("already started")
+
+// lifecycle runs the loop of a background service between its Start and Stop.
+type lifecycle struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// start runs the loop until stop is called. The loop keeps the values of ctx but not its cancellation,
+// so a Start with a timeout does not stop the loop.
+func (l *lifecycle) start(ctx context.Context, loop func(ctx context.Context)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done != nil {
+		return errAlreadyStarted
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	l.cancel, l.done = cancel, done
+
+	go func() {
+		defer close(done)
+		loop(ctx)
+	}()
+
+	return nil
+}
+
+// stop cancels the loop and waits until it finishes its current work or ctx is done.
+// Stopping a lifecycle which is not started does nothing.
+func (l *lifecycle) stop(ctx context.Context) error {
+	l.mu.Lock()
+	cancel, done := l.cancel, l.done
+	l.cancel, l.done = nil, nil
+	l.mu.Unlock()
+
+	if done == nil {
+		return nil
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
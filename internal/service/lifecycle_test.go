@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lifecycle(t *testing.T) {
+	t.Run("stop waits for the running work", func(t *testing.T) {
+		var l lifecycle
+		finished := false
+		err := l.start(context.Background(), func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished = true
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, errAlreadyStarted, l.start(context.Background(), func(ctx context.Context) {}))
+
+		assert.NoError(t, l.stop(context.Background()))
+		assert.True(t, finished)
+	})
+
+	t.Run("stop gives up when its context is done", func(t *testing.T) {
+		var l lifecycle
+		release := make(chan struct{})
+		defer close(release)
+		assert.NoError(t, l.start(context.Background(), func(ctx context.Context) { <-release }))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, l.stop(ctx))
+	})
+
+	t.Run("the loop is not canceled with the start context", func(t *testing.T) {
+		var l lifecycle
+		ctx, cancel := context.WithCancel(context.Background())
+		running := make(chan error, 1)
+		assert.NoError(t, l.start(ctx, func(ctx context.Context) {
+			<-ctx.Done()
+			running <- ctx.Err()
+		}))
+		cancel()
+
+		select {
+		case <-running:
+			t.Fatal("the loop is stopped with the start context")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		assert.NoError(t, l.stop(context.Background()))
+		assert.Equal(t, context.Canceled, <-running)
+	})
+
+	t.Run("stop without start", func(t *testing.T) {
+		var l lifecycle
+		assert.NoError(t, l.stop(context.Background()))
+	})
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/imageproc"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/elangreza/content-management-system/internal/storage"
+	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	maxMediaFileNameLength = 255
+	// decoding larger images for the derivatives takes too much memory
+	maxMediaPixels = 50_000_000
+)
+
+// allowedMediaTypes maps the sniffed content type into the extension of the stored file
+var allowedMediaTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type (
+	mediaRepo interface {
+		CreateMedia(ctx context.Context, media entity.Media) (int64, error)
+		GetMediaWithID(ctx context.Context, id int64) (*entity.Media, error)
+		GetMediaWithIDs(ctx context.Context, ids []int64) ([]entity.Media, error)
+		DeleteMedia(ctx context.Context, id int64) error
+	}
+
+	mediaStorage interface {
+		Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+		Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+
+	MediaService struct {
+		mediaRepo mediaRepo
+		storage   mediaStorage
+		// in bytes
+		maxSize int64
+		presets []entity.ImagePreset
+		now     func() time.Time
+		// concurrent requests of a missing derivative generate it once
+		derivatives singleflight.Group
+	}
+)
+
+func NewMediaService(mediaRepo mediaRepo, storage mediaStorage, maxSize int64, presets []entity.ImagePreset) *MediaService {
+	return &MediaService{
+		mediaRepo: mediaRepo,
+		storage:   storage,
+		maxSize:   maxSize,
+		presets:   presets,
+		now:       time.Now,
+	}
+}
+
+// => POST /media
+// The type is sniffed from the content, the extension and the content type of the client are not trusted.
+func (ms *MediaService) UploadMedia(ctx context.Context, fileName string, body io.Reader) (*params.MediaResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	fileName = filepath.Base(strings.ReplaceAll(strings.TrimSpace(fileName), `\`, "/"))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, errs.ValidationError{Message: "file name is required"}
+	}
+
+	if len(fileName) > maxMediaFileNameLength {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("file name cannot be longer than %d characters", maxMediaFileNameLength)}
+	}
+
+	// the upload is buffered, so it can be checked and cleaned before it is stored
+	data, err := io.ReadAll(io.LimitReader(body, ms.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errs.ValidationError{Message: "file cannot be empty"}
+	}
+
+	if int64(len(data)) > ms.maxSize {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("file cannot be larger than %d bytes", ms.maxSize)}
+	}
+
+	mimeType := http.DetectContentType(data)
+	ext, ok := allowedMediaTypes[mimeType]
+	if !ok {
+		return nil, errs.ValidationError{Message: "file must be a jpeg, png, gif or webp image"}
+	}
+
+	// the size is checked from the header, before the image is decoded to fix its orientation
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errs.ValidationError{Message: "file is not a valid image"}
+	}
+
+	if config.Width*config.Height > maxMediaPixels {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("image cannot have more than %d pixels", maxMediaPixels)}
+	}
+
+	// EXIF and other metadata can contain the GPS location of the uploader
+	data, err = imageproc.StripMetadata(data, mimeType)
+	if err != nil {
+		return nil, errs.ValidationError{Message: "file is not a valid image"}
+	}
+
+	// the width and the height are swapped by the orientation
+	config, _, err = image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errs.ValidationError{Message: "file is not a valid image"}
+	}
+
+	checksum := sha256.Sum256(data)
+	size := int64(len(data))
+
+	media := entity.Media{
+		StorageKey: ms.now().UTC().Format("2006/01/") + uuid.NewString() + ext,
+		FileName:   fileName,
+		MimeType:   mimeType,
+		Size:       size,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		Width:      config.Width,
+		Height:     config.Height,
+		CreatedBy:  userID,
+	}
+
+	if err := ms.storage.Put(ctx, media.StorageKey, bytes.NewReader(data), size, mimeType); err != nil {
+		return nil, err
+	}
+
+	media.ID, err = ms.mediaRepo.CreateMedia(ctx, media)
+	if err != nil {
+		// the stored file is not referenced by any row
+		_ = ms.storage.Delete(context.WithoutCancel(ctx), media.StorageKey)
+		return nil, err
+	}
+	media.CreatedAt = ms.now()
+
+	res := params.NewMediaResponse(media)
+	return &res, nil
+}
+
+// => GET /media/{id}
+// The caller must close the returned content.
+func (ms *MediaService) GetMedia(ctx context.Context, id int64) (*params.MediaResponse, io.ReadSeekCloser, error) {
+	media, err := ms.getMedia(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := ms.storage.Open(ctx, media.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			return nil, nil, errs.NotFound{Message: "media content"}
+		}
+		return nil, nil, err
+	}
+
+	res := params.NewMediaResponse(*media)
+	return &res, content, nil
+}
+
+// => DELETE /media/{id}
+// Only the uploader can delete the media, and only while no article version uses it.
+func (ms *MediaService) DeleteMedia(ctx context.Context, id int64) error {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return errors.New("error when parsing userID")
+	}
+
+	media, err := ms.getMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if media.CreatedBy != userID {
+		return errs.Forbidden{Message: "only the uploader can delete the media"}
+	}
+
+	if err := ms.mediaRepo.DeleteMedia(ctx, id); err != nil {
+		return err
+	}
+
+	// derivatives of presets that are not configured anymore are not known, so they are kept
+	for _, preset := range ms.presets {
+		if err := ms.storage.Delete(ctx, derivativeKey(*media, preset)); err != nil {
+			return err
+		}
+	}
+
+	return ms.storage.Delete(ctx, media.StorageKey)
+}
+
+// => GET /media/{id}/{preset}
+// The derivative is generated on the first request and kept in the storage.
+// The caller must close the returned content.
+func (ms *MediaService) GetMediaDerivative(ctx context.Context, id int64, presetName string) (*params.MediaDerivativeResponse, io.ReadSeekCloser, error) {
+	preset, ok := ms.preset(presetName)
+	if !ok {
+		return nil, nil, errs.NotFound{Message: "preset " + presetName}
+	}
+
+	media, err := ms.getMedia(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := derivativeKey(*media, preset)
+	content, err := ms.storage.Open(ctx, key)
+	if errors.Is(err, storage.ErrNotExist) {
+		_, err, _ = ms.derivatives.Do(key, func() (any, error) {
+			return nil, ms.createDerivative(context.WithoutCancel(ctx), *media, preset, key)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		content, err = ms.storage.Open(ctx, key)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := params.NewMediaDerivativeResponse(*media, preset)
+	return &res, content, nil
+}
+
+func (ms *MediaService) createDerivative(ctx context.Context, media entity.Media, preset entity.ImagePreset, key string) error {
+	original, err := ms.storage.Open(ctx, media.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			return errs.NotFound{Message: "media content"}
+		}
+		return err
+	}
+	defer original.Close()
+
+	data, err := imageproc.Derivative(original, preset)
+	if err != nil {
+		return err
+	}
+
+	return ms.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), preset.Format.MimeType())
+}
+
+// GetImages returns the images of the media library with their derivatives and srcset, by media ID.
+// Unknown IDs are skipped.
+func (ms *MediaService) GetImages(ctx context.Context, ids []int64) (map[int64]params.ImageResponse, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	medias, err := ms.mediaRepo.GetMediaWithIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[int64]params.ImageResponse, len(medias))
+	for _, media := range medias {
+		images[media.ID] = params.NewImageResponse(media, ms.presets)
+	}
+
+	return images, nil
+}
+
+func (ms *MediaService) preset(name string) (entity.ImagePreset, bool) {
+	for _, preset := range ms.presets {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+
+	return entity.ImagePreset{}, false
+}
+
+// derivativeKey contains the definition of the preset, so a changed preset is generated again.
+func derivativeKey(media entity.Media, preset entity.ImagePreset) string {
+	return fmt.Sprintf("derivatives/%d/%s-%dx%d-%s%s", media.ID, preset.Name, preset.Width, preset.Height, preset.Fit, preset.Format.Extension())
+}
+
+func (ms *MediaService) getMedia(ctx context.Context, id int64) (*entity.Media, error) {
+	media, err := ms.mediaRepo.GetMediaWithID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound{Message: "media"}
+		}
+		return nil, err
+	}
+
+	return media, nil
+}
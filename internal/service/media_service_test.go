@@ -0,0 +1,385 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/elangreza/content-management-system/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_media_repo.go -package=service_mock . mediaRepo
+//go:generate mockgen -destination=mock/mock_media_storage.go -package=service_mock . mediaStorage
+
+var testImagePresets = []entity.ImagePreset{
+	{Name: "small", Width: 2, Fit: constanta.FitContain, Format: constanta.PNG},
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// withPNGText adds a text chunk after the IHDR chunk.
+func withPNGText(content []byte, text string) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)))
+	chunk = append(chunk, "tEXt"+text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	ihdrEnd := 8 + 25
+	return append(append(append([]byte{}, content[:ihdrEnd]...), chunk...), content[ihdrEnd:]...)
+}
+
+// testOrientedJPEG returns a small jpeg with an EXIF orientation, which declares the given size in its header.
+func testOrientedJPEG(t *testing.T, width, height uint16) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1)), nil); err != nil {
+		t.Fatal(err)
+	}
+	content := buf.Bytes()
+
+	// the tiff header with one IFD entry, the orientation 6
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00")
+	segment := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(exif)+2))
+	content = append(append(append([]byte{}, content[:2]...), append(segment, exif...)...), content[2:]...)
+
+	// the baseline frame header holds the height and the width after the marker, the length and the precision
+	sof := bytes.Index(content, []byte{0xFF, 0xC0})
+	binary.BigEndian.PutUint16(content[sof+5:], height)
+	binary.BigEndian.PutUint16(content[sof+7:], width)
+
+	return content
+}
+
+func TestMediaService_UploadMedia(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+	content := testPNG(t, 3, 2)
+	now := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		fileName string
+		body     []byte
+		setup    func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage)
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			fileName: `C:\photos\cat.png`,
+			body:     content,
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(content)), "image/png").
+					DoAndReturn(func(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+						assert.True(t, strings.HasPrefix(key, "2025/08/"))
+						assert.True(t, strings.HasSuffix(key, ".png"))
+						got, _ := io.ReadAll(body)
+						assert.Equal(t, content, got)
+						return nil
+					})
+				repo.EXPECT().CreateMedia(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, media entity.Media) (int64, error) {
+						assert.Equal(t, "cat.png", media.FileName)
+						assert.Equal(t, 3, media.Width)
+						assert.Equal(t, 2, media.Height)
+						assert.Len(t, media.Checksum, 64)
+						assert.Equal(t, userID, media.CreatedBy)
+						return 9, nil
+					})
+			},
+		},
+		{
+			name:     "metadata is removed",
+			fileName: "cat.png",
+			body:     withPNGText(content, "GPS 52.37 4.89"),
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(content)), "image/png").
+					DoAndReturn(func(_ context.Context, _ string, body io.Reader, _ int64, _ string) error {
+						got, _ := io.ReadAll(body)
+						assert.Equal(t, content, got)
+						return nil
+					})
+				repo.EXPECT().CreateMedia(gomock.Any(), gomock.Any()).Return(int64(9), nil)
+			},
+		},
+		{
+			name:     "not an image",
+			fileName: "a.png",
+			body:     []byte("<html><script>alert(1)</script></html>"),
+			setup:    func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage) {},
+			wantErr:  errs.ValidationError{Message: "file must be a jpeg, png, gif or webp image"},
+		},
+		{
+			name:     "too large",
+			fileName: "a.png",
+			body:     append(content, make([]byte, 1024)...),
+			setup:    func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage) {},
+			wantErr:  errs.ValidationError{Message: "file cannot be larger than 512 bytes"},
+		},
+		{
+			name:     "too many pixels is rejected before the orientation is applied",
+			fileName: "a.jpg",
+			body:     testOrientedJPEG(t, 65535, 65535),
+			setup:    func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage) {},
+			wantErr:  errs.ValidationError{Message: "image cannot have more than 50000000 pixels"},
+		},
+		{
+			name:     "stored file is deleted when the repo fails",
+			fileName: "a.png",
+			body:     content,
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().CreateMedia(gomock.Any(), gomock.Any()).Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMediaRepo := service_mock.NewMockmediaRepo(ctrl)
+			mockMediaStorage := service_mock.NewMockmediaStorage(ctrl)
+			s := NewMediaService(mockMediaRepo, mockMediaStorage, 512, testImagePresets)
+			s.now = func() time.Time { return now }
+			tt.setup(mockMediaRepo, mockMediaStorage)
+
+			got, err := s.UploadMedia(ctx, tt.fileName, bytes.NewReader(tt.body))
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(9), got.ID)
+			assert.Equal(t, "/media/9", got.URL)
+			assert.Equal(t, "image/png", got.MimeType)
+		})
+	}
+}
+
+func TestMediaService_GetMedia(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(&entity.Media{ID: 1, StorageKey: "key"}, nil)
+				store.EXPECT().Open(gomock.Any(), "key").Return(nopReadSeekCloser{strings.NewReader("x")}, nil)
+			},
+		},
+		{
+			name: "media not found",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "media"},
+		},
+		{
+			name: "content not found",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(&entity.Media{ID: 1, StorageKey: "key"}, nil)
+				store.EXPECT().Open(gomock.Any(), "key").Return(nil, storage.ErrNotExist)
+			},
+			wantErr: errs.NotFound{Message: "media content"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMediaRepo := service_mock.NewMockmediaRepo(ctrl)
+			mockMediaStorage := service_mock.NewMockmediaStorage(ctrl)
+			s := NewMediaService(mockMediaRepo, mockMediaStorage, 512, testImagePresets)
+			tt.setup(mockMediaRepo, mockMediaStorage)
+
+			got, content, err := s.GetMedia(context.Background(), 1)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), got.ID)
+			assert.NoError(t, content.Close())
+		})
+	}
+}
+
+func TestMediaService_DeleteMedia(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(&entity.Media{ID: 1, StorageKey: "key", CreatedBy: userID}, nil)
+				repo.EXPECT().DeleteMedia(gomock.Any(), int64(1)).Return(nil)
+				store.EXPECT().Delete(gomock.Any(), "derivatives/1/small-2x0-contain.png").Return(nil)
+				store.EXPECT().Delete(gomock.Any(), "key").Return(nil)
+			},
+		},
+		{
+			name: "not the uploader",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(&entity.Media{ID: 1, StorageKey: "key", CreatedBy: uuid.New()}, nil)
+			},
+			wantErr: errs.Forbidden{Message: "only the uploader can delete the media"},
+		},
+		{
+			name: "used by article versions",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(&entity.Media{ID: 1, StorageKey: "key", CreatedBy: userID}, nil)
+				repo.EXPECT().DeleteMedia(gomock.Any(), int64(1)).Return(errs.Conflict{Message: "media is used by article versions"})
+			},
+			wantErr: errs.Conflict{Message: "media is used by article versions"},
+		},
+		{
+			name: "repo error",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMediaRepo := service_mock.NewMockmediaRepo(ctrl)
+			mockMediaStorage := service_mock.NewMockmediaStorage(ctrl)
+			s := NewMediaService(mockMediaRepo, mockMediaStorage, 512, testImagePresets)
+			tt.setup(mockMediaRepo, mockMediaStorage)
+
+			err := s.DeleteMedia(ctx, 1)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestMediaService_GetMediaDerivative(t *testing.T) {
+	original := testPNG(t, 4, 2)
+	media := &entity.Media{ID: 1, StorageKey: "key", FileName: "cat.png", Checksum: "abc", Width: 4, Height: 2}
+	derivativeKey := "derivatives/1/small-2x0-contain.png"
+
+	tests := []struct {
+		name    string
+		preset  string
+		setup   func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage)
+		wantErr error
+	}{
+		{
+			name:   "stored derivative",
+			preset: "small",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(media, nil)
+				store.EXPECT().Open(gomock.Any(), derivativeKey).Return(nopReadSeekCloser{strings.NewReader("x")}, nil)
+			},
+		},
+		{
+			name:   "derivative is generated on the first request",
+			preset: "small",
+			setup: func(repo *service_mock.MockmediaRepo, store *service_mock.MockmediaStorage) {
+				repo.EXPECT().GetMediaWithID(gomock.Any(), int64(1)).Return(media, nil)
+				store.EXPECT().Open(gomock.Any(), derivativeKey).Return(nil, storage.ErrNotExist)
+				store.EXPECT().Open(gomock.Any(), "key").Return(nopReadSeekCloser{bytes.NewReader(original)}, nil)
+				store.EXPECT().Put(gomock.Any(), derivativeKey, gomock.Any(), gomock.Any(), "image/png").
+					DoAndReturn(func(_ context.Context, _ string, body io.Reader, _ int64, _ string) error {
+						config, err := png.DecodeConfig(body)
+						assert.NoError(t, err)
+						assert.Equal(t, 2, config.Width)
+						assert.Equal(t, 1, config.Height)
+						return nil
+					})
+				store.EXPECT().Open(gomock.Any(), derivativeKey).Return(nopReadSeekCloser{strings.NewReader("x")}, nil)
+			},
+		},
+		{
+			name:    "unknown preset",
+			preset:  "huge",
+			setup:   func(*service_mock.MockmediaRepo, *service_mock.MockmediaStorage) {},
+			wantErr: errs.NotFound{Message: "preset huge"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMediaRepo := service_mock.NewMockmediaRepo(ctrl)
+			mockMediaStorage := service_mock.NewMockmediaStorage(ctrl)
+			s := NewMediaService(mockMediaRepo, mockMediaStorage, 512, testImagePresets)
+			tt.setup(mockMediaRepo, mockMediaStorage)
+
+			got, content, err := s.GetMediaDerivative(context.Background(), 1, tt.preset)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "image/png", got.MimeType)
+			assert.Equal(t, "cat.png", got.FileName)
+			assert.Equal(t, "/media/1/small", got.URL)
+			assert.NoError(t, content.Close())
+		})
+	}
+}
+
+func TestMediaService_GetImages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMediaRepo := service_mock.NewMockmediaRepo(ctrl)
+	s := NewMediaService(mockMediaRepo, service_mock.NewMockmediaStorage(ctrl), 512, testImagePresets)
+
+	mockMediaRepo.EXPECT().GetMediaWithIDs(gomock.Any(), []int64{1, 2}).Return([]entity.Media{
+		{ID: 1, MimeType: "image/jpeg", Width: 4, Height: 2},
+		// smaller than every responsive preset
+		{ID: 2, MimeType: "image/png", Width: 1, Height: 1},
+	}, nil)
+
+	got, err := s.GetImages(context.Background(), []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "/media/1/small 2w, /media/1 4w", got[1].Srcset)
+	assert.Equal(t, "/media/2 1w", got[2].Srcset)
+	assert.Len(t, got[1].Derivatives, 1)
+
+	images, err := s.GetImages(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, images)
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
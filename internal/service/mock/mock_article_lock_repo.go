@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: articleLockRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_article_lock_repo.go -package=service_mock . articleLockRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockarticleLockRepo is a mock of articleLockRepo interface.
+type MockarticleLockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockarticleLockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockarticleLockRepoMockRecorder is the mock recorder for MockarticleLockRepo.
+type MockarticleLockRepoMockRecorder struct {
+	mock *MockarticleLockRepo
+}
+
+// NewMockarticleLockRepo creates a new mock instance.
+func NewMockarticleLockRepo(ctrl *gomock.Controller) *MockarticleLockRepo {
+	mock := &MockarticleLockRepo{ctrl: ctrl}
+	mock.recorder = &MockarticleLockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockarticleLockRepo) EXPECT() *MockarticleLockRepoMockRecorder {
+	return m.recorder
+}
+
+// AcquireArticleLock mocks base method.
+func (m *MockarticleLockRepo) AcquireArticleLock(ctx context.Context, articleID int64, userID uuid.UUID, expiresAt time.Time) (*entity.ArticleLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireArticleLock", ctx, articleID, userID, expiresAt)
+	ret0, _ := ret[0].(*entity.ArticleLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireArticleLock indicates an expected call of AcquireArticleLock.
+func (mr *MockarticleLockRepoMockRecorder) AcquireArticleLock(ctx, articleID, userID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireArticleLock", reflect.TypeOf((*MockarticleLockRepo)(nil).AcquireArticleLock), ctx, articleID, userID, expiresAt)
+}
+
+// DeleteArticleLock mocks base method.
+func (m *MockarticleLockRepo) DeleteArticleLock(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArticleLock", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArticleLock indicates an expected call of DeleteArticleLock.
+func (mr *MockarticleLockRepoMockRecorder) DeleteArticleLock(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticleLock", reflect.TypeOf((*MockarticleLockRepo)(nil).DeleteArticleLock), ctx, articleID)
+}
+
+// GetArticleLock mocks base method.
+func (m *MockarticleLockRepo) GetArticleLock(ctx context.Context, articleID int64) (*entity.ArticleLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleLock", ctx, articleID)
+	ret0, _ := ret[0].(*entity.ArticleLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleLock indicates an expected call of GetArticleLock.
+func (mr *MockarticleLockRepoMockRecorder) GetArticleLock(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleLock", reflect.TypeOf((*MockarticleLockRepo)(nil).GetArticleLock), ctx, articleID)
+}
+
+// ReleaseArticleLock mocks base method.
+func (m *MockarticleLockRepo) ReleaseArticleLock(ctx context.Context, articleID int64, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseArticleLock", ctx, articleID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseArticleLock indicates an expected call of ReleaseArticleLock.
+func (mr *MockarticleLockRepoMockRecorder) ReleaseArticleLock(ctx, articleID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseArticleLock", reflect.TypeOf((*MockarticleLockRepo)(nil).ReleaseArticleLock), ctx, articleID, userID)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: articleLocker)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_article_locker.go -package=service_mock . articleLocker
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockarticleLocker is a mock of articleLocker interface.
+type MockarticleLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockarticleLockerMockRecorder
+	isgomock struct{}
+}
+
+// MockarticleLockerMockRecorder is the mock recorder for MockarticleLocker.
+type MockarticleLockerMockRecorder struct {
+	mock *MockarticleLocker
+}
+
+// NewMockarticleLocker creates a new mock instance.
+func NewMockarticleLocker(ctrl *gomock.Controller) *MockarticleLocker {
+	mock := &MockarticleLocker{ctrl: ctrl}
+	mock.recorder = &MockarticleLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockarticleLocker) EXPECT() *MockarticleLockerMockRecorder {
+	return m.recorder
+}
+
+// CheckArticleLock mocks base method.
+func (m *MockarticleLocker) CheckArticleLock(ctx context.Context, articleID int64, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckArticleLock", ctx, articleID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckArticleLock indicates an expected call of CheckArticleLock.
+func (mr *MockarticleLockerMockRecorder) CheckArticleLock(ctx, articleID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckArticleLock", reflect.TypeOf((*MockarticleLocker)(nil).CheckArticleLock), ctx, articleID, userID)
+}
@@ -88,6 +88,37 @@ func (mr *MockarticleRepoMockRecorder) DeleteArticle(ctx, articleID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticle", reflect.TypeOf((*MockarticleRepo)(nil).DeleteArticle), ctx, articleID)
 }
 
+// GetArticleCacheValidator mocks base method.
+func (m *MockarticleRepo) GetArticleCacheValidator(ctx context.Context, articleID int64) (*entity.CacheValidator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleCacheValidator", ctx, articleID)
+	ret0, _ := ret[0].(*entity.CacheValidator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleCacheValidator indicates an expected call of GetArticleCacheValidator.
+func (mr *MockarticleRepoMockRecorder) GetArticleCacheValidator(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleCacheValidator", reflect.TypeOf((*MockarticleRepo)(nil).GetArticleCacheValidator), ctx, articleID)
+}
+
+// GetArticleIDBySlug mocks base method.
+func (m *MockarticleRepo) GetArticleIDBySlug(ctx context.Context, slug string) (int64, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleIDBySlug", ctx, slug)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetArticleIDBySlug indicates an expected call of GetArticleIDBySlug.
+func (mr *MockarticleRepoMockRecorder) GetArticleIDBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleIDBySlug", reflect.TypeOf((*MockarticleRepo)(nil).GetArticleIDBySlug), ctx, slug)
+}
+
 // GetArticleVersionWithIDAndArticleID mocks base method.
 func (m *MockarticleRepo) GetArticleVersionWithIDAndArticleID(ctx context.Context, articleID, articleVersionID int64) (*entity.ArticleVersion, error) {
 	m.ctrl.T.Helper()
@@ -139,12 +170,13 @@ func (mr *MockarticleRepoMockRecorder) GetArticleWithID(ctx, articleID any) *gom
 }
 
 // GetArticles mocks base method.
-func (m *MockarticleRepo) GetArticles(ctx context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, error) {
+func (m *MockarticleRepo) GetArticles(ctx context.Context, req entity.GetArticlesQueryServiceParams) ([]entity.ArticleVersion, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetArticles", ctx, req)
 	ret0, _ := ret[0].([]entity.ArticleVersion)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetArticles indicates an expected call of GetArticles.
@@ -153,6 +185,36 @@ func (mr *MockarticleRepoMockRecorder) GetArticles(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticles", reflect.TypeOf((*MockarticleRepo)(nil).GetArticles), ctx, req)
 }
 
+// GetArticlesCacheValidator mocks base method.
+func (m *MockarticleRepo) GetArticlesCacheValidator(ctx context.Context) (*entity.CacheValidator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticlesCacheValidator", ctx)
+	ret0, _ := ret[0].(*entity.CacheValidator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticlesCacheValidator indicates an expected call of GetArticlesCacheValidator.
+func (mr *MockarticleRepoMockRecorder) GetArticlesCacheValidator(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesCacheValidator", reflect.TypeOf((*MockarticleRepo)(nil).GetArticlesCacheValidator), ctx)
+}
+
+// GetPublishedArticleVersions mocks base method.
+func (m *MockarticleRepo) GetPublishedArticleVersions(ctx context.Context) ([]entity.ArticleVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedArticleVersions", ctx)
+	ret0, _ := ret[0].([]entity.ArticleVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedArticleVersions indicates an expected call of GetPublishedArticleVersions.
+func (mr *MockarticleRepoMockRecorder) GetPublishedArticleVersions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedArticleVersions", reflect.TypeOf((*MockarticleRepo)(nil).GetPublishedArticleVersions), ctx)
+}
+
 // GetTagsWithArticleVersionID mocks base method.
 func (m *MockarticleRepo) GetTagsWithArticleVersionID(ctx context.Context, articleVersionID int64) ([]entity.Tag, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +230,21 @@ func (mr *MockarticleRepoMockRecorder) GetTagsWithArticleVersionID(ctx, articleV
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsWithArticleVersionID", reflect.TypeOf((*MockarticleRepo)(nil).GetTagsWithArticleVersionID), ctx, articleVersionID)
 }
 
+// GetTakenSlugs mocks base method.
+func (m *MockarticleRepo) GetTakenSlugs(ctx context.Context, slug string, articleID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenSlugs", ctx, slug, articleID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenSlugs indicates an expected call of GetTakenSlugs.
+func (mr *MockarticleRepoMockRecorder) GetTakenSlugs(ctx, slug, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenSlugs", reflect.TypeOf((*MockarticleRepo)(nil).GetTakenSlugs), ctx, slug, articleID)
+}
+
 // UpdateArticleStatus mocks base method.
 func (m *MockarticleRepo) UpdateArticleStatus(ctx context.Context, articleID, articleVersionID int64, status, prevStatus constanta.ArticleVersionStatus, updatedBy uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: categoryRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_category_repo.go -package=service_mock . categoryRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcategoryRepo is a mock of categoryRepo interface.
+type MockcategoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockcategoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockcategoryRepoMockRecorder is the mock recorder for MockcategoryRepo.
+type MockcategoryRepoMockRecorder struct {
+	mock *MockcategoryRepo
+}
+
+// NewMockcategoryRepo creates a new mock instance.
+func NewMockcategoryRepo(ctrl *gomock.Controller) *MockcategoryRepo {
+	mock := &MockcategoryRepo{ctrl: ctrl}
+	mock.recorder = &MockcategoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcategoryRepo) EXPECT() *MockcategoryRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockcategoryRepo) CreateCategory(ctx context.Context, category entity.Category) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockcategoryRepoMockRecorder) CreateCategory(ctx, category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockcategoryRepo)(nil).CreateCategory), ctx, category)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockcategoryRepo) DeleteCategory(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockcategoryRepoMockRecorder) DeleteCategory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockcategoryRepo)(nil).DeleteCategory), ctx, id)
+}
+
+// GetArticleCategories mocks base method.
+func (m *MockcategoryRepo) GetArticleCategories(ctx context.Context, articleID int64) ([]entity.ArticleCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleCategories", ctx, articleID)
+	ret0, _ := ret[0].([]entity.ArticleCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleCategories indicates an expected call of GetArticleCategories.
+func (mr *MockcategoryRepoMockRecorder) GetArticleCategories(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleCategories", reflect.TypeOf((*MockcategoryRepo)(nil).GetArticleCategories), ctx, articleID)
+}
+
+// GetCategories mocks base method.
+func (m *MockcategoryRepo) GetCategories(ctx context.Context, path string) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", ctx, path)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockcategoryRepoMockRecorder) GetCategories(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockcategoryRepo)(nil).GetCategories), ctx, path)
+}
+
+// GetCategoriesWithIDs mocks base method.
+func (m *MockcategoryRepo) GetCategoriesWithIDs(ctx context.Context, ids []int64) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesWithIDs", ctx, ids)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesWithIDs indicates an expected call of GetCategoriesWithIDs.
+func (mr *MockcategoryRepoMockRecorder) GetCategoriesWithIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesWithIDs", reflect.TypeOf((*MockcategoryRepo)(nil).GetCategoriesWithIDs), ctx, ids)
+}
+
+// GetCategoryWithID mocks base method.
+func (m *MockcategoryRepo) GetCategoryWithID(ctx context.Context, id int64) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryWithID", ctx, id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryWithID indicates an expected call of GetCategoryWithID.
+func (mr *MockcategoryRepoMockRecorder) GetCategoryWithID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryWithID", reflect.TypeOf((*MockcategoryRepo)(nil).GetCategoryWithID), ctx, id)
+}
+
+// SetArticleCategories mocks base method.
+func (m *MockcategoryRepo) SetArticleCategories(ctx context.Context, articleID, primaryCategoryID int64, categoryIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArticleCategories", ctx, articleID, primaryCategoryID, categoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArticleCategories indicates an expected call of SetArticleCategories.
+func (mr *MockcategoryRepoMockRecorder) SetArticleCategories(ctx, articleID, primaryCategoryID, categoryIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArticleCategories", reflect.TypeOf((*MockcategoryRepo)(nil).SetArticleCategories), ctx, articleID, primaryCategoryID, categoryIDs)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockcategoryRepo) UpdateCategory(ctx context.Context, category entity.Category, oldPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category, oldPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockcategoryRepoMockRecorder) UpdateCategory(ctx, category, oldPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockcategoryRepo)(nil).UpdateCategory), ctx, category, oldPath)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: commentRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_comment_repo.go -package=service_mock . commentRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcommentRepo is a mock of commentRepo interface.
+type MockcommentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommentRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockcommentRepoMockRecorder is the mock recorder for MockcommentRepo.
+type MockcommentRepoMockRecorder struct {
+	mock *MockcommentRepo
+}
+
+// NewMockcommentRepo creates a new mock instance.
+func NewMockcommentRepo(ctrl *gomock.Controller) *MockcommentRepo {
+	mock := &MockcommentRepo{ctrl: ctrl}
+	mock.recorder = &MockcommentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcommentRepo) EXPECT() *MockcommentRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateArticleComment mocks base method.
+func (m *MockcommentRepo) CreateArticleComment(ctx context.Context, comment entity.ArticleComment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArticleComment", ctx, comment)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArticleComment indicates an expected call of CreateArticleComment.
+func (mr *MockcommentRepoMockRecorder) CreateArticleComment(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArticleComment", reflect.TypeOf((*MockcommentRepo)(nil).CreateArticleComment), ctx, comment)
+}
+
+// GetArticleComment mocks base method.
+func (m *MockcommentRepo) GetArticleComment(ctx context.Context, id int64) (*entity.ArticleComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleComment", ctx, id)
+	ret0, _ := ret[0].(*entity.ArticleComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleComment indicates an expected call of GetArticleComment.
+func (mr *MockcommentRepoMockRecorder) GetArticleComment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleComment", reflect.TypeOf((*MockcommentRepo)(nil).GetArticleComment), ctx, id)
+}
+
+// GetArticleComments mocks base method.
+func (m *MockcommentRepo) GetArticleComments(ctx context.Context, articleVersionID int64) ([]entity.ArticleComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleComments", ctx, articleVersionID)
+	ret0, _ := ret[0].([]entity.ArticleComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleComments indicates an expected call of GetArticleComments.
+func (mr *MockcommentRepoMockRecorder) GetArticleComments(ctx, articleVersionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleComments", reflect.TypeOf((*MockcommentRepo)(nil).GetArticleComments), ctx, articleVersionID)
+}
+
+// GetUserNames mocks base method.
+func (m *MockcommentRepo) GetUserNames(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNames", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserNames indicates an expected call of GetUserNames.
+func (mr *MockcommentRepoMockRecorder) GetUserNames(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNames", reflect.TypeOf((*MockcommentRepo)(nil).GetUserNames), ctx, ids)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockcommentRepo) GetUserRoles(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]entity.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]entity.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockcommentRepoMockRecorder) GetUserRoles(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockcommentRepo)(nil).GetUserRoles), ctx, ids)
+}
+
+// ResolveArticleComment mocks base method.
+func (m *MockcommentRepo) ResolveArticleComment(ctx context.Context, id int64, resolvedBy uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveArticleComment", ctx, id, resolvedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveArticleComment indicates an expected call of ResolveArticleComment.
+func (mr *MockcommentRepoMockRecorder) ResolveArticleComment(ctx, id, resolvedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveArticleComment", reflect.TypeOf((*MockcommentRepo)(nil).ResolveArticleComment), ctx, id, resolvedBy)
+}
+
+// UnresolveArticleComment mocks base method.
+func (m *MockcommentRepo) UnresolveArticleComment(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnresolveArticleComment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnresolveArticleComment indicates an expected call of UnresolveArticleComment.
+func (mr *MockcommentRepoMockRecorder) UnresolveArticleComment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnresolveArticleComment", reflect.TypeOf((*MockcommentRepo)(nil).UnresolveArticleComment), ctx, id)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: eventBroker)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_event_broker.go -package=service_mock . eventBroker
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockeventBroker is a mock of eventBroker interface.
+type MockeventBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventBrokerMockRecorder
+	isgomock struct{}
+}
+
+// MockeventBrokerMockRecorder is the mock recorder for MockeventBroker.
+type MockeventBrokerMockRecorder struct {
+	mock *MockeventBroker
+}
+
+// NewMockeventBroker creates a new mock instance.
+func NewMockeventBroker(ctrl *gomock.Controller) *MockeventBroker {
+	mock := &MockeventBroker{ctrl: ctrl}
+	mock.recorder = &MockeventBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventBroker) EXPECT() *MockeventBrokerMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventBroker) Publish(ctx context.Context, event entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventBrokerMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventBroker)(nil).Publish), ctx, event)
+}
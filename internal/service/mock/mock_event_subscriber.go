@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: eventSubscriber)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_event_subscriber.go -package=service_mock . eventSubscriber
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	constanta "github.com/elangreza/content-management-system/internal/constanta"
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockeventSubscriber is a mock of eventSubscriber interface.
+type MockeventSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockeventSubscriberMockRecorder is the mock recorder for MockeventSubscriber.
+type MockeventSubscriberMockRecorder struct {
+	mock *MockeventSubscriber
+}
+
+// NewMockeventSubscriber creates a new mock instance.
+func NewMockeventSubscriber(ctrl *gomock.Controller) *MockeventSubscriber {
+	mock := &MockeventSubscriber{ctrl: ctrl}
+	mock.recorder = &MockeventSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventSubscriber) EXPECT() *MockeventSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockeventSubscriber) Subscribe(eventType constanta.EventType, subscriber string, handler func(context.Context, entity.OutboxEvent) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Subscribe", eventType, subscriber, handler)
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockeventSubscriberMockRecorder) Subscribe(eventType, subscriber, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockeventSubscriber)(nil).Subscribe), eventType, subscriber, handler)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: jobQueue)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_job_queue.go -package=service_mock . jobQueue
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+
+	constanta "github.com/elangreza/content-management-system/internal/constanta"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockjobQueue is a mock of jobQueue interface.
+type MockjobQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockjobQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockjobQueueMockRecorder is the mock recorder for MockjobQueue.
+type MockjobQueueMockRecorder struct {
+	mock *MockjobQueue
+}
+
+// NewMockjobQueue creates a new mock instance.
+func NewMockjobQueue(ctrl *gomock.Controller) *MockjobQueue {
+	mock := &MockjobQueue{ctrl: ctrl}
+	mock.recorder = &MockjobQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockjobQueue) EXPECT() *MockjobQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockjobQueue) Enqueue(ctx context.Context, jobType constanta.JobType, payload any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, jobType, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockjobQueueMockRecorder) Enqueue(ctx, jobType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockjobQueue)(nil).Enqueue), ctx, jobType, payload)
+}
+
+// Register mocks base method.
+func (m *MockjobQueue) Register(jobType constanta.JobType, handler func(context.Context, json.RawMessage) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", jobType, handler)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockjobQueueMockRecorder) Register(jobType, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockjobQueue)(nil).Register), jobType, handler)
+}
@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: jobRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_job_repo.go -package=service_mock . jobRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	constanta "github.com/elangreza/content-management-system/internal/constanta"
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockjobRepo is a mock of jobRepo interface.
+type MockjobRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockjobRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockjobRepoMockRecorder is the mock recorder for MockjobRepo.
+type MockjobRepoMockRecorder struct {
+	mock *MockjobRepo
+}
+
+// NewMockjobRepo creates a new mock instance.
+func NewMockjobRepo(ctrl *gomock.Controller) *MockjobRepo {
+	mock := &MockjobRepo{ctrl: ctrl}
+	mock.recorder = &MockjobRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockjobRepo) EXPECT() *MockjobRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimJobs mocks base method.
+func (m *MockjobRepo) ClaimJobs(ctx context.Context, limit int) ([]entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, limit)
+	ret0, _ := ret[0].([]entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockjobRepoMockRecorder) ClaimJobs(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockjobRepo)(nil).ClaimJobs), ctx, limit)
+}
+
+// CompleteJob mocks base method.
+func (m *MockjobRepo) CompleteJob(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteJob indicates an expected call of CompleteJob.
+func (mr *MockjobRepoMockRecorder) CompleteJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockjobRepo)(nil).CompleteJob), ctx, id)
+}
+
+// CreateJob mocks base method.
+func (m *MockjobRepo) CreateJob(ctx context.Context, job entity.Job) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockjobRepoMockRecorder) CreateJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockjobRepo)(nil).CreateJob), ctx, job)
+}
+
+// GetJobCounts mocks base method.
+func (m *MockjobRepo) GetJobCounts(ctx context.Context) (map[constanta.JobStatus]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobCounts", ctx)
+	ret0, _ := ret[0].(map[constanta.JobStatus]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobCounts indicates an expected call of GetJobCounts.
+func (mr *MockjobRepoMockRecorder) GetJobCounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobCounts", reflect.TypeOf((*MockjobRepo)(nil).GetJobCounts), ctx)
+}
+
+// GetJobs mocks base method.
+func (m *MockjobRepo) GetJobs(ctx context.Context, filter entity.JobFilter) ([]entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobs", ctx, filter)
+	ret0, _ := ret[0].([]entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobs indicates an expected call of GetJobs.
+func (mr *MockjobRepoMockRecorder) GetJobs(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockjobRepo)(nil).GetJobs), ctx, filter)
+}
+
+// KillJob mocks base method.
+func (m *MockjobRepo) KillJob(ctx context.Context, id int64, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillJob", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KillJob indicates an expected call of KillJob.
+func (mr *MockjobRepoMockRecorder) KillJob(ctx, id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillJob", reflect.TypeOf((*MockjobRepo)(nil).KillJob), ctx, id, lastError)
+}
+
+// RequeueStaleJobs mocks base method.
+func (m *MockjobRepo) RequeueStaleJobs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueStaleJobs", ctx, lockedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueStaleJobs indicates an expected call of RequeueStaleJobs.
+func (mr *MockjobRepoMockRecorder) RequeueStaleJobs(ctx, lockedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStaleJobs", reflect.TypeOf((*MockjobRepo)(nil).RequeueStaleJobs), ctx, lockedBefore)
+}
+
+// RetryJob mocks base method.
+func (m *MockjobRepo) RetryJob(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryJob", ctx, id, runAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryJob indicates an expected call of RetryJob.
+func (mr *MockjobRepoMockRecorder) RetryJob(ctx, id, runAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryJob", reflect.TypeOf((*MockjobRepo)(nil).RetryJob), ctx, id, runAt, lastError)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: mediaRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_media_repo.go -package=service_mock . mediaRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmediaRepo is a mock of mediaRepo interface.
+type MockmediaRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockmediaRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockmediaRepoMockRecorder is the mock recorder for MockmediaRepo.
+type MockmediaRepoMockRecorder struct {
+	mock *MockmediaRepo
+}
+
+// NewMockmediaRepo creates a new mock instance.
+func NewMockmediaRepo(ctrl *gomock.Controller) *MockmediaRepo {
+	mock := &MockmediaRepo{ctrl: ctrl}
+	mock.recorder = &MockmediaRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmediaRepo) EXPECT() *MockmediaRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateMedia mocks base method.
+func (m *MockmediaRepo) CreateMedia(ctx context.Context, media entity.Media) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedia", ctx, media)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedia indicates an expected call of CreateMedia.
+func (mr *MockmediaRepoMockRecorder) CreateMedia(ctx, media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedia", reflect.TypeOf((*MockmediaRepo)(nil).CreateMedia), ctx, media)
+}
+
+// DeleteMedia mocks base method.
+func (m *MockmediaRepo) DeleteMedia(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockmediaRepoMockRecorder) DeleteMedia(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockmediaRepo)(nil).DeleteMedia), ctx, id)
+}
+
+// GetMediaWithID mocks base method.
+func (m *MockmediaRepo) GetMediaWithID(ctx context.Context, id int64) (*entity.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaWithID", ctx, id)
+	ret0, _ := ret[0].(*entity.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaWithID indicates an expected call of GetMediaWithID.
+func (mr *MockmediaRepoMockRecorder) GetMediaWithID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaWithID", reflect.TypeOf((*MockmediaRepo)(nil).GetMediaWithID), ctx, id)
+}
+
+// GetMediaWithIDs mocks base method.
+func (m *MockmediaRepo) GetMediaWithIDs(ctx context.Context, ids []int64) ([]entity.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaWithIDs", ctx, ids)
+	ret0, _ := ret[0].([]entity.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaWithIDs indicates an expected call of GetMediaWithIDs.
+func (mr *MockmediaRepoMockRecorder) GetMediaWithIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaWithIDs", reflect.TypeOf((*MockmediaRepo)(nil).GetMediaWithIDs), ctx, ids)
+}
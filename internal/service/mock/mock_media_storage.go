@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: mediaStorage)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_media_storage.go -package=service_mock . mediaStorage
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmediaStorage is a mock of mediaStorage interface.
+type MockmediaStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockmediaStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockmediaStorageMockRecorder is the mock recorder for MockmediaStorage.
+type MockmediaStorageMockRecorder struct {
+	mock *MockmediaStorage
+}
+
+// NewMockmediaStorage creates a new mock instance.
+func NewMockmediaStorage(ctrl *gomock.Controller) *MockmediaStorage {
+	mock := &MockmediaStorage{ctrl: ctrl}
+	mock.recorder = &MockmediaStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmediaStorage) EXPECT() *MockmediaStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockmediaStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockmediaStorageMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockmediaStorage)(nil).Delete), ctx, key)
+}
+
+// Open mocks base method.
+func (m *MockmediaStorage) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(io.ReadSeekCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockmediaStorageMockRecorder) Open(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockmediaStorage)(nil).Open), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockmediaStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, body, size, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockmediaStorageMockRecorder) Put(ctx, key, body, size, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockmediaStorage)(nil).Put), ctx, key, body, size, contentType)
+}
@@ -0,0 +1,191 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: notificationRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_notification_repo.go -package=service_mock . notificationRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocknotificationRepo is a mock of notificationRepo interface.
+type MocknotificationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotificationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MocknotificationRepoMockRecorder is the mock recorder for MocknotificationRepo.
+type MocknotificationRepoMockRecorder struct {
+	mock *MocknotificationRepo
+}
+
+// NewMocknotificationRepo creates a new mock instance.
+func NewMocknotificationRepo(ctrl *gomock.Controller) *MocknotificationRepo {
+	mock := &MocknotificationRepo{ctrl: ctrl}
+	mock.recorder = &MocknotificationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknotificationRepo) EXPECT() *MocknotificationRepoMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MocknotificationRepo) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MocknotificationRepoMockRecorder) CountUnreadNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MocknotificationRepo)(nil).CountUnreadNotifications), ctx, userID)
+}
+
+// CreateNotifications mocks base method.
+func (m *MocknotificationRepo) CreateNotifications(ctx context.Context, notifications []entity.Notification) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", ctx, notifications)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MocknotificationRepoMockRecorder) CreateNotifications(ctx, notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MocknotificationRepo)(nil).CreateNotifications), ctx, notifications)
+}
+
+// GetArticleVersionAudience mocks base method.
+func (m *MocknotificationRepo) GetArticleVersionAudience(ctx context.Context, articleVersionID int64) (*entity.NotificationAudience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleVersionAudience", ctx, articleVersionID)
+	ret0, _ := ret[0].(*entity.NotificationAudience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleVersionAudience indicates an expected call of GetArticleVersionAudience.
+func (mr *MocknotificationRepoMockRecorder) GetArticleVersionAudience(ctx, articleVersionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleVersionAudience", reflect.TypeOf((*MocknotificationRepo)(nil).GetArticleVersionAudience), ctx, articleVersionID)
+}
+
+// GetCommentAudience mocks base method.
+func (m *MocknotificationRepo) GetCommentAudience(ctx context.Context, commentID int64) (*entity.NotificationAudience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentAudience", ctx, commentID)
+	ret0, _ := ret[0].(*entity.NotificationAudience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentAudience indicates an expected call of GetCommentAudience.
+func (mr *MocknotificationRepoMockRecorder) GetCommentAudience(ctx, commentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentAudience", reflect.TypeOf((*MocknotificationRepo)(nil).GetCommentAudience), ctx, commentID)
+}
+
+// GetNotificationEmail mocks base method.
+func (m *MocknotificationRepo) GetNotificationEmail(ctx context.Context, id int64) (*entity.NotificationEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationEmail", ctx, id)
+	ret0, _ := ret[0].(*entity.NotificationEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationEmail indicates an expected call of GetNotificationEmail.
+func (mr *MocknotificationRepoMockRecorder) GetNotificationEmail(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationEmail", reflect.TypeOf((*MocknotificationRepo)(nil).GetNotificationEmail), ctx, id)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MocknotificationRepo) GetNotificationPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]entity.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", ctx, userIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]entity.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MocknotificationRepoMockRecorder) GetNotificationPreferences(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MocknotificationRepo)(nil).GetNotificationPreferences), ctx, userIDs)
+}
+
+// GetNotifications mocks base method.
+func (m *MocknotificationRepo) GetNotifications(ctx context.Context, filter entity.NotificationFilter) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, filter)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MocknotificationRepoMockRecorder) GetNotifications(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MocknotificationRepo)(nil).GetNotifications), ctx, filter)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MocknotificationRepo) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MocknotificationRepoMockRecorder) MarkAllNotificationsRead(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MocknotificationRepo)(nil).MarkAllNotificationsRead), ctx, userID)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MocknotificationRepo) MarkNotificationRead(ctx context.Context, id int64, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MocknotificationRepoMockRecorder) MarkNotificationRead(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MocknotificationRepo)(nil).MarkNotificationRead), ctx, id, userID)
+}
+
+// SaveNotificationPreference mocks base method.
+func (m *MocknotificationRepo) SaveNotificationPreference(ctx context.Context, preference entity.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotificationPreference", ctx, preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNotificationPreference indicates an expected call of SaveNotificationPreference.
+func (mr *MocknotificationRepoMockRecorder) SaveNotificationPreference(ctx, preference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotificationPreference", reflect.TypeOf((*MocknotificationRepo)(nil).SaveNotificationPreference), ctx, preference)
+}
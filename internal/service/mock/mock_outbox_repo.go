@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: outboxRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_outbox_repo.go -package=service_mock . outboxRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockoutboxRepo is a mock of outboxRepo interface.
+type MockoutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockoutboxRepoMockRecorder is the mock recorder for MockoutboxRepo.
+type MockoutboxRepoMockRecorder struct {
+	mock *MockoutboxRepo
+}
+
+// NewMockoutboxRepo creates a new mock instance.
+func NewMockoutboxRepo(ctrl *gomock.Controller) *MockoutboxRepo {
+	mock := &MockoutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepo) EXPECT() *MockoutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimOutboxEvents mocks base method.
+func (m *MockoutboxRepo) ClaimOutboxEvents(ctx context.Context, lockedBefore time.Time, limit int) ([]entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxEvents", ctx, lockedBefore, limit)
+	ret0, _ := ret[0].([]entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxEvents indicates an expected call of ClaimOutboxEvents.
+func (mr *MockoutboxRepoMockRecorder) ClaimOutboxEvents(ctx, lockedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxEvents", reflect.TypeOf((*MockoutboxRepo)(nil).ClaimOutboxEvents), ctx, lockedBefore, limit)
+}
+
+// CompleteOutboxEvent mocks base method.
+func (m *MockoutboxRepo) CompleteOutboxEvent(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOutboxEvent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteOutboxEvent indicates an expected call of CompleteOutboxEvent.
+func (mr *MockoutboxRepoMockRecorder) CompleteOutboxEvent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOutboxEvent", reflect.TypeOf((*MockoutboxRepo)(nil).CompleteOutboxEvent), ctx, id)
+}
+
+// CreateOutboxDelivery mocks base method.
+func (m *MockoutboxRepo) CreateOutboxDelivery(ctx context.Context, eventID int64, subscriber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxDelivery", ctx, eventID, subscriber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOutboxDelivery indicates an expected call of CreateOutboxDelivery.
+func (mr *MockoutboxRepoMockRecorder) CreateOutboxDelivery(ctx, eventID, subscriber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxDelivery", reflect.TypeOf((*MockoutboxRepo)(nil).CreateOutboxDelivery), ctx, eventID, subscriber)
+}
+
+// DeletePublishedOutboxEvents mocks base method.
+func (m *MockoutboxRepo) DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedOutboxEvents", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublishedOutboxEvents indicates an expected call of DeletePublishedOutboxEvents.
+func (mr *MockoutboxRepoMockRecorder) DeletePublishedOutboxEvents(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedOutboxEvents", reflect.TypeOf((*MockoutboxRepo)(nil).DeletePublishedOutboxEvents), ctx, before)
+}
+
+// KillOutboxEvent mocks base method.
+func (m *MockoutboxRepo) KillOutboxEvent(ctx context.Context, id int64, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillOutboxEvent", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KillOutboxEvent indicates an expected call of KillOutboxEvent.
+func (mr *MockoutboxRepoMockRecorder) KillOutboxEvent(ctx, id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillOutboxEvent", reflect.TypeOf((*MockoutboxRepo)(nil).KillOutboxEvent), ctx, id, lastError)
+}
+
+// RetryOutboxEvent mocks base method.
+func (m *MockoutboxRepo) RetryOutboxEvent(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryOutboxEvent", ctx, id, runAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryOutboxEvent indicates an expected call of RetryOutboxEvent.
+func (mr *MockoutboxRepoMockRecorder) RetryOutboxEvent(ctx, id, runAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutboxEvent", reflect.TypeOf((*MockoutboxRepo)(nil).RetryOutboxEvent), ctx, id, runAt, lastError)
+}
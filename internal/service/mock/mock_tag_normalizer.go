@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: tagNormalizer)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_tag_normalizer.go -package=service_mock . tagNormalizer
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocktagNormalizer is a mock of tagNormalizer interface.
+type MocktagNormalizer struct {
+	ctrl     *gomock.Controller
+	recorder *MocktagNormalizerMockRecorder
+	isgomock struct{}
+}
+
+// MocktagNormalizerMockRecorder is the mock recorder for MocktagNormalizer.
+type MocktagNormalizerMockRecorder struct {
+	mock *MocktagNormalizer
+}
+
+// NewMocktagNormalizer creates a new mock instance.
+func NewMocktagNormalizer(ctrl *gomock.Controller) *MocktagNormalizer {
+	mock := &MocktagNormalizer{ctrl: ctrl}
+	mock.recorder = &MocktagNormalizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktagNormalizer) EXPECT() *MocktagNormalizerMockRecorder {
+	return m.recorder
+}
+
+// NormalizeTags mocks base method.
+func (m *MocktagNormalizer) NormalizeTags(ctx context.Context, names ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range names {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NormalizeTags", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormalizeTags indicates an expected call of NormalizeTags.
+func (mr *MocktagNormalizerMockRecorder) NormalizeTags(ctx any, names ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, names...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeTags", reflect.TypeOf((*MocktagNormalizer)(nil).NormalizeTags), varargs...)
+}
@@ -12,8 +12,8 @@ package service_mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
-	constanta "github.com/elangreza/content-management-system/internal/constanta"
 	entity "github.com/elangreza/content-management-system/internal/entity"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,19 +42,126 @@ func (m *MocktagRepo) EXPECT() *MocktagRepoMockRecorder {
 	return m.recorder
 }
 
-// GetArticleTags mocks base method.
-func (m *MocktagRepo) GetArticleTags(ctx context.Context, status constanta.ArticleVersionStatus) ([]entity.ArticleVersionTag, error) {
+// CreateTagSynonyms mocks base method.
+func (m *MocktagRepo) CreateTagSynonyms(ctx context.Context, name string, aliases []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArticleTags", ctx, status)
-	ret0, _ := ret[0].([]entity.ArticleVersionTag)
+	ret := m.ctrl.Call(m, "CreateTagSynonyms", ctx, name, aliases)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTagSynonyms indicates an expected call of CreateTagSynonyms.
+func (mr *MocktagRepoMockRecorder) CreateTagSynonyms(ctx, name, aliases any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTagSynonyms", reflect.TypeOf((*MocktagRepo)(nil).CreateTagSynonyms), ctx, name, aliases)
+}
+
+// DeleteTag mocks base method.
+func (m *MocktagRepo) DeleteTag(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MocktagRepoMockRecorder) DeleteTag(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MocktagRepo)(nil).DeleteTag), ctx, name)
+}
+
+// DeleteTagSynonym mocks base method.
+func (m *MocktagRepo) DeleteTagSynonym(ctx context.Context, name, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagSynonym", ctx, name, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTagSynonym indicates an expected call of DeleteTagSynonym.
+func (mr *MocktagRepoMockRecorder) DeleteTagSynonym(ctx, name, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagSynonym", reflect.TypeOf((*MocktagRepo)(nil).DeleteTagSynonym), ctx, name, alias)
+}
+
+// DeleteUnusedTags mocks base method.
+func (m *MocktagRepo) DeleteUnusedTags(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnusedTags", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnusedTags indicates an expected call of DeleteUnusedTags.
+func (mr *MocktagRepoMockRecorder) DeleteUnusedTags(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnusedTags", reflect.TypeOf((*MocktagRepo)(nil).DeleteUnusedTags), ctx)
+}
+
+// GetTagPairFrequency mocks base method.
+func (m *MocktagRepo) GetTagPairFrequency(ctx context.Context) (map[[2]string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagPairFrequency", ctx)
+	ret0, _ := ret[0].(map[[2]string]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArticleTags indicates an expected call of GetArticleTags.
-func (mr *MocktagRepoMockRecorder) GetArticleTags(ctx, status any) *gomock.Call {
+// GetTagPairFrequency indicates an expected call of GetTagPairFrequency.
+func (mr *MocktagRepoMockRecorder) GetTagPairFrequency(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleTags", reflect.TypeOf((*MocktagRepo)(nil).GetArticleTags), ctx, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagPairFrequency", reflect.TypeOf((*MocktagRepo)(nil).GetTagPairFrequency), ctx)
+}
+
+// GetTagStats mocks base method.
+func (m *MocktagRepo) GetTagStats(ctx context.Context, filter entity.TagStatsFilter) (*entity.TagStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagStats", ctx, filter)
+	ret0, _ := ret[0].(*entity.TagStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagStats indicates an expected call of GetTagStats.
+func (mr *MocktagRepoMockRecorder) GetTagStats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagStats", reflect.TypeOf((*MocktagRepo)(nil).GetTagStats), ctx, filter)
+}
+
+// GetTagSynonyms mocks base method.
+func (m *MocktagRepo) GetTagSynonyms(ctx context.Context, aliases ...string) ([]entity.TagSynonym, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range aliases {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTagSynonyms", varargs...)
+	ret0, _ := ret[0].([]entity.TagSynonym)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagSynonyms indicates an expected call of GetTagSynonyms.
+func (mr *MocktagRepoMockRecorder) GetTagSynonyms(ctx any, aliases ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, aliases...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagSynonyms", reflect.TypeOf((*MocktagRepo)(nil).GetTagSynonyms), varargs...)
+}
+
+// GetTagSynonymsWithTagName mocks base method.
+func (m *MocktagRepo) GetTagSynonymsWithTagName(ctx context.Context, name string) ([]entity.TagSynonym, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagSynonymsWithTagName", ctx, name)
+	ret0, _ := ret[0].([]entity.TagSynonym)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagSynonymsWithTagName indicates an expected call of GetTagSynonymsWithTagName.
+func (mr *MocktagRepoMockRecorder) GetTagSynonymsWithTagName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagSynonymsWithTagName", reflect.TypeOf((*MocktagRepo)(nil).GetTagSynonymsWithTagName), ctx, name)
 }
 
 // GetTagUsage mocks base method.
@@ -72,15 +179,30 @@ func (mr *MocktagRepoMockRecorder) GetTagUsage(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagUsage", reflect.TypeOf((*MocktagRepo)(nil).GetTagUsage), ctx)
 }
 
+// GetTagUsageDaily mocks base method.
+func (m *MocktagRepo) GetTagUsageDaily(ctx context.Context, since time.Time) ([]entity.TagUsageDaily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagUsageDaily", ctx, since)
+	ret0, _ := ret[0].([]entity.TagUsageDaily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagUsageDaily indicates an expected call of GetTagUsageDaily.
+func (mr *MocktagRepoMockRecorder) GetTagUsageDaily(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagUsageDaily", reflect.TypeOf((*MocktagRepo)(nil).GetTagUsageDaily), ctx, since)
+}
+
 // GetTags mocks base method.
-func (m *MocktagRepo) GetTags(ctx context.Context, names ...string) ([]string, error) {
+func (m *MocktagRepo) GetTags(ctx context.Context, names ...string) ([]entity.Tag, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx}
 	for _, a := range names {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "GetTags", varargs...)
-	ret0, _ := ret[0].([]string)
+	ret0, _ := ret[0].([]entity.Tag)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -92,6 +214,49 @@ func (mr *MocktagRepoMockRecorder) GetTags(ctx any, names ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MocktagRepo)(nil).GetTags), varargs...)
 }
 
+// MergeTags mocks base method.
+func (m *MocktagRepo) MergeTags(ctx context.Context, sources []string, target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, sources, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MocktagRepoMockRecorder) MergeTags(ctx, sources, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MocktagRepo)(nil).MergeTags), ctx, sources, target)
+}
+
+// ReconcileTagStats mocks base method.
+func (m *MocktagRepo) ReconcileTagStats(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileTagStats", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileTagStats indicates an expected call of ReconcileTagStats.
+func (mr *MocktagRepoMockRecorder) ReconcileTagStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTagStats", reflect.TypeOf((*MocktagRepo)(nil).ReconcileTagStats), ctx)
+}
+
+// UpdateTag mocks base method.
+func (m *MocktagRepo) UpdateTag(ctx context.Context, name string, tag entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, name, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MocktagRepoMockRecorder) UpdateTag(ctx, name, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MocktagRepo)(nil).UpdateTag), ctx, name, tag)
+}
+
 // UpsertTags mocks base method.
 func (m *MocktagRepo) UpsertTags(ctx context.Context, names ...string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: webhookRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_webhook_repo.go -package=service_mock . webhookRepo
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	constanta "github.com/elangreza/content-management-system/internal/constanta"
+	entity "github.com/elangreza/content-management-system/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockwebhookRepo is a mock of webhookRepo interface.
+type MockwebhookRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockwebhookRepoMockRecorder is the mock recorder for MockwebhookRepo.
+type MockwebhookRepoMockRecorder struct {
+	mock *MockwebhookRepo
+}
+
+// NewMockwebhookRepo creates a new mock instance.
+func NewMockwebhookRepo(ctrl *gomock.Controller) *MockwebhookRepo {
+	mock := &MockwebhookRepo{ctrl: ctrl}
+	mock.recorder = &MockwebhookRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookRepo) EXPECT() *MockwebhookRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockwebhookRepo) CreateWebhook(ctx context.Context, webhook entity.Webhook) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockwebhookRepoMockRecorder) CreateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockwebhookRepo)(nil).CreateWebhook), ctx, webhook)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockwebhookRepo) CreateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockwebhookRepoMockRecorder) CreateWebhookDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockwebhookRepo)(nil).CreateWebhookDelivery), ctx, delivery)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockwebhookRepo) DeleteWebhook(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockwebhookRepoMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockwebhookRepo)(nil).DeleteWebhook), ctx, id)
+}
+
+// DeleteWebhookDelivery mocks base method.
+func (m *MockwebhookRepo) DeleteWebhookDelivery(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookDelivery indicates an expected call of DeleteWebhookDelivery.
+func (mr *MockwebhookRepoMockRecorder) DeleteWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookDelivery", reflect.TypeOf((*MockwebhookRepo)(nil).DeleteWebhookDelivery), ctx, id)
+}
+
+// GetSubscribedWebhooks mocks base method.
+func (m *MockwebhookRepo) GetSubscribedWebhooks(ctx context.Context, event constanta.EventType) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribedWebhooks", ctx, event)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribedWebhooks indicates an expected call of GetSubscribedWebhooks.
+func (mr *MockwebhookRepoMockRecorder) GetSubscribedWebhooks(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribedWebhooks", reflect.TypeOf((*MockwebhookRepo)(nil).GetSubscribedWebhooks), ctx, event)
+}
+
+// GetWebhook mocks base method.
+func (m *MockwebhookRepo) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockwebhookRepoMockRecorder) GetWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockwebhookRepo)(nil).GetWebhook), ctx, id)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockwebhookRepo) GetWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockwebhookRepoMockRecorder) GetWebhookDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockwebhookRepo)(nil).GetWebhookDeliveries), ctx, filter)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockwebhookRepo) GetWebhookDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockwebhookRepoMockRecorder) GetWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockwebhookRepo)(nil).GetWebhookDelivery), ctx, id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockwebhookRepo) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockwebhookRepoMockRecorder) GetWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockwebhookRepo)(nil).GetWebhooks), ctx)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockwebhookRepo) RecordWebhookDeliveryAttempt(ctx context.Context, id int64, attempt entity.WebhookDeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, id, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockwebhookRepoMockRecorder) RecordWebhookDeliveryAttempt(ctx, id, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockwebhookRepo)(nil).RecordWebhookDeliveryAttempt), ctx, id, attempt)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockwebhookRepo) UpdateWebhook(ctx context.Context, webhook entity.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockwebhookRepoMockRecorder) UpdateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockwebhookRepo)(nil).UpdateWebhook), ctx, webhook)
+}
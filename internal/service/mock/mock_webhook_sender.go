@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/content-management-system/internal/service (interfaces: webhookSender)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_webhook_sender.go -package=service_mock . webhookSender
+//
+
+// Package service_mock is a generated GoMock package.
+package service_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	webhook "github.com/elangreza/content-management-system/internal/webhook"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockwebhookSender is a mock of webhookSender interface.
+type MockwebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockwebhookSenderMockRecorder is the mock recorder for MockwebhookSender.
+type MockwebhookSenderMockRecorder struct {
+	mock *MockwebhookSender
+}
+
+// NewMockwebhookSender creates a new mock instance.
+func NewMockwebhookSender(ctrl *gomock.Controller) *MockwebhookSender {
+	mock := &MockwebhookSender{ctrl: ctrl}
+	mock.recorder = &MockwebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookSender) EXPECT() *MockwebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockwebhookSender) Send(ctx context.Context, msg webhook.Message) (*webhook.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(*webhook.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockwebhookSenderMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockwebhookSender)(nil).Send), ctx, msg)
+}
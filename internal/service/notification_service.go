@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/mailer"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/google/uuid"
+)
+
+type (
+	notificationRepo interface {
+		GetArticleVersionAudience(ctx context.Context, articleVersionID int64) (*entity.NotificationAudience, error)
+		GetCommentAudience(ctx context.Context, commentID int64) (*entity.NotificationAudience, error)
+		CreateNotifications(ctx context.Context, notifications []entity.Notification) ([]entity.Notification, error)
+		GetNotifications(ctx context.Context, filter entity.NotificationFilter) ([]entity.Notification, error)
+		CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error)
+		GetNotificationEmail(ctx context.Context, id int64) (*entity.NotificationEmail, error)
+		MarkNotificationRead(ctx context.Context, id int64, userID uuid.UUID) error
+		MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error)
+		GetNotificationPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]entity.NotificationPreference, error)
+		SaveNotificationPreference(ctx context.Context, preference entity.NotificationPreference) error
+	}
+
+	NotificationService struct {
+		notificationRepo notificationRepo
+		jobs             jobQueue
+		// nil when no mail server is configured
+		sender mailer.Sender
+	}
+)
+
+// NewNotificationService notifies the users from the committed events, the emails are sent through the job queue
+// so a mail server which is down is retried.
+func NewNotificationService(notificationRepo notificationRepo, jobs jobQueue, events eventSubscriber, sender mailer.Sender) *NotificationService {
+	ns := &NotificationService{
+		notificationRepo: notificationRepo,
+		jobs:             jobs,
+		sender:           sender,
+	}
+
+	jobs.Register(constanta.JobSendNotificationEmail, ns.sendEmailJob)
+
+	events.Subscribe(constanta.EventVersionPublished, "notification", ns.notifyVersionEvent)
+	events.Subscribe(constanta.EventVersionArchived, "notification", ns.notifyVersionEvent)
+	events.Subscribe(constanta.EventCommentCreated, "notification", ns.notifyCommentEvent)
+
+	return ns
+}
+
+// notifyVersionEvent notifies the writers of the article that its version is published or archived.
+func (ns *NotificationService) notifyVersionEvent(ctx context.Context, event entity.OutboxEvent) error {
+	var data entity.ArticleEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("invalid payload for %s event: %w", event.Type, err)
+	}
+
+	// the version is deleted with its article before the event is relayed
+	audience, err := ns.notificationRepo.GetArticleVersionAudience(ctx, data.ArticleVersionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	notificationType := constanta.NotificationVersionPublished
+	if event.Type == constanta.EventVersionArchived {
+		notificationType = constanta.NotificationVersionArchived
+	}
+
+	notifications := make([]entity.Notification, 0, len(audience.Recipients))
+	for _, userID := range audience.Recipients {
+		notifications = append(notifications, entity.NewNotification(userID, notificationType, event.ID, *audience))
+	}
+
+	return ns.notify(ctx, notifications)
+}
+
+// notifyCommentEvent notifies the mentioned users, and the writer of the version and the users in the thread of the comment.
+// A mentioned user only gets the mention.
+func (ns *NotificationService) notifyCommentEvent(ctx context.Context, event entity.OutboxEvent) error {
+	var data entity.CommentEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("invalid payload for %s event: %w", event.Type, err)
+	}
+
+	audience, err := ns.notificationRepo.GetCommentAudience(ctx, data.CommentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	notified := map[uuid.UUID]bool{data.CreatedBy: true}
+	var notifications []entity.Notification
+	for _, userID := range data.Mentions {
+		if !notified[userID] {
+			notified[userID] = true
+			notifications = append(notifications, entity.NewNotification(userID, constanta.NotificationMention, event.ID, *audience))
+		}
+	}
+
+	for _, userID := range audience.Recipients {
+		if !notified[userID] {
+			notified[userID] = true
+			notifications = append(notifications, entity.NewNotification(userID, constanta.NotificationComment, event.ID, *audience))
+		}
+	}
+
+	return ns.notify(ctx, notifications)
+}
+
+// notify creates the notifications which the users have not muted, and enqueues the emails of the users
+// who chose the email delivery.
+func (ns *NotificationService) notify(ctx context.Context, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(notifications))
+	for _, notification := range notifications {
+		userIDs = append(userIDs, notification.UserID)
+	}
+
+	preferences, err := ns.notificationRepo.GetNotificationPreferences(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	allowed := make([]entity.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		if preference, ok := preferences[notification.UserID]; ok && preference.Mutes(notification.Type) {
+			continue
+		}
+		allowed = append(allowed, notification)
+	}
+
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	// a relayed event only returns the notifications which were not created before
+	created, err := ns.notificationRepo.CreateNotifications(ctx, allowed)
+	if err != nil {
+		return err
+	}
+
+	if ns.sender == nil {
+		return nil
+	}
+
+	var errList []error
+	for _, notification := range created {
+		if preferences[notification.UserID].Delivery != constanta.NotificationDeliveryEmail {
+			continue
+		}
+
+		if err := ns.jobs.Enqueue(ctx, constanta.JobSendNotificationEmail, entity.SendNotificationEmailPayload{NotificationID: notification.ID}); err != nil {
+			errList = append(errList, fmt.Errorf("notification %d: %w", notification.ID, err))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// sendEmailJob sends the notification to the email of its user, a notification which is already read in the app is not sent.
+func (ns *NotificationService) sendEmailJob(ctx context.Context, rawPayload json.RawMessage) error {
+	var payload entity.SendNotificationEmailPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s job: %w", constanta.JobSendNotificationEmail, err)
+	}
+
+	// the mail server was configured when the job was enqueued, but not anymore
+	if ns.sender == nil {
+		return nil
+	}
+
+	// the notifications are deleted with their user or article
+	email, err := ns.notificationRepo.GetNotificationEmail(ctx, payload.NotificationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if email.ReadAt != nil {
+		return nil
+	}
+
+	return ns.sender.Send(ctx, mailer.Message{
+		To:      email.UserEmail,
+		Subject: email.Message,
+		Body: fmt.Sprintf("Hi %s,\n\n%s.\n\nArticle %d, version %d.\n",
+			email.UserName, email.Message, email.ArticleID, email.ArticleVersionID),
+	})
+}
+
+// => GET /notifications
+func (ns *NotificationService) GetNotifications(ctx context.Context, req params.GetNotificationsRequest) (*params.GetNotificationsResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	notifications, err := ns.notificationRepo.GetNotifications(ctx, entity.NotificationFilter{
+		UserID:   userID,
+		Unread:   req.Unread,
+		BeforeID: req.BeforeID,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := ns.notificationRepo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &params.GetNotificationsResponse{
+		Notifications: params.NewNotificationResponses(notifications...),
+		UnreadCount:   unreadCount,
+	}
+
+	if len(notifications) == req.Limit {
+		res.NextBeforeID = notifications[len(notifications)-1].ID
+	}
+
+	return res, nil
+}
+
+// => PUT /notifications/{notificationID}/read
+func (ns *NotificationService) MarkNotificationRead(ctx context.Context, id int64) error {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return errors.New("error when parsing userID")
+	}
+
+	if err := ns.notificationRepo.MarkNotificationRead(ctx, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound{Message: fmt.Sprintf("notification %d", id)}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// => PUT /notifications/read
+func (ns *NotificationService) MarkAllNotificationsRead(ctx context.Context) (*params.MarkNotificationsReadResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	updated, err := ns.notificationRepo.MarkAllNotificationsRead(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &params.MarkNotificationsReadResponse{Updated: updated}, nil
+}
+
+// => GET /notifications/preferences
+func (ns *NotificationService) GetNotificationPreference(ctx context.Context) (*params.NotificationPreferenceResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	preferences, err := ns.notificationRepo.GetNotificationPreferences(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	preference, ok := preferences[userID]
+	if !ok {
+		preference = entity.DefaultNotificationPreference(userID)
+	}
+
+	res := params.NewNotificationPreferenceResponse(preference, ns.sender != nil)
+	return &res, nil
+}
+
+// => PUT /notifications/preferences
+func (ns *NotificationService) UpdateNotificationPreference(ctx context.Context, req params.NotificationPreferenceRequest) (*params.NotificationPreferenceResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	if req.Delivery == constanta.NotificationDeliveryEmail && ns.sender == nil {
+		return nil, errs.ValidationError{Message: "email delivery is not configured"}
+	}
+
+	if err := ns.notificationRepo.SaveNotificationPreference(ctx, entity.NotificationPreference{
+		UserID:     userID,
+		Delivery:   req.Delivery,
+		MutedTypes: req.MutedTypes,
+	}); err != nil {
+		return nil, err
+	}
+
+	return ns.GetNotificationPreference(ctx)
+}
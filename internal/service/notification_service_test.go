@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/mailer"
+	"github.com/elangreza/content-management-system/internal/params"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_notification_repo.go -package=service_mock . notificationRepo
+
+type sendFunc func(ctx context.Context, msg mailer.Message) error
+
+func (f sendFunc) Send(ctx context.Context, msg mailer.Message) error {
+	return f(ctx, msg)
+}
+
+func newTestNotificationService(ctrl *gomock.Controller, sender mailer.Sender) (*NotificationService, *service_mock.MocknotificationRepo, *service_mock.MockjobQueue) {
+	mockNotificationRepo := service_mock.NewMocknotificationRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Register(constanta.JobSendNotificationEmail, gomock.Any())
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	return NewNotificationService(mockNotificationRepo, mockJobQueue, mockEventSubscriber, sender), mockNotificationRepo, mockJobQueue
+}
+
+func TestNotificationService_notifyCommentEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, mockNotificationRepo, mockJobQueue := newTestNotificationService(ctrl, sendFunc(func(ctx context.Context, msg mailer.Message) error { return nil }))
+
+	commenter, mentioned, writer, muted := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	audience := &entity.NotificationAudience{
+		ArticleID: 1, ArticleVersionID: 2, CommentID: 3, Title: "hello", Version: 4,
+		ActorID: &commenter, ActorName: "writer",
+		Recipients: []uuid.UUID{mentioned, writer, muted},
+	}
+	mention := entity.NewNotification(mentioned, constanta.NotificationMention, 9, *audience)
+	comment := entity.NewNotification(writer, constanta.NotificationComment, 9, *audience)
+
+	mockNotificationRepo.EXPECT().GetCommentAudience(gomock.Any(), int64(3)).Return(audience, nil)
+	mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uuid.UUID{mentioned, writer, muted}).Return(map[uuid.UUID]entity.NotificationPreference{
+		mentioned: {UserID: mentioned, Delivery: constanta.NotificationDeliveryEmail},
+		muted:     {UserID: muted, Delivery: constanta.NotificationDeliveryEmail, MutedTypes: []constanta.NotificationType{constanta.NotificationComment}},
+	}, nil)
+	// the mentioned user only gets the mention, and the muted user gets nothing
+	mockNotificationRepo.EXPECT().CreateNotifications(gomock.Any(), []entity.Notification{mention, comment}).DoAndReturn(
+		func(ctx context.Context, notifications []entity.Notification) ([]entity.Notification, error) {
+			notifications[0].ID = 10
+			notifications[1].ID = 11
+			return notifications, nil
+		})
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobSendNotificationEmail, entity.SendNotificationEmailPayload{NotificationID: 10}).Return(nil)
+
+	payload, _ := json.Marshal(entity.CommentEventData{CommentID: 3, ArticleID: 1, ArticleVersionID: 2, CreatedBy: commenter, Mentions: []uuid.UUID{mentioned, commenter}})
+	err := s.notifyCommentEvent(context.Background(), entity.OutboxEvent{ID: 9, Type: constanta.EventCommentCreated, Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, "writer mentioned you in a comment on version 4 of \"hello\"", mention.Message)
+}
+
+func TestNotificationService_notifyVersionEvent(t *testing.T) {
+	editor, writer := uuid.New(), uuid.New()
+	audience := &entity.NotificationAudience{
+		ArticleID: 1, ArticleVersionID: 2, Title: "hello", Version: 4,
+		ActorID: &editor, ActorName: "editor",
+		Recipients: []uuid.UUID{writer},
+	}
+
+	tests := []struct {
+		name    string
+		event   constanta.EventType
+		prepare func(*service_mock.MocknotificationRepo)
+	}{
+		{
+			name:  "archived",
+			event: constanta.EventVersionArchived,
+			prepare: func(repo *service_mock.MocknotificationRepo) {
+				repo.EXPECT().GetArticleVersionAudience(gomock.Any(), int64(2)).Return(audience, nil)
+				repo.EXPECT().GetNotificationPreferences(gomock.Any(), []uuid.UUID{writer}).Return(map[uuid.UUID]entity.NotificationPreference{}, nil)
+				notification := entity.NewNotification(writer, constanta.NotificationVersionArchived, 9, *audience)
+				assert.Equal(t, "editor archived version 4 of \"hello\"", notification.Message)
+				repo.EXPECT().CreateNotifications(gomock.Any(), []entity.Notification{notification}).Return([]entity.Notification{notification}, nil)
+			},
+		},
+		{
+			name:  "article deleted before the event is relayed",
+			event: constanta.EventVersionPublished,
+			prepare: func(repo *service_mock.MocknotificationRepo) {
+				repo.EXPECT().GetArticleVersionAudience(gomock.Any(), int64(2)).Return(nil, sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// without a sender nothing is enqueued
+			s, mockNotificationRepo, _ := newTestNotificationService(ctrl, nil)
+			tt.prepare(mockNotificationRepo)
+
+			err := s.notifyVersionEvent(context.Background(), entity.OutboxEvent{ID: 9, Type: tt.event, Payload: []byte(`{"article_id":1,"article_version_id":2}`)})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotificationService_sendEmailJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var sent []mailer.Message
+	s, mockNotificationRepo, _ := newTestNotificationService(ctrl, sendFunc(func(ctx context.Context, msg mailer.Message) error {
+		sent = append(sent, msg)
+		return nil
+	}))
+
+	readAt := time.Now()
+	mockNotificationRepo.EXPECT().GetNotificationEmail(gomock.Any(), int64(10)).Return(&entity.NotificationEmail{
+		Notification: entity.Notification{ID: 10, ArticleID: 1, ArticleVersionID: 2, Message: "editor published version 4 of \"hello\""},
+		UserName:     "writer",
+		UserEmail:    "writer@cms.test",
+	}, nil)
+	mockNotificationRepo.EXPECT().GetNotificationEmail(gomock.Any(), int64(11)).Return(&entity.NotificationEmail{
+		Notification: entity.Notification{ID: 11, ReadAt: &readAt},
+	}, nil)
+
+	assert.NoError(t, s.sendEmailJob(context.Background(), []byte(`{"NotificationID":10}`)))
+	// already read in the app
+	assert.NoError(t, s.sendEmailJob(context.Background(), []byte(`{"NotificationID":11}`)))
+	assert.Equal(t, []mailer.Message{{
+		To:      "writer@cms.test",
+		Subject: "editor published version 4 of \"hello\"",
+		Body:    "Hi writer,\n\neditor published version 4 of \"hello\".\n\nArticle 1, version 2.\n",
+	}}, sent)
+}
+
+func TestNotificationService_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, mockNotificationRepo, _ := newTestNotificationService(ctrl, nil)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+	now := time.Now()
+	mockNotificationRepo.EXPECT().GetNotifications(gomock.Any(), entity.NotificationFilter{UserID: userID, Unread: true, Limit: 2}).Return([]entity.Notification{
+		{ID: 5, Type: constanta.NotificationMention, CreatedAt: now},
+		{ID: 4, Type: constanta.NotificationComment, CreatedAt: now},
+	}, nil)
+	mockNotificationRepo.EXPECT().CountUnreadNotifications(gomock.Any(), userID).Return(3, nil)
+
+	got, err := s.GetNotifications(ctx, params.GetNotificationsRequest{Unread: true, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &params.GetNotificationsResponse{
+		Notifications: []params.NotificationResponse{
+			{ID: 5, Type: constanta.NotificationMention, CreatedAt: now},
+			{ID: 4, Type: constanta.NotificationComment, CreatedAt: now},
+		},
+		UnreadCount:  3,
+		NextBeforeID: 4,
+	}, got)
+}
+
+func TestNotificationService_MarkNotificationRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, mockNotificationRepo, _ := newTestNotificationService(ctrl, nil)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+	mockNotificationRepo.EXPECT().MarkNotificationRead(gomock.Any(), int64(3), userID).Return(sql.ErrNoRows)
+
+	assert.Equal(t, errs.NotFound{Message: "notification 3"}, s.MarkNotificationRead(ctx, 3))
+}
+
+func TestNotificationService_UpdateNotificationPreference(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+
+	t.Run("email without a mail server", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		s, _, _ := newTestNotificationService(ctrl, nil)
+
+		_, err := s.UpdateNotificationPreference(ctx, params.NotificationPreferenceRequest{Delivery: constanta.NotificationDeliveryEmail})
+		assert.Equal(t, errs.ValidationError{Message: "email delivery is not configured"}, err)
+	})
+
+	t.Run("saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		s, mockNotificationRepo, _ := newTestNotificationService(ctrl, sendFunc(func(ctx context.Context, msg mailer.Message) error { return nil }))
+
+		preference := entity.NotificationPreference{
+			UserID:     userID,
+			Delivery:   constanta.NotificationDeliveryEmail,
+			MutedTypes: []constanta.NotificationType{constanta.NotificationComment},
+		}
+		mockNotificationRepo.EXPECT().SaveNotificationPreference(gomock.Any(), preference).Return(nil)
+		mockNotificationRepo.EXPECT().GetNotificationPreferences(gomock.Any(), []uuid.UUID{userID}).Return(map[uuid.UUID]entity.NotificationPreference{userID: preference}, nil)
+
+		got, err := s.UpdateNotificationPreference(ctx, params.NotificationPreferenceRequest{
+			Delivery:   preference.Delivery,
+			MutedTypes: preference.MutedTypes,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &params.NotificationPreferenceResponse{
+			Delivery:       constanta.NotificationDeliveryEmail,
+			MutedTypes:     []constanta.NotificationType{constanta.NotificationComment},
+			EmailAvailable: true,
+		}, got)
+	})
+}
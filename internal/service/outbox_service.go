@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+)
+
+type (
+	outboxRepo interface {
+		ClaimOutboxEvents(ctx context.Context, lockedBefore time.Time, limit int) ([]entity.OutboxEvent, error)
+		CompleteOutboxEvent(ctx context.Context, id int64) error
+		RetryOutboxEvent(ctx context.Context, id int64, runAt time.Time, lastError string) error
+		KillOutboxEvent(ctx context.Context, id int64, lastError string) error
+		CreateOutboxDelivery(ctx context.Context, eventID int64, subscriber string) error
+		DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	// eventBroker forwards the committed events to an external message broker, for example kafka or nats.
+	eventBroker interface {
+		Publish(ctx context.Context, event entity.OutboxEvent) error
+	}
+
+	// eventSubscriber gets the committed events from the outbox.
+	eventSubscriber interface {
+		Subscribe(eventType constanta.EventType, subscriber string, handler func(ctx context.Context, event entity.OutboxEvent) error)
+	}
+
+	// EventHandler gets a committed event, the event is published again to the handler when it returns an error.
+	EventHandler func(ctx context.Context, event entity.OutboxEvent) error
+
+	// eventSubscription is a handler with the name its deliveries are recorded with.
+	eventSubscription struct {
+		subscriber string
+		handler    EventHandler
+	}
+
+	OutboxService struct {
+		outboxRepo outboxRepo
+		// nil when no broker is configured
+		broker    eventBroker
+		mu        sync.RWMutex
+		handlers  map[constanta.EventType][]eventSubscription
+		lifecycle lifecycle
+	}
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxBatchSize    = 100
+	// a claimed event is claimed again when its relay does not finish it in this time
+	outboxStaleAfter = 5 * time.Minute
+	outboxTimeout    = 30 * time.Second
+	// the published events are kept for inspection in this time
+	outboxRetention     = 7 * 24 * time.Hour
+	outboxCleanInterval = time.Hour
+	// the subscriber name of the broker in the deliveries
+	brokerSubscriber = "broker"
+)
+
+// NewOutboxService relays the events which the repositories write in the transactions of their changes.
+// The events are delivered at least once to every subscriber, so the handlers must accept an event which is published again.
+func NewOutboxService(outboxRepo outboxRepo, broker eventBroker) *OutboxService {
+	return &OutboxService{
+		outboxRepo: outboxRepo,
+		broker:     broker,
+		handlers:   make(map[constanta.EventType][]eventSubscription),
+	}
+}
+
+// Subscribe adds the handler of the event type, the events without a handler are only sent to the broker.
+// The subscriber names the handler in the deliveries of the event, so it must be unique for the event type
+// and stay the same between the releases. A delivery is recorded once for all the instances of the application,
+// so a handler which only changes the memory of its instance, like the event stream, needs a single instance
+// or the broker to reach the other instances.
+func (ob *OutboxService) Subscribe(eventType constanta.EventType, subscriber string, handler func(ctx context.Context, event entity.OutboxEvent) error) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.handlers[eventType] = append(ob.handlers[eventType], eventSubscription{subscriber: subscriber, handler: handler})
+}
+
+// Start publishes the committed events in the background until Stop is called.
+func (ob *OutboxService) Start(ctx context.Context) error {
+	return ob.lifecycle.start(ctx, ob.relay)
+}
+
+// Stop stops claiming events and waits for the event which is being published.
+func (ob *OutboxService) Stop(ctx context.Context) error {
+	return ob.lifecycle.stop(ctx)
+}
+
+// relay publishes the claimed events one by one. The handlers get the events of an article in the order of its changes,
+// since an event of an article is only claimed after the earlier events of the article are published or dead.
+func (ob *OutboxService) relay(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	lastClean := time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if time.Since(lastClean) > outboxCleanInterval {
+			ob.deletePublishedEvents(ctx)
+			lastClean = time.Now()
+		}
+
+		events, err := ob.outboxRepo.ClaimOutboxEvents(ctx, time.Now().Add(-outboxStaleAfter), outboxBatchSize)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				slog.Error("failed to claim outbox events", "error", err)
+			}
+			continue
+		}
+
+		// the claimed events are finished after a stop, so they are not published again after outboxStaleAfter
+		for _, event := range events {
+			ob.process(event)
+		}
+	}
+}
+
+func (ob *OutboxService) deletePublishedEvents(ctx context.Context) {
+	deleted, err := ob.outboxRepo.DeletePublishedOutboxEvents(ctx, time.Now().Add(-outboxRetention))
+	if err != nil {
+		slog.Error("failed to delete published outbox events", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		slog.Info("deleted published outbox events", "count", deleted)
+	}
+}
+
+// process publishes the claimed event and records its result. A failed event is retried with backoff
+// until its max attempts, then it is dead and kept with its last error for inspection.
+func (ob *OutboxService) process(event entity.OutboxEvent) {
+	err := ob.publish(event)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch {
+	case err == nil:
+		err = ob.outboxRepo.CompleteOutboxEvent(ctx, event.ID)
+	case event.Attempts >= event.MaxAttempts:
+		slog.Error("outbox event is dead", "id", event.ID, "type", event.Type, "attempts", event.Attempts, "error", err)
+		err = ob.outboxRepo.KillOutboxEvent(ctx, event.ID, err.Error())
+	default:
+		slog.Warn("failed to publish outbox event, retrying", "id", event.ID, "type", event.Type, "attempts", event.Attempts, "error", err)
+		err = ob.outboxRepo.RetryOutboxEvent(ctx, event.ID, time.Now().Add(jobBackoff(event.Attempts)), err.Error())
+	}
+
+	if err != nil {
+		slog.Error("failed to record the outbox event result", "id", event.ID, "error", err)
+	}
+}
+
+// publish sends the event to every handler and to the broker which did not handle it in an earlier publish.
+// All of them are tried, so a failing handler does not hold back the others, and only the failed ones get the event again.
+func (ob *OutboxService) publish(event entity.OutboxEvent) error {
+	ob.mu.RLock()
+	subscriptions := ob.handlers[event.Type]
+	ob.mu.RUnlock()
+
+	if ob.broker != nil {
+		subscriptions = append(slices.Clip(subscriptions), eventSubscription{subscriber: brokerSubscriber, handler: ob.broker.Publish})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outboxTimeout)
+	defer cancel()
+
+	var errList []error
+	for _, subscription := range subscriptions {
+		if slices.Contains(event.Delivered, subscription.subscriber) {
+			continue
+		}
+
+		if err := callEventHandler(ctx, subscription.handler, event); err != nil {
+			errList = append(errList, fmt.Errorf("%s: %w", subscription.subscriber, err))
+			continue
+		}
+
+		// the handler gets the event again when its delivery is not recorded
+		if err := ob.outboxRepo.CreateOutboxDelivery(ctx, event.ID, subscription.subscriber); err != nil {
+			errList = append(errList, fmt.Errorf("%s: %w", subscription.subscriber, err))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+func callEventHandler(ctx context.Context, handler EventHandler, event entity.OutboxEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, event)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_outbox_repo.go -package=service_mock . outboxRepo
+//go:generate mockgen -destination=mock/mock_event_broker.go -package=service_mock . eventBroker
+//go:generate mockgen -destination=mock/mock_event_subscriber.go -package=service_mock . eventSubscriber
+
+func TestOutboxService_process(t *testing.T) {
+	event := entity.OutboxEvent{ID: 7, Type: constanta.EventVersionPublished, Payload: []byte(`{"article_id":1}`), Attempts: 1, MaxAttempts: 10}
+	failing := func(ctx context.Context, got entity.OutboxEvent) error {
+		return errors.New("db down")
+	}
+	unexpected := func(ctx context.Context, got entity.OutboxEvent) error {
+		t.Error("unexpected handler call")
+		return nil
+	}
+
+	tests := []struct {
+		name          string
+		event         entity.OutboxEvent
+		subscriptions []eventSubscription
+		setup         func(*service_mock.MockoutboxRepo, *service_mock.MockeventBroker, entity.OutboxEvent)
+	}{
+		{
+			name:  "published",
+			event: event,
+			subscriptions: []eventSubscription{
+				{subscriber: "first", handler: func(ctx context.Context, got entity.OutboxEvent) error {
+					assert.Equal(t, event, got)
+					return nil
+				}},
+			},
+			setup: func(repo *service_mock.MockoutboxRepo, broker *service_mock.MockeventBroker, event entity.OutboxEvent) {
+				repo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(7), "first").Return(nil)
+				broker.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				repo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(7), brokerSubscriber).Return(nil)
+				repo.EXPECT().CompleteOutboxEvent(gomock.Any(), int64(7)).Return(nil)
+			},
+		},
+		{
+			name:  "failed handler is retried after the others are called",
+			event: event,
+			subscriptions: []eventSubscription{
+				{subscriber: "first", handler: failing},
+				{subscriber: "second", handler: func(ctx context.Context, got entity.OutboxEvent) error {
+					panic("boom")
+				}},
+				{subscriber: "third", handler: func(ctx context.Context, got entity.OutboxEvent) error {
+					return nil
+				}},
+			},
+			setup: func(repo *service_mock.MockoutboxRepo, broker *service_mock.MockeventBroker, event entity.OutboxEvent) {
+				repo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(7), "third").Return(nil)
+				broker.EXPECT().Publish(gomock.Any(), event).Return(errors.New("broker down"))
+				repo.EXPECT().RetryOutboxEvent(gomock.Any(), int64(7), gomock.Any(), "first: db down\nsecond: event handler panicked: boom\nbroker: broker down").
+					DoAndReturn(func(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+						assert.WithinDuration(t, time.Now().Add(jobBackoffBase), runAt, time.Second)
+						return nil
+					})
+			},
+		},
+		{
+			name: "only the subscribers which did not handle the event get it again",
+			event: entity.OutboxEvent{ID: 7, Type: constanta.EventVersionPublished, Payload: []byte(`{"article_id":1}`), Attempts: 2, MaxAttempts: 10,
+				Delivered: []string{"first", brokerSubscriber}},
+			subscriptions: []eventSubscription{
+				{subscriber: "first", handler: unexpected},
+				{subscriber: "second", handler: func(ctx context.Context, got entity.OutboxEvent) error {
+					return nil
+				}},
+			},
+			setup: func(repo *service_mock.MockoutboxRepo, broker *service_mock.MockeventBroker, event entity.OutboxEvent) {
+				repo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(7), "second").Return(nil)
+				repo.EXPECT().CompleteOutboxEvent(gomock.Any(), int64(7)).Return(nil)
+			},
+		},
+		{
+			name:  "a handler whose delivery is not recorded gets the event again",
+			event: event,
+			subscriptions: []eventSubscription{
+				{subscriber: "first", handler: func(ctx context.Context, got entity.OutboxEvent) error {
+					return nil
+				}},
+			},
+			setup: func(repo *service_mock.MockoutboxRepo, broker *service_mock.MockeventBroker, event entity.OutboxEvent) {
+				repo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(7), "first").Return(errors.New("db down"))
+				broker.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				repo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(7), brokerSubscriber).Return(nil)
+				repo.EXPECT().RetryOutboxEvent(gomock.Any(), int64(7), gomock.Any(), "first: db down").Return(nil)
+			},
+		},
+		{
+			name: "dead after the max attempts",
+			event: entity.OutboxEvent{ID: 7, Type: constanta.EventVersionPublished, Payload: []byte(`{"article_id":1}`), Attempts: 10, MaxAttempts: 10,
+				Delivered: []string{brokerSubscriber}},
+			subscriptions: []eventSubscription{
+				{subscriber: "first", handler: failing},
+			},
+			setup: func(repo *service_mock.MockoutboxRepo, broker *service_mock.MockeventBroker, event entity.OutboxEvent) {
+				repo.EXPECT().KillOutboxEvent(gomock.Any(), int64(7), "first: db down").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockOutboxRepo := service_mock.NewMockoutboxRepo(ctrl)
+			mockEventBroker := service_mock.NewMockeventBroker(ctrl)
+			s := NewOutboxService(mockOutboxRepo, mockEventBroker)
+			for _, subscription := range tt.subscriptions {
+				s.Subscribe(tt.event.Type, subscription.subscriber, subscription.handler)
+			}
+			// the handlers of the other events are not called
+			s.Subscribe(constanta.EventTagCreated, "first", unexpected)
+			tt.setup(mockOutboxRepo, mockEventBroker, tt.event)
+
+			s.process(tt.event)
+		})
+	}
+}
+
+func TestOutboxService_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOutboxRepo := service_mock.NewMockoutboxRepo(ctrl)
+	s := NewOutboxService(mockOutboxRepo, nil)
+
+	published := make(chan entity.OutboxEvent, 1)
+	s.Subscribe(constanta.EventArticleCreated, "test", func(ctx context.Context, event entity.OutboxEvent) error {
+		published <- event
+		return nil
+	})
+
+	mockOutboxRepo.EXPECT().DeletePublishedOutboxEvents(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	mockOutboxRepo.EXPECT().ClaimOutboxEvents(gomock.Any(), gomock.Any(), outboxBatchSize).Return([]entity.OutboxEvent{{ID: 1, Type: constanta.EventArticleCreated}}, nil)
+	mockOutboxRepo.EXPECT().CreateOutboxDelivery(gomock.Any(), int64(1), "test").Return(nil)
+	mockOutboxRepo.EXPECT().CompleteOutboxEvent(gomock.Any(), int64(1)).Return(nil)
+	mockOutboxRepo.EXPECT().ClaimOutboxEvents(gomock.Any(), gomock.Any(), outboxBatchSize).Return(nil, nil).AnyTimes()
+
+	assert.NoError(t, s.Start(context.Background()))
+	assert.ErrorIs(t, s.Start(context.Background()), errAlreadyStarted)
+
+	select {
+	case event := <-published:
+		assert.Equal(t, int64(1), event.ID)
+	case <-time.After(3 * time.Second):
+		t.Fatal("the event is not published")
+	}
+
+	assert.NoError(t, s.Stop(context.Background()))
+}
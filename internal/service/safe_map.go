@@ -1,50 +1,52 @@
 package service
 
-type MapCommand[K comparable, V any] struct {
-	Op      string
-	Key     K
-	Value   V
-	ReplyCh chan interface{}
-}
+import (
+	"maps"
+	"sync"
+)
 
+// SafeMap guards a map with a lock, so it does not need a goroutine which must be stopped.
 type SafeMap[K comparable, V any] struct {
-	cmdCh chan MapCommand[K, V]
+	mu sync.RWMutex
+	m  map[K]V
 }
 
 func NewSafeMap[K comparable, V any]() *SafeMap[K, V] {
-	m := make(map[K]V)
-	cmdCh := make(chan MapCommand[K, V])
-	go func() {
-		for cmd := range cmdCh {
-			switch cmd.Op {
-			case "get":
-				cmd.ReplyCh <- m[cmd.Key]
-			case "exist":
-				_, ok := m[cmd.Key]
-				cmd.ReplyCh <- ok
-			case "set":
-				m[cmd.Key] = cmd.Value
-				cmd.ReplyCh <- struct{}{}
-			}
-		}
-	}()
-	return &SafeMap[K, V]{cmdCh: cmdCh}
+	return NewSafeMapFrom(make(map[K]V))
+}
+
+// NewSafeMapFrom owns the map, so a calculated map is shared without setting its keys one by one.
+// The map must not be used by the caller anymore.
+func NewSafeMapFrom[K comparable, V any](m map[K]V) *SafeMap[K, V] {
+	return &SafeMap[K, V]{m: m}
 }
 
 func (s *SafeMap[K, V]) Get(key K) V {
-	reply := make(chan interface{})
-	s.cmdCh <- MapCommand[K, V]{Op: "get", Key: key, ReplyCh: reply}
-	return (<-reply).(V)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.m[key]
 }
 
 func (s *SafeMap[K, V]) Exist(key K) bool {
-	reply := make(chan interface{})
-	s.cmdCh <- MapCommand[K, V]{Op: "exist", Key: key, ReplyCh: reply}
-	return (<-reply).(bool)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.m[key]
+	return ok
 }
 
 func (s *SafeMap[K, V]) Set(key K, value V) {
-	reply := make(chan interface{})
-	s.cmdCh <- MapCommand[K, V]{Op: "set", Key: key, Value: value, ReplyCh: reply}
-	<-reply
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.m[key] = value
+}
+
+// Snapshot returns a copy of the map, so it can be ranged without blocking the other operations.
+func (s *SafeMap[K, V]) Snapshot() map[K]V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return maps.Clone(s.m)
 }
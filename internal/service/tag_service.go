@@ -2,11 +2,19 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
@@ -18,83 +26,193 @@ type (
 	// tagRepo defines the methods that the tag repository must implement.
 	tagRepo interface {
 		UpsertTags(ctx context.Context, names ...string) error
-		GetTags(ctx context.Context, names ...string) ([]string, error)
+		GetTags(ctx context.Context, names ...string) ([]entity.Tag, error)
+		UpdateTag(ctx context.Context, name string, tag entity.Tag) error
+		MergeTags(ctx context.Context, sources []string, target string) error
+		DeleteTag(ctx context.Context, name string) error
+		DeleteUnusedTags(ctx context.Context) (int64, error)
+		GetTagSynonyms(ctx context.Context, aliases ...string) ([]entity.TagSynonym, error)
+		GetTagSynonymsWithTagName(ctx context.Context, name string) ([]entity.TagSynonym, error)
+		CreateTagSynonyms(ctx context.Context, name string, aliases []string) error
+		DeleteTagSynonym(ctx context.Context, name, alias string) error
 		GetTagUsage(ctx context.Context) (map[string]entity.TagUsage, error)
-		GetArticleTags(ctx context.Context, status constanta.ArticleVersionStatus) ([]entity.ArticleVersionTag, error)
+		GetTagUsageDaily(ctx context.Context, since time.Time) ([]entity.TagUsageDaily, error)
+		GetTagStats(ctx context.Context, filter entity.TagStatsFilter) (*entity.TagStats, error)
+		GetTagPairFrequency(ctx context.Context) (map[[2]string]int, error)
+		ReconcileTagStats(ctx context.Context) (int64, error)
 	}
 
-	TagActionTrigger struct {
-		Name    constanta.TagServiceAction
-		Payload interface{}
+	// jobQueue runs the tag calculations in the background, they are retried when they fail.
+	jobQueue interface {
+		Enqueue(ctx context.Context, jobType constanta.JobType, payload any) error
+		Register(jobType constanta.JobType, handler func(ctx context.Context, payload json.RawMessage) error)
 	}
 
-	TagService struct {
-		articleRepo      articleRepo
-		tagRepo          tagRepo
+	// tagStats is a load of the statistics. A reload stores new statistics instead of changing these,
+	// so a request which loads them once reads the maps of the same load.
+	tagStats struct {
 		tagUsage         *SafeMap[string, entity.TagUsage]
 		tagPairFrequency *SafeMap[[2]string, int]
-		actionTrigger    chan TagActionTrigger
+		// related articles of every published article, ordered by score
+		relatedArticles *SafeMap[int64, []entity.RelatedArticle]
+	}
+
+	TagService struct {
+		articleRepo articleRepo
+		tagRepo     tagRepo
+		// reloaded by the routine and the jobs while the requests read it
+		stats       atomic.Pointer[tagStats]
+		jobs        jobQueue
+		tagRules    entity.TagRules
+		tagTrending entity.TagTrending
+		lifecycle   lifecycle
 	}
 )
 
-func NewTagService(articleRepo articleRepo, tagRepo tagRepo) *TagService {
-	tagUsage := NewSafeMap[string, entity.TagUsage]()
-	tagPairFrequency := NewSafeMap[[2]string, int]()
+func newTagStats() *tagStats {
+	return &tagStats{
+		tagUsage:         NewSafeMap[string, entity.TagUsage](),
+		tagPairFrequency: NewSafeMap[[2]string, int](),
+		relatedArticles:  NewSafeMap[int64, []entity.RelatedArticle](),
+	}
+}
+
+func NewTagService(articleRepo articleRepo, tagRepo tagRepo, jobs jobQueue, events eventSubscriber, tagRules entity.TagRules, tagTrending entity.TagTrending) *TagService {
 	ts := &TagService{
-		articleRepo:      articleRepo,
-		tagRepo:          tagRepo,
-		tagUsage:         tagUsage,
-		tagPairFrequency: tagPairFrequency,
-		actionTrigger:    make(chan TagActionTrigger),
+		articleRepo: articleRepo,
+		tagRepo:     tagRepo,
+		jobs:        jobs,
+		tagRules:    tagRules,
+		tagTrending: tagTrending,
 	}
+	ts.stats.Store(newTagStats())
+
+	jobs.Register(constanta.JobCalculateTagStats, ts.calculateTagStatsJob)
+	jobs.Register(constanta.JobCalculateArticleTagRelation, ts.calculateArticleTagRelationJob)
 
-	go ts.tagRoutine()
+	// the calculations are enqueued after the changes of the articles are committed
+	events.Subscribe(constanta.EventArticleCreated, "tag", ts.scoreArticleVersionEvent)
+	events.Subscribe(constanta.EventVersionCreated, "tag", ts.scoreArticleVersionEvent)
+	events.Subscribe(constanta.EventVersionPublished, "tag", ts.scoreArticleVersionEvent)
+	events.Subscribe(constanta.EventVersionArchived, "tag", ts.calculateTagStatsEvent)
+	events.Subscribe(constanta.EventArticleDeleted, "tag", ts.calculateTagStatsEvent)
 
 	return ts
 }
 
-func (s *TagService) tagRoutine() {
-	// run for the first time immediately
-	s.calculateTagUsageAndPairFrequency()
+// the statistics are kept by the repository on every publish, archive and delete.
+// The routine reloads them with the related articles, and reconciles them with the article versions less often.
+const (
+	tagStatsRefreshInterval   = 10 * time.Second
+	tagStatsReconcileInterval = 10 * time.Minute
+)
+
+// Start loads the statistics and keeps them fresh in the background until Stop is called.
+// The first load is done before returning, so the statistics are ready when the server accepts requests.
+func (s *TagService) Start(ctx context.Context) error {
+	s.reconcileTagStats(ctx)
+	s.refreshTagStats(ctx)
+	s.refreshRelatedArticles(ctx)
+
+	return s.lifecycle.start(ctx, s.tagRoutine)
+}
+
+// Stop stops the routine and waits for the refresh or the reconciliation which is running.
+func (s *TagService) Stop(ctx context.Context) error {
+	return s.lifecycle.stop(ctx)
+}
+
+func (s *TagService) tagRoutine(ctx context.Context) {
+	// the running work is not canceled, it must not be stopped in the middle of a transaction
+	workCtx := context.WithoutCancel(ctx)
 
-	newTicker := time.NewTicker(10 * time.Second)
+	newTicker := time.NewTicker(tagStatsRefreshInterval)
 	defer newTicker.Stop()
+	reconcileTicker := time.NewTicker(tagStatsReconcileInterval)
+	defer reconcileTicker.Stop()
 	for {
 		select {
+		case <-ctx.Done():
+			return
 		case <-newTicker.C:
-			// slog.Info("ticker triggered")
-			s.calculateTagUsageAndPairFrequency()
-		case action, ok := <-s.actionTrigger:
-			if !ok {
-				return
-			}
-			switch action.Name {
-			case constanta.CalculateTagUsageAndPairFrequency:
-				slog.Info("calculateTagUsageAndPairFrequency")
-				s.calculateTagUsageAndPairFrequency()
-			case constanta.CalculateArticleTagRelation:
-				slog.Info("calculateArticleTagRelation")
-				s.calculateTagUsageAndPairFrequency()
-				if err := s.updateArticleVersionTagRelationshipScore(action.Payload); err != nil {
-					slog.Error("failed to update relationship score", "error", err)
-					continue
-				}
-			default:
-				slog.Error("unknown action", "action", action.Name)
-			}
+			s.refreshTagStats(workCtx)
+			// the related articles are weighted with the tag usage, so they are rebuilt after it
+			s.refreshRelatedArticles(workCtx)
+		case <-reconcileTicker.C:
+			s.reconcileTagStats(workCtx)
 		}
 	}
 }
 
-func (s *TagService) updateArticleVersionTagRelationshipScore(actionPayload any) error {
-
-	ctx := context.Background()
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+// reconcileTagStats fixes the statistics which drifted from the published versions.
+func (s *TagService) reconcileTagStats(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	payload, ok := actionPayload.(entity.CalculateArticleVersionTagRelationShipScorePayload)
-	if !ok {
-		return fmt.Errorf("invalid payload for calculateArticleTagRelation action")
+	fixed, err := s.tagRepo.ReconcileTagStats(ctx)
+	if err != nil {
+		slog.Error("failed to reconcile tag stats", "error", err)
+		return
+	}
+
+	if fixed > 0 {
+		slog.Info("reconciled tag stats", "fixed", fixed)
+	}
+}
+
+func (s *TagService) refreshTagStats(ctx context.Context) {
+	if err := s.calculateTagUsageAndPairFrequency(ctx); err != nil {
+		slog.Error("failed to calculate tag stats", "error", err)
+	}
+}
+
+// refreshRelatedArticles rebuilds the related articles, it scans every published version.
+func (s *TagService) refreshRelatedArticles(ctx context.Context) {
+	relatedArticles, err := s.getRelatedArticles(ctx)
+	if err != nil {
+		slog.Error("failed to get related articles", "error", err)
+		return
+	}
+
+	s.storeTagStats(func(stats *tagStats) {
+		stats.relatedArticles = relatedArticles
+	})
+}
+
+// loadTagStats returns the statistics of the last load, the zero value of the service has empty statistics.
+func (s *TagService) loadTagStats() *tagStats {
+	if stats := s.stats.Load(); stats != nil {
+		return stats
+	}
+
+	s.stats.CompareAndSwap(nil, newTagStats())
+	return s.stats.Load()
+}
+
+// storeTagStats stores the statistics changed by update with the other statistics of the last load.
+// The update is run again when another reload stored its statistics in the meantime, so none of them is lost.
+func (s *TagService) storeTagStats(update func(stats *tagStats)) {
+	for {
+		current := s.loadTagStats()
+		next := *current
+		update(&next)
+		if s.stats.CompareAndSwap(current, &next) {
+			return
+		}
+	}
+}
+
+// calculateTagStatsJob reloads the statistics of this instance, the other instances reload them with their ticker.
+func (s *TagService) calculateTagStatsJob(ctx context.Context, _ json.RawMessage) error {
+	return s.calculateTagUsageAndPairFrequency(ctx)
+}
+
+// calculateArticleTagRelationJob scores the tags of the article version with the loaded statistics,
+// they are at most one refresh behind the repository.
+func (s *TagService) calculateArticleTagRelationJob(ctx context.Context, rawPayload json.RawMessage) error {
+	var payload entity.CalculateArticleVersionTagRelationShipScorePayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s job: %w", constanta.JobCalculateArticleTagRelation, err)
 	}
 
 	score := s.calculateArticleVersionTagRelationShipScore(payload.Tags)
@@ -108,88 +226,285 @@ func (s *TagService) updateArticleVersionTagRelationshipScore(actionPayload any)
 	return nil
 }
 
-func (s *TagService) calculateTagUsageAndPairFrequency() {
-	var err error
-	s.tagUsage, err = s.getTagUsage(context.Background())
+// calculateTagUsageAndPairFrequency reloads the statistics, the previous values are kept when a part fails.
+func (s *TagService) calculateTagUsageAndPairFrequency(ctx context.Context) error {
+	var errList []error
+	tagUsage, err := s.getTagUsage(ctx)
 	if err != nil {
-		slog.Error("failed to get tag usage counts", "error", err)
+		errList = append(errList, fmt.Errorf("failed to get tag usage counts: %w", err))
 	}
-	s.tagPairFrequency, err = s.getTagPairFrequency(context.Background())
+
+	tagPairFrequency, err := s.getTagPairFrequency(ctx)
 	if err != nil {
-		slog.Error("failed to get tag pairs", "error", err)
+		errList = append(errList, fmt.Errorf("failed to get tag pairs: %w", err))
 	}
+
+	s.storeTagStats(func(stats *tagStats) {
+		if tagUsage != nil {
+			stats.tagUsage = tagUsage
+		}
+		if tagPairFrequency != nil {
+			stats.tagPairFrequency = tagPairFrequency
+		}
+	})
+
+	return errors.Join(errList...)
 }
 
+// => POST /tags
+// The names are normalized like the tags of the articles.
 func (s *TagService) CreateTag(ctx context.Context, tagNames ...string) error {
+	tagNames, err := s.NormalizeTags(ctx, tagNames...)
+	if err != nil {
+		return err
+	}
+
 	if err := s.tagRepo.UpsertTags(ctx, tagNames...); err != nil {
 		return err
 	}
 
 	// recalculate tag usage and pair frequency
-	s.CreateTagTrigger(constanta.CalculateTagUsageAndPairFrequency, nil)
+	s.CreateTagTrigger(ctx, constanta.JobCalculateTagStats, nil)
 
 	return nil
 }
 
-func (s *TagService) CreateTagTrigger(name constanta.TagServiceAction, payload any) {
-	go func() {
-		s.actionTrigger <- TagActionTrigger{
-			Name:    name,
-			Payload: payload,
+// NormalizeTags applies the tag rules to the names and replaces the aliases with their canonical tag.
+// The result is sorted and does not contain duplicates.
+func (s *TagService) NormalizeTags(ctx context.Context, names ...string) ([]string, error) {
+	tagNames := make([]string, 0, len(names))
+	for _, name := range names {
+		tagName, err := s.normalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		tagNames = append(tagNames, tagName)
+	}
+
+	if len(tagNames) > 0 {
+		synonyms, err := s.tagRepo.GetTagSynonyms(ctx, tagNames...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, synonym := range synonyms {
+			for i, tagName := range tagNames {
+				if tagName == synonym.Alias {
+					tagNames[i] = synonym.TagName
+				}
+			}
 		}
-	}()
+	}
+
+	slices.Sort(tagNames)
+	tagNames = slices.Compact(tagNames)
+
+	if len(tagNames) > s.tagRules.MaxPerArticle {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("an article can have at most %d tags", s.tagRules.MaxPerArticle)}
+	}
+
+	return tagNames, nil
+}
+
+// normalizeTagName applies the tag rules to a single name without resolving its synonym.
+func (s *TagService) normalizeTagName(name string) (string, error) {
+	tagName := entity.NormalizeTagName(name, s.tagRules.SlugMode)
+	if tagName == "" {
+		return "", errs.ValidationError{Message: "tag name cannot be empty"}
+	}
+
+	if utf8.RuneCountInString(tagName) > s.tagRules.MaxLength {
+		return "", errs.ValidationError{Message: fmt.Sprintf("tag %s cannot be longer than %d characters", tagName, s.tagRules.MaxLength)}
+	}
+
+	if !entity.IsValidTagName(tagName) {
+		return "", errs.ValidationError{Message: fmt.Sprintf("tag %s can only contain letters, numbers, spaces and the characters - + # .", tagName)}
+	}
+
+	if entity.IsReservedTagName(tagName) {
+		return "", errs.ValidationError{Message: fmt.Sprintf("tag %s is reserved by the tag API", tagName)}
+	}
+
+	return tagName, nil
+}
+
+// CreateTagTrigger enqueues a tag calculation. A failed enqueue is only logged,
+// the statistics are reconciled periodically.
+func (s *TagService) CreateTagTrigger(ctx context.Context, jobType constanta.JobType, payload any) {
+	if err := s.jobs.Enqueue(ctx, jobType, payload); err != nil {
+		slog.Error("failed to enqueue tag job", "type", jobType, "error", err)
+	}
+}
+
+// scoreArticleVersionEvent enqueues the relationship score of the created or published version.
+func (s *TagService) scoreArticleVersionEvent(ctx context.Context, event entity.OutboxEvent) error {
+	var data entity.ArticleEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("invalid payload for %s event: %w", event.Type, err)
+	}
+
+	return s.jobs.Enqueue(ctx, constanta.JobCalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{
+		Tags:             entity.NewTags(data.Tags...),
+		ArticleVersionID: data.ArticleVersionID,
+	})
+}
+
+// calculateTagStatsEvent reloads the statistics which were changed by an archive or a delete.
+func (s *TagService) calculateTagStatsEvent(ctx context.Context, event entity.OutboxEvent) error {
+	return s.jobs.Enqueue(ctx, constanta.JobCalculateTagStats, nil)
 }
 
-func (s *TagService) GetTags(ctx context.Context, req params.GetTagsRequest) ([]params.GetTagResponse, error) {
+func (s *TagService) GetTags(ctx context.Context, req params.GetTagsRequest) ([]params.GetTagResponse, *params.PaginationResponse, error) {
 	tags, err := s.tagRepo.GetTags(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var responses []params.GetTagResponse
 	for _, tag := range tags {
-		response := params.GetTagResponse{
-			Name: tag,
-		}
-		ok := s.tagUsage.Exist(tag)
-		if ok {
-			usage := s.tagUsage.Get(tag)
-			response.UsageCount = usage.Count
-			response.TrendingScore = usage.TrendingScore
-			response.LastUsed = usage.LastUsed
-		}
-		responses = append(responses, response)
+		responses = append(responses, s.newTagResponse(tag))
 	}
 
 	sort.Slice(responses, func(i, j int) bool {
-		switch req.SortValue {
-		case "usage_count":
-			if req.Direction == "asc" {
-				return responses[i].UsageCount < responses[j].UsageCount
-			}
-			return responses[i].UsageCount > responses[j].UsageCount
-		case "trending_score":
-			if req.Direction == "asc" {
-				return responses[i].TrendingScore < responses[j].TrendingScore
-			}
-			return responses[i].TrendingScore > responses[j].TrendingScore
-		case "name":
-			if req.Direction == "asc" {
-				return responses[i].Name < responses[j].Name
-			}
-			return responses[i].Name > responses[j].Name
-		case "last_used":
-			if req.Direction == "asc" {
-				return responses[i].LastUsed.Unix() < responses[j].LastUsed.Unix()
-			}
-			return responses[i].LastUsed.Unix() > responses[j].LastUsed.Unix()
+		return lessTag(req, responses[i], responses[j])
+	})
+
+	if !req.IsPaginated() {
+		return responses, &params.PaginationResponse{
+			Total: int64(len(responses)),
+			Limit: len(responses),
+		}, nil
+	}
+
+	return paginateTags(req, responses)
+}
+
+// lessTag orders tags by the requested sort value and breaks ties by name.
+func lessTag(req params.GetTagsRequest, a, b params.GetTagResponse) bool {
+	asc := req.Direction == "asc"
+	switch req.SortValue {
+	case "usage_count":
+		if a.UsageCount != b.UsageCount {
+			return (a.UsageCount < b.UsageCount) == asc
+		}
+	case "trending_score":
+		if a.TrendingScore != b.TrendingScore {
+			return (a.TrendingScore < b.TrendingScore) == asc
+		}
+	case "name":
+		if a.Name != b.Name {
+			return (a.Name < b.Name) == asc
 		}
 		return false
-	})
+	case "last_used":
+		if a.LastUsed.Unix() != b.LastUsed.Unix() {
+			return (a.LastUsed.Unix() < b.LastUsed.Unix()) == asc
+		}
+	default:
+		return false
+	}
+
+	return a.Name < b.Name
+}
+
+func tagCursorValues(sortValue string, tag params.GetTagResponse) []string {
+	var value string
+	switch sortValue {
+	case "usage_count":
+		value = strconv.Itoa(tag.UsageCount)
+	case "trending_score":
+		value = strconv.FormatFloat(tag.TrendingScore, 'g', -1, 64)
+	case "last_used":
+		value = strconv.FormatInt(tag.LastUsed.Unix(), 10)
+	}
+
+	return []string{value, tag.Name}
+}
+
+func tagFromCursorValues(sortValue string, values []string) (params.GetTagResponse, error) {
+	if len(values) != 2 {
+		return params.GetTagResponse{}, errs.ValidationError{Message: "not valid cursor"}
+	}
+
+	tag := params.GetTagResponse{Name: values[1]}
+
+	var err error
+	switch sortValue {
+	case "usage_count":
+		tag.UsageCount, err = strconv.Atoi(values[0])
+	case "trending_score":
+		tag.TrendingScore, err = strconv.ParseFloat(values[0], 64)
+	case "last_used":
+		var unix int64
+		unix, err = strconv.ParseInt(values[0], 10, 64)
+		tag.LastUsed = time.Unix(unix, 0)
+	}
+	if err != nil {
+		return params.GetTagResponse{}, errs.ValidationError{Message: "not valid cursor"}
+	}
+
+	return tag, nil
+}
+
+// paginateTags slices the sorted tags with the keyset cursor from the request.
+func paginateTags(req params.GetTagsRequest, sortedTags []params.GetTagResponse) ([]params.GetTagResponse, *params.PaginationResponse, error) {
+	limit := req.Limit
+	if limit < 1 {
+		limit = 10
+	}
+
+	start, end := 0, len(sortedTags)
+	switch {
+	case req.After != "":
+		values, err := params.DecodeCursor(req.After, req.GetSorts())
+		if err != nil {
+			return nil, nil, errs.ValidationError{Message: err.Error()}
+		}
+		cursorTag, err := tagFromCursorValues(req.SortValue, values)
+		if err != nil {
+			return nil, nil, err
+		}
+		start = sort.Search(len(sortedTags), func(i int) bool {
+			return lessTag(req, cursorTag, sortedTags[i])
+		})
+		end = min(start+limit, len(sortedTags))
+	case req.Before != "":
+		values, err := params.DecodeCursor(req.Before, req.GetSorts())
+		if err != nil {
+			return nil, nil, errs.ValidationError{Message: err.Error()}
+		}
+		cursorTag, err := tagFromCursorValues(req.SortValue, values)
+		if err != nil {
+			return nil, nil, err
+		}
+		end = sort.Search(len(sortedTags), func(i int) bool {
+			return !lessTag(req, sortedTags[i], cursorTag)
+		})
+		start = max(end-limit, 0)
+	default:
+		end = min(limit, len(sortedTags))
+	}
+
+	page := sortedTags[start:end]
+	pagination := &params.PaginationResponse{
+		Total: int64(len(sortedTags)),
+		Limit: limit,
+	}
+	if len(page) > 0 {
+		if end < len(sortedTags) {
+			pagination.NextCursor = params.EncodeCursor(req.GetSorts(), tagCursorValues(req.SortValue, page[len(page)-1]))
+		}
+		if start > 0 {
+			pagination.PrevCursor = params.EncodeCursor(req.GetSorts(), tagCursorValues(req.SortValue, page[0]))
+		}
+	}
+	pagination.HasNext = pagination.NextCursor != ""
 
-	return responses, nil
+	return page, pagination, nil
 }
 
+// => GET /tags/{name}
+// A name which is not found is normalized and looked up again, so "Golang" finds the tag go when golang is its alias.
 func (s *TagService) GetTag(ctx context.Context, tagName string) (*params.GetTagResponse, error) {
 	tags, err := s.tagRepo.GetTags(ctx, tagName)
 	if err != nil {
@@ -197,22 +512,498 @@ func (s *TagService) GetTag(ctx context.Context, tagName string) (*params.GetTag
 	}
 
 	if len(tags) == 0 {
-		return nil, errs.NotFound{Message: fmt.Sprintf("tag %s", tagName)}
+		normalized, err := s.NormalizeTags(ctx, tagName)
+		if err != nil || normalized[0] == tagName {
+			return nil, errs.NotFound{Message: fmt.Sprintf("tag %s", tagName)}
+		}
+
+		tags, err = s.tagRepo.GetTags(ctx, normalized[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tags) == 0 {
+			return nil, errs.NotFound{Message: fmt.Sprintf("tag %s", tagName)}
+		}
 	}
 
+	response := s.newTagResponse(tags[0])
+
+	return &response, nil
+}
+
+// => GET /tags/{name}/stats
+// The empty buckets of the range are filled with zero.
+func (s *TagService) GetTagStats(ctx context.Context, tagName string, req params.GetTagStatsRequest) (*params.TagStatsResponse, error) {
+	tag, err := s.GetTag(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.tagRepo.GetTagStats(ctx, entity.TagStatsFilter{
+		TagName:  tag.Name,
+		Interval: req.Interval,
+		From:     req.From,
+		// the last day is included
+		To:    req.To.AddDate(0, 0, 1),
+		Limit: req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int, len(stats.Series))
+	for _, bucket := range stats.Series {
+		counts[bucket.Start.Unix()] = bucket.Count
+	}
+
+	res := &params.TagStatsResponse{
+		Name:            tag.Name,
+		From:            req.From,
+		To:              req.To,
+		Interval:        req.Interval,
+		Series:          []params.TagStatsBucketResponse{},
+		CoOccurringTags: make([]params.TagCountResponse, 0, len(stats.CoOccurringTags)),
+		TopAuthors:      make([]params.TagAuthorResponse, 0, len(stats.TopAuthors)),
+	}
+
+	for start := entity.TagStatsBucketStart(req.From, req.Interval); !start.After(req.To); start = entity.NextTagStatsBucket(start, req.Interval) {
+		count := counts[start.Unix()]
+		res.Total += count
+		res.Series = append(res.Series, params.TagStatsBucketResponse{Start: start, Count: count})
+	}
+
+	for _, tag := range stats.CoOccurringTags {
+		res.CoOccurringTags = append(res.CoOccurringTags, params.TagCountResponse{Name: tag.Name, Count: tag.Count})
+	}
+
+	for _, author := range stats.TopAuthors {
+		res.TopAuthors = append(res.TopAuthors, params.TagAuthorResponse{ID: author.ID, Name: author.Name, Count: author.Count})
+	}
+
+	return res, nil
+}
+
+// newTagResponse adds the usage of the tag from the last calculation.
+func (s *TagService) newTagResponse(tag entity.Tag) params.GetTagResponse {
 	response := params.GetTagResponse{
-		Name: tagName,
+		Name:        tag.Name,
+		DisplayName: tag.DisplayName,
+		Description: tag.Description,
+		Color:       tag.Color,
+		Slug:        tag.Slug,
 	}
 
-	ok := s.tagUsage.Exist(tagName)
-	if ok {
-		usage := s.tagUsage.Get(tagName)
+	tagUsage := s.loadTagStats().tagUsage
+	if tagUsage.Exist(tag.Name) {
+		usage := tagUsage.Get(tag.Name)
 		response.UsageCount = usage.Count
 		response.TrendingScore = usage.TrendingScore
 		response.LastUsed = usage.LastUsed
 	}
 
-	return &response, nil
+	return response
+}
+
+// => PUT /tags/{name}
+// A different name renames the tag in every article version.
+func (s *TagService) UpdateTag(ctx context.Context, tagName string, req params.UpdateTagRequest) (*params.GetTagResponse, error) {
+	name, err := s.normalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := entity.Tag{
+		Name:        name,
+		DisplayName: req.DisplayName,
+		Description: req.Description,
+		Color:       req.Color,
+		Slug:        req.Slug,
+	}
+
+	if err := s.tagRepo.UpdateTag(ctx, tagName, tag); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound{Message: fmt.Sprintf("tag %s", tagName)}
+		}
+		return nil, err
+	}
+
+	// the usage is kept under the old name until it is recalculated
+	s.CreateTagTrigger(ctx, constanta.JobCalculateTagStats, nil)
+
+	return s.GetTag(ctx, tag.Name)
+}
+
+// => POST /tags/merge
+// The article versions of the sources get the target instead, then the sources are deleted.
+func (s *TagService) MergeTags(ctx context.Context, req params.MergeTagsRequest) (*params.GetTagResponse, error) {
+	sources, err := s.tagRepo.GetTags(ctx, req.Sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range req.Sources {
+		if !slices.ContainsFunc(sources, func(tag entity.Tag) bool { return tag.Name == name }) {
+			return nil, errs.NotFound{Message: fmt.Sprintf("tag %s", name)}
+		}
+	}
+
+	// the target can be an alias of its canonical tag
+	target, err := s.NormalizeTags(ctx, req.Target)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(req.Sources, target[0]) {
+		return nil, errs.ValidationError{Message: "target tag cannot be a source tag"}
+	}
+
+	if err := s.tagRepo.MergeTags(ctx, req.Sources, target[0]); err != nil {
+		return nil, err
+	}
+
+	s.CreateTagTrigger(ctx, constanta.JobCalculateTagStats, nil)
+
+	return s.GetTag(ctx, target[0])
+}
+
+// => DELETE /tags/{name}
+// Only a tag which is not used by any article version can be deleted.
+func (s *TagService) DeleteTag(ctx context.Context, tagName string) error {
+	if err := s.tagRepo.DeleteTag(ctx, tagName); err != nil {
+		if err == sql.ErrNoRows {
+			return errs.NotFound{Message: fmt.Sprintf("tag %s", tagName)}
+		}
+		return err
+	}
+
+	s.CreateTagTrigger(ctx, constanta.JobCalculateTagStats, nil)
+
+	return nil
+}
+
+// => DELETE /tags
+func (s *TagService) DeleteUnusedTags(ctx context.Context) (*params.DeleteUnusedTagsResponse, error) {
+	deleted, err := s.tagRepo.DeleteUnusedTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.CreateTagTrigger(ctx, constanta.JobCalculateTagStats, nil)
+
+	return &params.DeleteUnusedTagsResponse{Deleted: deleted}, nil
+}
+
+// => GET /tags/{name}/synonyms
+func (s *TagService) GetTagSynonyms(ctx context.Context, tagName string) ([]params.TagSynonymResponse, error) {
+	tags, err := s.tagRepo.GetTags(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, errs.NotFound{Message: fmt.Sprintf("tag %s", tagName)}
+	}
+
+	synonyms, err := s.tagRepo.GetTagSynonymsWithTagName(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	return params.NewTagSynonymResponses(synonyms), nil
+}
+
+// => POST /tags/{name}/synonyms
+// An existing tag cannot become an alias, it must be merged into the tag instead.
+func (s *TagService) CreateTagSynonyms(ctx context.Context, tagName string, req params.CreateTagSynonymsRequest) ([]params.TagSynonymResponse, error) {
+	aliases := make([]string, 0, len(req.Aliases))
+	for _, alias := range req.Aliases {
+		alias, err := s.normalizeTagName(alias)
+		if err != nil {
+			return nil, err
+		}
+		if alias == tagName {
+			return nil, errs.ValidationError{Message: "tag cannot be a synonym of itself"}
+		}
+		aliases = append(aliases, alias)
+	}
+	slices.Sort(aliases)
+	aliases = slices.Compact(aliases)
+
+	tags, err := s.tagRepo.GetTags(ctx, aliases...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) > 0 {
+		return nil, errs.Conflict{Message: fmt.Sprintf("tag %s already exist, merge it into %s instead", tags[0].Name, tagName)}
+	}
+
+	if err := s.tagRepo.CreateTagSynonyms(ctx, tagName, aliases); err != nil {
+		return nil, err
+	}
+
+	return s.GetTagSynonyms(ctx, tagName)
+}
+
+// => DELETE /tags/{name}/synonyms/{alias}
+func (s *TagService) DeleteTagSynonym(ctx context.Context, tagName, alias string) error {
+	if err := s.tagRepo.DeleteTagSynonym(ctx, tagName, alias); err != nil {
+		if err == sql.ErrNoRows {
+			return errs.NotFound{Message: fmt.Sprintf("synonym %s of tag %s", alias, tagName)}
+		}
+		return err
+	}
+
+	return nil
+}
+
+const (
+	// weights of the tag suggestion score, their sum is 1
+	suggestionCoOccurrenceWeight = 0.6
+	suggestionPrefixWeight       = 0.25
+	suggestionTrendingWeight     = 0.15
+)
+
+// => GET /tags/suggest
+// The used tags are ranked by how often they occur with the current tags, how well they match the query and their trending score.
+func (s *TagService) SuggestTags(ctx context.Context, req params.GetTagSuggestionsRequest) ([]params.TagSuggestionResponse, error) {
+	current, err := s.NormalizeTags(ctx, req.Tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	query := entity.NormalizeTagName(req.Query, s.tagRules.SlugMode)
+	stats := s.loadTagStats()
+	usages := stats.tagUsage.Snapshot()
+	pairFrequencies := stats.tagPairFrequency.Snapshot()
+
+	var maxTrendingScore float64
+	for _, usage := range usages {
+		maxTrendingScore = max(maxTrendingScore, usage.TrendingScore)
+	}
+
+	suggestions := []params.TagSuggestionResponse{}
+	for name, usage := range usages {
+		if slices.Contains(current, name) {
+			continue
+		}
+
+		prefix := tagPrefixScore(name, query)
+		if query != "" && prefix == 0 {
+			continue
+		}
+
+		// same as the relationship score of the article with the tag, averaged over the current tags
+		var coOccurrence int
+		var relation float64
+		for _, tag := range current {
+			frequency := pairFrequencies[newTagPair(tag, name)]
+			if frequency == 0 || usages[tag].Count == 0 || usage.Count == 0 {
+				continue
+			}
+			coOccurrence += frequency
+			relation += float64(frequency) / math.Sqrt(float64(usages[tag].Count*usage.Count))
+		}
+		if len(current) > 0 {
+			relation /= float64(len(current))
+		}
+
+		var trending float64
+		if maxTrendingScore > 0 {
+			trending = usage.TrendingScore / maxTrendingScore
+		}
+
+		// without a query only the related or trending tags are suggested
+		if query == "" && coOccurrence == 0 && trending == 0 {
+			continue
+		}
+
+		score := suggestionCoOccurrenceWeight*relation + suggestionPrefixWeight*prefix + suggestionTrendingWeight*trending
+		suggestions = append(suggestions, params.TagSuggestionResponse{
+			Name:          name,
+			Score:         math.Round(score*10000) / 10000,
+			CoOccurrence:  coOccurrence,
+			TrendingScore: usage.TrendingScore,
+			PrefixMatch:   prefix > 0,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		if suggestions[i].CoOccurrence != suggestions[j].CoOccurrence {
+			return suggestions[i].CoOccurrence > suggestions[j].CoOccurrence
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+
+	if len(suggestions) > req.Limit {
+		suggestions = suggestions[:req.Limit]
+	}
+
+	return suggestions, nil
+}
+
+// tagPrefixScore is 1 when the name starts with the query and 0.5 when one of its words does.
+func tagPrefixScore(name, query string) float64 {
+	if query == "" {
+		return 0
+	}
+
+	if strings.HasPrefix(name, query) {
+		return 1
+	}
+
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '-' }) {
+		if strings.HasPrefix(word, query) {
+			return 0.5
+		}
+	}
+
+	return 0
+}
+
+const (
+	// number of related articles kept for every article
+	maxRelatedArticles = 20
+	// weights of the related article score, their sum is 1
+	relatedTagOverlapWeight   = 0.7
+	relatedRecencyWeight      = 0.2
+	relatedRelationshipWeight = 0.1
+	// the recency of an article published this long ago is halved
+	relatedRecencyHalfLife = 30 * 24 * time.Hour
+)
+
+// => GET /articles/{id}/related
+// The related articles are read from the cache which is rebuilt by the tag routine.
+func (s *TagService) GetRelatedArticles(ctx context.Context, articleID int64, limit int) ([]params.RelatedArticleResponse, error) {
+	relatedArticles := s.loadTagStats().relatedArticles
+	if !relatedArticles.Exist(articleID) {
+		article, err := s.articleRepo.GetArticleWithID(ctx, articleID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errs.NotFound{Message: "article"}
+			}
+			return nil, err
+		}
+
+		if article.PublishedVersionID == 0 {
+			return nil, errs.NotFound{Message: "published article"}
+		}
+
+		// published after the last rebuild
+		return []params.RelatedArticleResponse{}, nil
+	}
+
+	related := relatedArticles.Get(articleID)
+	if len(related) > limit {
+		related = related[:limit]
+	}
+
+	return params.NewRelatedArticleResponses(related), nil
+}
+
+// getRelatedArticles ranks the other published articles of every published article by the
+// IDF weighted jaccard similarity of their tags, their recency and their tag relationship score.
+func (s *TagService) getRelatedArticles(ctx context.Context) (*SafeMap[int64, []entity.RelatedArticle], error) {
+	// timeout must be less than the periodic ticker duration
+	ctx, cancel := context.WithTimeout(ctx, 8*time.Second)
+	defer cancel()
+
+	versions, err := s.articleRepo.GetPublishedArticleVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// rare tags say more about an article than common ones
+	tagUsage := s.loadTagStats().tagUsage
+	idf := make(map[string]float64)
+	articlesWithTag := make(map[string][]int)
+	for i, version := range versions {
+		for _, tag := range version.Tags {
+			articlesWithTag[tag.Name] = append(articlesWithTag[tag.Name], i)
+			if _, ok := idf[tag.Name]; !ok {
+				usage := max(tagUsage.Get(tag.Name).Count, 1)
+				idf[tag.Name] = math.Log(1 + float64(len(versions))/float64(usage))
+			}
+		}
+	}
+
+	now := time.Now()
+	sm := NewSafeMap[int64, []entity.RelatedArticle]()
+	for i, version := range versions {
+		var weight float64
+		tags := make(map[string]bool, len(version.Tags))
+		for _, tag := range version.Tags {
+			tags[tag.Name] = true
+			weight += idf[tag.Name]
+		}
+
+		// only the articles sharing a tag are candidates
+		shared := make(map[int][]string)
+		for _, tag := range version.Tags {
+			for _, j := range articlesWithTag[tag.Name] {
+				if j != i {
+					shared[j] = append(shared[j], tag.Name)
+				}
+			}
+		}
+
+		related := make([]entity.RelatedArticle, 0, len(shared))
+		for j, sharedTags := range shared {
+			candidate := versions[j]
+
+			var intersection float64
+			for _, tag := range sharedTags {
+				intersection += idf[tag]
+			}
+			union := weight
+			for _, tag := range candidate.Tags {
+				if !tags[tag.Name] {
+					union += idf[tag.Name]
+				}
+			}
+
+			published := publishedAt(candidate)
+			recency := math.Exp2(-float64(now.Sub(published)) / float64(relatedRecencyHalfLife))
+			score := relatedTagOverlapWeight*intersection/union + relatedRecencyWeight*min(recency, 1) + relatedRelationshipWeight*candidate.TagRelationShipScore
+
+			related = append(related, entity.RelatedArticle{
+				ArticleID:        candidate.ArticleID,
+				ArticleVersionID: candidate.ArticleVersionID,
+				Title:            candidate.Title,
+				Slug:             candidate.Slug,
+				Excerpt:          candidate.Excerpt,
+				SharedTags:       sharedTags,
+				Score:            math.Round(score*10000) / 10000,
+				PublishedAt:      published,
+			})
+		}
+
+		sort.Slice(related, func(a, b int) bool {
+			if related[a].Score != related[b].Score {
+				return related[a].Score > related[b].Score
+			}
+			return related[a].ArticleID < related[b].ArticleID
+		})
+		if len(related) > maxRelatedArticles {
+			related = related[:maxRelatedArticles]
+		}
+
+		sm.Set(version.ArticleID, related)
+	}
+
+	return sm, nil
+}
+
+// publishedAt returns when the version was published, the status change updates the version.
+func publishedAt(version entity.ArticleVersion) time.Time {
+	if version.UpdatedAt != nil {
+		return *version.UpdatedAt
+	}
+
+	return version.CreatedAt
 }
 
 func (s *TagService) getTagUsage(ctx context.Context) (*SafeMap[string, entity.TagUsage], error) {
@@ -225,25 +1016,89 @@ func (s *TagService) getTagUsage(ctx context.Context) (*SafeMap[string, entity.T
 		return nil, err
 	}
 
-	sm := NewSafeMap[string, entity.TagUsage]()
+	trending, err := s.getTrendingUsage(ctx, s.tagTrending.Window)
+	if err != nil {
+		return nil, err
+	}
 
-	now := time.Now()
-	interval := 24 * time.Hour
 	for tag, usage := range tagUsages {
-		if usage.LastUsed.IsZero() || usage.LastUsed.Add(interval).Before(now) {
-			usageCopy := usage
-			usageCopy.TrendingScore = 0
-			sm.Set(tag, usageCopy)
+		usage.TrendingScore = trending[tag].TrendingScore
+		tagUsages[tag] = usage
+	}
+
+	return NewSafeMapFrom(tagUsages), nil
+}
+
+// getTrendingUsage sums the daily usage of every tag in the window. Each day of the trending score
+// is weighted with 0.5^(age / half life), so a tag used every day outranks a tag used once long ago.
+func (s *TagService) getTrendingUsage(ctx context.Context, window time.Duration) (map[string]entity.TagUsage, error) {
+	// the days are stored without time zone
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.Add(-window).Add(24 * time.Hour)
+
+	usages, err := s.tagRepo.GetTagUsageDaily(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	trending := make(map[string]entity.TagUsage)
+	for _, daily := range usages {
+		age := today.Sub(daily.Day.UTC().Truncate(24 * time.Hour))
+		usage := trending[daily.TagName]
+		usage.Count += daily.Count
+		usage.TrendingScore += float64(daily.Count) * math.Exp2(-float64(max(age, 0))/float64(s.tagTrending.HalfLife))
+		if daily.Day.After(usage.LastUsed) {
+			usage.LastUsed = daily.Day
+		}
+		trending[daily.TagName] = usage
+	}
+
+	for tag, usage := range trending {
+		usage.TrendingScore = math.Round(usage.TrendingScore*10000) / 10000
+		trending[tag] = usage
+	}
+
+	return trending, nil
+}
+
+// => GET /tags/trending
+// The tags are ordered by their trending score in the window.
+func (s *TagService) GetTrendingTags(ctx context.Context, req params.GetTrendingTagsRequest) ([]params.TrendingTagResponse, error) {
+	window := req.WindowDuration()
+	if window == 0 {
+		window = s.tagTrending.Window
+	}
+
+	trending, err := s.getTrendingUsage(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]params.TrendingTagResponse, 0, len(trending))
+	for tag, usage := range trending {
+		if usage.TrendingScore == 0 {
 			continue
 		}
+		res = append(res, params.TrendingTagResponse{
+			Name:          tag,
+			TrendingScore: usage.TrendingScore,
+			UsageCount:    usage.Count,
+			LastUsed:      usage.LastUsed,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].TrendingScore != res[j].TrendingScore {
+			return res[i].TrendingScore > res[j].TrendingScore
+		}
+		return res[i].Name < res[j].Name
+	})
 
-		score := float64(usage.Count) / float64(1+interval.Hours())
-		usageCopy := usage
-		usageCopy.TrendingScore = score
-		sm.Set(tag, usageCopy)
+	if len(res) > req.Limit {
+		res = res[:req.Limit]
 	}
 
-	return sm, nil
+	return res, nil
 }
 
 func (s *TagService) getTagPairFrequency(ctx context.Context) (*SafeMap[[2]string, int], error) {
@@ -251,28 +1106,34 @@ func (s *TagService) getTagPairFrequency(ctx context.Context) (*SafeMap[[2]strin
 	ctx, cancel := context.WithTimeout(ctx, 8*time.Second)
 	defer cancel()
 
-	articleVersionTags, err := s.tagRepo.GetArticleTags(ctx, constanta.Published)
+	frequencies, err := s.tagRepo.GetTagPairFrequency(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	tagPairs := NewSafeMap[[2]string, int]()
+	// the database can order the pair differently, the pairs of a renamed tag can be in both orders until they are reconciled
+	sums := make(map[[2]string]int, len(frequencies))
+	for pair, frequency := range frequencies {
+		sums[newTagPair(pair[0], pair[1])] += frequency
+	}
 
-	for i := 0; i < len(articleVersionTags); i++ {
-		for j := i + 1; j < len(articleVersionTags); j++ {
-			pair := [2]string{articleVersionTags[i].TagName, articleVersionTags[j].TagName}
-			tagPairs.Set(pair, tagPairs.Get(pair)+1)
-		}
+	return NewSafeMapFrom(sums), nil
+}
+
+// newTagPair orders the names of the pair, so a pair has the same key in both directions.
+func newTagPair(a, b string) [2]string {
+	if b < a {
+		return [2]string{b, a}
 	}
 
-	return tagPairs, nil
+	return [2]string{a, b}
 }
 
 func (s *TagService) getTagPair(tags []entity.Tag) [][2]string {
 	var pairs [][2]string
 	for i := 0; i < len(tags); i++ {
 		for j := i + 1; j < len(tags); j++ {
-			pairs = append(pairs, [2]string{tags[i].Name, tags[j].Name})
+			pairs = append(pairs, newTagPair(tags[i].Name, tags[j].Name))
 		}
 	}
 
@@ -285,13 +1146,14 @@ func (s *TagService) calculateArticleVersionTagRelationShipScore(tags []entity.T
 		return 0
 	}
 
+	stats := s.loadTagStats()
 	var scoreSum float64
 	var validPairs int
 	pairs := s.getTagPair(tags)
 	for _, pair := range pairs {
-		coOccur := float64(s.tagPairFrequency.Get(pair))
-		freq1 := float64(s.tagUsage.Get(pair[0]).Count)
-		freq2 := float64(s.tagUsage.Get(pair[1]).Count)
+		coOccur := float64(stats.tagPairFrequency.Get(pair))
+		freq1 := float64(stats.tagUsage.Get(pair[0]).Count)
+		freq2 := float64(stats.tagUsage.Get(pair[1]).Count)
 		if freq1 == 0 || freq2 == 0 {
 			continue
 		}
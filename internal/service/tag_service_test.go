@@ -2,40 +2,83 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/elangreza/content-management-system/internal/constanta"
 	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
 	"github.com/elangreza/content-management-system/internal/params"
 	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
 //go:generate mockgen -destination=mock/mock_tag_repo.go -package=service_mock . tagRepo
+//go:generate mockgen -destination=mock/mock_job_queue.go -package=service_mock . jobQueue
 
 func TestTagService_CreateTag(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
-	s := &TagService{tagRepo: mockTagRepo, tagUsage: NewSafeMap[string, entity.TagUsage](), tagPairFrequency: NewSafeMap[[2]string, int](), actionTrigger: make(chan TagActionTrigger, 1)}
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
 
 	tests := []struct {
 		name    string
+		input   []string
 		setup   func()
 		wantErr bool
 	}{
 		{
-			name: "success",
+			name:  "success",
+			input: []string{"tag1", "tag2"},
 			setup: func() {
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "tag1", "tag2").Return(nil, nil)
 				mockTagRepo.EXPECT().UpsertTags(gomock.Any(), "tag1", "tag2").Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "repo error",
+			name:  "success with normalized names and synonyms",
+			input: []string{" Golang ", "Machine_Learning", "GO"},
 			setup: func() {
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "golang", "machine-learning", "go").Return([]entity.TagSynonym{{Alias: "golang", TagName: "go"}}, nil)
+				mockTagRepo.EXPECT().UpsertTags(gomock.Any(), "go", "machine-learning").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "not valid character",
+			input:   []string{"tag1", "tag$"},
+			setup:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "reserved by the tag API",
+			input:   []string{" Trending "},
+			setup:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			input:   []string{strings.Repeat("a", entity.DefaultTagRules.MaxLength+1)},
+			setup:   func() {},
+			wantErr: true,
+		},
+		{
+			name:  "repo error",
+			input: []string{"tag1", "tag2"},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "tag1", "tag2").Return(nil, nil)
 				mockTagRepo.EXPECT().UpsertTags(gomock.Any(), "tag1", "tag2").Return(errors.New("db error"))
 			},
 			wantErr: true,
@@ -44,7 +87,7 @@ func TestTagService_CreateTag(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			err := s.CreateTag(context.Background(), "tag1", "tag2")
+			err := s.CreateTag(context.Background(), tt.input...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateTag() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -52,14 +95,36 @@ func TestTagService_CreateTag(t *testing.T) {
 	}
 }
 
+func TestTagService_NormalizeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	s := &TagService{tagRepo: mockTagRepo, tagRules: entity.TagRules{MaxLength: 20, MaxPerArticle: 2, SlugMode: constanta.TagSlugNone}}
+
+	mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "c++", "c++", "node js").Return(nil, nil)
+	got, err := s.NormalizeTags(context.Background(), "C++", "ｃ＋＋", " Node   JS ")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c++", "node js"}, got)
+
+	mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "a", "b", "c").Return(nil, nil)
+	_, err = s.NormalizeTags(context.Background(), "a", "b", "c")
+	assert.Equal(t, errs.ValidationError{Message: "an article can have at most 2 tags"}, err)
+
+	got, err = s.NormalizeTags(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 func TestTagService_GetTags(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
-	s := &TagService{tagRepo: mockTagRepo, tagUsage: NewSafeMap[string, entity.TagUsage](), tagPairFrequency: NewSafeMap[[2]string, int](), actionTrigger: make(chan TagActionTrigger, 1)}
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
 
 	now := time.Now()
-	s.tagUsage.Set("tag1", entity.TagUsage{Count: 5, TrendingScore: 1.2, LastUsed: now})
+	s.loadTagStats().tagUsage.Set("tag1", entity.TagUsage{Count: 5, TrendingScore: 1.2, LastUsed: now})
 
 	tests := []struct {
 		name    string
@@ -70,7 +135,7 @@ func TestTagService_GetTags(t *testing.T) {
 		{
 			name: "success",
 			setup: func() {
-				mockTagRepo.EXPECT().GetTags(gomock.Any()).Return([]string{"tag1"}, nil)
+				mockTagRepo.EXPECT().GetTags(gomock.Any()).Return(entity.NewTags("tag1"), nil)
 			},
 			want:    []params.GetTagResponse{{Name: "tag1", UsageCount: 5, TrendingScore: 1.2, LastUsed: now}},
 			wantErr: false,
@@ -87,7 +152,7 @@ func TestTagService_GetTags(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			got, err := s.GetTags(context.Background(), params.GetTagsRequest{})
+			got, _, err := s.GetTags(context.Background(), params.GetTagsRequest{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetTags() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -99,17 +164,83 @@ func TestTagService_GetTags(t *testing.T) {
 	}
 }
 
+func TestTagService_GetTags_Cursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+
+	s.loadTagStats().tagUsage.Set("a", entity.TagUsage{Count: 3})
+	s.loadTagStats().tagUsage.Set("b", entity.TagUsage{Count: 2})
+	s.loadTagStats().tagUsage.Set("c", entity.TagUsage{Count: 2})
+	s.loadTagStats().tagUsage.Set("d", entity.TagUsage{Count: 1})
+	mockTagRepo.EXPECT().GetTags(gomock.Any()).Return(entity.NewTags("d", "c", "b", "a"), nil).AnyTimes()
+
+	names := func(tags []params.GetTagResponse) []string {
+		res := make([]string, len(tags))
+		for i, tag := range tags {
+			res[i] = tag.Name
+		}
+		return res
+	}
+
+	req := params.GetTagsRequest{SortValue: "usage_count", Direction: "desc", Limit: 2}
+	first, pagination, err := s.GetTags(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(names(first), want) {
+		t.Errorf("GetTags() first page = %v, want %v", names(first), want)
+	}
+	if pagination.PrevCursor != "" {
+		t.Errorf("GetTags() prev cursor = %v, want empty", pagination.PrevCursor)
+	}
+
+	req.After = pagination.NextCursor
+	second, pagination, err := s.GetTags(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"c", "d"}; !reflect.DeepEqual(names(second), want) {
+		t.Errorf("GetTags() second page = %v, want %v", names(second), want)
+	}
+	if pagination.NextCursor != "" {
+		t.Errorf("GetTags() next cursor = %v, want empty", pagination.NextCursor)
+	}
+
+	req.After = ""
+	req.Before = pagination.PrevCursor
+	back, _, err := s.GetTags(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(names(back), want) {
+		t.Errorf("GetTags() previous page = %v, want %v", names(back), want)
+	}
+
+	req.Before = ""
+	req.After = "not-a-cursor"
+	if _, _, err := s.GetTags(context.Background(), req); err == nil {
+		t.Errorf("GetTags() error = nil, want error")
+	}
+}
+
 func TestTagService_GetTag(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
-	s := &TagService{tagRepo: mockTagRepo, tagUsage: NewSafeMap[string, entity.TagUsage](), tagPairFrequency: NewSafeMap[[2]string, int](), actionTrigger: make(chan TagActionTrigger, 1)}
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
 
 	now := time.Now()
-	s.tagUsage.Set("tag1", entity.TagUsage{Count: 5, TrendingScore: 1.2, LastUsed: now})
+	s.loadTagStats().tagUsage.Set("tag1", entity.TagUsage{Count: 5, TrendingScore: 1.2, LastUsed: now})
 
 	tests := []struct {
 		name    string
+		input   string
 		setup   func()
 		want    *params.GetTagResponse
 		wantErr bool
@@ -117,7 +248,18 @@ func TestTagService_GetTag(t *testing.T) {
 		{
 			name: "success",
 			setup: func() {
-				mockTagRepo.EXPECT().GetTags(gomock.Any(), "tag1").Return([]string{"tag1"}, nil)
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "tag1").Return([]entity.Tag{{Name: "tag1", DisplayName: "Tag 1", Color: "#1e90ff", Slug: "tag1"}}, nil)
+			},
+			want:    &params.GetTagResponse{Name: "tag1", DisplayName: "Tag 1", Color: "#1e90ff", Slug: "tag1", UsageCount: 5, TrendingScore: 1.2, LastUsed: now},
+			wantErr: false,
+		},
+		{
+			name:  "success with alias",
+			input: "Tag_One",
+			setup: func() {
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "Tag_One").Return([]entity.Tag{}, nil)
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "tag-one").Return([]entity.TagSynonym{{Alias: "tag-one", TagName: "tag1"}}, nil)
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "tag1").Return([]entity.Tag{{Name: "tag1"}}, nil)
 			},
 			want:    &params.GetTagResponse{Name: "tag1", UsageCount: 5, TrendingScore: 1.2, LastUsed: now},
 			wantErr: false,
@@ -125,7 +267,8 @@ func TestTagService_GetTag(t *testing.T) {
 		{
 			name: "not found",
 			setup: func() {
-				mockTagRepo.EXPECT().GetTags(gomock.Any(), "tag1").Return([]string{}, nil)
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "tag1").Return([]entity.Tag{}, nil)
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "tag1").Return(nil, nil)
 			},
 			want:    nil,
 			wantErr: true,
@@ -134,7 +277,11 @@ func TestTagService_GetTag(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			got, err := s.GetTag(context.Background(), "tag1")
+			input := "tag1"
+			if tt.input != "" {
+				input = tt.input
+			}
+			got, err := s.GetTag(context.Background(), input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetTag() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -146,25 +293,133 @@ func TestTagService_GetTag(t *testing.T) {
 	}
 }
 
+func TestTagService_GetTagStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+
+	day := func(d int) time.Time { return time.Date(2025, 8, d, 0, 0, 0, 0, time.UTC) }
+	authorID := uuid.New()
+
+	tests := []struct {
+		name    string
+		req     params.GetTagStatsRequest
+		setup   func()
+		want    *params.TagStatsResponse
+		wantErr bool
+	}{
+		{
+			name: "daily buckets are filled with zero",
+			req:  params.GetTagStatsRequest{From: day(1), To: day(3), Interval: constanta.TagStatsDay, Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "go").Return([]entity.Tag{{Name: "go"}}, nil)
+				mockTagRepo.EXPECT().GetTagStats(gomock.Any(), entity.TagStatsFilter{TagName: "go", Interval: constanta.TagStatsDay, From: day(1), To: day(4), Limit: 10}).
+					Return(&entity.TagStats{
+						Series:          []entity.TagStatsBucket{{Start: day(2), Count: 3}},
+						CoOccurringTags: []entity.TagCount{{Name: "cms", Count: 2}},
+						TopAuthors:      []entity.TagAuthor{{ID: authorID, Name: "editor", Count: 3}},
+					}, nil)
+			},
+			want: &params.TagStatsResponse{
+				Name:     "go",
+				From:     day(1),
+				To:       day(3),
+				Interval: constanta.TagStatsDay,
+				Total:    3,
+				Series: []params.TagStatsBucketResponse{
+					{Start: day(1), Count: 0},
+					{Start: day(2), Count: 3},
+					{Start: day(3), Count: 0},
+				},
+				CoOccurringTags: []params.TagCountResponse{{Name: "cms", Count: 2}},
+				TopAuthors:      []params.TagAuthorResponse{{ID: authorID, Name: "editor", Count: 3}},
+			},
+		},
+		{
+			name: "weekly buckets start on monday",
+			req:  params.GetTagStatsRequest{From: day(6), To: day(12), Interval: constanta.TagStatsWeek, Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "go").Return([]entity.Tag{{Name: "go"}}, nil)
+				mockTagRepo.EXPECT().GetTagStats(gomock.Any(), gomock.Any()).
+					Return(&entity.TagStats{Series: []entity.TagStatsBucket{{Start: day(11), Count: 1}}}, nil)
+			},
+			want: &params.TagStatsResponse{
+				Name:     "go",
+				From:     day(6),
+				To:       day(12),
+				Interval: constanta.TagStatsWeek,
+				Total:    1,
+				Series: []params.TagStatsBucketResponse{
+					{Start: day(4), Count: 0},
+					{Start: day(11), Count: 1},
+				},
+				CoOccurringTags: []params.TagCountResponse{},
+				TopAuthors:      []params.TagAuthorResponse{},
+			},
+		},
+		{
+			name: "tag not found",
+			req:  params.GetTagStatsRequest{From: day(1), To: day(3), Interval: constanta.TagStatsDay, Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "go").Return([]entity.Tag{}, nil)
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "go").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "repo error",
+			req:  params.GetTagStatsRequest{From: day(1), To: day(3), Interval: constanta.TagStatsDay, Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTags(gomock.Any(), "go").Return([]entity.Tag{{Name: "go"}}, nil)
+				mockTagRepo.EXPECT().GetTagStats(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			got, err := s.GetTagStats(context.Background(), "go", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTagStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestTagService_getTagUsage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
-	s := &TagService{tagRepo: mockTagRepo, tagUsage: NewSafeMap[string, entity.TagUsage](), tagPairFrequency: NewSafeMap[[2]string, int](), actionTrigger: make(chan TagActionTrigger, 1)}
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules, tagTrending: entity.DefaultTagTrending}
 
 	now := time.Now()
+	today := now.UTC().Truncate(24 * time.Hour)
 	tagUsages := map[string]entity.TagUsage{"tag1": {Count: 3, LastUsed: now, TrendingScore: 0}}
 
 	tests := []struct {
 		name    string
 		setup   func()
+		want    float64
 		wantErr bool
 	}{
 		{
 			name: "success",
 			setup: func() {
 				mockTagRepo.EXPECT().GetTagUsage(gomock.Any()).Return(tagUsages, nil)
+				mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), today.Add(-6*24*time.Hour)).Return([]entity.TagUsageDaily{
+					{TagName: "tag1", Day: today, Count: 1},
+					// two half lives ago
+					{TagName: "tag1", Day: today.Add(-4 * 24 * time.Hour), Count: 2},
+				}, nil)
 			},
+			want:    1.5,
 			wantErr: false,
 		},
 		{
@@ -174,25 +429,106 @@ func TestTagService_getTagUsage(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "daily usage error",
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagUsage(gomock.Any()).Return(tagUsages, nil)
+				mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			_, err := s.getTagUsage(context.Background())
+			got, err := s.getTagUsage(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getTagUsage() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if !tt.wantErr {
+				usage := got.Get("tag1")
+				assert.Equal(t, 3, usage.Count)
+				assert.Equal(t, tt.want, usage.TrendingScore)
+			}
 		})
 	}
 }
 
-func TestTagService_getTagPairFrequency(t *testing.T) {
+func TestTagService_GetTrendingTags(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
-	s := &TagService{tagRepo: mockTagRepo, tagUsage: NewSafeMap[string, entity.TagUsage](), tagPairFrequency: NewSafeMap[[2]string, int](), actionTrigger: make(chan TagActionTrigger, 1)}
+	s := &TagService{tagRepo: mockTagRepo, tagRules: entity.DefaultTagRules, tagTrending: entity.DefaultTagTrending}
 
-	articleTags := []entity.ArticleVersionTag{{TagName: "tag1", ArticleVersionID: 1}, {TagName: "tag2", ArticleVersionID: 1}}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	daily := []entity.TagUsageDaily{
+		{TagName: "go", Day: today, Count: 2},
+		{TagName: "cms", Day: today.Add(-2 * 24 * time.Hour), Count: 4},
+		{TagName: "go", Day: today.Add(-24 * time.Hour), Count: 1},
+		{TagName: "old", Day: today.Add(-8 * 24 * time.Hour), Count: 1},
+		// decayed below the rounding of the score
+		{TagName: "older", Day: today.Add(-29 * 24 * time.Hour), Count: 1},
+	}
+
+	tests := []struct {
+		name    string
+		req     params.GetTrendingTagsRequest
+		setup   func()
+		want    []params.TrendingTagResponse
+		wantErr bool
+	}{
+		{
+			name: "configured window",
+			req:  params.GetTrendingTagsRequest{Limit: 2},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), today.Add(-6*24*time.Hour)).Return(daily[:3], nil)
+			},
+			want: []params.TrendingTagResponse{
+				{Name: "go", TrendingScore: 2.7071, UsageCount: 3, LastUsed: today},
+				{Name: "cms", TrendingScore: 2, UsageCount: 4, LastUsed: today.Add(-2 * 24 * time.Hour)},
+			},
+		},
+		{
+			name: "requested window",
+			req:  params.GetTrendingTagsRequest{Window: "30d", Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), today.Add(-29*24*time.Hour)).Return(daily[3:], nil)
+			},
+			want: []params.TrendingTagResponse{
+				{Name: "old", TrendingScore: 0.0625, UsageCount: 1, LastUsed: today.Add(-8 * 24 * time.Hour)},
+			},
+		},
+		{
+			name: "repo error",
+			req:  params.GetTrendingTagsRequest{Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			assert.NoError(t, tt.req.Validate())
+			got, err := s.GetTrendingTags(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTrendingTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTagService_getTagPairFrequency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
 
 	tests := []struct {
 		name    string
@@ -202,14 +538,18 @@ func TestTagService_getTagPairFrequency(t *testing.T) {
 		{
 			name: "success",
 			setup: func() {
-				mockTagRepo.EXPECT().GetArticleTags(gomock.Any(), gomock.Any()).Return(articleTags, nil)
+				// the pairs of a renamed tag can be in both orders
+				mockTagRepo.EXPECT().GetTagPairFrequency(gomock.Any()).Return(map[[2]string]int{
+					{"tag1", "tag2"}: 1,
+					{"tag2", "tag1"}: 1,
+				}, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "repo error",
 			setup: func() {
-				mockTagRepo.EXPECT().GetArticleTags(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				mockTagRepo.EXPECT().GetTagPairFrequency(gomock.Any()).Return(nil, errors.New("db error"))
 			},
 			wantErr: true,
 		},
@@ -217,21 +557,21 @@ func TestTagService_getTagPairFrequency(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			_, err := s.getTagPairFrequency(context.Background())
+			got, err := s.getTagPairFrequency(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getTagPairFrequency() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if !tt.wantErr {
+				assert.Equal(t, map[[2]string]int{{"tag1", "tag2"}: 2}, got.Snapshot())
+			}
 		})
 	}
 }
 
 func TestTagService_getTagPair(t *testing.T) {
 	type fields struct {
-		articleRepo      articleRepo
-		tagRepo          tagRepo
-		tagUsage         *SafeMap[string, entity.TagUsage]
-		tagPairFrequency *SafeMap[[2]string, int]
-		actionTrigger    chan TagActionTrigger
+		articleRepo articleRepo
+		tagRepo     tagRepo
 	}
 	type args struct {
 		tags []entity.Tag
@@ -262,11 +602,8 @@ func TestTagService_getTagPair(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &TagService{
-				articleRepo:      tt.fields.articleRepo,
-				tagRepo:          tt.fields.tagRepo,
-				tagUsage:         tt.fields.tagUsage,
-				tagPairFrequency: tt.fields.tagPairFrequency,
-				actionTrigger:    tt.fields.actionTrigger,
+				articleRepo: tt.fields.articleRepo,
+				tagRepo:     tt.fields.tagRepo,
 			}
 			if got := s.getTagPair(tt.args.tags); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TagService.getTagPair() = %v, want %v", got, tt.want)
@@ -274,3 +611,568 @@ func TestTagService_getTagPair(t *testing.T) {
 		})
 	}
 }
+
+func TestTagService_UpdateTag(t *testing.T) {
+	req := params.UpdateTagRequest{Name: "golang", DisplayName: "Go", Color: "#00add8", Slug: "golang"}
+
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MocktagRepo)
+		want    *params.GetTagResponse
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().UpdateTag(gomock.Any(), "go", entity.Tag{Name: "golang", DisplayName: "Go", Color: "#00add8", Slug: "golang"}).Return(nil)
+				repo.EXPECT().GetTags(gomock.Any(), "golang").Return([]entity.Tag{{Name: "golang", DisplayName: "Go", Color: "#00add8", Slug: "golang"}}, nil)
+			},
+			want: &params.GetTagResponse{Name: "golang", DisplayName: "Go", Color: "#00add8", Slug: "golang"},
+		},
+		{
+			name: "not found",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().UpdateTag(gomock.Any(), "go", gomock.Any()).Return(sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "tag go"},
+		},
+		{
+			name: "new name is taken",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().UpdateTag(gomock.Any(), "go", gomock.Any()).Return(errs.AlreadyExist{Name: "tag golang"})
+			},
+			wantErr: errs.AlreadyExist{Name: "tag golang"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+			mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+			mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+			s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+			tt.setup(mockTagRepo)
+
+			got, err := s.UpdateTag(context.Background(), "go", req)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	req := params.MergeTagsRequest{Sources: []string{"golang", "go-lang"}, Target: "go"}
+
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MocktagRepo)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().GetTags(gomock.Any(), "golang", "go-lang").Return(entity.NewTags("golang", "go-lang"), nil)
+				repo.EXPECT().GetTagSynonyms(gomock.Any(), "go").Return(nil, nil)
+				repo.EXPECT().MergeTags(gomock.Any(), []string{"golang", "go-lang"}, "go").Return(nil)
+				repo.EXPECT().GetTags(gomock.Any(), "go").Return(entity.NewTags("go"), nil)
+			},
+		},
+		{
+			name: "source not found",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().GetTags(gomock.Any(), "golang", "go-lang").Return(entity.NewTags("golang"), nil)
+			},
+			wantErr: errs.NotFound{Message: "tag go-lang"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+			mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+			mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+			s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+			tt.setup(mockTagRepo)
+
+			_, err := s.MergeTags(context.Background(), req)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestTagService_DeleteTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MocktagRepo)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().DeleteTag(gomock.Any(), "typo").Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().DeleteTag(gomock.Any(), "typo").Return(sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "tag typo"},
+		},
+		{
+			name: "used by article versions",
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().DeleteTag(gomock.Any(), "typo").Return(errs.Conflict{Message: "tag is used by article versions"})
+			},
+			wantErr: errs.Conflict{Message: "tag is used by article versions"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+			mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+			mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+			s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+			tt.setup(mockTagRepo)
+
+			err := s.DeleteTag(context.Background(), "typo")
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestTagService_CreateTagSynonyms(t *testing.T) {
+	createdAt := time.Now()
+
+	tests := []struct {
+		name    string
+		req     params.CreateTagSynonymsRequest
+		setup   func(*service_mock.MocktagRepo)
+		want    []params.TagSynonymResponse
+		wantErr error
+	}{
+		{
+			name: "success",
+			req:  params.CreateTagSynonymsRequest{Aliases: []string{"Golang", " golang", "Go_Lang"}},
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().GetTags(gomock.Any(), "go-lang", "golang").Return(nil, nil)
+				repo.EXPECT().CreateTagSynonyms(gomock.Any(), "go", []string{"go-lang", "golang"}).Return(nil)
+				repo.EXPECT().GetTags(gomock.Any(), "go").Return(entity.NewTags("go"), nil)
+				repo.EXPECT().GetTagSynonymsWithTagName(gomock.Any(), "go").Return([]entity.TagSynonym{
+					{Alias: "go-lang", TagName: "go", CreatedAt: createdAt},
+					{Alias: "golang", TagName: "go", CreatedAt: createdAt},
+				}, nil)
+			},
+			want: []params.TagSynonymResponse{
+				{Alias: "go-lang", TagName: "go", CreatedAt: createdAt},
+				{Alias: "golang", TagName: "go", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:    "alias is the tag",
+			req:     params.CreateTagSynonymsRequest{Aliases: []string{"GO"}},
+			setup:   func(repo *service_mock.MocktagRepo) {},
+			wantErr: errs.ValidationError{Message: "tag cannot be a synonym of itself"},
+		},
+		{
+			name: "alias is an existing tag",
+			req:  params.CreateTagSynonymsRequest{Aliases: []string{"golang"}},
+			setup: func(repo *service_mock.MocktagRepo) {
+				repo.EXPECT().GetTags(gomock.Any(), "golang").Return(entity.NewTags("golang"), nil)
+			},
+			wantErr: errs.Conflict{Message: "tag golang already exist, merge it into go instead"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+			mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+			mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+			s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+			tt.setup(mockTagRepo)
+
+			got, err := s.CreateTagSynonyms(context.Background(), "go", tt.req)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagService_DeleteTagSynonym(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	s := &TagService{tagRepo: mockTagRepo, tagRules: entity.DefaultTagRules}
+
+	mockTagRepo.EXPECT().DeleteTagSynonym(gomock.Any(), "go", "golang").Return(sql.ErrNoRows)
+
+	err := s.DeleteTagSynonym(context.Background(), "go", "golang")
+	assert.Equal(t, errs.NotFound{Message: "synonym golang of tag go"}, err)
+}
+
+func TestTagService_SuggestTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(nil).AnyTimes()
+	s := &TagService{tagRepo: mockTagRepo, jobs: mockJobQueue, tagRules: entity.DefaultTagRules}
+
+	s.loadTagStats().tagUsage.Set("go", entity.TagUsage{Count: 4})
+	s.loadTagStats().tagUsage.Set("docker", entity.TagUsage{Count: 4, TrendingScore: 0.2})
+	s.loadTagStats().tagUsage.Set("database", entity.TagUsage{Count: 1})
+	s.loadTagStats().tagUsage.Set("kubernetes", entity.TagUsage{Count: 2, TrendingScore: 0.4})
+	s.loadTagStats().tagUsage.Set("distributed-systems", entity.TagUsage{Count: 1})
+	s.loadTagStats().tagPairFrequency.Set(newTagPair("go", "docker"), 2)
+	s.loadTagStats().tagPairFrequency.Set(newTagPair("go", "database"), 1)
+
+	tests := []struct {
+		name  string
+		req   params.GetTagSuggestionsRequest
+		setup func()
+		want  []params.TagSuggestionResponse
+	}{
+		{
+			name: "related tags",
+			req:  params.GetTagSuggestionsRequest{Tags: []string{"Go"}, Limit: 10},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "go").Return(nil, nil)
+			},
+			want: []params.TagSuggestionResponse{
+				{Name: "docker", Score: 0.375, CoOccurrence: 2, TrendingScore: 0.2},
+				{Name: "database", Score: 0.3, CoOccurrence: 1},
+				{Name: "kubernetes", Score: 0.15, TrendingScore: 0.4},
+			},
+		},
+		{
+			name: "prefix",
+			req:  params.GetTagSuggestionsRequest{Tags: []string{"go"}, Query: "D", Limit: 2},
+			setup: func() {
+				mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), "go").Return(nil, nil)
+			},
+			want: []params.TagSuggestionResponse{
+				{Name: "docker", Score: 0.625, CoOccurrence: 2, TrendingScore: 0.2, PrefixMatch: true},
+				{Name: "database", Score: 0.55, CoOccurrence: 1, PrefixMatch: true},
+			},
+		},
+		{
+			name:  "word prefix without current tags",
+			req:   params.GetTagSuggestionsRequest{Query: "sys", Limit: 10},
+			setup: func() {},
+			want: []params.TagSuggestionResponse{
+				{Name: "distributed-systems", Score: 0.125, PrefixMatch: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			got, err := s.SuggestTags(context.Background(), tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagService_getRelatedArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	s := &TagService{articleRepo: mockArticleRepo, tagRules: entity.DefaultTagRules}
+
+	s.loadTagStats().tagUsage.Set("go", entity.TagUsage{Count: 3})
+	s.loadTagStats().tagUsage.Set("cms", entity.TagUsage{Count: 1})
+	s.loadTagStats().tagUsage.Set("docker", entity.TagUsage{Count: 1})
+
+	now := time.Now()
+	old := now.Add(-relatedRecencyHalfLife)
+	mockArticleRepo.EXPECT().GetPublishedArticleVersions(gomock.Any()).Return([]entity.ArticleVersion{
+		{ArticleID: 1, ArticleVersionID: 10, Title: "Go CMS", Tags: entity.NewTags("cms", "go"), CreatedAt: now},
+		{ArticleID: 2, ArticleVersionID: 20, Title: "Go", Tags: entity.NewTags("go"), CreatedAt: old, UpdatedAt: &now},
+		{ArticleID: 3, ArticleVersionID: 30, Title: "Go Docker", Tags: entity.NewTags("docker", "go"), CreatedAt: old, TagRelationShipScore: 0.5},
+		{ArticleID: 4, ArticleVersionID: 40, Title: "Untagged", Tags: []entity.Tag{}, CreatedAt: now},
+	}, nil)
+
+	got, err := s.getRelatedArticles(context.Background())
+	assert.NoError(t, err)
+
+	related := got.Get(1)
+	assert.Len(t, related, 2)
+	// the same shared tag, the recently published article ranks first
+	assert.Equal(t, []int64{2, 3}, []int64{related[0].ArticleID, related[1].ArticleID})
+	assert.Equal(t, []string{"go"}, related[0].SharedTags)
+	assert.Equal(t, now, related[0].PublishedAt)
+	assert.Greater(t, related[0].Score, related[1].Score)
+
+	assert.True(t, got.Exist(4))
+	assert.Empty(t, got.Get(4))
+}
+
+func TestTagService_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Register(gomock.Any(), gomock.Any()).Times(2)
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(5)
+	s := NewTagService(mockArticleRepo, mockTagRepo, mockJobQueue, mockEventSubscriber, entity.DefaultTagRules, entity.DefaultTagTrending)
+
+	// the statistics are loaded before Start returns
+	mockTagRepo.EXPECT().ReconcileTagStats(gomock.Any()).Return(int64(0), nil)
+	mockTagRepo.EXPECT().GetTagUsage(gomock.Any()).Return(map[string]entity.TagUsage{"go": {Count: 2}}, nil)
+	mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockTagRepo.EXPECT().GetTagPairFrequency(gomock.Any()).Return(map[[2]string]int{}, nil)
+	mockArticleRepo.EXPECT().GetPublishedArticleVersions(gomock.Any()).Return(nil, nil)
+
+	assert.NoError(t, s.Start(context.Background()))
+	assert.Equal(t, 2, s.loadTagStats().tagUsage.Get("go").Count)
+
+	assert.NoError(t, s.Stop(context.Background()))
+	// stopping again does nothing
+	assert.NoError(t, s.Stop(context.Background()))
+}
+
+func TestTagService_calculateArticleTagRelationJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	s := &TagService{articleRepo: mockArticleRepo, tagRepo: mockTagRepo, tagRules: entity.DefaultTagRules, tagTrending: entity.DefaultTagTrending}
+
+	t.Run("not valid payload", func(t *testing.T) {
+		err := s.calculateArticleTagRelationJob(context.Background(), []byte(`[]`))
+		assert.Error(t, err)
+	})
+
+	t.Run("a failed update is returned for a retry", func(t *testing.T) {
+		mockArticleRepo.EXPECT().UpdateArticleVersionRelationshipScore(gomock.Any(), int64(3), float64(0)).Return(errors.New("db down"))
+
+		err := s.calculateArticleTagRelationJob(context.Background(), []byte(`{"Tags":[{"Name":"go"}],"ArticleVersionID":3}`))
+		assert.ErrorContains(t, err, "db down")
+	})
+
+	t.Run("scored with the loaded statistics", func(t *testing.T) {
+		s.loadTagStats().tagUsage.Set("go", entity.TagUsage{Count: 4})
+		s.loadTagStats().tagUsage.Set("cms", entity.TagUsage{Count: 1})
+		s.loadTagStats().tagPairFrequency.Set(newTagPair("go", "cms"), 1)
+		mockArticleRepo.EXPECT().UpdateArticleVersionRelationshipScore(gomock.Any(), int64(3), 0.5).Return(nil)
+
+		err := s.calculateArticleTagRelationJob(context.Background(), []byte(`{"Tags":[{"Name":"cms"},{"Name":"go"}],"ArticleVersionID":3}`))
+		assert.NoError(t, err)
+	})
+}
+
+func TestTagService_reloadWhileReading(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+	s := &TagService{articleRepo: mockArticleRepo, tagRepo: mockTagRepo, tagRules: entity.DefaultTagRules, tagTrending: entity.DefaultTagTrending}
+
+	mockTagRepo.EXPECT().GetTagUsage(gomock.Any()).DoAndReturn(func(context.Context) (map[string]entity.TagUsage, error) {
+		return map[string]entity.TagUsage{"go": {Count: 2}, "cms": {Count: 1}}, nil
+	}).AnyTimes()
+	mockTagRepo.EXPECT().GetTagUsageDaily(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockTagRepo.EXPECT().GetTagPairFrequency(gomock.Any()).DoAndReturn(func(context.Context) (map[[2]string]int, error) {
+		return map[[2]string]int{{"cms", "go"}: 1}, nil
+	}).AnyTimes()
+	mockTagRepo.EXPECT().GetTagSynonyms(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockArticleRepo.EXPECT().GetPublishedArticleVersions(gomock.Any()).Return([]entity.ArticleVersion{
+		{ArticleID: 1, ArticleVersionID: 10, Tags: entity.NewTags("cms", "go")},
+		{ArticleID: 2, ArticleVersionID: 20, Tags: entity.NewTags("go")},
+	}, nil).AnyTimes()
+
+	// loaded before the requests, as in Start
+	assert.NoError(t, s.calculateTagUsageAndPairFrequency(context.Background()))
+	s.refreshRelatedArticles(context.Background())
+
+	// run with -race, the reloads of the routine and the jobs replace the statistics while the requests read them
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				assert.NoError(t, s.calculateTagUsageAndPairFrequency(context.Background()))
+				s.refreshRelatedArticles(context.Background())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				s.newTagResponse(entity.Tag{Name: "go"})
+				_, err := s.SuggestTags(context.Background(), params.GetTagSuggestionsRequest{Tags: []string{"go"}, Limit: 5})
+				assert.NoError(t, err)
+				_, err = s.GetRelatedArticles(context.Background(), 1, 5)
+				assert.NoError(t, err)
+				s.calculateArticleVersionTagRelationShipScore(entity.NewTags("cms", "go"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, s.newTagResponse(entity.Tag{Name: "go"}).UsageCount)
+	assert.Len(t, s.loadTagStats().relatedArticles.Get(1), 1)
+}
+
+func TestTagService_GetRelatedArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockArticleRepo := service_mock.NewMockarticleRepo(ctrl)
+	s := &TagService{articleRepo: mockArticleRepo}
+
+	s.loadTagStats().relatedArticles.Set(1, []entity.RelatedArticle{{ArticleID: 2, Score: 0.9}, {ArticleID: 3, Score: 0.5}})
+
+	tests := []struct {
+		name      string
+		articleID int64
+		setup     func()
+		want      []params.RelatedArticleResponse
+		wantErr   error
+	}{
+		{
+			name:      "from the cache",
+			articleID: 1,
+			setup:     func() {},
+			want:      []params.RelatedArticleResponse{{ArticleID: 2, Score: 0.9}},
+		},
+		{
+			name:      "published after the last refresh",
+			articleID: 5,
+			setup: func() {
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(5)).Return(&entity.Article{ID: 5, PublishedVersionID: 7}, nil)
+			},
+			want: []params.RelatedArticleResponse{},
+		},
+		{
+			name:      "not published",
+			articleID: 6,
+			setup: func() {
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(6)).Return(&entity.Article{ID: 6, DraftedVersionID: 8}, nil)
+			},
+			wantErr: errs.NotFound{Message: "published article"},
+		},
+		{
+			name:      "not found",
+			articleID: 7,
+			setup: func() {
+				mockArticleRepo.EXPECT().GetArticleWithID(gomock.Any(), int64(7)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "article"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			got, err := s.GetRelatedArticles(context.Background(), tt.articleID, 1)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// recountTagPairFrequency is the full recount of the tag routine before the repository kept the statistics.
+// Every refresh loaded the tags of every published version and counted their pairs again.
+func recountTagPairFrequency(articleVersionTags []entity.ArticleVersionTag) *SafeMap[[2]string, int] {
+	versionTags := make(map[int64][]string)
+	for _, tag := range articleVersionTags {
+		versionTags[tag.ArticleVersionID] = append(versionTags[tag.ArticleVersionID], tag.TagName)
+	}
+
+	frequencies := make(map[[2]string]int)
+	for _, tags := range versionTags {
+		for i := 0; i < len(tags); i++ {
+			for j := i + 1; j < len(tags); j++ {
+				frequencies[newTagPair(tags[i], tags[j])]++
+			}
+		}
+	}
+
+	tagPairs := NewSafeMap[[2]string, int]()
+	for pair, frequency := range frequencies {
+		tagPairs.Set(pair, frequency)
+	}
+
+	return tagPairs
+}
+
+// newBenchmarkArticleVersionTags returns the tags of the published versions and their pair frequency as kept by the repository.
+func newBenchmarkArticleVersionTags(versions, tagsPerVersion, vocabulary int) ([]entity.ArticleVersionTag, map[[2]string]int) {
+	articleVersionTags := make([]entity.ArticleVersionTag, 0, versions*tagsPerVersion)
+	for version := 0; version < versions; version++ {
+		for i := 0; i < tagsPerVersion; i++ {
+			articleVersionTags = append(articleVersionTags, entity.ArticleVersionTag{
+				TagName:          fmt.Sprintf("tag%d", (version*7+i*vocabulary/tagsPerVersion)%vocabulary),
+				ArticleVersionID: int64(version + 1),
+			})
+		}
+	}
+
+	return articleVersionTags, recountTagPairFrequency(articleVersionTags).Snapshot()
+}
+
+func BenchmarkTagService_getTagPairFrequency(b *testing.B) {
+	for _, versions := range []int{1000, 10000, 50000} {
+		articleVersionTags, frequencies := newBenchmarkArticleVersionTags(versions, 5, 500)
+
+		b.Run(fmt.Sprintf("full_recount/versions=%d", versions), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				recountTagPairFrequency(articleVersionTags)
+			}
+		})
+
+		b.Run(fmt.Sprintf("persisted/versions=%d", versions), func(b *testing.B) {
+			ctrl := gomock.NewController(b)
+			mockTagRepo := service_mock.NewMocktagRepo(ctrl)
+			mockTagRepo.EXPECT().GetTagPairFrequency(gomock.Any()).Return(frequencies, nil).AnyTimes()
+			s := &TagService{tagRepo: mockTagRepo}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.getTagPairFrequency(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestTagService_events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	s := &TagService{jobs: mockJobQueue}
+
+	t.Run("the published version is scored", func(t *testing.T) {
+		mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateArticleTagRelation, entity.CalculateArticleVersionTagRelationShipScorePayload{
+			Tags:             entity.NewTags("cms", "go"),
+			ArticleVersionID: 2,
+		}).Return(nil)
+
+		err := s.scoreArticleVersionEvent(context.Background(), entity.OutboxEvent{
+			Type:    constanta.EventVersionPublished,
+			Payload: []byte(`{"article_id":1,"article_version_id":2,"tags":["cms","go"]}`),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("a failed enqueue is returned, so the event is published again", func(t *testing.T) {
+		mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobCalculateTagStats, nil).Return(errors.New("db down"))
+
+		err := s.calculateTagStatsEvent(context.Background(), entity.OutboxEvent{Type: constanta.EventArticleDeleted, Payload: []byte(`{"article_id":1}`)})
+		assert.EqualError(t, err, "db down")
+	})
+
+	t.Run("not valid payload", func(t *testing.T) {
+		err := s.scoreArticleVersionEvent(context.Background(), entity.OutboxEvent{Type: constanta.EventVersionCreated, Payload: []byte(`[]`)})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	"github.com/elangreza/content-management-system/internal/webhook"
+	"github.com/google/uuid"
+)
+
+type (
+	webhookRepo interface {
+		CreateWebhook(ctx context.Context, webhook entity.Webhook) (int64, error)
+		GetWebhooks(ctx context.Context) ([]entity.Webhook, error)
+		GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error)
+		GetSubscribedWebhooks(ctx context.Context, event constanta.EventType) ([]entity.Webhook, error)
+		UpdateWebhook(ctx context.Context, webhook entity.Webhook) error
+		DeleteWebhook(ctx context.Context, id int64) error
+		CreateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) (int64, error)
+		DeleteWebhookDelivery(ctx context.Context, id int64) error
+		GetWebhookDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+		GetWebhookDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error)
+		RecordWebhookDeliveryAttempt(ctx context.Context, id int64, attempt entity.WebhookDeliveryAttempt) error
+	}
+
+	webhookSender interface {
+		Send(ctx context.Context, msg webhook.Message) (*webhook.Response, error)
+	}
+
+	WebhookService struct {
+		webhookRepo webhookRepo
+		jobs        jobQueue
+		sender      webhookSender
+	}
+
+	// webhookEnvelope is the body of a delivery.
+	webhookEnvelope struct {
+		ID        int64               `json:"id"`
+		Event     constanta.EventType `json:"event"`
+		CreatedAt time.Time           `json:"created_at"`
+		Data      json.RawMessage     `json:"data"`
+	}
+)
+
+// NewWebhookService sends the deliveries through the job queue, so they are retried with backoff when the receiver fails.
+func NewWebhookService(webhookRepo webhookRepo, jobs jobQueue, events eventSubscriber, sender webhookSender) *WebhookService {
+	ws := &WebhookService{
+		webhookRepo: webhookRepo,
+		jobs:        jobs,
+		sender:      sender,
+	}
+
+	jobs.Register(constanta.JobDeliverWebhook, ws.deliverJob)
+
+	for _, event := range constanta.Events {
+		events.Subscribe(event, "webhook", ws.publishEvent)
+	}
+
+	return ws
+}
+
+// publishEvent creates a delivery of the committed event for every subscribed webhook.
+// The event is published again when a delivery cannot be created, the webhooks which already have its delivery are skipped then.
+func (ws *WebhookService) publishEvent(ctx context.Context, event entity.OutboxEvent) error {
+	webhooks, err := ws.webhookRepo.GetSubscribedWebhooks(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+	for _, wh := range webhooks {
+		_, err := ws.deliver(ctx, wh.ID, event.ID, event.Type, event.Payload)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			errList = append(errList, fmt.Errorf("webhook %d: %w", wh.ID, err))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// deliver stores the delivery and enqueues its job, outboxEventID is zero when the delivery is not of a relayed event.
+// It returns sql.ErrNoRows when the webhook already has the delivery of the event.
+func (ws *WebhookService) deliver(ctx context.Context, webhookID, outboxEventID int64, event constanta.EventType, data any) (*entity.WebhookDelivery, error) {
+	delivery, err := entity.NewWebhookDelivery(webhookID, event, data)
+	if err != nil {
+		return nil, err
+	}
+	delivery.OutboxEventID = outboxEventID
+
+	delivery.ID, err = ws.webhookRepo.CreateWebhookDelivery(ctx, *delivery)
+	if err != nil {
+		return nil, err
+	}
+	delivery.CreatedAt = time.Now()
+
+	if err := ws.jobs.Enqueue(ctx, constanta.JobDeliverWebhook, entity.DeliverWebhookPayload{DeliveryID: delivery.ID}); err != nil {
+		// the delivery is created again with its job when the event is published again
+		if deleteErr := ws.webhookRepo.DeleteWebhookDelivery(ctx, delivery.ID); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// deliverJob sends the delivery once and records the attempt, the job is retried when the receiver fails.
+func (ws *WebhookService) deliverJob(ctx context.Context, rawPayload json.RawMessage) error {
+	var payload entity.DeliverWebhookPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s job: %w", constanta.JobDeliverWebhook, err)
+	}
+
+	// the deliveries are deleted with their webhook
+	delivery, err := ws.webhookRepo.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	wh, err := ws.webhookRepo.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:        delivery.ID,
+		Event:     delivery.Event,
+		CreatedAt: delivery.CreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, sendErr := ws.sender.Send(ctx, webhook.Message{
+		URL:        wh.URL,
+		Secret:     wh.Secret,
+		Event:      string(delivery.Event),
+		DeliveryID: delivery.ID,
+		Body:       body,
+	})
+
+	attempt := entity.WebhookDeliveryAttempt{Status: constanta.WebhookDeliverySucceeded}
+	if res != nil {
+		attempt.ResponseStatus = res.StatusCode
+		attempt.ResponseBody = res.Body
+	}
+	if sendErr != nil {
+		attempt.Status = constanta.WebhookDeliveryFailed
+		attempt.LastError = sendErr.Error()
+	}
+
+	// the delivery is not sent again only because its attempt cannot be recorded
+	if err := ws.webhookRepo.RecordWebhookDeliveryAttempt(ctx, delivery.ID, attempt); err != nil {
+		slog.Error("failed to record webhook delivery attempt", "delivery_id", delivery.ID, "error", err)
+	}
+
+	return sendErr
+}
+
+// => POST /webhooks
+// The secret is only shown in the response of the creation.
+func (ws *WebhookService) CreateWebhook(ctx context.Context, req params.WebhookRequest) (*params.WebhookResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	wh, err := entity.NewWebhook(req.URL, req.Secret, req.Events, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := ws.webhookRepo.CreateWebhook(ctx, *wh)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ws.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res.Secret = wh.Secret
+
+	return res, nil
+}
+
+// => GET /webhooks
+func (ws *WebhookService) GetWebhooks(ctx context.Context) ([]params.WebhookResponse, error) {
+	webhooks, err := ws.webhookRepo.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]params.WebhookResponse, 0, len(webhooks))
+	for _, wh := range webhooks {
+		res = append(res, params.NewWebhookResponse(wh))
+	}
+
+	return res, nil
+}
+
+// => GET /webhooks/{webhookID}
+func (ws *WebhookService) GetWebhook(ctx context.Context, id int64) (*params.WebhookResponse, error) {
+	wh, err := ws.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := params.NewWebhookResponse(*wh)
+	return &res, nil
+}
+
+// => PUT /webhooks/{webhookID}
+// The secret is kept when it is empty, a new secret is shown in the response.
+func (ws *WebhookService) UpdateWebhook(ctx context.Context, id int64, req params.WebhookRequest) (*params.WebhookResponse, error) {
+	wh, err := ws.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	wh.URL = req.URL
+	wh.Events = req.Events
+	wh.Active = req.Active
+	if req.Secret != "" {
+		wh.Secret = req.Secret
+	}
+
+	if err := ws.webhookRepo.UpdateWebhook(ctx, *wh); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: fmt.Sprintf("webhook %d", id)}
+		}
+		return nil, err
+	}
+
+	res, err := ws.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res.Secret = req.Secret
+
+	return res, nil
+}
+
+// => DELETE /webhooks/{webhookID}
+// The deliveries of the webhook are deleted with it.
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := ws.webhookRepo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NotFound{Message: fmt.Sprintf("webhook %d", id)}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// => POST /webhooks/{webhookID}/ping
+// The ping is sent even when the webhook is not active, so the receiver can be tested before it is enabled.
+func (ws *WebhookService) PingWebhook(ctx context.Context, id int64) (*params.WebhookDeliveryResponse, error) {
+	if _, err := ws.getWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+
+	delivery, err := ws.deliver(ctx, id, 0, constanta.EventPing, map[string]int64{"webhook_id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	return &params.NewWebhookDeliveryResponses(*delivery)[0], nil
+}
+
+// => GET /webhooks/{webhookID}/deliveries
+func (ws *WebhookService) GetWebhookDeliveries(ctx context.Context, webhookID int64, req params.GetWebhookDeliveriesRequest) (*params.GetWebhookDeliveriesResponse, error) {
+	if _, err := ws.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := ws.webhookRepo.GetWebhookDeliveries(ctx, entity.WebhookDeliveryFilter{
+		WebhookID: webhookID,
+		Status:    req.Status,
+		BeforeID:  req.BeforeID,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &params.GetWebhookDeliveriesResponse{
+		Deliveries: params.NewWebhookDeliveryResponses(deliveries...),
+	}
+
+	if len(deliveries) == req.Limit {
+		res.NextBeforeID = deliveries[len(deliveries)-1].ID
+	}
+
+	return res, nil
+}
+
+// => POST /webhooks/deliveries/{deliveryID}/redeliver
+// The event is sent again in a new delivery, so the attempts of the original delivery are kept.
+func (ws *WebhookService) RedeliverWebhookDelivery(ctx context.Context, deliveryID int64) (*params.WebhookDeliveryResponse, error) {
+	original, err := ws.webhookRepo.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: fmt.Sprintf("webhook delivery %d", deliveryID)}
+		}
+		return nil, err
+	}
+
+	delivery, err := ws.deliver(ctx, original.WebhookID, 0, original.Event, original.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &params.NewWebhookDeliveryResponses(*delivery)[0], nil
+}
+
+func (ws *WebhookService) getWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	wh, err := ws.webhookRepo.GetWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: fmt.Sprintf("webhook %d", id)}
+		}
+		return nil, err
+	}
+
+	return wh, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elangreza/content-management-system/internal/constanta"
+	"github.com/elangreza/content-management-system/internal/entity"
+	errs "github.com/elangreza/content-management-system/internal/error"
+	"github.com/elangreza/content-management-system/internal/params"
+	service_mock "github.com/elangreza/content-management-system/internal/service/mock"
+	"github.com/elangreza/content-management-system/internal/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -destination=mock/mock_webhook_repo.go -package=service_mock . webhookRepo
+//go:generate mockgen -destination=mock/mock_webhook_sender.go -package=service_mock . webhookSender
+
+func newTestWebhookService(ctrl *gomock.Controller, sender webhookSender) (*WebhookService, *service_mock.MockwebhookRepo, *service_mock.MockjobQueue) {
+	mockWebhookRepo := service_mock.NewMockwebhookRepo(ctrl)
+	mockJobQueue := service_mock.NewMockjobQueue(ctrl)
+	mockJobQueue.EXPECT().Register(constanta.JobDeliverWebhook, gomock.Any())
+	mockEventSubscriber := service_mock.NewMockeventSubscriber(ctrl)
+	mockEventSubscriber.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(len(constanta.Events))
+
+	return NewWebhookService(mockWebhookRepo, mockJobQueue, mockEventSubscriber, sender), mockWebhookRepo, mockJobQueue
+}
+
+func TestWebhookService_publishEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, mockWebhookRepo, mockJobQueue := newTestWebhookService(ctrl, service_mock.NewMockwebhookSender(ctrl))
+
+	mockWebhookRepo.EXPECT().GetSubscribedWebhooks(gomock.Any(), constanta.EventTagCreated).Return([]entity.Webhook{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+	delivery := func(webhookID int64) entity.WebhookDelivery {
+		return entity.WebhookDelivery{
+			WebhookID:     webhookID,
+			OutboxEventID: 9,
+			Event:         constanta.EventTagCreated,
+			Payload:       json.RawMessage(`{"name":"go"}`),
+			Status:        constanta.WebhookDeliveryPending,
+		}
+	}
+	mockWebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), delivery(1)).Return(int64(10), nil)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobDeliverWebhook, entity.DeliverWebhookPayload{DeliveryID: 10}).Return(nil)
+	// a failed delivery does not stop the others, it is returned so the event is published again
+	mockWebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), delivery(2)).Return(int64(0), errors.New("db down"))
+	// the delivery of an event which is published again is not created again
+	mockWebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), delivery(3)).Return(int64(0), sql.ErrNoRows)
+	// the delivery without its job is removed, so it is created again with the job
+	mockWebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), delivery(4)).Return(int64(11), nil)
+	mockJobQueue.EXPECT().Enqueue(gomock.Any(), constanta.JobDeliverWebhook, entity.DeliverWebhookPayload{DeliveryID: 11}).Return(errors.New("queue down"))
+	mockWebhookRepo.EXPECT().DeleteWebhookDelivery(gomock.Any(), int64(11)).Return(nil)
+
+	err := s.publishEvent(context.Background(), entity.OutboxEvent{ID: 9, Type: constanta.EventTagCreated, Payload: json.RawMessage(`{"name":"go"}`)})
+	assert.EqualError(t, err, "webhook 2: db down\nwebhook 4: queue down")
+}
+
+func TestWebhookService_deliverJob(t *testing.T) {
+	var received []webhook.Delivery
+	receiver := httptest.NewServer(webhook.NewReceiver("secret-of-the-receiver", webhook.DefaultTolerance, func(d webhook.Delivery) {
+		received = append(received, d)
+	}))
+	defer receiver.Close()
+
+	createdAt := time.Date(2025, 8, 22, 9, 0, 0, 0, time.UTC)
+	delivery := &entity.WebhookDelivery{ID: 10, WebhookID: 1, Event: constanta.EventTagCreated, Payload: json.RawMessage(`{"name":"go"}`), CreatedAt: createdAt}
+
+	tests := []struct {
+		name    string
+		secret  string
+		setup   func(*service_mock.MockwebhookRepo)
+		wantErr bool
+		want    []webhook.Delivery
+	}{
+		{
+			name:   "delivered",
+			secret: "secret-of-the-receiver",
+			setup: func(repo *service_mock.MockwebhookRepo) {
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), int64(10)).Return(delivery, nil)
+				repo.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(&entity.Webhook{ID: 1, URL: receiver.URL, Secret: "secret-of-the-receiver"}, nil)
+				repo.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), int64(10), entity.WebhookDeliveryAttempt{
+					Status:         constanta.WebhookDeliverySucceeded,
+					ResponseStatus: 204,
+				}).Return(nil)
+			},
+			want: []webhook.Delivery{{
+				Event:      "tag.created",
+				DeliveryID: "10",
+				Body:       []byte(`{"id":10,"event":"tag.created","created_at":"2025-08-22T09:00:00Z","data":{"name":"go"}}`),
+			}},
+		},
+		{
+			name: "rejected by the receiver is retried",
+			setup: func(repo *service_mock.MockwebhookRepo) {
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), int64(10)).Return(delivery, nil)
+				repo.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(&entity.Webhook{ID: 1, URL: receiver.URL, Secret: "other-secret"}, nil)
+				repo.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), int64(10), entity.WebhookDeliveryAttempt{
+					Status:         constanta.WebhookDeliveryFailed,
+					ResponseStatus: 401,
+					ResponseBody:   webhook.ErrInvalidSignature.Error(),
+					LastError:      "webhook responded with 401",
+				}).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "delivery of a deleted webhook",
+			setup: func(repo *service_mock.MockwebhookRepo) {
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), int64(10)).Return(nil, sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			received = nil
+			s, mockWebhookRepo, _ := newTestWebhookService(ctrl, webhook.NewSender(nil))
+			tt.setup(mockWebhookRepo)
+
+			err := s.deliverJob(context.Background(), json.RawMessage(`{"DeliveryID":10}`))
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, received)
+		})
+	}
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, mockWebhookRepo, _ := newTestWebhookService(ctrl, service_mock.NewMockwebhookSender(ctrl))
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), constanta.LocalUserID, userID)
+
+	var secret string
+	mockWebhookRepo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, wh entity.Webhook) (int64, error) {
+		assert.Len(t, wh.Secret, 64)
+		assert.True(t, wh.Active)
+		assert.Equal(t, userID, wh.CreatedBy)
+		secret = wh.Secret
+		return 3, nil
+	})
+	mockWebhookRepo.EXPECT().GetWebhook(gomock.Any(), int64(3)).DoAndReturn(func(context.Context, int64) (*entity.Webhook, error) {
+		return &entity.Webhook{ID: 3, URL: "http://localhost:9000/hook", Secret: secret, Active: true, CreatedBy: userID}, nil
+	})
+
+	got, err := s.CreateWebhook(ctx, params.WebhookRequest{URL: "http://localhost:9000/hook"})
+	assert.NoError(t, err)
+	assert.Equal(t, secret, got.Secret)
+	assert.Equal(t, []constanta.EventType{}, got.Events)
+}
+
+func TestWebhookService_RedeliverWebhookDelivery(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*service_mock.MockwebhookRepo, *service_mock.MockjobQueue)
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func(repo *service_mock.MockwebhookRepo, jobs *service_mock.MockjobQueue) {
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), int64(10)).Return(&entity.WebhookDelivery{
+					ID:        10,
+					WebhookID: 1,
+					Event:     constanta.EventArticleDeleted,
+					Payload:   json.RawMessage(`{"article_id":4}`),
+					Status:    constanta.WebhookDeliveryFailed,
+					Attempts:  5,
+				}, nil)
+				repo.EXPECT().CreateWebhookDelivery(gomock.Any(), entity.WebhookDelivery{
+					WebhookID: 1,
+					Event:     constanta.EventArticleDeleted,
+					Payload:   json.RawMessage(`{"article_id":4}`),
+					Status:    constanta.WebhookDeliveryPending,
+				}).Return(int64(11), nil)
+				jobs.EXPECT().Enqueue(gomock.Any(), constanta.JobDeliverWebhook, entity.DeliverWebhookPayload{DeliveryID: 11}).Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			setup: func(repo *service_mock.MockwebhookRepo, jobs *service_mock.MockjobQueue) {
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), int64(10)).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: errs.NotFound{Message: "webhook delivery 10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s, mockWebhookRepo, mockJobQueue := newTestWebhookService(ctrl, service_mock.NewMockwebhookSender(ctrl))
+			tt.setup(mockWebhookRepo, mockJobQueue)
+
+			got, err := s.RedeliverWebhookDelivery(context.Background(), 10)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, int64(11), got.ID)
+				assert.Equal(t, constanta.WebhookDeliveryPending, got.Status)
+			}
+		})
+	}
+}
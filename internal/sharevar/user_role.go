@@ -16,5 +16,10 @@ var (
 		constanta.ReadDraftedAndArchivedArticle,
 		constanta.CreateArticle,
 		constanta.DeleteArticle,
-		constanta.UpdateStatusArticle)
+		constanta.UpdateStatusArticle,
+		constanta.ManageCategory,
+		constanta.ManageTag,
+		constanta.ManageJob,
+		constanta.ManageWebhook,
+		constanta.BreakArticleLock)
 )
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, s.Put(ctx, "2025/08/a.txt", strings.NewReader("hello world"), 11, "text/plain"))
+
+	object, err := s.Open(ctx, "2025/08/a.txt")
+	assert.NoError(t, err)
+	_, err = object.Seek(6, io.SeekStart)
+	assert.NoError(t, err)
+	got, err := io.ReadAll(object)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(got))
+	assert.NoError(t, object.Close())
+
+	assert.NoError(t, s.Delete(ctx, "2025/08/a.txt"))
+	assert.NoError(t, s.Delete(ctx, "2025/08/a.txt"))
+	_, err = s.Open(ctx, "2025/08/a.txt")
+	assert.ErrorIs(t, err, ErrNotExist)
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../a.txt", "a/../../b.txt"} {
+		err := s.Put(context.Background(), key, strings.NewReader("a"), 1, "text/plain")
+		assert.Error(t, err, key)
+	}
+
+	err = s.Put(context.Background(), "a.txt", strings.NewReader("a"), 2, "text/plain")
+	assert.Error(t, err)
+}
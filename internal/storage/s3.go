@@ -0,0 +1,350 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3AmzDateFormat   = "20060102T150405Z"
+)
+
+type S3Config struct {
+	// base url of the S3 compatible server, for example https://s3.amazonaws.com or http://localhost:9000
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// S3Storage keeps the objects in a bucket of an S3 compatible server.
+// Requests use path style urls and are signed with signature version 4.
+type S3Storage struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+	now             func() time.Time
+}
+
+var _ Storage = (*S3Storage)(nil)
+
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("%s is not valid s3 endpoint", cfg.Endpoint)
+	}
+
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("s3 bucket and region are required")
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3Storage{
+		endpoint:        endpoint,
+		region:          cfg.Region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		client:          client,
+		now:             time.Now,
+	}, nil
+}
+
+func (ss *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	payloadHash := s3UnsignedPayload
+
+	// seekable bodies are hashed, so the payload is signed too
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return err
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		payloadHash = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	req, err := ss.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := ss.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkS3Response(res, http.StatusOK)
+}
+
+func (ss *S3Storage) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	req, err := ss.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ss.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotExist
+	}
+
+	if err := checkS3Response(res, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &s3Object{ctx: ctx, storage: ss, key: key, size: res.ContentLength}, nil
+}
+
+func (ss *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := ss.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := ss.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkS3Response(res, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (ss *S3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("%s is not valid key", key)
+	}
+
+	u := *ss.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + ss.bucket + "/" + key
+	u.RawPath = awsURIEncode(u.Path, false)
+
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+func (ss *S3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	ss.sign(req, payloadHash, ss.now())
+	return ss.client.Do(req)
+}
+
+// sign adds the signature version 4 authorization header into the request.
+// Every header that is already set is signed.
+func (ss *S3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(s3AmzDateFormat)
+	scope := strings.Join([]string{amzDate[:8], ss.region, "s3", "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+ss.secretAccessKey), amzDate[:8])
+	signingKey = hmacSHA256(signingKey, ss.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, ss.accessKeyID, scope, signedHeaders, signature))
+}
+
+// s3Object reads the object lazily with range requests, so seeking does not download the skipped bytes.
+type s3Object struct {
+	ctx     context.Context
+	storage *S3Storage
+	key     string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (so *s3Object) Read(p []byte) (int, error) {
+	if so.offset >= so.size {
+		return 0, io.EOF
+	}
+
+	if so.body == nil {
+		req, err := so.storage.newRequest(so.ctx, http.MethodGet, so.key, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(so.offset, 10)+"-")
+
+		res, err := so.storage.do(req, emptyPayloadHash)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := checkS3Response(res, http.StatusPartialContent, http.StatusOK); err != nil {
+			res.Body.Close()
+			return 0, err
+		}
+		so.body = res.Body
+	}
+
+	n, err := so.body.Read(p)
+	so.offset += int64(n)
+	return n, err
+}
+
+func (so *s3Object) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = so.offset + offset
+	case io.SeekEnd:
+		next = so.size + offset
+	default:
+		return 0, errors.New("not valid whence")
+	}
+
+	if next < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if next != so.offset {
+		so.closeBody()
+		so.offset = next
+	}
+
+	return next, nil
+}
+
+func (so *s3Object) Close() error {
+	so.closeBody()
+	return nil
+}
+
+func (so *s3Object) closeBody() {
+	if so.body != nil {
+		so.body.Close()
+		so.body = nil
+	}
+}
+
+var emptyPayloadHash = sha256Hex(nil)
+
+func checkS3Response(res *http.Response, expectedStatuses ...int) error {
+	for _, status := range expectedStatuses {
+		if res.StatusCode == status {
+			return nil
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+	return fmt.Errorf("s3 %s %s: %s %s", res.Request.Method, res.Request.URL.Path, res.Status, strings.TrimSpace(string(message)))
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsURIEncode(key, true)+"="+awsURIEncode(value, true))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// awsURIEncode encodes every byte except the unreserved characters.
+func awsURIEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+
+	return sb.String()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
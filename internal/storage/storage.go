@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotExist is returned when the object of the key does not exist.
+var ErrNotExist = errors.New("object does not exist")
+
+// Storage keeps the uploaded files. Keys are slash separated paths.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Open returns a seekable object, so it can be served with range requests.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete does not return an error when the object does not exist.
+	Delete(ctx context.Context, key string) error
+}
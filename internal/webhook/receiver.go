@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// Delivery is a received message whose signature is verified.
+type Delivery struct {
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// default tolerance of the timestamp of a received delivery
+const DefaultTolerance = 5 * time.Minute
+
+// the receiver does not read bodies larger than this
+const maxReceivedBody = 1 << 20
+
+// NewReceiver returns a handler which verifies the deliveries signed with secret and passes them to handle.
+// The deliveries with an invalid signature are rejected with 401. It can be used to test the webhooks locally.
+func NewReceiver(secret string, tolerance time.Duration, handle func(Delivery)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxReceivedBody))
+		if err != nil {
+			http.Error(w, "cannot read body", http.StatusBadRequest)
+			return
+		}
+
+		if err := Verify(secret, r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body, tolerance, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		handle(Delivery{
+			Event:      r.Header.Get(EventHeader),
+			DeliveryID: r.Header.Get(DeliveryHeader),
+			Body:       body,
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Message is a delivery which is signed and posted to the url of its webhook.
+	Message struct {
+		URL        string
+		Secret     string
+		Event      string
+		DeliveryID int64
+		Body       []byte
+	}
+
+	// Response of the receiver, it is kept in the delivery log.
+	Response struct {
+		StatusCode int
+		// the beginning of the body
+		Body string
+	}
+
+	// Sender posts the messages with a signature, it is safe for concurrent use.
+	Sender struct {
+		client *http.Client
+		now    func() time.Time
+	}
+)
+
+// the delivery log only keeps the beginning of the response body
+const maxResponseBody = 1024
+
+// NewSender uses a client with a 10 seconds timeout when client is nil.
+func NewSender(client *http.Client) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Sender{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Send posts the message. The response is returned with an error when the receiver does not respond with 2xx,
+// it is nil when the receiver cannot be reached.
+func (s *Sender) Send(ctx context.Context, msg Message) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.URL, bytes.NewReader(msg.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "content-management-system-webhook")
+	req.Header.Set(EventHeader, msg.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(msg.DeliveryID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(msg.Secret, timestamp, msg.Body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	response := &Response{
+		StatusCode: res.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return response, fmt.Errorf("webhook responded with %d", res.StatusCode)
+	}
+
+	return response, nil
+}
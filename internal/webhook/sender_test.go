@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSender_Send(t *testing.T) {
+	var got []Delivery
+	srv := httptest.NewServer(NewReceiver("secret", DefaultTolerance, func(d Delivery) {
+		got = append(got, d)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(nil)
+
+	res, err := sender.Send(context.Background(), Message{URL: srv.URL, Secret: "secret", Event: "article.created", DeliveryID: 3, Body: []byte(`{"id":3}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Equal(t, []Delivery{{Event: "article.created", DeliveryID: "3", Body: []byte(`{"id":3}`)}}, got)
+
+	res, err = sender.Send(context.Background(), Message{URL: srv.URL, Secret: "wrong", Event: "article.created", DeliveryID: 4, Body: []byte(`{"id":4}`)})
+	assert.EqualError(t, err, "webhook responded with 401")
+	assert.Equal(t, &Response{StatusCode: http.StatusUnauthorized, Body: ErrInvalidSignature.Error()}, res)
+	assert.Len(t, got, 1)
+}
+
+func TestSender_Send_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	res, err := NewSender(nil).Send(context.Background(), Message{URL: srv.URL, Secret: "secret", Body: []byte(`{}`)})
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
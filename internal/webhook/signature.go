@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HMAC-SHA256 of "{timestamp}.{body}" with the secret of the webhook, formatted as sha256={hex}
+	SignatureHeader = "X-Webhook-Signature"
+	// unix seconds of the attempt, it is signed so an old delivery cannot be replayed
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	// id of the delivery, a redelivery has a new id
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredTimestamp = errors.New("webhook timestamp is outside the tolerance")
+)
+
+// Sign returns the value of the signature header of the body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the headers of a received delivery. The timestamp must be within tolerance of now,
+// zero tolerance skips the check.
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) || !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		if diff := now.Sub(time.Unix(ts, 0)); diff > tolerance || diff < -tolerance {
+			return ErrExpiredTimestamp
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11", Sign("secret", 1700000000, []byte(`{"id":1}`)))
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+	signature := Sign("secret", now.Unix(), body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid", secret: "secret", signature: signature, timestamp: timestamp, body: body, now: now},
+		{name: "valid within tolerance", secret: "secret", signature: signature, timestamp: timestamp, body: body, now: now.Add(time.Minute)},
+		{name: "other secret", secret: "other", signature: signature, timestamp: timestamp, body: body, now: now, wantErr: ErrInvalidSignature},
+		{name: "changed body", secret: "secret", signature: signature, timestamp: timestamp, body: []byte(`{"id":2}`), now: now, wantErr: ErrInvalidSignature},
+		{name: "changed timestamp", secret: "secret", signature: signature, timestamp: "1700000001", body: body, now: now, wantErr: ErrInvalidSignature},
+		{name: "missing signature", secret: "secret", timestamp: timestamp, body: body, now: now, wantErr: ErrInvalidSignature},
+		{name: "old timestamp", secret: "secret", signature: signature, timestamp: timestamp, body: body, now: now.Add(time.Hour), wantErr: ErrExpiredTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, DefaultTolerance, tt.now)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
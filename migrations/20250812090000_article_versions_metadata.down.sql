@@ -0,0 +1,16 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "article_slugs";
+
+DROP INDEX IF EXISTS "article_versions_published_slug_index";
+
+ALTER TABLE
+    article_versions DROP COLUMN IF EXISTS "slug",
+    DROP COLUMN IF EXISTS "excerpt",
+    DROP COLUMN IF EXISTS "seo_title",
+    DROP COLUMN IF EXISTS "seo_description",
+    DROP COLUMN IF EXISTS "canonical_url",
+    DROP COLUMN IF EXISTS "og_image";
+
+COMMIT;
@@ -0,0 +1,52 @@
+BEGIN
+;
+
+ALTER TABLE
+    article_versions
+ADD
+    COLUMN "slug" VARCHAR(255) NOT NULL DEFAULT '',
+ADD
+    COLUMN "excerpt" TEXT NOT NULL DEFAULT '',
+ADD
+    COLUMN "seo_title" VARCHAR(255) NOT NULL DEFAULT '',
+ADD
+    COLUMN "seo_description" TEXT NOT NULL DEFAULT '',
+ADD
+    COLUMN "canonical_url" TEXT NOT NULL DEFAULT '',
+ADD
+    COLUMN "og_image" TEXT NOT NULL DEFAULT '';
+
+-- existing versions get the slug from the title, suffixed with the article id to stay unique
+UPDATE
+    article_versions
+SET
+    slug = TRIM(
+        BOTH '-'
+        FROM
+            LOWER(REGEXP_REPLACE(title, '[^a-zA-Z0-9]+', '-', 'g'))
+    ) || '-' || article_id,
+    excerpt = LEFT(body, 200);
+
+-- only one published article can own the slug
+CREATE UNIQUE INDEX "article_versions_published_slug_index" ON "article_versions" ("slug")
+WHERE
+    status = 1;
+
+-- every slug that has been published, so old urls can be redirected to the current slug
+CREATE TABLE IF NOT EXISTS "article_slugs" (
+    "slug" VARCHAR(255) PRIMARY KEY,
+    "article_id" INT NOT NULL REFERENCES articles("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO
+    article_slugs (slug, article_id)
+SELECT
+    slug,
+    article_id
+FROM
+    article_versions
+WHERE
+    status IN (1, 2) ON CONFLICT (slug) DO NOTHING;
+
+COMMIT;
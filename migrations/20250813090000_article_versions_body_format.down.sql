@@ -0,0 +1,7 @@
+BEGIN
+;
+
+ALTER TABLE
+    article_versions DROP COLUMN IF EXISTS "body_format";
+
+COMMIT;
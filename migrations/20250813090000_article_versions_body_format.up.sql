@@ -0,0 +1,12 @@
+BEGIN
+;
+
+-- existing bodies are treated as markdown. Raw HTML inside them is not rendered
+ALTER TABLE
+    article_versions
+ADD
+    COLUMN IF NOT EXISTS "body_format" VARCHAR(16) NOT NULL DEFAULT 'markdown' CHECK (
+        "body_format" IN ('markdown', 'html', 'plain')
+    );
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN
+;
+
+-- structured alternative of the body. The body keeps the markdown rendering of the blocks
+ALTER TABLE
+    article_versions
+ADD
+    COLUMN IF NOT EXISTS "blocks" JSONB NULL CHECK (
+        "blocks" IS NULL
+        OR jsonb_typeof("blocks" -> 'blocks') = 'array'
+    );
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "article_version_media";
+
+DROP TABLE IF EXISTS "media";
+
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "media" (
+    "id" SERIAL PRIMARY KEY,
+    "storage_key" VARCHAR(255) NOT NULL UNIQUE,
+    "file_name" VARCHAR(255) NOT NULL,
+    "mime_type" VARCHAR(100) NOT NULL,
+    "size" BIGINT NOT NULL,
+    -- hex sha256 of the content
+    "checksum" CHAR(64) NOT NULL,
+    "width" INT NOT NULL DEFAULT 0,
+    "height" INT NOT NULL DEFAULT 0,
+    "created_by" UUID NOT NULL REFERENCES users("id"),
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "media_created_by_index" ON "media" ("created_by");
+
+-- media cannot be deleted while an article version references it
+CREATE TABLE IF NOT EXISTS "article_version_media" (
+    "article_version_id" INT NOT NULL REFERENCES article_versions("id") ON DELETE CASCADE,
+    "media_id" INT NOT NULL REFERENCES media("id") ON DELETE RESTRICT,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("article_version_id", "media_id")
+);
+
+CREATE INDEX IF NOT EXISTS "article_version_media_media_id_index" ON "article_version_media" ("media_id");
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN
+;
+
+UPDATE users SET "role" = "role" & ~16 WHERE "role" = 31;
+
+DROP TABLE IF EXISTS "article_categories";
+
+DROP TABLE IF EXISTS "categories";
+
+COMMIT;
@@ -0,0 +1,44 @@
+BEGIN
+;
+
+-- the tree is stored as a materialized path of ids, for example /1/4/9/,
+-- so a subtree is every category whose path starts with the path of its root
+CREATE TABLE IF NOT EXISTS "categories" (
+    "id" SERIAL PRIMARY KEY,
+    "parent_id" INT NULL REFERENCES categories("id") ON DELETE RESTRICT,
+    "name" VARCHAR(100) NOT NULL,
+    "slug" VARCHAR(100) NOT NULL,
+    "path" TEXT NOT NULL DEFAULT '',
+    "depth" INT NOT NULL DEFAULT 0,
+    -- order between the siblings
+    "position" INT NOT NULL DEFAULT 0,
+    "created_by" UUID NOT NULL REFERENCES users("id"),
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL
+);
+
+CREATE TRIGGER "log_category_update" BEFORE
+UPDATE
+    ON "categories" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+CREATE UNIQUE INDEX IF NOT EXISTS "categories_parent_slug_index" ON "categories" (COALESCE("parent_id", 0), "slug");
+
+CREATE INDEX IF NOT EXISTS "categories_path_index" ON "categories" ("path" text_pattern_ops);
+
+-- a category cannot be deleted while an article is assigned to it
+CREATE TABLE IF NOT EXISTS "article_categories" (
+    "article_id" INT NOT NULL REFERENCES articles("id") ON DELETE CASCADE,
+    "category_id" INT NOT NULL REFERENCES categories("id") ON DELETE RESTRICT,
+    "is_primary" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("article_id", "category_id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "article_categories_primary_index" ON "article_categories" ("article_id") WHERE "is_primary";
+
+CREATE INDEX IF NOT EXISTS "article_categories_category_id_index" ON "article_categories" ("category_id");
+
+-- editors manage the categories
+UPDATE users SET "role" = "role" | 16 WHERE "role" = 15;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN
+;
+
+UPDATE users SET "role" = "role" & ~32 WHERE "role" = 63;
+
+ALTER TABLE
+    "article_version_tags" DROP CONSTRAINT IF EXISTS "article_version_tags_tag_name_fkey",
+ADD
+    CONSTRAINT "article_version_tags_tag_name_fkey" FOREIGN KEY ("tag_name") REFERENCES tags("name");
+
+DROP INDEX IF EXISTS "tags_slug_index";
+
+ALTER TABLE
+    "tags" DROP COLUMN IF EXISTS "display_name",
+    DROP COLUMN IF EXISTS "description",
+    DROP COLUMN IF EXISTS "color",
+    DROP COLUMN IF EXISTS "slug";
+
+COMMIT;
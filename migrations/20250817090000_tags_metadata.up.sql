@@ -0,0 +1,26 @@
+BEGIN
+;
+
+ALTER TABLE
+    "tags"
+ADD
+    COLUMN "display_name" VARCHAR(100) NOT NULL DEFAULT '',
+ADD
+    COLUMN "description" VARCHAR(500) NOT NULL DEFAULT '',
+ADD
+    COLUMN "color" VARCHAR(7) NOT NULL DEFAULT '',
+ADD
+    COLUMN "slug" VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS "tags_slug_index" ON "tags" ("slug") WHERE "slug" <> '';
+
+-- renaming a tag renames it in every article version
+ALTER TABLE
+    "article_version_tags" DROP CONSTRAINT IF EXISTS "article_version_tags_tag_name_fkey",
+ADD
+    CONSTRAINT "article_version_tags_tag_name_fkey" FOREIGN KEY ("tag_name") REFERENCES tags("name") ON UPDATE CASCADE;
+
+-- editors manage the tags
+UPDATE users SET "role" = "role" | 32 WHERE "role" = 31;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN
+;
+
+-- aliases are replaced with their canonical tag when the tags are written
+CREATE TABLE IF NOT EXISTS "tag_synonyms" (
+    "alias" VARCHAR PRIMARY KEY,
+    "tag_name" VARCHAR NOT NULL REFERENCES tags("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "tag_synonyms_tag_name_index" ON "tag_synonyms" ("tag_name");
+
+COMMIT;
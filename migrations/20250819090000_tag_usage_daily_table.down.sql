@@ -0,0 +1,6 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "tag_usage_daily";
+
+COMMIT;
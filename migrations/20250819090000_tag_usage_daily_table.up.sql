@@ -0,0 +1,30 @@
+BEGIN
+;
+
+-- number of times the tag is published per day, used by the trending score
+CREATE TABLE IF NOT EXISTS "tag_usage_daily" (
+    "tag_name" VARCHAR NOT NULL REFERENCES tags("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "day" DATE NOT NULL,
+    "count" INT NOT NULL DEFAULT 0,
+    PRIMARY KEY ("tag_name", "day")
+);
+
+CREATE INDEX IF NOT EXISTS "tag_usage_daily_day_index" ON "tag_usage_daily" ("day");
+
+-- the versions which are or were published are counted on the day they were last updated
+INSERT INTO
+    tag_usage_daily (tag_name, "day", "count")
+SELECT
+    avt.tag_name,
+    COALESCE(av.updated_at, av.created_at)::DATE,
+    COUNT(*)
+FROM
+    article_version_tags avt
+    JOIN article_versions av ON av.id = avt.article_version_id
+WHERE
+    av.status IN (1, 2)
+GROUP BY
+    1,
+    2;
+
+COMMIT;
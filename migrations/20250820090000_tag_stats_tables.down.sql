@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "tag_pair_stats";
+
+DROP TABLE IF EXISTS "tag_stats";
+
+COMMIT;
@@ -0,0 +1,52 @@
+BEGIN
+;
+
+-- usage of the tags in the published versions, maintained when a version is published, archived or deleted
+CREATE TABLE IF NOT EXISTS "tag_stats" (
+    "tag_name" VARCHAR PRIMARY KEY REFERENCES tags("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "usage_count" INT NOT NULL DEFAULT 0,
+    "last_used" TIMESTAMPTZ NULL
+);
+
+-- number of published versions using both tags
+CREATE TABLE IF NOT EXISTS "tag_pair_stats" (
+    "tag_a" VARCHAR NOT NULL REFERENCES tags("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "tag_b" VARCHAR NOT NULL REFERENCES tags("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "count" INT NOT NULL DEFAULT 0,
+    PRIMARY KEY ("tag_a", "tag_b")
+);
+
+CREATE INDEX IF NOT EXISTS "tag_pair_stats_tag_b_index" ON "tag_pair_stats" ("tag_b");
+
+INSERT INTO
+    tag_stats (tag_name, usage_count, last_used)
+SELECT
+    avt.tag_name,
+    COUNT(*),
+    MAX(av.created_at)
+FROM
+    article_version_tags avt
+    JOIN article_versions av ON av.id = avt.article_version_id
+WHERE
+    av.status = 1
+GROUP BY
+    avt.tag_name;
+
+INSERT INTO
+    tag_pair_stats (tag_a, tag_b, "count")
+SELECT
+    a.tag_name,
+    b.tag_name,
+    COUNT(*)
+FROM
+    article_version_tags a
+    JOIN article_version_tags b ON b.article_version_id = a.article_version_id
+    AND a.tag_name < b.tag_name
+    JOIN article_versions av ON av.id = a.article_version_id
+WHERE
+    av.status = 1
+GROUP BY
+    a.tag_name,
+    b.tag_name;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN
+;
+
+UPDATE users SET "role" = "role" & ~64 WHERE "role" = 127;
+
+DROP TABLE IF EXISTS "jobs";
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN
+;
+
+-- background jobs, claimed by the workers with FOR UPDATE SKIP LOCKED
+CREATE TABLE IF NOT EXISTS "jobs" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "type" VARCHAR(100) NOT NULL,
+    "payload" JSONB NOT NULL DEFAULT '{}',
+    -- pending, running, succeeded or dead
+    "status" VARCHAR(20) NOT NULL DEFAULT 'pending',
+    "attempts" INT NOT NULL DEFAULT 0,
+    "max_attempts" INT NOT NULL DEFAULT 5,
+    "run_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "locked_at" TIMESTAMPTZ NULL,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL
+);
+
+-- the workers only look for the pending jobs which are due
+CREATE INDEX IF NOT EXISTS "jobs_pending_index" ON "jobs" ("run_at", "id") WHERE "status" = 'pending';
+
+CREATE INDEX IF NOT EXISTS "jobs_status_index" ON "jobs" ("status", "id");
+
+CREATE TRIGGER "log_job_update" BEFORE
+UPDATE
+    ON "jobs" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+-- editors inspect the jobs
+UPDATE users SET "role" = "role" | 64 WHERE "role" = 63;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN
+;
+
+UPDATE users SET "role" = "role" & ~128 WHERE "role" = 255;
+
+DROP TABLE IF EXISTS "webhook_deliveries";
+
+DROP TABLE IF EXISTS "webhooks";
+
+COMMIT;
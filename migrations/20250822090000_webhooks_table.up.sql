@@ -0,0 +1,46 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "url" TEXT NOT NULL,
+    "secret" VARCHAR(255) NOT NULL,
+    -- subscribed event types, empty subscribes every event
+    "events" VARCHAR(100)[] NOT NULL DEFAULT '{}',
+    "active" BOOLEAN NOT NULL DEFAULT TRUE,
+    "created_by" UUID NOT NULL REFERENCES users(id),
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL
+);
+
+CREATE TRIGGER "log_webhook_update" BEFORE
+UPDATE
+    ON "webhooks" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+-- every event sent to a webhook, the redelivery is a new row
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "webhook_id" BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    "event" VARCHAR(100) NOT NULL,
+    "payload" JSONB NOT NULL DEFAULT '{}',
+    -- pending, succeeded or failed
+    "status" VARCHAR(20) NOT NULL DEFAULT 'pending',
+    "attempts" INT NOT NULL DEFAULT 0,
+    "response_status" INT NOT NULL DEFAULT 0,
+    "response_body" TEXT NOT NULL DEFAULT '',
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL,
+    "delivered_at" TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS "webhook_deliveries_webhook_index" ON "webhook_deliveries" ("webhook_id", "id");
+
+CREATE TRIGGER "log_webhook_delivery_update" BEFORE
+UPDATE
+    ON "webhook_deliveries" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+-- editors manage the webhooks
+UPDATE users SET "role" = "role" | 128 WHERE "role" = 127;
+
+COMMIT;
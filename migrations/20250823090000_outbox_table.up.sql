@@ -0,0 +1,22 @@
+BEGIN
+;
+
+-- domain events written in the transaction of their change, published by the relay after the commit
+CREATE TABLE IF NOT EXISTS "outbox" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "event_type" VARCHAR(100) NOT NULL,
+    "payload" JSONB NOT NULL DEFAULT '{}',
+    "attempts" INT NOT NULL DEFAULT 0,
+    "run_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "locked_at" TIMESTAMPTZ NULL,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "published_at" TIMESTAMPTZ NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- the relay only looks for the events which are not published yet
+CREATE INDEX IF NOT EXISTS "outbox_unpublished_index" ON "outbox" ("id") WHERE "published_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "outbox_published_at_index" ON "outbox" ("published_at");
+
+COMMIT;
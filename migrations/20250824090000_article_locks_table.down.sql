@@ -0,0 +1,8 @@
+BEGIN
+;
+
+UPDATE users SET "role" = "role" & ~256 WHERE "role" = 511;
+
+DROP TABLE IF EXISTS "article_locks";
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN
+;
+
+-- the edit lock of an article, an expired lock is taken over by the next user who locks the article
+CREATE TABLE IF NOT EXISTS "article_locks" (
+    "article_id" BIGINT PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL
+);
+
+CREATE TRIGGER "log_article_lock_update" BEFORE
+UPDATE
+    ON "article_locks" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+-- editors break the edit locks of the other users
+UPDATE users SET "role" = "role" | 256 WHERE "role" = 255;
+
+COMMIT;
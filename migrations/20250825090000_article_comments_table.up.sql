@@ -0,0 +1,35 @@
+BEGIN
+;
+
+-- the review comments of the versions, a thread is its first comment and the replies to it
+CREATE TABLE IF NOT EXISTS "article_comments" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "article_id" BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    "article_version_id" BIGINT NOT NULL REFERENCES article_versions(id) ON DELETE CASCADE,
+    -- the first comment of the thread, null for the first comment
+    "parent_id" BIGINT NULL REFERENCES article_comments(id) ON DELETE CASCADE,
+    -- title or body, null when the comment is not anchored
+    "anchor_field" VARCHAR(10) NULL,
+    -- character range [anchor_start, anchor_end) of the field, null when the anchored text is not in a carried version anymore
+    "anchor_start" INT NULL,
+    "anchor_end" INT NULL,
+    -- the anchored text when the comment was created
+    "anchor_text" TEXT NULL,
+    "body" TEXT NOT NULL,
+    "mentions" UUID[] NOT NULL DEFAULT '{}',
+    "resolved_by" UUID NULL REFERENCES users(id),
+    "resolved_at" TIMESTAMPTZ NULL,
+    -- the comment of the previous version which this comment is carried from
+    "carried_from_id" BIGINT NULL REFERENCES article_comments(id) ON DELETE SET NULL,
+    "created_by" UUID NOT NULL REFERENCES users(id),
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS "article_comments_article_version_id_index" ON "article_comments" ("article_version_id", "id");
+
+CREATE TRIGGER "log_article_comment_update" BEFORE
+UPDATE
+    ON "article_comments" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "notification_preferences";
+
+DROP TABLE IF EXISTS "notifications";
+
+COMMIT;
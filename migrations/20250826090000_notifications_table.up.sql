@@ -0,0 +1,41 @@
+BEGIN
+;
+
+-- the notifications of a user, created from the committed events of the outbox
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "type" VARCHAR(50) NOT NULL,
+    -- the outbox event which created the notification, a relayed event does not notify twice
+    "event_id" BIGINT NOT NULL,
+    "article_id" BIGINT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    "article_version_id" BIGINT NULL REFERENCES article_versions(id) ON DELETE CASCADE,
+    "comment_id" BIGINT NULL REFERENCES article_comments(id) ON DELETE CASCADE,
+    -- the user who made the change, null when the user is deleted
+    "actor_id" UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    "message" TEXT NOT NULL,
+    "read_at" TIMESTAMPTZ NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE ("user_id", "event_id")
+);
+
+CREATE INDEX IF NOT EXISTS "notifications_user_id_index" ON "notifications" ("user_id", "id");
+
+CREATE INDEX IF NOT EXISTS "notifications_unread_index" ON "notifications" ("user_id") WHERE "read_at" IS NULL;
+
+-- a user without preferences gets every notification in the app only
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+    "user_id" UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    -- in_app or email, the email is sent besides the notification in the app
+    "delivery" VARCHAR(20) NOT NULL DEFAULT 'in_app',
+    -- the notification types which are not created for the user
+    "muted_types" VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NULL
+);
+
+CREATE TRIGGER "log_notification_preference_update" BEFORE
+UPDATE
+    ON "notification_preferences" FOR EACH ROW EXECUTE PROCEDURE log_update_master();
+
+COMMIT;
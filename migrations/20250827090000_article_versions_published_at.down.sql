@@ -0,0 +1,9 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "article_versions_published_at_index";
+
+ALTER TABLE
+    article_versions DROP COLUMN IF EXISTS "published_at";
+
+COMMIT;
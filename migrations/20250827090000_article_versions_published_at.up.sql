@@ -0,0 +1,43 @@
+BEGIN
+;
+
+-- the time the version was first published, updated_at changes on every update of the version
+ALTER TABLE
+    article_versions
+ADD
+    COLUMN "published_at" TIMESTAMPTZ NULL;
+
+-- the last update of a published version is the closest known time of its publish
+UPDATE
+    article_versions
+SET
+    published_at = COALESCE(updated_at, created_at)
+WHERE
+    status = 1;
+
+-- the archived versions were only published when their publish event is still in the outbox
+UPDATE
+    article_versions av
+SET
+    published_at = o.created_at
+FROM
+    (
+        SELECT
+            (payload ->> 'article_version_id')::INT AS article_version_id,
+            MIN(created_at) AS created_at
+        FROM
+            outbox
+        WHERE
+            event_type = 'version.published'
+        GROUP BY
+            1
+    ) o
+WHERE
+    av.id = o.article_version_id
+    AND av.status = 2;
+
+CREATE INDEX IF NOT EXISTS "article_versions_published_at_index" ON "article_versions" ("published_at")
+WHERE
+    "published_at" IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "article_categories_created_at_index";
+
+DROP INDEX IF EXISTS "article_versions_changed_at_index";
+
+DROP INDEX IF EXISTS "articles_changed_at_index";
+
+COMMIT;
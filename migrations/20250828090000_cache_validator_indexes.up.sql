@@ -0,0 +1,11 @@
+BEGIN
+;
+
+-- the cache validators of the article reads take the latest change of every table
+CREATE INDEX IF NOT EXISTS "articles_changed_at_index" ON "articles" ((COALESCE("updated_at", "created_at")));
+
+CREATE INDEX IF NOT EXISTS "article_versions_changed_at_index" ON "article_versions" ((COALESCE("updated_at", "created_at")));
+
+CREATE INDEX IF NOT EXISTS "article_categories_created_at_index" ON "article_categories" ("created_at");
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "webhook_deliveries_outbox_event_index";
+
+ALTER TABLE
+    webhook_deliveries DROP COLUMN IF EXISTS "outbox_event_id";
+
+DROP TABLE IF EXISTS "outbox_deliveries";
+
+DROP INDEX IF EXISTS "outbox_unpublished_index";
+
+CREATE INDEX IF NOT EXISTS "outbox_unpublished_index" ON "outbox" ("id")
+WHERE
+    "published_at" IS NULL;
+
+ALTER TABLE
+    outbox DROP COLUMN IF EXISTS "dead_at",
+    DROP COLUMN IF EXISTS "max_attempts";
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN
+;
+
+-- the changes of the events are committed, so an event is retried longer than a job before it is dead
+ALTER TABLE
+    outbox
+ADD
+    COLUMN "max_attempts" INT NOT NULL DEFAULT 10,
+ADD
+    COLUMN "dead_at" TIMESTAMPTZ NULL;
+
+DROP INDEX IF EXISTS "outbox_unpublished_index";
+
+-- the relay only looks for the events which are not published and not dead
+CREATE INDEX IF NOT EXISTS "outbox_unpublished_index" ON "outbox" ("id")
+WHERE
+    "published_at" IS NULL
+    AND "dead_at" IS NULL;
+
+-- the subscribers which handled the event, a retried event is only published to the others
+CREATE TABLE IF NOT EXISTS "outbox_deliveries" (
+    "event_id" BIGINT NOT NULL REFERENCES outbox("id") ON DELETE CASCADE,
+    "subscriber" VARCHAR(100) NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("event_id", "subscriber")
+);
+
+-- the event of a delivery, empty for the pings and the redeliveries
+ALTER TABLE
+    webhook_deliveries
+ADD
+    COLUMN "outbox_event_id" BIGINT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "webhook_deliveries_outbox_event_index" ON "webhook_deliveries" ("webhook_id", "outbox_event_id");
+
+COMMIT;
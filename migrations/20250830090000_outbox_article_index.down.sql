@@ -0,0 +1,6 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "outbox_unpublished_article_index";
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN
+;
+
+-- the relay looks for the earlier events of the article of an event, which are not published and not dead
+CREATE INDEX IF NOT EXISTS "outbox_unpublished_article_index" ON "outbox" (("payload" ->> 'article_id'), "id")
+WHERE
+    "published_at" IS NULL
+    AND "dead_at" IS NULL;
+
+COMMIT;